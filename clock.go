@@ -0,0 +1,25 @@
+package ziva
+
+import (
+	"github.com/qzeleza/ziva/internal/common"
+)
+
+// ----------------------------------------------------------------------------
+// Источник времени
+// ----------------------------------------------------------------------------
+
+// Clock — источник времени очереди: тайм-ауты задач, обратный отсчёт,
+// задержки повторов FuncTask и анимация спиннеров.
+type Clock = common.Clock
+
+// WithClock задаёт источник времени очереди и всех её задач.
+// По умолчанию используется системное время; поддельные часы пакета zivatest
+// позволяют тестам мгновенно и детерминированно вызывать тайм-ауты.
+// С часами, отличными от системных, курсор поля ввода не мигает.
+//
+// @param clock Источник времени (nil — системное время)
+// @return Указатель на очередь задач
+func (q *Queue) WithClock(clock Clock) *Queue {
+	q.model.WithClock(clock)
+	return q
+}
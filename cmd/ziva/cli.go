@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qzeleza/ziva"
+)
+
+// Коды завершения подкоманд.
+// Интерфейс выводится в stderr, поэтому stdout содержит только результат.
+const (
+	exitOK        = 0   // Успешное завершение или ответ «Да»
+	exitNo        = 1   // Ответ «Нет» или ошибка выполнения
+	exitUsage     = 2   // Неверные аргументы командной строки
+	exitNotFound  = 127 // Команда для ziva run не найдена
+	exitCancelled = 130 // Отмена пользователем (как при прерывании по Ctrl+C в shell)
+)
+
+// command описывает подкоманду CLI
+type command struct {
+	name    string                  // Имя подкоманды
+	usage   string                  // Синтаксис вызова
+	summary string                  // Краткое описание для справки
+	run     func(args []string) int // Обработчик; возвращает код завершения
+}
+
+// commands — подкоманды в порядке вывода в справке
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "confirm", usage: "confirm [флаги] <вопрос>", summary: "вопрос Да/Нет; код 0 — да, 1 — нет", run: runConfirm},
+		{name: "choose", usage: "choose [флаги] <заголовок> <элемент>...", summary: "выбор одного (или нескольких с --multi) элементов", run: runChoose},
+		{name: "input", usage: "input [флаги] <заголовок>", summary: "ввод значения с проверкой по --type", run: runInput},
+		{name: "run", usage: "run [флаги] -- <команда> [аргументы]...", summary: "выполнение команды с индикатором", run: runCommand},
+		{name: "help", usage: "help", summary: "эта справка", run: runHelp},
+	}
+}
+
+// findCommand ищет подкоманду по имени.
+//
+// @param name Имя подкоманды
+// @return Подкоманда и признак её наличия
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// printUsage выводит список подкоманд и коды завершения.
+//
+// @param w Поток вывода
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Использование: ziva <команда> [флаги] [аргументы]")
+	fmt.Fprintln(w, "Без команды запускается демонстрация всех типов задач.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Команды:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-42s %s\n", cmd.usage, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Коды завершения: 0 — успех/да, 1 — нет/ошибка, 2 — неверные аргументы, 130 — отмена.")
+	fmt.Fprintln(w, "Интерфейс выводится в stderr, результат — в stdout:")
+	fmt.Fprintln(w, `  env=$(ziva choose "Среда" dev staging prod) || exit $?`)
+}
+
+// runHelp выводит справку по подкомандам
+func runHelp([]string) int {
+	printUsage(os.Stdout)
+	return exitOK
+}
+
+// commonOptions содержит флаги, общие для всех подкоманд
+type commonOptions struct {
+	header string // Заголовок очереди над задачей
+	lang   string // Язык интерфейса
+}
+
+// newFlagSet создаёт набор флагов подкоманды с общими флагами.
+//
+// @param cmd Подкоманда
+// @param opts Общие флаги, заполняемые при разборе
+// @return Набор флагов
+func newFlagSet(cmd command, opts *commonOptions) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Использование: ziva %s\n%s\n\nФлаги:\n", cmd.usage, cmd.summary)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.header, "header", "", "заголовок над задачей")
+	fs.StringVar(&opts.lang, "lang", "", "язык интерфейса Ziva (например, ru или en)")
+	return fs
+}
+
+// parseFlags разбирает аргументы подкоманды и применяет общие настройки.
+//
+// @param fs Набор флагов
+// @param opts Общие флаги
+// @param args Аргументы командной строки
+// @param minArgs Минимальное число позиционных аргументов
+// @return Код завершения и признак того, что выполнение следует прекратить
+func parseFlags(fs *flag.FlagSet, opts *commonOptions, args []string, minArgs int) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, true
+		}
+		return exitUsage, true
+	}
+	if fs.NArg() < minArgs {
+		fs.Usage()
+		return exitUsage, true
+	}
+
+	configureLanguage(opts.lang)
+	useStderrColorProfile()
+	if err := ziva.UserThemeError(); err != nil {
+		fmt.Fprintf(os.Stderr, "ziva: %v\n", err)
+	}
+	return exitOK, false
+}
+
+// useStderrColorProfile определяет цветовые возможности по stderr, куда выводится интерфейс.
+// Иначе при перехвате stdout (env=$(ziva choose ...)) цвета были бы отключены.
+func useStderrColorProfile() {
+	renderer := lipgloss.NewRenderer(os.Stderr)
+	lipgloss.SetColorProfile(renderer.ColorProfile())
+	lipgloss.SetHasDarkBackground(renderer.HasDarkBackground())
+}
+
+// runTask выполняет одну задачу в очереди, выводящей интерфейс в stderr.
+//
+// @param opts Общие флаги
+// @param t Задача
+// @return Результат задачи или ошибка запуска интерфейса
+func runTask(opts commonOptions, t ziva.Task) (ziva.TaskResult, error) {
+	queue := ziva.NewQueue(opts.header).
+		WithOutput(os.Stderr).
+		WithOutSummary().
+		WithOutResultLine()
+	queue.AddTasks(t)
+
+	if err := queue.Run(); err != nil {
+		return ziva.TaskResult{}, err
+	}
+	return queue.Results()[0], nil
+}
+
+// resultExitCode переводит итог задачи в код завершения.
+//
+// @param result Результат задачи
+// @return Код завершения
+func resultExitCode(result ziva.TaskResult) int {
+	switch result.Status {
+	case ziva.ResultCancelled, ziva.ResultPending:
+		return exitCancelled
+	case ziva.ResultError:
+		return exitNo
+	}
+	return exitOK
+}
+
+// reportError выводит ошибку подкоманды в stderr.
+//
+// @param cmd Имя подкоманды
+// @param err Ошибка
+// @return Код завершения exitNo
+func reportError(cmd string, err error) int {
+	fmt.Fprintf(os.Stderr, "ziva %s: %v\n", cmd, err)
+	return exitNo
+}
+
+// parseItems разбирает элементы списка вида "ключ" или "ключ=Название".
+//
+// @param args Позиционные аргументы
+// @return Элементы списка
+func parseItems(args []string) []ziva.Item {
+	items := make([]ziva.Item, 0, len(args))
+	for _, arg := range args {
+		key, name, found := strings.Cut(arg, "=")
+		if !found || strings.TrimSpace(name) == "" {
+			name = key
+		}
+		items = append(items, ziva.Item{Key: key, Name: name})
+	}
+	return items
+}
+
+// splitList разбирает список значений, перечисленных через запятую.
+//
+// @param value Строка значений
+// @return Непустые значения без окружающих пробелов
+func splitList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
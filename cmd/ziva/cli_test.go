@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/qzeleza/ziva"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseItems проверяет разбор элементов вида "ключ" и "ключ=Название"
+func TestParseItems(t *testing.T) {
+	items := parseItems([]string{"dev", "prod=Боевая среда", "empty=", "a=b=c"})
+
+	assert.Equal(t, []ziva.Item{
+		{Key: "dev", Name: "dev"},
+		{Key: "prod", Name: "Боевая среда"},
+		{Key: "empty", Name: "empty"},
+		{Key: "a", Name: "b=c"},
+	}, items)
+}
+
+// TestSplitList проверяет разбор значений через запятую
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, splitList(" a, ,b "))
+	assert.Nil(t, splitList(""))
+}
+
+// TestResultExitCode проверяет соответствие итога задачи коду завершения
+func TestResultExitCode(t *testing.T) {
+	assert.Equal(t, exitOK, resultExitCode(ziva.TaskResult{Status: ziva.ResultSuccess}))
+	assert.Equal(t, exitNo, resultExitCode(ziva.TaskResult{Status: ziva.ResultError}))
+	assert.Equal(t, exitCancelled, resultExitCode(ziva.TaskResult{Status: ziva.ResultCancelled}))
+	assert.Equal(t, exitCancelled, resultExitCode(ziva.TaskResult{Status: ziva.ResultPending}))
+}
+
+// TestCommandExitCode проверяет передачу кода завершения внешней команды
+func TestCommandExitCode(t *testing.T) {
+	err := exec.Command("sh", "-c", "exit 3").Run()
+	assert.Equal(t, 3, commandExitCode(fmt.Errorf("обёртка: %w", err)))
+
+	_, err = exec.LookPath("ziva-command-that-does-not-exist")
+	assert.Equal(t, exitNotFound, commandExitCode(err))
+
+	assert.Equal(t, exitNo, commandExitCode(errors.New("ошибка")))
+}
+
+// TestCommandError проверяет добавление последних строк stderr к ошибке команды
+func TestCommandError(t *testing.T) {
+	base := errors.New("exit status 1")
+	err := commandError(base, "1\n2\n3\n4\n5\n6\n7\n")
+
+	assert.ErrorIs(t, err, base)
+	assert.Equal(t, "exit status 1\n3\n4\n5\n6\n7", err.Error())
+	assert.Equal(t, base, commandError(base, "  \n"))
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/qzeleza/ziva"
+	"github.com/qzeleza/ziva/internal/validation"
+)
+
+// ----------------------------------------------------------------------------
+// confirm
+// ----------------------------------------------------------------------------
+
+// runConfirm задаёт вопрос Да/Нет и печатает ответ "yes" или "no".
+// Код завершения: 0 — да, 1 — нет, 130 — отмена.
+func runConfirm(args []string) int {
+	cmd, _ := findCommand("confirm")
+	var opts commonOptions
+	fs := newFlagSet(cmd, &opts)
+	defaultNo := fs.Bool("default-no", false, "по умолчанию выбран ответ «Нет»")
+	timeout := fs.Duration("timeout", 0, "выбрать ответ по умолчанию через указанное время (например, 10s)")
+	affirmative := fs.String("affirmative", "", "текст варианта «Да»")
+	negative := fs.String("negative", "", "текст варианта «Нет»")
+	quiet := fs.Bool("quiet", false, "не печатать ответ, только код завершения")
+	if code, stop := parseFlags(fs, &opts, args, 1); stop {
+		return code
+	}
+
+	question := strings.Join(fs.Args(), " ")
+	confirm := ziva.NewYesNoTask(question, question).
+		WithCustomLabels(*affirmative, *negative)
+	switch {
+	case *timeout > 0 && *defaultNo:
+		confirm.WithDefaultNoAndTimeout(*timeout)
+	case *timeout > 0:
+		confirm.WithDefaultYesAndTimeout(*timeout)
+	case *defaultNo:
+		confirm.WithDefaultNo()
+	default:
+		confirm.WithDefaultYes()
+	}
+
+	result, err := runTask(opts, confirm)
+	if err != nil {
+		return reportError(cmd.name, err)
+	}
+
+	answer, code := "no", exitNo
+	switch {
+	case result.Status == ziva.ResultPending:
+		return exitCancelled
+	case result.Status == ziva.ResultSuccess && confirm.IsYes():
+		answer, code = "yes", exitOK
+	case result.Status == ziva.ResultCancelled:
+		return exitCancelled
+	}
+	if !*quiet {
+		fmt.Println(answer)
+	}
+	return code
+}
+
+// ----------------------------------------------------------------------------
+// choose
+// ----------------------------------------------------------------------------
+
+// runChoose предлагает выбрать элемент из списка и печатает ключ выбранного элемента.
+// С флагом --multi печатает ключи всех выбранных элементов через разделитель.
+func runChoose(args []string) int {
+	cmd, _ := findCommand("choose")
+	var opts commonOptions
+	fs := newFlagSet(cmd, &opts)
+	multi := fs.Bool("multi", false, "разрешить выбор нескольких элементов")
+	selectAll := fs.Bool("select-all", false, "добавить пункт «Выбрать все» (с --multi)")
+	require := fs.Bool("require", false, "требовать выбор хотя бы одного элемента (с --multi)")
+	defaultValue := fs.String("default", "", "ключ элемента по умолчанию (с --multi — ключи через запятую)")
+	height := fs.Int("height", 0, "число одновременно видимых элементов (0 — все)")
+	separator := fs.String("separator", "\n", "разделитель выбранных ключей (с --multi)")
+	timeout := fs.Duration("timeout", 0, "выбрать значение по умолчанию через указанное время (например, 10s)")
+	if code, stop := parseFlags(fs, &opts, args, 2); stop {
+		return code
+	}
+
+	title := fs.Arg(0)
+	items := parseItems(fs.Args()[1:])
+
+	if *multi {
+		choose := ziva.NewMultiSelectTask(title, items).WithRequireSelection(*require)
+		if *selectAll {
+			choose.WithSelectAll()
+		}
+		if *height > 0 {
+			choose.WithViewport(*height)
+		}
+		if values := splitList(*defaultValue); len(values) > 0 {
+			choose.WithDefaultItems(values)
+		}
+		if *timeout > 0 {
+			choose.WithTimeout(*timeout, splitList(*defaultValue))
+		}
+
+		result, err := runTask(opts, choose)
+		if err != nil {
+			return reportError(cmd.name, err)
+		}
+		if code := resultExitCode(result); code != exitOK {
+			return code
+		}
+		if len(result.Values) > 0 {
+			fmt.Println(strings.Join(result.Values, *separator))
+		}
+		return exitOK
+	}
+
+	choose := ziva.NewSingleSelectTask(title, items)
+	if *height > 0 {
+		choose.WithViewport(*height)
+	}
+	if *defaultValue != "" {
+		choose.WithDefaultItem(*defaultValue)
+	}
+	if *timeout > 0 {
+		fallback := *defaultValue
+		if fallback == "" {
+			fallback = items[0].Key
+		}
+		choose.WithTimeout(*timeout, fallback)
+	}
+
+	result, err := runTask(opts, choose)
+	if err != nil {
+		return reportError(cmd.name, err)
+	}
+	if code := resultExitCode(result); code != exitOK {
+		return code
+	}
+	fmt.Println(result.Value)
+	return exitOK
+}
+
+// ----------------------------------------------------------------------------
+// input
+// ----------------------------------------------------------------------------
+
+// inputKind описывает тип значения подкоманды input
+type inputKind struct {
+	inputType ziva.InputType              // Тип поля ввода
+	validator func() validation.Validator // Валидатор (nil — валидатор типа поля)
+}
+
+// inputKinds — поддерживаемые значения флага --type
+var inputKinds = map[string]inputKind{
+	"text":     {inputType: ziva.InputTypeText},
+	"password": {inputType: ziva.InputTypePassword},
+	"email":    {inputType: ziva.InputTypeEmail},
+	"number":   {inputType: ziva.InputTypeNumber},
+	"port":     {inputType: ziva.InputTypeNumber, validator: ziva.DefaultValidators.Port},
+	"ip":       {inputType: ziva.InputTypeIP},
+	"ipv4":     {inputType: ziva.InputTypeIP, validator: ziva.DefaultValidators.IPv4},
+	"ipv6":     {inputType: ziva.InputTypeIP, validator: ziva.DefaultValidators.IPv6},
+	"domain":   {inputType: ziva.InputTypeDomain},
+	"url":      {inputType: ziva.InputTypeText, validator: ziva.DefaultValidators.URL},
+	"path":     {inputType: ziva.InputTypeText, validator: ziva.DefaultValidators.Path},
+	"username": {inputType: ziva.InputTypeText, validator: ziva.DefaultValidators.Username},
+}
+
+// inputKindNames возвращает отсортированный список значений флага --type
+func inputKindNames() string {
+	names := make([]string, 0, len(inputKinds))
+	for name := range inputKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// runInput запрашивает значение с проверкой по типу и печатает его.
+func runInput(args []string) int {
+	cmd, _ := findCommand("input")
+	var opts commonOptions
+	fs := newFlagSet(cmd, &opts)
+	kindName := fs.String("type", "text", "тип значения: "+inputKindNames())
+	prompt := fs.String("prompt", "", "подсказка перед полем ввода")
+	placeholder := fs.String("placeholder", "", "текст-заполнитель пустого поля")
+	allowEmpty := fs.Bool("allow-empty", false, "разрешить пустое значение")
+	defaultValue := fs.String("default", "", "значение, подставляемое по истечении --timeout")
+	timeout := fs.Duration("timeout", 0, "подставить значение по умолчанию через указанное время (например, 10s)")
+	if code, stop := parseFlags(fs, &opts, args, 1); stop {
+		return code
+	}
+
+	kind, ok := inputKinds[strings.ToLower(*kindName)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "ziva %s: неизвестный тип %q, допустимые: %s\n", cmd.name, *kindName, inputKindNames())
+		return exitUsage
+	}
+
+	input := ziva.NewInputTask(strings.Join(fs.Args(), " "), *prompt)
+	if kind.validator != nil {
+		input.WithValidator(kind.validator())
+	}
+	input.WithInputType(kind.inputType)
+	input.WithAllowEmpty(*allowEmpty)
+	if *placeholder != "" {
+		input.WithPlaceholder(*placeholder)
+	}
+	if *timeout > 0 {
+		input.WithTimeout(*timeout, *defaultValue)
+	}
+
+	result, err := runTask(opts, input)
+	if err != nil {
+		return reportError(cmd.name, err)
+	}
+	if code := resultExitCode(result); code != exitOK {
+		return code
+	}
+	fmt.Println(input.GetValue())
+	return exitOK
+}
+
+// ----------------------------------------------------------------------------
+// run
+// ----------------------------------------------------------------------------
+
+// maxErrorOutputLines — число последних строк stderr команды, добавляемых к ошибке задачи
+const maxErrorOutputLines = 5
+
+// runCommand выполняет внешнюю команду с индикатором выполнения.
+// Stdout команды печатается после завершения, код завершения совпадает с кодом команды.
+func runCommand(args []string) int {
+	cmd, _ := findCommand("run")
+	var opts commonOptions
+	fs := newFlagSet(cmd, &opts)
+	title := fs.String("title", "", "заголовок задачи (по умолчанию — сама команда)")
+	timeout := fs.Duration("timeout", 0, "прервать команду через указанное время (например, 5m)")
+	if code, stop := parseFlags(fs, &opts, args, 1); stop {
+		return code
+	}
+
+	argv := fs.Args()
+	if *title == "" {
+		*title = strings.Join(argv, " ")
+	}
+
+	// Функция задачи удерживает mu до возврата: после отмены или истечения срока
+	// задача завершается раньше функции, и вывод читается только после её возврата
+	var mu sync.Mutex
+	var stdout, stderr bytes.Buffer
+	var runErr error
+	run := ziva.NewFuncTaskCtx(*title, func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stdout.Reset()
+		stderr.Reset()
+		command := exec.CommandContext(ctx, argv[0], argv[1:]...)
+		command.Stdout = &stdout
+		command.Stderr = &stderr
+		runErr = command.Run()
+		if runErr != nil && ctx.Err() == nil {
+			return commandError(runErr, stderr.String())
+		}
+		return runErr
+	})
+	if *timeout > 0 {
+		run.WithDeadline(*timeout)
+	}
+
+	result, err := runTask(opts, run)
+	if err != nil {
+		return reportError(cmd.name, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	os.Stdout.Write(stdout.Bytes())
+	os.Stderr.Write(stderr.Bytes())
+
+	switch result.Status {
+	case ziva.ResultCancelled, ziva.ResultPending:
+		return exitCancelled
+	case ziva.ResultError:
+		return commandExitCode(runErr)
+	}
+	return exitOK
+}
+
+// commandError дополняет ошибку команды последними строками её stderr.
+//
+// @param err Ошибка выполнения команды
+// @param output Вывод команды в stderr
+// @return Ошибка для отображения в задаче
+func commandError(err error, output string) error {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) > maxErrorOutputLines {
+		lines = lines[len(lines)-maxErrorOutputLines:]
+	}
+	if tail := strings.TrimSpace(strings.Join(lines, "\n")); tail != "" {
+		return fmt.Errorf("%w\n%s", err, tail)
+	}
+	return err
+}
+
+// commandExitCode возвращает код завершения для ошибки выполнения команды.
+//
+// @param err Ошибка выполнения команды
+// @return Код завершения команды, 127 — команда не найдена, 1 — прочие ошибки
+func commandExitCode(err error) int {
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return exitNo
+	case errors.As(err, &exitErr) && exitErr.ExitCode() > 0:
+		return exitErr.ExitCode()
+	case errors.Is(err, exec.ErrNotFound):
+		return exitNotFound
+	}
+	return exitNo
+}
@@ -24,8 +24,27 @@ type pingResult struct {
 }
 
 func main() {
+	// Подкоманды для shell-скриптов: ziva confirm, ziva choose, ziva input, ziva run
+	if len(os.Args) > 1 {
+		if cmd, ok := findCommand(os.Args[1]); ok {
+			os.Exit(cmd.run(os.Args[2:]))
+		}
+		if !strings.HasPrefix(os.Args[1], "-") {
+			fmt.Fprintf(os.Stderr, "ziva: неизвестная команда %q\n\n", os.Args[1])
+			printUsage(os.Stderr)
+			os.Exit(exitUsage)
+		}
+	}
+
+	runDemo()
+}
+
+// runDemo запускает демонстрацию всех типов задач (режим без подкоманды).
+func runDemo() {
 	// Настраиваем язык интерфейса и предупреждаем о возможных ограничениях терминала
-	activeLang := configureLanguage()
+	langFlag := flag.String("lang", "", "язык интерфейса Ziva (например, ru или en)")
+	flag.Parse()
+	activeLang := configureLanguage(*langFlag)
 	warnTerminalCapabilities(activeLang)
 	ziva.SetDefaultLanguage("ru")
 
@@ -326,15 +345,13 @@ func checkConnection(result *pingResult) error {
 	return nil
 }
 
-// configureLanguage разбирает язык из CLI/конфига, проверяет доступность локали и применяет его.
-func configureLanguage() string {
+// configureLanguage выбирает язык из флага -lang или окружения, проверяет доступность локали и применяет его.
+func configureLanguage(langFlag string) string {
 	if defLang := strings.TrimSpace(os.Getenv("ZIVA_DEFAULT_LANG")); defLang != "" {
 		ziva.SetDefaultLanguage(defLang)
 	}
-	langFlag := flag.String("lang", "", "язык интерфейса Ziva (например, ru или en)")
-	flag.Parse()
 
-	lang := strings.TrimSpace(*langFlag)
+	lang := strings.TrimSpace(langFlag)
 	if lang == "" {
 		lang = strings.TrimSpace(os.Getenv("ZIVA_LANG"))
 	}
@@ -376,10 +393,10 @@ func localeAvailable(locale string) bool {
 
 // printRussianLocaleHint выводит рекомендации по установке русской локали и шрифта.
 func printRussianLocaleHint() {
-	fmt.Println("⚠️ Не удалось найти локаль ru_RU.UTF-8. Переключаю интерфейс на английский.")
-	fmt.Println("   Установите русскую локаль командой (Debian/Ubuntu): sudo locale-gen ru_RU.UTF-8 && sudo update-locale LANG=ru_RU.UTF-8")
-	fmt.Println("   Для Entware/BusyBox: opkg install locale-full glibc-binary-locales && export LANG=ru_RU.UTF-8")
-	fmt.Println("   При необходимости настройте шрифт: setterm -reset && setterm -store")
+	fmt.Fprintln(os.Stderr, "⚠️ Не удалось найти локаль ru_RU.UTF-8. Переключаю интерфейс на английский.")
+	fmt.Fprintln(os.Stderr, "   Установите русскую локаль командой (Debian/Ubuntu): sudo locale-gen ru_RU.UTF-8 && sudo update-locale LANG=ru_RU.UTF-8")
+	fmt.Fprintln(os.Stderr, "   Для Entware/BusyBox: opkg install locale-full glibc-binary-locales && export LANG=ru_RU.UTF-8")
+	fmt.Fprintln(os.Stderr, "   При необходимости настройте шрифт: setterm -reset && setterm -store")
 }
 
 // warnTerminalCapabilities предупреждает о возможных ограничениях терминала.
@@ -389,13 +406,13 @@ func warnTerminalCapabilities(lang string) {
 	colorTerm := strings.TrimSpace(os.Getenv("COLORTERM"))
 
 	if !strings.Contains(langEnv, "utf") {
-		fmt.Println("⚠️ Текущая локаль не содержит UTF-8. Псевдографика может отображаться некорректно.")
-		fmt.Println("   Совет: export LANG=ru_RU.UTF-8 && export LC_ALL=ru_RU.UTF-8")
+		fmt.Fprintln(os.Stderr, "⚠️ Текущая локаль не содержит UTF-8. Псевдографика может отображаться некорректно.")
+		fmt.Fprintln(os.Stderr, "   Совет: export LANG=ru_RU.UTF-8 && export LC_ALL=ru_RU.UTF-8")
 	}
 	if colorTerm == "" {
-		fmt.Println("⚠️ Терминал не сообщает о поддержке цвета (COLORTERM пуст). Включите цветной режим или используйте современный эмулятор.")
+		fmt.Fprintln(os.Stderr, "⚠️ Терминал не сообщает о поддержке цвета (COLORTERM пуст). Включите цветной режим или используйте современный эмулятор.")
 	}
 	if term == "linux" || strings.Contains(term, "vt100") || strings.Contains(term, "busybox") {
-		fmt.Println("ℹ️ Для Entware/BusyBox установите шрифт UTF-8: setterm -store && setterm -font latarcyrheb-sun32")
+		fmt.Fprintln(os.Stderr, "ℹ️ Для Entware/BusyBox установите шрифт UTF-8: setterm -store && setterm -font latarcyrheb-sun32")
 	}
 }
@@ -0,0 +1,111 @@
+package ziva
+
+import (
+	"time"
+
+	"github.com/qzeleza/ziva/internal/task"
+)
+
+// ----------------------------------------------------------------------------
+// Выполнение внешних команд
+// ----------------------------------------------------------------------------
+
+// CommandTask представляет задачу выполнения внешней команды
+type CommandTask struct {
+	*task.CommandTask
+}
+
+// NewCommandTask создает задачу, запускающую внешнюю команду без оболочки.
+// Пока команда выполняется, под заголовком выводятся последние строки stdout и stderr.
+// Ненулевой код завершения завершает задачу ошибкой с кодом в контексте "exit_code".
+//
+//	ziva.NewCommandTask("Проверка связи", "ping", "-c", "3", "8.8.8.8").WithOutputOnFailure()
+//
+// @param title Заголовок задачи
+// @param name Имя или путь исполняемого файла
+// @param args Аргументы команды
+// @return Указатель на новую задачу выполнения команды
+func NewCommandTask(title, name string, args ...string) *CommandTask {
+	return &CommandTask{task.NewCommandTask(title, name, args...)}
+}
+
+// WithDir задаёт рабочий каталог команды
+//
+// @param dir Рабочий каталог
+// @return Указатель на задачу для цепочки вызовов
+func (t *CommandTask) WithDir(dir string) *CommandTask {
+	t.CommandTask.WithDir(dir)
+	return t
+}
+
+// WithEnv добавляет переменные окружения команды к окружению текущего процесса
+//
+// @param env Переменные в виде KEY=value
+// @return Указатель на задачу для цепочки вызовов
+func (t *CommandTask) WithEnv(env ...string) *CommandTask {
+	t.CommandTask.WithEnv(env...)
+	return t
+}
+
+// WithOutputLines задаёт число последних строк вывода под заголовком (по умолчанию 5, 0 — не выводить)
+//
+// @param lines Число строк
+// @return Указатель на задачу для цепочки вызовов
+func (t *CommandTask) WithOutputLines(lines int) *CommandTask {
+	t.CommandTask.WithOutputLines(lines)
+	return t
+}
+
+// WithOutputOnFailure оставляет последние строки вывода в итоговом виде задачи,
+// если команда завершилась ошибкой
+//
+// @return Указатель на задачу для цепочки вызовов
+func (t *CommandTask) WithOutputOnFailure() *CommandTask {
+	t.CommandTask.WithOutputOnFailure()
+	return t
+}
+
+// WithStopOnError устанавливает флаг остановки очереди при ошибке
+//
+// @param stop Флаг остановки очереди при ошибке
+// @return Указатель на задачу для цепочки вызовов
+func (t *CommandTask) WithStopOnError(stop bool) *CommandTask {
+	t.SetStopOnError(stop)
+	return t
+}
+
+// WithDeadline ограничивает время выполнения команды; по истечении срока процесс завершается
+//
+// @param deadline Максимальная длительность выполнения
+// @return Указатель на задачу для цепочки вызовов
+func (t *CommandTask) WithDeadline(deadline time.Duration) *CommandTask {
+	t.FuncTask.WithDeadline(deadline)
+	return t
+}
+
+// WithRetry включает повтор команды при повторяемых ошибках
+//
+// @param policy Политика повтора
+// @return Указатель на задачу для цепочки вызовов
+func (t *CommandTask) WithRetry(policy RetryPolicy) *CommandTask {
+	t.FuncTask.WithRetry(policy)
+	return t
+}
+
+// WithID задаёт стабильный идентификатор задачи для сопоставления результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *CommandTask) WithID(id string) *CommandTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *CommandTask) When(condition func(Results) bool) *CommandTask {
+	t.SetCondition(condition)
+	return t
+}
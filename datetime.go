@@ -0,0 +1,320 @@
+package ziva
+
+import (
+	"flag"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/task"
+)
+
+// ----------------------------------------------------------------------------
+// Выбор даты, времени и длительности
+// ----------------------------------------------------------------------------
+
+// DateTask представляет задачу выбора даты
+type DateTask struct {
+	*task.DateTimeTask
+}
+
+// TimeTask представляет задачу выбора времени суток
+type TimeTask struct {
+	*task.DateTimeTask
+}
+
+// DurationTask представляет задачу выбора длительности
+type DurationTask struct {
+	*task.DateTimeTask
+}
+
+// NewDateTask создает задачу выбора даты: ←/→ переключают день, месяц и год,
+// ↑/↓ изменяют выбранное поле, цифры вводят его значение. Под полями выводится
+// календарь месяца с названиями месяцев и дней недели на языке интерфейса.
+// Выбранная дата возвращается GetTime(), в результатах очереди - строкой
+// "2006-01-02" (вид KindDate).
+//
+//	ziva.NewDateTask("Дата начала").
+//		WithRange(time.Now(), time.Now().AddDate(1, 0, 0))
+//
+// @param title Заголовок задачи
+// @return Указатель на новую задачу выбора даты
+func NewDateTask(title string) *DateTask {
+	return &DateTask{task.NewDateTask(title)}
+}
+
+// WithValue задаёт начальную дату (по умолчанию - текущая)
+//
+// @param value Начальная дата
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTask) WithValue(value time.Time) *DateTask {
+	t.DateTimeTask.WithValue(value)
+	return t
+}
+
+// WithRange ограничивает выбор диапазоном дат; нулевое значение снимает границу
+//
+// @param low Самая ранняя дата
+// @param high Самая поздняя дата
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTask) WithRange(low, high time.Time) *DateTask {
+	t.DateTimeTask.WithRange(low, high)
+	return t
+}
+
+// WithTimeout устанавливает тайм-аут для задачи с датой по умолчанию
+//
+// @param duration Тайм-аут
+// @param defaultValue Дата по умолчанию
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTask) WithTimeout(duration time.Duration, defaultValue time.Time) *DateTask {
+	t.DateTimeTask.WithTimeout(duration, defaultValue)
+	return t
+}
+
+// FromEnv берёт начальную дату из переменной окружения (в формате "2006-01-02")
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTask) FromEnv(name string, mode ...PrefillMode) *DateTask {
+	t.DateTimeTask.FromEnv(name, mode...)
+	return t
+}
+
+// FromFlag берёт начальную дату из флага командной строки, явно указанного при разборе fs
+//
+// @param fs Набор флагов
+// @param name Имя флага
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *DateTask {
+	t.DateTimeTask.FromFlag(fs, name, mode...)
+	return t
+}
+
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTask) WithID(id string) *DateTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTask) When(condition func(Results) bool) *DateTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTask) Then(followUp func(Results) []Task) *DateTask {
+	t.SetFollowUp(followUp)
+	return t
+}
+
+// NewTimeTask создает задачу выбора времени суток: ←/→ переключают часы и минуты
+// (и секунды, см. WithSeconds), ↑/↓ изменяют выбранное поле с переносом в соседнее.
+// Выбранное время возвращается GetTime() на нулевую дату в UTC, в результатах
+// очереди - строкой "15:04" (вид KindTime).
+//
+//	ziva.NewTimeTask("Время перезагрузки").
+//		WithValue(time.Date(0, 1, 1, 4, 0, 0, 0, time.UTC))
+//
+// @param title Заголовок задачи
+// @return Указатель на новую задачу выбора времени
+func NewTimeTask(title string) *TimeTask {
+	return &TimeTask{task.NewTimeTask(title)}
+}
+
+// WithValue задаёт начальное время (учитываются часы, минуты и секунды; по умолчанию - текущее)
+//
+// @param value Начальное время
+// @return Указатель на задачу для цепочки вызовов
+func (t *TimeTask) WithValue(value time.Time) *TimeTask {
+	t.DateTimeTask.WithValue(value)
+	return t
+}
+
+// WithRange ограничивает выбор диапазоном времени суток; нулевое значение снимает границу
+//
+// @param low Самое раннее время
+// @param high Самое позднее время
+// @return Указатель на задачу для цепочки вызовов
+func (t *TimeTask) WithRange(low, high time.Time) *TimeTask {
+	t.DateTimeTask.WithRange(low, high)
+	return t
+}
+
+// WithSeconds показывает поле секунд (по умолчанию время выбирается с точностью до минуты)
+//
+// @param show Показывать поле секунд
+// @return Указатель на задачу для цепочки вызовов
+func (t *TimeTask) WithSeconds(show bool) *TimeTask {
+	t.DateTimeTask.WithSeconds(show)
+	return t
+}
+
+// WithTimeout устанавливает тайм-аут для задачи со временем по умолчанию
+//
+// @param duration Тайм-аут
+// @param defaultValue Время по умолчанию
+// @return Указатель на задачу для цепочки вызовов
+func (t *TimeTask) WithTimeout(duration time.Duration, defaultValue time.Time) *TimeTask {
+	t.DateTimeTask.WithTimeout(duration, defaultValue)
+	return t
+}
+
+// FromEnv берёт начальное время из переменной окружения (в формате "15:04")
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *TimeTask) FromEnv(name string, mode ...PrefillMode) *TimeTask {
+	t.DateTimeTask.FromEnv(name, mode...)
+	return t
+}
+
+// FromFlag берёт начальное время из флага командной строки, явно указанного при разборе fs
+//
+// @param fs Набор флагов
+// @param name Имя флага
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *TimeTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *TimeTask {
+	t.DateTimeTask.FromFlag(fs, name, mode...)
+	return t
+}
+
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *TimeTask) WithID(id string) *TimeTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *TimeTask) When(condition func(Results) bool) *TimeTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *TimeTask) Then(followUp func(Results) []Task) *TimeTask {
+	t.SetFollowUp(followUp)
+	return t
+}
+
+// NewDurationTask создает задачу выбора длительности: ←/→ переключают часы, минуты
+// и секунды, ↑/↓ изменяют выбранное поле с переносом в соседнее. Выбранная длительность
+// возвращается GetDuration(), в результатах очереди - строкой в формате time.Duration
+// (вид KindDuration).
+//
+//	ziva.NewDurationTask("Интервал резервного копирования").
+//		WithValue(6 * time.Hour).
+//		WithRange(time.Minute, 24*time.Hour)
+//
+// @param title Заголовок задачи
+// @return Указатель на новую задачу выбора длительности
+func NewDurationTask(title string) *DurationTask {
+	return &DurationTask{task.NewDurationTask(title)}
+}
+
+// WithValue задаёт начальную длительность (по умолчанию - нулевая или нижняя граница)
+//
+// @param value Начальная длительность
+// @return Указатель на задачу для цепочки вызовов
+func (t *DurationTask) WithValue(value time.Duration) *DurationTask {
+	t.DateTimeTask.WithDuration(value)
+	return t
+}
+
+// WithRange ограничивает выбор диапазоном длительностей; неположительное значение снимает границу
+//
+// @param low Наименьшая длительность
+// @param high Наибольшая длительность
+// @return Указатель на задачу для цепочки вызовов
+func (t *DurationTask) WithRange(low, high time.Duration) *DurationTask {
+	t.DateTimeTask.WithDurationRange(low, high)
+	return t
+}
+
+// WithSeconds показывает или скрывает поле секунд (по умолчанию показывается)
+//
+// @param show Показывать поле секунд
+// @return Указатель на задачу для цепочки вызовов
+func (t *DurationTask) WithSeconds(show bool) *DurationTask {
+	t.DateTimeTask.WithSeconds(show)
+	return t
+}
+
+// WithTimeout устанавливает тайм-аут для задачи с длительностью по умолчанию
+//
+// @param duration Тайм-аут
+// @param defaultValue Длительность по умолчанию
+// @return Указатель на задачу для цепочки вызовов
+func (t *DurationTask) WithTimeout(duration time.Duration, defaultValue time.Duration) *DurationTask {
+	t.DateTimeTask.WithTimeout(duration, defaultValue)
+	return t
+}
+
+// FromEnv берёт начальную длительность из переменной окружения (в формате "1h30m")
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *DurationTask) FromEnv(name string, mode ...PrefillMode) *DurationTask {
+	t.DateTimeTask.FromEnv(name, mode...)
+	return t
+}
+
+// FromFlag берёт начальную длительность из флага командной строки, явно указанного при разборе fs
+//
+// @param fs Набор флагов
+// @param name Имя флага
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *DurationTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *DurationTask {
+	t.DateTimeTask.FromFlag(fs, name, mode...)
+	return t
+}
+
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *DurationTask) WithID(id string) *DurationTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *DurationTask) When(condition func(Results) bool) *DurationTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *DurationTask) Then(followUp func(Results) []Task) *DurationTask {
+	t.SetFollowUp(followUp)
+	return t
+}
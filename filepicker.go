@@ -0,0 +1,124 @@
+package ziva
+
+import (
+	"github.com/qzeleza/ziva/internal/task"
+)
+
+// ----------------------------------------------------------------------------
+// Выбор файлов и каталогов
+// ----------------------------------------------------------------------------
+
+// FilePickerTask представляет задачу выбора файла или каталога
+type FilePickerTask struct {
+	*task.FilePickerTask
+}
+
+// NewFilePickerTask создает задачу выбора файла в виде списка содержимого каталога:
+// → и Enter открывают каталог, ← поднимается на уровень выше, набранные буквы
+// переносят курсор к подходящему имени. Результат задачи - путь с разрешёнными
+// символическими ссылками (вид KindPath).
+//
+//	ziva.NewFilePickerTask("Файл конфигурации", "/etc/config").
+//		WithRoot("/etc").
+//		WithExtensions("conf", "json")
+//
+// @param title Заголовок задачи
+// @param startDir Каталог, открываемый при запуске ("" - текущий рабочий каталог или корень)
+// @return Указатель на новую задачу выбора файла
+func NewFilePickerTask(title, startDir string) *FilePickerTask {
+	return &FilePickerTask{task.NewFilePickerTask(title, startDir)}
+}
+
+// WithRoot ограничивает просмотр каталогом root и его подкаталогами: подняться выше корня
+// нельзя, ссылки за его пределы недоступны, а ответы с путями вне корня отклоняются
+//
+// @param root Корневой каталог
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithRoot(root string) *FilePickerTask {
+	t.FilePickerTask.WithRoot(root)
+	return t
+}
+
+// WithExtensions оставляет в списке только файлы с указанными расширениями
+//
+// @param extensions Расширения с точкой или без неё ("conf", ".json")
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithExtensions(extensions ...string) *FilePickerTask {
+	t.FilePickerTask.WithExtensions(extensions...)
+	return t
+}
+
+// WithPatterns оставляет в списке только файлы, имена которых подходят под шаблоны filepath.Match
+//
+// @param patterns Шаблоны имён файлов ("*.conf", "eth?")
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithPatterns(patterns ...string) *FilePickerTask {
+	t.FilePickerTask.WithPatterns(patterns...)
+	return t
+}
+
+// WithHidden показывает скрытые файлы сразу при открытии списка (по умолчанию они скрыты,
+// во время выбора переключаются клавишами Alt+.)
+//
+// @param show Показывать скрытые файлы
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithHidden(show bool) *FilePickerTask {
+	t.FilePickerTask.WithHidden(show)
+	return t
+}
+
+// WithDirsOnly переключает задачу на выбор каталогов
+//
+// @param only Выбирать только каталоги
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithDirsOnly(only bool) *FilePickerTask {
+	t.FilePickerTask.WithDirsOnly(only)
+	return t
+}
+
+// WithMultiple разрешает отметить несколько элементов пробелом;
+// результат задачи содержит список путей в Values
+//
+// @param multiple Разрешить выбор нескольких элементов
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithMultiple(multiple bool) *FilePickerTask {
+	t.FilePickerTask.WithMultiple(multiple)
+	return t
+}
+
+// WithViewport устанавливает количество одновременно видимых элементов списка (по умолчанию 10)
+//
+// @param size Количество видимых элементов (0 = показать все)
+// @param showCounters Показывать количество скрытых элементов
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithViewport(size int, showCounters ...bool) *FilePickerTask {
+	t.FilePickerTask.WithViewport(size, showCounters...)
+	return t
+}
+
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithID(id string) *FilePickerTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) When(condition func(Results) bool) *FilePickerTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) Then(followUp func(Results) []Task) *FilePickerTask {
+	t.SetFollowUp(followUp)
+	return t
+}
@@ -0,0 +1,79 @@
+package ziva
+
+import (
+	"github.com/qzeleza/ziva/internal/form"
+)
+
+// ----------------------------------------------------------------------------
+// Форма по тегам структуры
+// ----------------------------------------------------------------------------
+
+// Form создаёт форму по полям структуры, запускает её и записывает ответы
+// в поля структуры. Заголовок очереди — имя типа структуры (см. NewForm).
+//
+// @param target Указатель на структуру с тегами ziva
+// @return Ошибка описания формы, выполнения очереди или преобразования ответа
+func Form(target interface{}) error {
+	f, err := form.Parse(target)
+	if err != nil {
+		return err
+	}
+	return formQueue(f.Title, f).Run()
+}
+
+// NewForm создаёт очередь задач по полям структуры с тегами ziva.
+// После успешного выполнения очереди (Run) ответы записываются в поля структуры.
+//
+// Тип задачи выбирается по типу поля: bool — NewYesNoTask, string и целые числа —
+// NewInputTask (NewSingleSelectTask при заданном choices), []string — NewMultiSelectTask
+// (choices обязателен), net.IP — NewInputTask с типом ввода InputTypeIP.
+// Ненулевые значения полей используются как значения по умолчанию.
+//
+// Параметры тега перечисляются через запятую:
+//
+//	type Config struct {
+//		Env   string   `ziva:"title=Среда,choices=dev:Разработка|prod:Боевая,default=dev"`
+//		Port  int      `ziva:"title=Порт,validator=port,default=8080"`
+//		Debug bool     `ziva:"title=Отладка,question=Включить отладку?"`
+//		Tags  []string `ziva:"title=Компоненты,choices=web|db|cache,default=web|db"`
+//		Addr  net.IP   `ziva:"title=Адрес,optional"`
+//		Cache string   `ziva:"-"`
+//	}
+//
+// Допустимые параметры: title, id (по умолчанию — имя поля), question, prompt,
+// placeholder, validator, input, choices, default, timeout, optional, required.
+// Ошибки тегов возвращаются как SpecErrors с путями вида "Config.Port.validator";
+// ответ, который не удалось преобразовать в тип поля, — как ошибка валидации.
+//
+// @param title Заголовок очереди
+// @param target Указатель на структуру с тегами ziva
+// @return Очередь задач или ошибка описания формы
+func NewForm(title string, target interface{}) (*Queue, error) {
+	f, err := form.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	return formQueue(title, f), nil
+}
+
+// formQueue создаёт очередь задач формы и привязывает её ответы к полям структуры
+func formQueue(title string, f *form.Form) *Queue {
+	queue := NewQueue(title)
+	for i, taskSpec := range f.Tasks {
+		switch t := taskFromSpec(taskSpec).(type) {
+		case *YesNoTask:
+			// Ответ "Нет" — значение false, а не ошибка
+			t.WithNoAsError()
+			queue.AddTasks(t)
+		case *InputTask:
+			if validator := f.Validator(i); validator != nil {
+				t.WithValidator(validator)
+			}
+			queue.AddTasks(t)
+		default:
+			queue.AddTasks(t)
+		}
+	}
+	queue.bind = f.Assign
+	return queue
+}
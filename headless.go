@@ -0,0 +1,66 @@
+package ziva
+
+import (
+	"io"
+
+	"github.com/qzeleza/ziva/internal/query"
+)
+
+// ----------------------------------------------------------------------------
+// Неинтерактивный режим
+// ----------------------------------------------------------------------------
+
+// AnswerSource предоставляет заранее подготовленные ответы для неинтерактивного режима.
+// Ответ ищется по идентификатору задачи (WithID), а затем по её заголовку.
+type AnswerSource = query.AnswerSource
+
+// Answers — карта ответов "идентификатор или заголовок задачи → ответ".
+// Для YesNoTask ответом служит bool или строка "да"/"yes"/"нет"/"no",
+// для SingleSelectTask — ключ, название или индекс элемента,
+// для MultiSelectTask — список значений или строка через запятую,
+// для InputTask — строка или число.
+type Answers = query.MapAnswers
+
+// EnvAnswers читает ответы из переменных окружения вида PREFIX_ID_ЗАДАЧИ.
+type EnvAnswers = query.EnvAnswers
+
+// ChainAnswers объединяет несколько источников ответов: используется первый найденный ответ.
+type ChainAnswers = query.ChainAnswers
+
+// LoadAnswers читает ответы в формате YAML или JSON.
+//
+// @param r Источник данных
+// @return Карта ответов или ошибка разбора
+func LoadAnswers(r io.Reader) (Answers, error) {
+	return query.LoadAnswers(r)
+}
+
+// LoadAnswersFile читает ответы из файла в формате YAML или JSON.
+//
+// @param path Путь к файлу
+// @return Карта ответов или ошибка чтения/разбора
+func LoadAnswersFile(path string) (Answers, error) {
+	return query.LoadAnswersFile(path)
+}
+
+// AnswersFromEnv создаёт источник ответов из переменных окружения с указанным префиксом.
+// Например, для префикса "APP_" задача с ID "db-port" читает переменную APP_DB_PORT.
+//
+// @param prefix Префикс имён переменных окружения
+// @return Источник ответов
+func AnswersFromEnv(prefix string) AnswerSource {
+	return EnvAnswers{Prefix: prefix}
+}
+
+// WithHeadless включает неинтерактивный режим выполнения очереди (CI, provisioning-скрипты).
+// Задачи YesNo/SingleSelect/MultiSelect/Input получают ответы из источника, FuncTask
+// выполняются как обычно. Если ответа нет, используется значение по умолчанию
+// (тайм-аут или явно заданный элемент), иначе Run возвращает понятную ошибку.
+// В этом режиме Run также возвращает ошибку задачи, остановившей очередь.
+//
+// @param answers Источник ответов (nil — только значения по умолчанию)
+// @return Указатель на очередь задач
+func (q *Queue) WithHeadless(answers AnswerSource) *Queue {
+	q.model.WithHeadless(answers)
+	return q
+}
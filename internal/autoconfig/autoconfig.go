@@ -151,11 +151,6 @@ func firstNonEmpty(vals ...string) string {
 func isLimitedTerminal() bool {
 	term := strings.ToLower(os.Getenv("TERM"))
 
-	// Уважать NO_COLOR спеку — считать терминал ограниченным
-	if os.Getenv("NO_COLOR") != "" {
-		return true
-	}
-
 	// Принудительный ASCII‑режим
 	if v := strings.TrimSpace(strings.ToLower(os.Getenv("ZIVA_ASCII_ONLY"))); v == "1" || v == "true" || v == "yes" || v == "on" {
 		return true
@@ -173,8 +168,9 @@ func isLimitedTerminal() bool {
 
 	utf := strings.Contains(lang, "utf") || strings.Contains(lcAll, "utf") || strings.Contains(lcCtype, "utf")
 
-	// COLORTERM часто присутствует на современных терминалах
-	colorTerm := os.Getenv("COLORTERM") != ""
+	// COLORTERM часто присутствует на современных терминалах; CLICOLOR_FORCE
+	// явно требует цвета. NO_COLOR и CLICOLOR=0 отключают только цвет (см. ui.ColorModeFromEnv)
+	colorTerm := os.Getenv("COLORTERM") != "" || ui.ColorModeFromEnv() == ui.ColorAlways
 
 	return !utf || !colorTerm
 }
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Clock — источник времени очереди и задач: тайм-ауты, тикеры обратного отсчёта,
+// задержки повторов FuncTask и анимация спиннеров.
+// Подменяется поддельными часами в тестах (пакет zivatest), чтобы тайм-ауты
+// срабатывали мгновенно и детерминированно.
+type Clock interface {
+	// Now возвращает текущее время.
+	Now() time.Time
+
+	// Tick возвращает команду, которая через d передаёт сообщение fn(момент срабатывания).
+	Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd
+
+	// After возвращает канал, в который через d придёт момент срабатывания.
+	// Используется для ожиданий внутри выполняющихся функций.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock — часы на основе системного времени (используются по умолчанию)
+var SystemClock Clock = systemClock{}
+
+// systemClock реализует Clock через пакет time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return tea.Tick(d, fn)
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// ClockOrSystem возвращает переданные часы или системные, если часы не заданы.
+//
+// @param clock Часы (может быть nil)
+// @return Часы для использования
+func ClockOrSystem(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock
+	}
+	return clock
+}
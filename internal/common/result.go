@@ -0,0 +1,92 @@
+// Package common содержит общие интерфейсы и константы для всего приложения.
+package common
+
+import (
+	"time"
+
+	terrors "github.com/qzeleza/ziva/internal/errors"
+)
+
+// Виды задач, используемые в результатах очереди
+const (
+	KindYesNo        = "yesno"         // Вопрос Да/Нет
+	KindSingleSelect = "single_select" // Выбор одного варианта
+	KindMultiSelect  = "multi_select"  // Выбор нескольких вариантов
+	KindInput        = "input"         // Ввод значения
+	KindPath         = "path"          // Выбор файла или каталога
+	KindDate         = "date"          // Выбор даты
+	KindTime         = "time"          // Выбор времени суток
+	KindDuration     = "duration"      // Выбор длительности
+	KindNumber       = "number"        // Выбор числа
+	KindFunc         = "func"          // Выполнение функции
+	KindParallel     = "parallel"      // Параллельная группа функций
+	KindUnknown      = "task"          // Задача неизвестного вида
+)
+
+// ResultStatus описывает итоговое состояние задачи
+type ResultStatus string
+
+// Возможные состояния задачи в результатах очереди
+const (
+	ResultSuccess   ResultStatus = "success"   // Задача завершена успешно
+	ResultError     ResultStatus = "error"     // Задача завершена с ошибкой
+	ResultCancelled ResultStatus = "cancelled" // Задача отменена пользователем
+	ResultPending   ResultStatus = "pending"   // Задача не была выполнена
+	ResultSkipped   ResultStatus = "skipped"   // Задача пропущена по условию
+)
+
+// TaskResult содержит итог выполнения одной задачи очереди.
+type TaskResult struct {
+	Index     int               // Порядковый номер задачи в очереди (с нуля)
+	ID        string            // Идентификатор задачи (если задан)
+	Title     string            // Заголовок задачи
+	Kind      string            // Вид задачи (KindYesNo, KindInput и т.д.)
+	Status    ResultStatus      // Итоговое состояние
+	Value     interface{}       // Выбранное или введённое значение (bool, string)
+	Values    []string          // Выбранные значения задачи множественного выбора
+	TimedOut  bool              // Значение выбрано автоматически по истечении тайм-аута
+	Secret    bool              // Значение скрыто при выводе (например, пароль)
+	Err       error             // Ошибка задачи
+	ErrorType terrors.ErrorType // Тип ошибки задачи
+	Duration  time.Duration     // Время выполнения задачи
+	Attempts  int               // Число попыток выполнения функции (0 — задача не выполняла функцию)
+}
+
+// Results — упорядоченный список результатов задач очереди.
+type Results []TaskResult
+
+// ByID возвращает результат задачи с указанным идентификатором.
+//
+// @param id Идентификатор задачи
+// @return Результат задачи и признак его наличия
+func (r Results) ByID(id string) (TaskResult, bool) {
+	for _, result := range r {
+		if id != "" && result.ID == id {
+			return result, true
+		}
+	}
+	return TaskResult{}, false
+}
+
+// ByTitle возвращает результат первой задачи с указанным заголовком.
+//
+// @param title Заголовок задачи
+// @return Результат задачи и признак его наличия
+func (r Results) ByTitle(title string) (TaskResult, bool) {
+	for _, result := range r {
+		if result.Title == title {
+			return result, true
+		}
+	}
+	return TaskResult{}, false
+}
+
+// HasErrors сообщает, завершилась ли хотя бы одна задача с ошибкой.
+func (r Results) HasErrors() bool {
+	for _, result := range r {
+		if result.Status == ResultError {
+			return true
+		}
+	}
+	return false
+}
@@ -67,6 +67,51 @@ const (
 	InputVisiblePadding = 2
 )
 
+// Константы для многострочного ввода
+const (
+	// DefaultTextAreaHeight высота многострочного поля по умолчанию (в строках)
+	DefaultTextAreaHeight = 6
+
+	// MaxTextAreaLength максимальная длина многострочного текста по умолчанию
+	MaxTextAreaLength = 8192
+
+	// TextAreaPreviewLines количество строк текста в итоговом виде задачи
+	TextAreaPreviewLines = 3
+)
+
+// Константы для выбора файлов и каталогов
+const (
+	// DefaultFilePickerViewport количество видимых элементов списка файлов по умолчанию
+	DefaultFilePickerViewport = 10
+
+	// TypeAheadResetDelay пауза, после которой набранные для быстрого перехода символы сбрасываются
+	TypeAheadResetDelay = time.Second
+)
+
+// Константы для выбора даты, времени и длительности
+const (
+	// DateLayout формат даты в ответах и результатах задач
+	DateLayout = "2006-01-02"
+
+	// TimeLayout формат времени суток в ответах и результатах задач
+	TimeLayout = "15:04"
+
+	// TimeSecondsLayout формат времени суток с секундами
+	TimeSecondsLayout = "15:04:05"
+
+	// MaxDurationHours наибольшее значение поля часов длительности
+	MaxDurationHours = 9999
+)
+
+// Константы для выбора числа
+const (
+	// DefaultNumberGaugeWidth ширина шкалы задачи выбора числа в символах
+	DefaultNumberGaugeWidth = 30
+
+	// MaxNumberPrecision наибольшее количество знаков после запятой в задаче выбора числа
+	MaxNumberPrecision = 6
+)
+
 // Константы для отображения
 const (
 	// DefaultLayoutWidth ширина макета по умолчанию
@@ -248,5 +293,215 @@ var (
 	ValidatorCompositeAnySeparator       = " ИЛИ "
 )
 
+// Переменные для сообщений неинтерактивного режима
+var (
+	// ErrAnswerMissing сообщение об отсутствии ответа для задачи
+	ErrAnswerMissing         = "нет ответа для неинтерактивного режима и значение по умолчанию не задано"
+	ErrAnswerUnknownOption   = "вариант %q отсутствует в списке"
+	ErrAnswerDisabledOption  = "вариант %q недоступен для выбора"
+	ErrAnswerUnsupportedType = "неподдерживаемый тип ответа %T"
+	ErrAnswerUnsupportedTask = "задача не поддерживает неинтерактивный режим"
+)
+
+// Переменные для пропущенных по условию задач
+var (
+	// TaskStatusSkipped статус задачи, пропущенной по условию
+	TaskStatusSkipped = "ПРОПУЩЕНО"
+	// DefaultSkippedSummaryLabel подпись количества пропущенных задач в сводке
+	DefaultSkippedSummaryLabel = "пропущено"
+)
+
+// Переменные для отображения прогресса FuncTask
+var (
+	// ProgressETALabel подпись оставшегося времени в строке прогресса
+	ProgressETALabel = "осталось"
+	// ProgressElapsedFormat строка с общим временем выполнения задачи
+	ProgressElapsedFormat = "Выполнено за %s"
+)
+
+// Переменные для параллельной группы задач
+var (
+	// ParallelGroupFailedFormat итог группы, в которой часть задач завершилась с ошибкой
+	ParallelGroupFailedFormat = "%d из %d задач завершились с ошибкой"
+)
+
+// Переменные для повтора выполнения FuncTask
+var (
+	// RetryWaitFormat состояние задачи в ожидании очередной попытки
+	RetryWaitFormat = "попытка %d/%d, повтор через %s"
+	// RetryAttemptFormat номер выполняющейся попытки
+	RetryAttemptFormat = "попытка %d/%d"
+	// RetryAttemptsFormat общее число попыток в итоговом представлении
+	RetryAttemptsFormat = "Попыток: %d"
+	// RetryExhaustedPrompt вопрос после исчерпания попыток
+	RetryExhaustedPrompt = "Попытки исчерпаны. Что делать дальше?"
+	// RetryChoiceRetry вариант повторного выполнения
+	RetryChoiceRetry = "Повторить"
+	// RetryChoiceSkip вариант пропуска задачи
+	RetryChoiceSkip = "Пропустить"
+	// RetryChoiceAbort вариант завершения задачи с ошибкой
+	RetryChoiceAbort = "Прервать"
+	// RetryPromptHint подсказка управления выбором после исчерпания попыток
+	RetryPromptHint = "[←/→ выбор, Enter подтверждение]"
+)
+
+// Переменные для фильтра элементов в задачах выбора
+var (
+	// FilterPromptLabel подпись строки ввода фильтра
+	FilterPromptLabel = "Поиск:"
+	// FilterCountFormat количество найденных элементов
+	FilterCountFormat = "(%d из %d)"
+	// FilterNoMatches сообщение при отсутствии совпадений
+	FilterNoMatches = "ничего не найдено"
+	// FilterHelp подсказка управления в режиме фильтра
+	FilterHelp = "[ввод - фильтр, Enter - применить, Backspace - удалить символ, Esc - сбросить фильтр]"
+)
+
+// Переменные для сообщений декларативного описания очереди и фабрики валидаторов
+var (
+	// ErrSpecUnknownField сообщение о неизвестном поле описания очереди
+	ErrSpecUnknownField       = "неизвестное поле %q"
+	ErrSpecRequiredField      = "обязательное поле %q не задано"
+	ErrSpecFieldNotApplicable = "поле %q не применяется к задаче типа %q"
+	ErrSpecUnknownTaskType    = "неизвестный тип задачи %q, допустимые: %s"
+	ErrSpecUnknownInputType   = "неизвестный тип ввода %q, допустимые: %s"
+	ErrSpecUnknownItem        = "элемент %q отсутствует в списке задачи"
+	ErrSpecDuplicateKey       = "повторяющийся ключ %q"
+	ErrSpecInvalidValue       = "недопустимое значение %v: %s"
+	ErrSpecExpectedBool       = "ожидается yes/no или true/false"
+	ErrSpecExpectedString     = "ожидается строка"
+	ErrSpecExpectedList       = "ожидается список или строка через запятую"
+	// ErrValidatorUnknown сообщение о неизвестном имени валидатора
+	ErrValidatorUnknown = "неизвестный валидатор %q"
+	ErrValidatorArgs    = "валидатор %q ожидает аргументов: %d, получено: %d"
+	ErrValidatorSyntax  = "неверная запись валидатора %q"
+)
+
+// Переменные для сообщений файла темы оформления
+var (
+	// ErrThemeUnknown сообщение о неизвестной базовой теме
+	ErrThemeUnknown      = "неизвестная тема %q, допустимые: %s"
+	ErrThemeUnknownKey   = "неизвестный ключ %q, допустимые: %s"
+	ErrThemeInvalidColor = "недопустимый цвет %q: ожидается #RGB, #RRGGBB, номер ANSI-цвета от 0 до 255 или none"
+	ErrThemeSymbolWidth  = "символ %q занимает позиций: %d, ожидается одна"
+)
+
+// Переменные для построчного режима
+var (
+	// LineDefaultHint подсказка о значении по умолчанию (выбирается пустой строкой)
+	LineDefaultHint = "(по умолчанию %s)"
+	// LineMultiHint подсказка о вводе нескольких вариантов
+	LineMultiHint = "через запятую"
+	// LineDisabledMark отметка недоступного варианта
+	LineDisabledMark = "(недоступно)"
+	// LineChoiceRequired сообщение о пустом ответе без значения по умолчанию
+	LineChoiceRequired = "выберите один из вариантов"
+	// ErrLineInputClosed сообщение о закрытом вводе до получения ответа
+	ErrLineInputClosed = "ввод закрыт до получения ответа"
+)
+
+// Переменные для вопроса о возобновлении очереди и ошибок файла состояния
+var (
+	// ResumeQuestionFormat вопрос о возобновлении прерванной очереди с номером шага
+	ResumeQuestionFormat = "Запуск был прерван. Продолжить с шага %d?"
+	// ErrStateFileCorrupt сообщение о повреждённом файле состояния
+	ErrStateFileCorrupt = "файл состояния повреждён: %v"
+)
+
+// Переменные для сообщений формы, построенной по тегам структуры
+var (
+	// ErrFormTarget сообщение о неверном аргументе формы
+	ErrFormTarget          = "ожидается указатель на структуру, получено %T"
+	ErrFormUnsupportedType = "тип %s не поддерживается формой"
+	ErrFormUnknownOption   = "неизвестный параметр тега %q"
+	ErrFormChoicesRequired = "для списка строк нужен параметр choices"
+)
+
+// Переменные для записи ответов в файлы конфигурации
+var (
+	// SinkOverwriteQuestion вопрос о перезаписи изменённого файла конфигурации
+	SinkOverwriteQuestion = "Перезаписать %s? [д/Н]"
+	// ErrSinkUnknownTask сообщение о задаче, отсутствующей в результатах очереди
+	ErrSinkUnknownTask = "задача %q не найдена в очереди"
+	ErrSinkInvalidKey  = "недопустимое имя %q для формата %s"
+)
+
+// Переменные для начальных значений из переменных окружения и флагов
+var (
+	// PrefillSourceFormat пометка об источнике значения в итоговом виде задачи
+	PrefillSourceFormat = "(из %s)"
+)
+
+// Переменные для задачи выполнения команды
+var (
+	// ErrCommandExitCode ошибка завершения команды с ненулевым кодом
+	ErrCommandExitCode = "команда %s завершилась с кодом %d"
+	ErrCommandStart    = "не удалось запустить команду %s: %v"
+)
+
+// Переменные для многострочного ввода
+var (
+	// TextAreaConfirmHint подсказка по управлению многострочным полем
+	TextAreaConfirmHint     = "[Ctrl+D или Alt+Enter - подтвердить, Enter - новая строка, Ctrl+C - отменить]"
+	TextAreaLengthFormat    = "%d/%d символов"
+	TextAreaMoreLinesFormat = "… ещё строк: %d"
+	ErrTextTooLong          = "текст длиннее %d символов"
+	LineTextAreaHint        = "(завершите ввод строкой \".\")"
+)
+
+// Переменные для выбора файлов и каталогов
+var (
+	// FilePickerHelp подсказка управления выбором файла
+	FilePickerHelp         = "[↑/↓ навигация, →/← вглубь/вверх, Enter - выбрать, Esc - отмена]"
+	FilePickerMultiHelp    = "[↑/↓ навигация, →/← вглубь/вверх, пробел - отметить, Enter - готово, Esc - отмена]"
+	FilePickerDirHelp      = "[↑/↓ навигация, →/← вглубь/вверх, пробел - выбрать каталог, Esc - отмена]"
+	FilePickerKeysHelp     = "[буквы - переход к имени, Alt+. - скрытые файлы]"
+	FilePickerCurrentDir   = "(этот каталог)"
+	FilePickerEmpty        = "(нет подходящих файлов)"
+	FilePickerMarkedFormat = "отмечено: %d"
+	ErrPathOutsideRoot     = "путь %s находится за пределами каталога %s"
+	ErrPathNotFound        = "путь %s не существует"
+	ErrPathNotDir          = "%s не является каталогом"
+	ErrPathIsDir           = "%s является каталогом, а не файлом"
+	ErrPathFiltered        = "файл %s не подходит под фильтр"
+	ErrDirRead             = "не удалось прочитать каталог %s"
+	LinePathHint           = "(путь относительно %s)"
+	LinePathsHint          = "(пути относительно %s через запятую)"
+)
+
+// Переменные для выбора даты, времени и длительности
+var (
+	// MonthNames названия месяцев для заголовка календаря
+	MonthNames = []string{"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь", "Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь"}
+	// MonthNamesGenitive названия месяцев в дате вида "15 марта 2025"
+	MonthNamesGenitive = []string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"}
+	// WeekdayNames названия дней недели, начиная с понедельника
+	WeekdayNames = []string{"понедельник", "вторник", "среда", "четверг", "пятница", "суббота", "воскресенье"}
+	// WeekdayShortNames сокращённые названия дней недели для календаря, начиная с понедельника
+	WeekdayShortNames = []string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Вс"}
+	// DurationHoursUnit обозначение часов в длительности
+	DurationHoursUnit   = "ч"
+	DurationMinutesUnit = "мин"
+	DurationSecondsUnit = "с"
+	// DateTimeHelp подсказка управления выбором даты, времени и длительности
+	DateTimeHelp          = "[←/→ поле, ↑/↓ изменить, цифры - ввод, Enter - подтвердить, Esc - отмена]"
+	DateTimeRangeFormat   = "допустимо: %s – %s"
+	ErrValueBelowMin      = "значение должно быть не меньше %s"
+	ErrValueAboveMax      = "значение должно быть не больше %s"
+	ErrDateTimeFormat     = "ожидается значение в формате %s"
+	DateFormatHint        = "ГГГГ-ММ-ДД"
+	TimeFormatHint        = "ЧЧ:ММ"
+	TimeSecondsFormatHint = "ЧЧ:ММ:СС"
+	DurationFormatHint    = "1h30m"
+)
+
+// Переменные для выбора числа
+var (
+	// NumberHelp подсказка управления выбором числа
+	NumberHelp = "[←/→ шаг, PgUp/PgDn ×10, цифры - ввод, Enter - подтвердить, Esc - отмена]"
+	// LineNumberHint подсказка диапазона числа в построчном режиме
+	LineNumberHint = "(от %s до %s)"
+)
+
 const ClearScreen = "\033[H\033[2J"
 const HardClearScreen = "\033[3J\033[H\033[2J"
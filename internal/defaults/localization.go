@@ -118,6 +118,137 @@ type dictionary struct {
 	ValidatorListSeparator               string
 	ValidatorCompositeAllSeparator       string
 	ValidatorCompositeAnySeparator       string
+
+	// Headless mode strings
+	ErrAnswerMissing         string
+	ErrAnswerUnknownOption   string
+	ErrAnswerDisabledOption  string
+	ErrAnswerUnsupportedType string
+	ErrAnswerUnsupportedTask string
+
+	// Пропущенные задачи
+	TaskStatusSkipped          string
+	DefaultSkippedSummaryLabel string
+
+	// Прогресс выполнения функции
+	ProgressETALabel      string
+	ProgressElapsedFormat string
+
+	// Параллельная группа задач
+	ParallelGroupFailedFormat string
+
+	// Повтор выполнения функции
+	RetryWaitFormat      string
+	RetryAttemptFormat   string
+	RetryAttemptsFormat  string
+	RetryExhaustedPrompt string
+	RetryChoiceRetry     string
+	RetryChoiceSkip      string
+	RetryChoiceAbort     string
+	RetryPromptHint      string
+
+	// Фильтр элементов списка
+	FilterPromptLabel string
+	FilterCountFormat string
+	FilterNoMatches   string
+	FilterHelp        string
+
+	// Queue definition strings
+	ErrSpecUnknownField       string
+	ErrSpecRequiredField      string
+	ErrSpecFieldNotApplicable string
+	ErrSpecUnknownTaskType    string
+	ErrSpecUnknownInputType   string
+	ErrSpecUnknownItem        string
+	ErrSpecDuplicateKey       string
+	ErrSpecInvalidValue       string
+	ErrSpecExpectedBool       string
+	ErrSpecExpectedString     string
+	ErrSpecExpectedList       string
+	ErrValidatorUnknown       string
+	ErrValidatorArgs          string
+	ErrValidatorSyntax        string
+
+	// Theme file strings
+	ErrThemeUnknown      string
+	ErrThemeUnknownKey   string
+	ErrThemeInvalidColor string
+	ErrThemeSymbolWidth  string
+
+	// Line mode strings
+	LineDefaultHint    string
+	LineMultiHint      string
+	LineDisabledMark   string
+	LineChoiceRequired string
+	ErrLineInputClosed string
+
+	// Queue resume strings
+	ResumeQuestionFormat string
+	ErrStateFileCorrupt  string
+
+	// Struct form messages
+	ErrFormTarget          string
+	ErrFormUnsupportedType string
+	ErrFormUnknownOption   string
+	ErrFormChoicesRequired string
+
+	// Result sink strings
+	SinkOverwriteQuestion string
+	ErrSinkUnknownTask    string
+	ErrSinkInvalidKey     string
+
+	// Prefill source strings
+	PrefillSourceFormat string
+
+	// Command task strings
+	ErrCommandExitCode string
+	ErrCommandStart    string
+
+	// Text area strings
+	TextAreaConfirmHint     string
+	TextAreaLengthFormat    string
+	TextAreaMoreLinesFormat string
+	ErrTextTooLong          string
+	LineTextAreaHint        string
+
+	// File picker strings
+	FilePickerHelp         string
+	FilePickerMultiHelp    string
+	FilePickerDirHelp      string
+	FilePickerKeysHelp     string
+	FilePickerCurrentDir   string
+	FilePickerEmpty        string
+	FilePickerMarkedFormat string
+	ErrPathOutsideRoot     string
+	ErrPathNotFound        string
+	ErrPathNotDir          string
+	ErrPathIsDir           string
+	ErrPathFiltered        string
+	ErrDirRead             string
+	LinePathHint           string
+	LinePathsHint          string
+
+	// Date, time and duration strings
+	MonthNames            []string
+	MonthNamesGenitive    []string
+	WeekdayNames          []string
+	WeekdayShortNames     []string
+	DurationHoursUnit     string
+	DurationMinutesUnit   string
+	DurationSecondsUnit   string
+	DateTimeHelp          string
+	DateTimeRangeFormat   string
+	ErrValueBelowMin      string
+	ErrValueAboveMax      string
+	ErrDateTimeFormat     string
+	DateFormatHint        string
+	TimeFormatHint        string
+	TimeSecondsFormatHint string
+	DurationFormatHint    string
+
+	// Number task strings
+	NumberHelp     string
+	LineNumberHint string
 }
 
 var (
@@ -238,6 +369,101 @@ var (
 			ValidatorListSeparator:               ", ",
 			ValidatorCompositeAllSeparator:       "; ",
 			ValidatorCompositeAnySeparator:       " ИЛИ ",
+			ErrAnswerMissing:                     "нет ответа для неинтерактивного режима и значение по умолчанию не задано",
+			ErrAnswerUnknownOption:               "вариант %q отсутствует в списке",
+			ErrAnswerDisabledOption:              "вариант %q недоступен для выбора",
+			ErrAnswerUnsupportedType:             "неподдерживаемый тип ответа %T",
+			ErrAnswerUnsupportedTask:             "задача не поддерживает неинтерактивный режим",
+			TaskStatusSkipped:                    "ПРОПУЩЕНО",
+			DefaultSkippedSummaryLabel:           "пропущено",
+			ProgressETALabel:                     "осталось",
+			ProgressElapsedFormat:                "Выполнено за %s",
+			ParallelGroupFailedFormat:            "%d из %d задач завершились с ошибкой",
+			RetryWaitFormat:                      "попытка %d/%d, повтор через %s",
+			RetryAttemptFormat:                   "попытка %d/%d",
+			RetryAttemptsFormat:                  "Попыток: %d",
+			RetryExhaustedPrompt:                 "Попытки исчерпаны. Что делать дальше?",
+			RetryChoiceRetry:                     "Повторить",
+			RetryChoiceSkip:                      "Пропустить",
+			RetryChoiceAbort:                     "Прервать",
+			RetryPromptHint:                      "[←/→ выбор, Enter подтверждение]",
+			FilterPromptLabel:                    "Поиск:",
+			FilterCountFormat:                    "(%d из %d)",
+			FilterNoMatches:                      "ничего не найдено",
+			FilterHelp:                           "[ввод - фильтр, Enter - применить, Backspace - удалить символ, Esc - сбросить фильтр]",
+			ErrSpecUnknownField:                  "неизвестное поле %q",
+			ErrSpecRequiredField:                 "обязательное поле %q не задано",
+			ErrSpecFieldNotApplicable:            "поле %q не применяется к задаче типа %q",
+			ErrSpecUnknownTaskType:               "неизвестный тип задачи %q, допустимые: %s",
+			ErrSpecUnknownInputType:              "неизвестный тип ввода %q, допустимые: %s",
+			ErrSpecUnknownItem:                   "элемент %q отсутствует в списке задачи",
+			ErrSpecDuplicateKey:                  "повторяющийся ключ %q",
+			ErrSpecInvalidValue:                  "недопустимое значение %v: %s",
+			ErrSpecExpectedBool:                  "ожидается yes/no или true/false",
+			ErrSpecExpectedString:                "ожидается строка",
+			ErrSpecExpectedList:                  "ожидается список или строка через запятую",
+			ErrValidatorUnknown:                  "неизвестный валидатор %q",
+			ErrValidatorArgs:                     "валидатор %q ожидает аргументов: %d, получено: %d",
+			ErrValidatorSyntax:                   "неверная запись валидатора %q",
+			ErrThemeUnknown:                      "неизвестная тема %q, допустимые: %s",
+			ErrThemeUnknownKey:                   "неизвестный ключ %q, допустимые: %s",
+			ErrThemeInvalidColor:                 "недопустимый цвет %q: ожидается #RGB, #RRGGBB, номер ANSI-цвета от 0 до 255 или none",
+			ErrThemeSymbolWidth:                  "символ %q занимает позиций: %d, ожидается одна",
+			LineDefaultHint:                      "(по умолчанию %s)",
+			LineMultiHint:                        "через запятую",
+			LineDisabledMark:                     "(недоступно)",
+			LineChoiceRequired:                   "выберите один из вариантов",
+			ErrLineInputClosed:                   "ввод закрыт до получения ответа",
+			ResumeQuestionFormat:                 "Запуск был прерван. Продолжить с шага %d?",
+			ErrStateFileCorrupt:                  "файл состояния повреждён: %v",
+			ErrFormTarget:                        "ожидается указатель на структуру, получено %T",
+			ErrFormUnsupportedType:               "тип %s не поддерживается формой",
+			ErrFormUnknownOption:                 "неизвестный параметр тега %q",
+			ErrFormChoicesRequired:               "для списка строк нужен параметр choices",
+			SinkOverwriteQuestion:                "Перезаписать %s? [д/Н]",
+			ErrSinkUnknownTask:                   "задача %q не найдена в очереди",
+			ErrSinkInvalidKey:                    "недопустимое имя %q для формата %s",
+			PrefillSourceFormat:                  "(из %s)",
+			ErrCommandExitCode:                   "команда %s завершилась с кодом %d",
+			ErrCommandStart:                      "не удалось запустить команду %s: %v",
+			TextAreaConfirmHint:                  "[Ctrl+D или Alt+Enter - подтвердить, Enter - новая строка, Ctrl+C - отменить]",
+			TextAreaLengthFormat:                 "%d/%d символов",
+			TextAreaMoreLinesFormat:              "… ещё строк: %d",
+			ErrTextTooLong:                       "текст длиннее %d символов",
+			LineTextAreaHint:                     "(завершите ввод строкой \".\")",
+			FilePickerHelp:                       "[↑/↓ навигация, →/← вглубь/вверх, Enter - выбрать, Esc - отмена]",
+			FilePickerMultiHelp:                  "[↑/↓ навигация, →/← вглубь/вверх, пробел - отметить, Enter - готово, Esc - отмена]",
+			FilePickerDirHelp:                    "[↑/↓ навигация, →/← вглубь/вверх, пробел - выбрать каталог, Esc - отмена]",
+			FilePickerKeysHelp:                   "[буквы - переход к имени, Alt+. - скрытые файлы]",
+			FilePickerCurrentDir:                 "(этот каталог)",
+			FilePickerEmpty:                      "(нет подходящих файлов)",
+			FilePickerMarkedFormat:               "отмечено: %d",
+			ErrPathOutsideRoot:                   "путь %s находится за пределами каталога %s",
+			ErrPathNotFound:                      "путь %s не существует",
+			ErrPathNotDir:                        "%s не является каталогом",
+			ErrPathIsDir:                         "%s является каталогом, а не файлом",
+			ErrPathFiltered:                      "файл %s не подходит под фильтр",
+			ErrDirRead:                           "не удалось прочитать каталог %s",
+			LinePathHint:                         "(путь относительно %s)",
+			LinePathsHint:                        "(пути относительно %s через запятую)",
+			MonthNames:                           []string{"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь", "Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь"},
+			MonthNamesGenitive:                   []string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+			WeekdayNames:                         []string{"понедельник", "вторник", "среда", "четверг", "пятница", "суббота", "воскресенье"},
+			WeekdayShortNames:                    []string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Вс"},
+			DurationHoursUnit:                    "ч",
+			DurationMinutesUnit:                  "мин",
+			DurationSecondsUnit:                  "с",
+			DateTimeHelp:                         "[←/→ поле, ↑/↓ изменить, цифры - ввод, Enter - подтвердить, Esc - отмена]",
+			DateTimeRangeFormat:                  "допустимо: %s – %s",
+			ErrValueBelowMin:                     "значение должно быть не меньше %s",
+			ErrValueAboveMax:                     "значение должно быть не больше %s",
+			ErrDateTimeFormat:                    "ожидается значение в формате %s",
+			DateFormatHint:                       "ГГГГ-ММ-ДД",
+			TimeFormatHint:                       "ЧЧ:ММ",
+			TimeSecondsFormatHint:                "ЧЧ:ММ:СС",
+			DurationFormatHint:                   "1h30m",
+			NumberHelp:                           "[←/→ шаг, PgUp/PgDn ×10, цифры - ввод, Enter - подтвердить, Esc - отмена]",
+			LineNumberHint:                       "(от %s до %s)",
 		},
 		"en": {
 			StatusSuccess:                        "SUCCESS",
@@ -352,6 +578,101 @@ var (
 			ValidatorListSeparator:               ", ",
 			ValidatorCompositeAllSeparator:       "; ",
 			ValidatorCompositeAnySeparator:       " OR ",
+			ErrAnswerMissing:                     "no answer provided for non-interactive mode and no default value is set",
+			ErrAnswerUnknownOption:               "option %q is not in the list",
+			ErrAnswerDisabledOption:              "option %q is disabled",
+			ErrAnswerUnsupportedType:             "unsupported answer type %T",
+			ErrAnswerUnsupportedTask:             "task does not support non-interactive mode",
+			TaskStatusSkipped:                    "SKIPPED",
+			DefaultSkippedSummaryLabel:           "skipped",
+			ProgressETALabel:                     "ETA",
+			ProgressElapsedFormat:                "Completed in %s",
+			ParallelGroupFailedFormat:            "%d of %d tasks failed",
+			RetryWaitFormat:                      "attempt %d/%d, retrying in %s",
+			RetryAttemptFormat:                   "attempt %d/%d",
+			RetryAttemptsFormat:                  "Attempts: %d",
+			RetryExhaustedPrompt:                 "All attempts failed. What next?",
+			RetryChoiceRetry:                     "Retry",
+			RetryChoiceSkip:                      "Skip",
+			RetryChoiceAbort:                     "Abort",
+			RetryPromptHint:                      "[←/→ choose, Enter confirm]",
+			FilterPromptLabel:                    "Search:",
+			FilterCountFormat:                    "(%d of %d)",
+			FilterNoMatches:                      "no matches",
+			FilterHelp:                           "[type to filter, Enter - apply, Backspace - delete character, Esc - clear filter]",
+			ErrSpecUnknownField:                  "unknown field %q",
+			ErrSpecRequiredField:                 "required field %q is missing",
+			ErrSpecFieldNotApplicable:            "field %q does not apply to task type %q",
+			ErrSpecUnknownTaskType:               "unknown task type %q, expected one of: %s",
+			ErrSpecUnknownInputType:              "unknown input type %q, expected one of: %s",
+			ErrSpecUnknownItem:                   "item %q is not in the task's list",
+			ErrSpecDuplicateKey:                  "duplicate key %q",
+			ErrSpecInvalidValue:                  "invalid value %v: %s",
+			ErrSpecExpectedBool:                  "expected yes/no or true/false",
+			ErrSpecExpectedString:                "expected a string",
+			ErrSpecExpectedList:                  "expected a list or a comma-separated string",
+			ErrValidatorUnknown:                  "unknown validator %q",
+			ErrValidatorArgs:                     "validator %q expects %d argument(s), got %d",
+			ErrValidatorSyntax:                   "malformed validator expression %q",
+			ErrThemeUnknown:                      "unknown theme %q, allowed: %s",
+			ErrThemeUnknownKey:                   "unknown key %q, allowed: %s",
+			ErrThemeInvalidColor:                 "invalid color %q: expected #RGB, #RRGGBB, an ANSI color number from 0 to 255 or none",
+			ErrThemeSymbolWidth:                  "symbol %q is %d cells wide, expected one",
+			LineDefaultHint:                      "(default %s)",
+			LineMultiHint:                        "comma-separated",
+			LineDisabledMark:                     "(unavailable)",
+			LineChoiceRequired:                   "choose one of the options",
+			ErrLineInputClosed:                   "input closed before an answer was given",
+			ResumeQuestionFormat:                 "The previous run was interrupted. Resume from step %d?",
+			ErrStateFileCorrupt:                  "state file is corrupt: %v",
+			ErrFormTarget:                        "expected a pointer to a struct, got %T",
+			ErrFormUnsupportedType:               "type %s is not supported by forms",
+			ErrFormUnknownOption:                 "unknown tag option %q",
+			ErrFormChoicesRequired:               "a string list requires the choices option",
+			SinkOverwriteQuestion:                "Overwrite %s? [y/N]",
+			ErrSinkUnknownTask:                   "task %q not found in the queue",
+			ErrSinkInvalidKey:                    "invalid name %q for format %s",
+			PrefillSourceFormat:                  "(from %s)",
+			ErrCommandExitCode:                   "command %s exited with code %d",
+			ErrCommandStart:                      "failed to start command %s: %v",
+			TextAreaConfirmHint:                  "[Ctrl+D or Alt+Enter - submit, Enter - new line, Ctrl+C - cancel]",
+			TextAreaLengthFormat:                 "%d/%d characters",
+			TextAreaMoreLinesFormat:              "… %d more lines",
+			ErrTextTooLong:                       "text is longer than %d characters",
+			LineTextAreaHint:                     "(finish with a line containing \".\")",
+			FilePickerHelp:                       "[↑/↓ navigate, →/← open/up, Enter - select, Esc - cancel]",
+			FilePickerMultiHelp:                  "[↑/↓ navigate, →/← open/up, space - mark, Enter - done, Esc - cancel]",
+			FilePickerDirHelp:                    "[↑/↓ navigate, →/← open/up, space - select directory, Esc - cancel]",
+			FilePickerKeysHelp:                   "[letters - jump to name, Alt+. - hidden files]",
+			FilePickerCurrentDir:                 "(this directory)",
+			FilePickerEmpty:                      "(no matching files)",
+			FilePickerMarkedFormat:               "marked: %d",
+			ErrPathOutsideRoot:                   "path %s is outside of %s",
+			ErrPathNotFound:                      "path %s does not exist",
+			ErrPathNotDir:                        "%s is not a directory",
+			ErrPathIsDir:                         "%s is a directory, not a file",
+			ErrPathFiltered:                      "file %s does not match the filter",
+			ErrDirRead:                           "cannot read directory %s",
+			LinePathHint:                         "(path relative to %s)",
+			LinePathsHint:                        "(comma-separated paths relative to %s)",
+			MonthNames:                           []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+			MonthNamesGenitive:                   []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+			WeekdayNames:                         []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"},
+			WeekdayShortNames:                    []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"},
+			DurationHoursUnit:                    "h",
+			DurationMinutesUnit:                  "min",
+			DurationSecondsUnit:                  "s",
+			DateTimeHelp:                         "[←/→ field, ↑/↓ change, digits - type, Enter - confirm, Esc - cancel]",
+			DateTimeRangeFormat:                  "allowed: %s – %s",
+			ErrValueBelowMin:                     "value must not be less than %s",
+			ErrValueAboveMax:                     "value must not be greater than %s",
+			ErrDateTimeFormat:                    "expected a value in the format %s",
+			DateFormatHint:                       "YYYY-MM-DD",
+			TimeFormatHint:                       "HH:MM",
+			TimeSecondsFormatHint:                "HH:MM:SS",
+			DurationFormatHint:                   "1h30m",
+			NumberHelp:                           "[←/→ step, PgUp/PgDn ×10, digits - type, Enter - confirm, Esc - cancel]",
+			LineNumberHint:                       "(from %s to %s)",
 		},
 		"tr": {
 			StatusSuccess:                        "BAŞARILI",
@@ -466,6 +787,101 @@ var (
 			ValidatorListSeparator:               ", ",
 			ValidatorCompositeAllSeparator:       "; ",
 			ValidatorCompositeAnySeparator:       " VEYA ",
+			ErrAnswerMissing:                     "etkileşimsiz mod için yanıt yok ve varsayılan değer ayarlanmamış",
+			ErrAnswerUnknownOption:               "%q seçeneği listede yok",
+			ErrAnswerDisabledOption:              "%q seçeneği devre dışı",
+			ErrAnswerUnsupportedType:             "desteklenmeyen yanıt türü %T",
+			ErrAnswerUnsupportedTask:             "görev etkileşimsiz modu desteklemiyor",
+			TaskStatusSkipped:                    "ATLANDI",
+			DefaultSkippedSummaryLabel:           "atlandı",
+			ProgressETALabel:                     "kalan",
+			ProgressElapsedFormat:                "%s içinde tamamlandı",
+			ParallelGroupFailedFormat:            "%d/%d görev başarısız oldu",
+			RetryWaitFormat:                      "deneme %d/%d, %s sonra yeniden denenecek",
+			RetryAttemptFormat:                   "deneme %d/%d",
+			RetryAttemptsFormat:                  "Deneme sayısı: %d",
+			RetryExhaustedPrompt:                 "Tüm denemeler başarısız oldu. Ne yapılsın?",
+			RetryChoiceRetry:                     "Yeniden dene",
+			RetryChoiceSkip:                      "Atla",
+			RetryChoiceAbort:                     "İptal et",
+			RetryPromptHint:                      "[←/→ seç, Enter onayla]",
+			FilterPromptLabel:                    "Ara:",
+			FilterCountFormat:                    "(%d / %d)",
+			FilterNoMatches:                      "eşleşme yok",
+			FilterHelp:                           "[filtrelemek için yazın, Enter - uygula, Backspace - karakter sil, Esc - filtreyi temizle]",
+			ErrSpecUnknownField:                  "bilinmeyen alan %q",
+			ErrSpecRequiredField:                 "zorunlu alan %q eksik",
+			ErrSpecFieldNotApplicable:            "%q alanı %q görev türüne uygulanamaz",
+			ErrSpecUnknownTaskType:               "bilinmeyen görev türü %q, beklenen: %s",
+			ErrSpecUnknownInputType:              "bilinmeyen giriş türü %q, beklenen: %s",
+			ErrSpecUnknownItem:                   "%q öğesi görevin listesinde yok",
+			ErrSpecDuplicateKey:                  "yinelenen anahtar %q",
+			ErrSpecInvalidValue:                  "geçersiz değer %v: %s",
+			ErrSpecExpectedBool:                  "yes/no veya true/false bekleniyor",
+			ErrSpecExpectedString:                "metin bekleniyor",
+			ErrSpecExpectedList:                  "liste veya virgülle ayrılmış metin bekleniyor",
+			ErrValidatorUnknown:                  "bilinmeyen doğrulayıcı %q",
+			ErrValidatorArgs:                     "%q doğrulayıcısı %d argüman bekliyor, %d alındı",
+			ErrValidatorSyntax:                   "hatalı doğrulayıcı ifadesi %q",
+			ErrThemeUnknown:                      "bilinmeyen tema %q, izin verilenler: %s",
+			ErrThemeUnknownKey:                   "bilinmeyen anahtar %q, izin verilenler: %s",
+			ErrThemeInvalidColor:                 "geçersiz renk %q: #RGB, #RRGGBB, 0 ile 255 arasında bir ANSI renk numarası veya none bekleniyor",
+			ErrThemeSymbolWidth:                  "%q sembolü %d hücre genişliğinde, bir hücre bekleniyor",
+			LineDefaultHint:                      "(varsayılan %s)",
+			LineMultiHint:                        "virgülle ayrılmış",
+			LineDisabledMark:                     "(kullanılamaz)",
+			LineChoiceRequired:                   "seçeneklerden birini seçin",
+			ErrLineInputClosed:                   "yanıt alınmadan giriş kapandı",
+			ResumeQuestionFormat:                 "Önceki çalıştırma yarıda kaldı. %d. adımdan devam edilsin mi?",
+			ErrStateFileCorrupt:                  "durum dosyası bozuk: %v",
+			ErrFormTarget:                        "bir yapı işaretçisi bekleniyor, alınan: %T",
+			ErrFormUnsupportedType:               "%s türü formlarda desteklenmiyor",
+			ErrFormUnknownOption:                 "bilinmeyen etiket seçeneği %q",
+			ErrFormChoicesRequired:               "dize listesi için choices seçeneği gerekli",
+			SinkOverwriteQuestion:                "%s üzerine yazılsın mı? [e/H]",
+			ErrSinkUnknownTask:                   "%q görevi kuyrukta bulunamadı",
+			ErrSinkInvalidKey:                    "%s biçimi için geçersiz ad %q",
+			PrefillSourceFormat:                  "(%s kaynağından)",
+			ErrCommandExitCode:                   "%s komutu %d koduyla sonlandı",
+			ErrCommandStart:                      "%s komutu başlatılamadı: %v",
+			TextAreaConfirmHint:                  "[Ctrl+D veya Alt+Enter - onayla, Enter - yeni satır, Ctrl+C - iptal]",
+			TextAreaLengthFormat:                 "%d/%d karakter",
+			TextAreaMoreLinesFormat:              "… %d satır daha",
+			ErrTextTooLong:                       "metin %d karakterden uzun",
+			LineTextAreaHint:                     "(girişi \".\" satırıyla bitirin)",
+			FilePickerHelp:                       "[↑/↓ gezinme, →/← aç/yukarı, Enter - seç, Esc - iptal]",
+			FilePickerMultiHelp:                  "[↑/↓ gezinme, →/← aç/yukarı, boşluk - işaretle, Enter - tamam, Esc - iptal]",
+			FilePickerDirHelp:                    "[↑/↓ gezinme, →/← aç/yukarı, boşluk - dizini seç, Esc - iptal]",
+			FilePickerKeysHelp:                   "[harfler - ada atla, Alt+. - gizli dosyalar]",
+			FilePickerCurrentDir:                 "(bu dizin)",
+			FilePickerEmpty:                      "(uygun dosya yok)",
+			FilePickerMarkedFormat:               "işaretli: %d",
+			ErrPathOutsideRoot:                   "%s yolu %s dizininin dışında",
+			ErrPathNotFound:                      "%s yolu mevcut değil",
+			ErrPathNotDir:                        "%s bir dizin değil",
+			ErrPathIsDir:                         "%s bir dosya değil, dizin",
+			ErrPathFiltered:                      "%s dosyası filtreye uymuyor",
+			ErrDirRead:                           "%s dizini okunamadı",
+			LinePathHint:                         "(%s dizinine göre yol)",
+			LinePathsHint:                        "(%s dizinine göre virgülle ayrılmış yollar)",
+			MonthNames:                           []string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+			MonthNamesGenitive:                   []string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+			WeekdayNames:                         []string{"Pazartesi", "Salı", "Çarşamba", "Perşembe", "Cuma", "Cumartesi", "Pazar"},
+			WeekdayShortNames:                    []string{"Pt", "Sa", "Ça", "Pe", "Cu", "Ct", "Pz"},
+			DurationHoursUnit:                    "sa",
+			DurationMinutesUnit:                  "dk",
+			DurationSecondsUnit:                  "sn",
+			DateTimeHelp:                         "[←/→ alan, ↑/↓ değiştir, rakamlar - giriş, Enter - onayla, Esc - iptal]",
+			DateTimeRangeFormat:                  "izin verilen: %s – %s",
+			ErrValueBelowMin:                     "değer %s değerinden küçük olmamalı",
+			ErrValueAboveMax:                     "değer %s değerinden büyük olmamalı",
+			ErrDateTimeFormat:                    "%s biçiminde bir değer bekleniyor",
+			DateFormatHint:                       "YYYY-AA-GG",
+			TimeFormatHint:                       "SS:DD",
+			TimeSecondsFormatHint:                "SS:DD:ss",
+			DurationFormatHint:                   "1h30m",
+			NumberHelp:                           "[←/→ adım, PgUp/PgDn ×10, rakamlar - giriş, Enter - onayla, Esc - iptal]",
+			LineNumberHint:                       "(%s ile %s arası)",
 		},
 		"be": {
 			StatusSuccess:                        "Паспяхова",
@@ -580,6 +996,101 @@ var (
 			ValidatorListSeparator:               ", ",
 			ValidatorCompositeAllSeparator:       "; ",
 			ValidatorCompositeAnySeparator:       " АБО ",
+			ErrAnswerMissing:                     "няма адказу для неінтэрактыўнага рэжыму і значэнне па змаўчанні не зададзена",
+			ErrAnswerUnknownOption:               "варыянт %q адсутнічае ў спісе",
+			ErrAnswerDisabledOption:              "варыянт %q недаступны для выбару",
+			ErrAnswerUnsupportedType:             "непадтрымліваемы тып адказу %T",
+			ErrAnswerUnsupportedTask:             "задача не падтрымлівае неінтэрактыўны рэжым",
+			TaskStatusSkipped:                    "ПРАПУШЧАНА",
+			DefaultSkippedSummaryLabel:           "прапушчана",
+			ProgressETALabel:                     "засталося",
+			ProgressElapsedFormat:                "Выканана за %s",
+			ParallelGroupFailedFormat:            "%d з %d задач завяршыліся з памылкай",
+			RetryWaitFormat:                      "спроба %d/%d, паўтор праз %s",
+			RetryAttemptFormat:                   "спроба %d/%d",
+			RetryAttemptsFormat:                  "Спроб: %d",
+			RetryExhaustedPrompt:                 "Спробы скончыліся. Што рабіць далей?",
+			RetryChoiceRetry:                     "Паўтарыць",
+			RetryChoiceSkip:                      "Прапусціць",
+			RetryChoiceAbort:                     "Перапыніць",
+			RetryPromptHint:                      "[←/→ выбар, Enter пацвярджэнне]",
+			FilterPromptLabel:                    "Пошук:",
+			FilterCountFormat:                    "(%d з %d)",
+			FilterNoMatches:                      "нічога не знойдзена",
+			FilterHelp:                           "[увод - фільтр, Enter - ужыць, Backspace - выдаліць сімвал, Esc - скінуць фільтр]",
+			ErrSpecUnknownField:                  "невядомае поле %q",
+			ErrSpecRequiredField:                 "абавязковае поле %q не зададзена",
+			ErrSpecFieldNotApplicable:            "поле %q не ўжываецца да задачы тыпу %q",
+			ErrSpecUnknownTaskType:               "невядомы тып задачы %q, дапушчальныя: %s",
+			ErrSpecUnknownInputType:              "невядомы тып уводу %q, дапушчальныя: %s",
+			ErrSpecUnknownItem:                   "элемент %q адсутнічае ў спісе задачы",
+			ErrSpecDuplicateKey:                  "паўторны ключ %q",
+			ErrSpecInvalidValue:                  "недапушчальнае значэнне %v: %s",
+			ErrSpecExpectedBool:                  "чакаецца yes/no або true/false",
+			ErrSpecExpectedString:                "чакаецца радок",
+			ErrSpecExpectedList:                  "чакаецца спіс або радок праз коску",
+			ErrValidatorUnknown:                  "невядомы валідатар %q",
+			ErrValidatorArgs:                     "валідатар %q чакае аргументаў: %d, атрымана: %d",
+			ErrValidatorSyntax:                   "няправільны запіс валідатара %q",
+			ErrThemeUnknown:                      "невядомая тэма %q, дапушчальныя: %s",
+			ErrThemeUnknownKey:                   "невядомы ключ %q, дапушчальныя: %s",
+			ErrThemeInvalidColor:                 "недапушчальны колер %q: чакаецца #RGB, #RRGGBB, нумар ANSI-колеру ад 0 да 255 або none",
+			ErrThemeSymbolWidth:                  "сімвал %q займае пазіцый: %d, чакаецца адна",
+			LineDefaultHint:                      "(па змаўчанні %s)",
+			LineMultiHint:                        "праз коску",
+			LineDisabledMark:                     "(недаступна)",
+			LineChoiceRequired:                   "выберыце адзін з варыянтаў",
+			ErrLineInputClosed:                   "увод закрыты да атрымання адказу",
+			ResumeQuestionFormat:                 "Запуск быў перапынены. Працягнуць з кроку %d?",
+			ErrStateFileCorrupt:                  "файл стану пашкоджаны: %v",
+			ErrFormTarget:                        "чакаецца паказальнік на структуру, атрымана %T",
+			ErrFormUnsupportedType:               "тып %s не падтрымліваецца формай",
+			ErrFormUnknownOption:                 "невядомы параметр тэга %q",
+			ErrFormChoicesRequired:               "для спіса радкоў патрэбны параметр choices",
+			SinkOverwriteQuestion:                "Перазапісаць %s? [т/Н]",
+			ErrSinkUnknownTask:                   "задача %q не знойдзена ў чарзе",
+			ErrSinkInvalidKey:                    "недапушчальнае імя %q для фармату %s",
+			PrefillSourceFormat:                  "(з %s)",
+			ErrCommandExitCode:                   "каманда %s завяршылася з кодам %d",
+			ErrCommandStart:                      "не ўдалося запусціць каманду %s: %v",
+			TextAreaConfirmHint:                  "[Ctrl+D або Alt+Enter - пацвердзіць, Enter - новы радок, Ctrl+C - скасаваць]",
+			TextAreaLengthFormat:                 "%d/%d сімвалаў",
+			TextAreaMoreLinesFormat:              "… яшчэ радкоў: %d",
+			ErrTextTooLong:                       "тэкст даўжэйшы за %d сімвалаў",
+			LineTextAreaHint:                     "(завяршыце ўвод радком \".\")",
+			FilePickerHelp:                       "[↑/↓ навігацыя, →/← углыб/уверх, Enter - выбраць, Esc - адмена]",
+			FilePickerMultiHelp:                  "[↑/↓ навігацыя, →/← углыб/уверх, прабел - адзначыць, Enter - гатова, Esc - адмена]",
+			FilePickerDirHelp:                    "[↑/↓ навігацыя, →/← углыб/уверх, прабел - выбраць каталог, Esc - адмена]",
+			FilePickerKeysHelp:                   "[літары - пераход да імя, Alt+. - схаваныя файлы]",
+			FilePickerCurrentDir:                 "(гэты каталог)",
+			FilePickerEmpty:                      "(няма адпаведных файлаў)",
+			FilePickerMarkedFormat:               "адзначана: %d",
+			ErrPathOutsideRoot:                   "шлях %s знаходзіцца за межамі каталога %s",
+			ErrPathNotFound:                      "шлях %s не існуе",
+			ErrPathNotDir:                        "%s не з'яўляецца каталогам",
+			ErrPathIsDir:                         "%s з'яўляецца каталогам, а не файлам",
+			ErrPathFiltered:                      "файл %s не адпавядае фільтру",
+			ErrDirRead:                           "не ўдалося прачытаць каталог %s",
+			LinePathHint:                         "(шлях адносна %s)",
+			LinePathsHint:                        "(шляхі адносна %s праз коску)",
+			MonthNames:                           []string{"Студзень", "Люты", "Сакавік", "Красавік", "Травень", "Чэрвень", "Ліпень", "Жнівень", "Верасень", "Кастрычнік", "Лістапад", "Снежань"},
+			MonthNamesGenitive:                   []string{"студзеня", "лютага", "сакавіка", "красавіка", "траўня", "чэрвеня", "ліпеня", "жніўня", "верасня", "кастрычніка", "лістапада", "снежня"},
+			WeekdayNames:                         []string{"панядзелак", "аўторак", "серада", "чацвер", "пятніца", "субота", "нядзеля"},
+			WeekdayShortNames:                    []string{"Пн", "Аў", "Ср", "Чц", "Пт", "Сб", "Нд"},
+			DurationHoursUnit:                    "г",
+			DurationMinutesUnit:                  "хв",
+			DurationSecondsUnit:                  "с",
+			DateTimeHelp:                         "[←/→ поле, ↑/↓ змяніць, лічбы - увод, Enter - пацвердзіць, Esc - адмена]",
+			DateTimeRangeFormat:                  "дапушчальна: %s – %s",
+			ErrValueBelowMin:                     "значэнне павінна быць не меншым за %s",
+			ErrValueAboveMax:                     "значэнне павінна быць не большым за %s",
+			ErrDateTimeFormat:                    "чакаецца значэнне ў фармаце %s",
+			DateFormatHint:                       "ГГГГ-ММ-ДД",
+			TimeFormatHint:                       "ГГ:ХХ",
+			TimeSecondsFormatHint:                "ГГ:ХХ:СС",
+			DurationFormatHint:                   "1h30m",
+			NumberHelp:                           "[←/→ крок, PgUp/PgDn ×10, лічбы - увод, Enter - пацвердзіць, Esc - адмена]",
+			LineNumberHint:                       "(ад %s да %s)",
 		},
 		"uk": {
 			StatusSuccess:                        "УСПІХ",
@@ -694,6 +1205,101 @@ var (
 			ValidatorListSeparator:               ", ",
 			ValidatorCompositeAllSeparator:       "; ",
 			ValidatorCompositeAnySeparator:       " АБО ",
+			ErrAnswerMissing:                     "немає відповіді для неінтерактивного режиму і значення за замовчуванням не задано",
+			ErrAnswerUnknownOption:               "варіант %q відсутній у списку",
+			ErrAnswerDisabledOption:              "варіант %q недоступний для вибору",
+			ErrAnswerUnsupportedType:             "непідтримуваний тип відповіді %T",
+			ErrAnswerUnsupportedTask:             "завдання не підтримує неінтерактивний режим",
+			TaskStatusSkipped:                    "ПРОПУЩЕНО",
+			DefaultSkippedSummaryLabel:           "пропущено",
+			ProgressETALabel:                     "залишилось",
+			ProgressElapsedFormat:                "Виконано за %s",
+			ParallelGroupFailedFormat:            "%d з %d завдань завершились з помилкою",
+			RetryWaitFormat:                      "спроба %d/%d, повтор через %s",
+			RetryAttemptFormat:                   "спроба %d/%d",
+			RetryAttemptsFormat:                  "Спроб: %d",
+			RetryExhaustedPrompt:                 "Спроби вичерпано. Що робити далі?",
+			RetryChoiceRetry:                     "Повторити",
+			RetryChoiceSkip:                      "Пропустити",
+			RetryChoiceAbort:                     "Перервати",
+			RetryPromptHint:                      "[←/→ вибір, Enter підтвердження]",
+			FilterPromptLabel:                    "Пошук:",
+			FilterCountFormat:                    "(%d з %d)",
+			FilterNoMatches:                      "нічого не знайдено",
+			FilterHelp:                           "[введення - фільтр, Enter - застосувати, Backspace - видалити символ, Esc - скинути фільтр]",
+			ErrSpecUnknownField:                  "невідоме поле %q",
+			ErrSpecRequiredField:                 "обов'язкове поле %q не задано",
+			ErrSpecFieldNotApplicable:            "поле %q не застосовується до задачі типу %q",
+			ErrSpecUnknownTaskType:               "невідомий тип задачі %q, допустимі: %s",
+			ErrSpecUnknownInputType:              "невідомий тип введення %q, допустимі: %s",
+			ErrSpecUnknownItem:                   "елемент %q відсутній у списку задачі",
+			ErrSpecDuplicateKey:                  "повторюваний ключ %q",
+			ErrSpecInvalidValue:                  "неприпустиме значення %v: %s",
+			ErrSpecExpectedBool:                  "очікується yes/no або true/false",
+			ErrSpecExpectedString:                "очікується рядок",
+			ErrSpecExpectedList:                  "очікується список або рядок через кому",
+			ErrValidatorUnknown:                  "невідомий валідатор %q",
+			ErrValidatorArgs:                     "валідатор %q очікує аргументів: %d, отримано: %d",
+			ErrValidatorSyntax:                   "неправильний запис валідатора %q",
+			ErrThemeUnknown:                      "невідома тема %q, допустимі: %s",
+			ErrThemeUnknownKey:                   "невідомий ключ %q, допустимі: %s",
+			ErrThemeInvalidColor:                 "неприпустимий колір %q: очікується #RGB, #RRGGBB, номер ANSI-кольору від 0 до 255 або none",
+			ErrThemeSymbolWidth:                  "символ %q займає позицій: %d, очікується одна",
+			LineDefaultHint:                      "(за замовчуванням %s)",
+			LineMultiHint:                        "через кому",
+			LineDisabledMark:                     "(недоступно)",
+			LineChoiceRequired:                   "виберіть один із варіантів",
+			ErrLineInputClosed:                   "введення закрито до отримання відповіді",
+			ResumeQuestionFormat:                 "Запуск було перервано. Продовжити з кроку %d?",
+			ErrStateFileCorrupt:                  "файл стану пошкоджено: %v",
+			ErrFormTarget:                        "очікується вказівник на структуру, отримано %T",
+			ErrFormUnsupportedType:               "тип %s не підтримується формою",
+			ErrFormUnknownOption:                 "невідомий параметр тегу %q",
+			ErrFormChoicesRequired:               "для списку рядків потрібен параметр choices",
+			SinkOverwriteQuestion:                "Перезаписати %s? [т/Н]",
+			ErrSinkUnknownTask:                   "завдання %q не знайдено в черзі",
+			ErrSinkInvalidKey:                    "неприпустиме ім'я %q для формату %s",
+			PrefillSourceFormat:                  "(з %s)",
+			ErrCommandExitCode:                   "команда %s завершилася з кодом %d",
+			ErrCommandStart:                      "не вдалося запустити команду %s: %v",
+			TextAreaConfirmHint:                  "[Ctrl+D або Alt+Enter - підтвердити, Enter - новий рядок, Ctrl+C - скасувати]",
+			TextAreaLengthFormat:                 "%d/%d символів",
+			TextAreaMoreLinesFormat:              "… ще рядків: %d",
+			ErrTextTooLong:                       "текст довший за %d символів",
+			LineTextAreaHint:                     "(завершіть введення рядком \".\")",
+			FilePickerHelp:                       "[↑/↓ навігація, →/← вглиб/вгору, Enter - вибрати, Esc - скасування]",
+			FilePickerMultiHelp:                  "[↑/↓ навігація, →/← вглиб/вгору, пробіл - позначити, Enter - готово, Esc - скасування]",
+			FilePickerDirHelp:                    "[↑/↓ навігація, →/← вглиб/вгору, пробіл - вибрати каталог, Esc - скасування]",
+			FilePickerKeysHelp:                   "[літери - перехід до імені, Alt+. - приховані файли]",
+			FilePickerCurrentDir:                 "(цей каталог)",
+			FilePickerEmpty:                      "(немає відповідних файлів)",
+			FilePickerMarkedFormat:               "позначено: %d",
+			ErrPathOutsideRoot:                   "шлях %s знаходиться за межами каталогу %s",
+			ErrPathNotFound:                      "шлях %s не існує",
+			ErrPathNotDir:                        "%s не є каталогом",
+			ErrPathIsDir:                         "%s є каталогом, а не файлом",
+			ErrPathFiltered:                      "файл %s не відповідає фільтру",
+			ErrDirRead:                           "не вдалося прочитати каталог %s",
+			LinePathHint:                         "(шлях відносно %s)",
+			LinePathsHint:                        "(шляхи відносно %s через кому)",
+			MonthNames:                           []string{"Січень", "Лютий", "Березень", "Квітень", "Травень", "Червень", "Липень", "Серпень", "Вересень", "Жовтень", "Листопад", "Грудень"},
+			MonthNamesGenitive:                   []string{"січня", "лютого", "березня", "квітня", "травня", "червня", "липня", "серпня", "вересня", "жовтня", "листопада", "грудня"},
+			WeekdayNames:                         []string{"понеділок", "вівторок", "середа", "четвер", "п'ятниця", "субота", "неділя"},
+			WeekdayShortNames:                    []string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Нд"},
+			DurationHoursUnit:                    "год",
+			DurationMinutesUnit:                  "хв",
+			DurationSecondsUnit:                  "с",
+			DateTimeHelp:                         "[←/→ поле, ↑/↓ змінити, цифри - введення, Enter - підтвердити, Esc - скасування]",
+			DateTimeRangeFormat:                  "допустимо: %s – %s",
+			ErrValueBelowMin:                     "значення має бути не меншим за %s",
+			ErrValueAboveMax:                     "значення має бути не більшим за %s",
+			ErrDateTimeFormat:                    "очікується значення у форматі %s",
+			DateFormatHint:                       "РРРР-ММ-ДД",
+			TimeFormatHint:                       "ГГ:ХХ",
+			TimeSecondsFormatHint:                "ГГ:ХХ:СС",
+			DurationFormatHint:                   "1h30m",
+			NumberHelp:                           "[←/→ крок, PgUp/PgDn ×10, цифри - введення, Enter - підтвердити, Esc - скасування]",
+			LineNumberHint:                       "(від %s до %s)",
 		},
 	}
 )
@@ -812,6 +1418,101 @@ func applyDictionary(dict dictionary) {
 	ValidatorListSeparator = dict.ValidatorListSeparator
 	ValidatorCompositeAllSeparator = dict.ValidatorCompositeAllSeparator
 	ValidatorCompositeAnySeparator = dict.ValidatorCompositeAnySeparator
+	ErrAnswerMissing = dict.ErrAnswerMissing
+	ErrAnswerUnknownOption = dict.ErrAnswerUnknownOption
+	ErrAnswerDisabledOption = dict.ErrAnswerDisabledOption
+	ErrAnswerUnsupportedType = dict.ErrAnswerUnsupportedType
+	ErrAnswerUnsupportedTask = dict.ErrAnswerUnsupportedTask
+	TaskStatusSkipped = dict.TaskStatusSkipped
+	DefaultSkippedSummaryLabel = dict.DefaultSkippedSummaryLabel
+	ProgressETALabel = dict.ProgressETALabel
+	ProgressElapsedFormat = dict.ProgressElapsedFormat
+	ParallelGroupFailedFormat = dict.ParallelGroupFailedFormat
+	RetryWaitFormat = dict.RetryWaitFormat
+	RetryAttemptFormat = dict.RetryAttemptFormat
+	RetryAttemptsFormat = dict.RetryAttemptsFormat
+	RetryExhaustedPrompt = dict.RetryExhaustedPrompt
+	RetryChoiceRetry = dict.RetryChoiceRetry
+	RetryChoiceSkip = dict.RetryChoiceSkip
+	RetryChoiceAbort = dict.RetryChoiceAbort
+	RetryPromptHint = dict.RetryPromptHint
+	FilterPromptLabel = dict.FilterPromptLabel
+	FilterCountFormat = dict.FilterCountFormat
+	FilterNoMatches = dict.FilterNoMatches
+	FilterHelp = dict.FilterHelp
+	ErrSpecUnknownField = dict.ErrSpecUnknownField
+	ErrSpecRequiredField = dict.ErrSpecRequiredField
+	ErrSpecFieldNotApplicable = dict.ErrSpecFieldNotApplicable
+	ErrSpecUnknownTaskType = dict.ErrSpecUnknownTaskType
+	ErrSpecUnknownInputType = dict.ErrSpecUnknownInputType
+	ErrSpecUnknownItem = dict.ErrSpecUnknownItem
+	ErrSpecDuplicateKey = dict.ErrSpecDuplicateKey
+	ErrSpecInvalidValue = dict.ErrSpecInvalidValue
+	ErrSpecExpectedBool = dict.ErrSpecExpectedBool
+	ErrSpecExpectedString = dict.ErrSpecExpectedString
+	ErrSpecExpectedList = dict.ErrSpecExpectedList
+	ErrValidatorUnknown = dict.ErrValidatorUnknown
+	ErrValidatorArgs = dict.ErrValidatorArgs
+	ErrValidatorSyntax = dict.ErrValidatorSyntax
+	ErrThemeUnknown = dict.ErrThemeUnknown
+	ErrThemeUnknownKey = dict.ErrThemeUnknownKey
+	ErrThemeInvalidColor = dict.ErrThemeInvalidColor
+	ErrThemeSymbolWidth = dict.ErrThemeSymbolWidth
+	LineDefaultHint = dict.LineDefaultHint
+	LineMultiHint = dict.LineMultiHint
+	LineDisabledMark = dict.LineDisabledMark
+	LineChoiceRequired = dict.LineChoiceRequired
+	ErrLineInputClosed = dict.ErrLineInputClosed
+	ResumeQuestionFormat = dict.ResumeQuestionFormat
+	ErrStateFileCorrupt = dict.ErrStateFileCorrupt
+	ErrFormTarget = dict.ErrFormTarget
+	ErrFormUnsupportedType = dict.ErrFormUnsupportedType
+	ErrFormUnknownOption = dict.ErrFormUnknownOption
+	ErrFormChoicesRequired = dict.ErrFormChoicesRequired
+	SinkOverwriteQuestion = dict.SinkOverwriteQuestion
+	ErrSinkUnknownTask = dict.ErrSinkUnknownTask
+	ErrSinkInvalidKey = dict.ErrSinkInvalidKey
+	PrefillSourceFormat = dict.PrefillSourceFormat
+	ErrCommandExitCode = dict.ErrCommandExitCode
+	ErrCommandStart = dict.ErrCommandStart
+	TextAreaConfirmHint = dict.TextAreaConfirmHint
+	TextAreaLengthFormat = dict.TextAreaLengthFormat
+	TextAreaMoreLinesFormat = dict.TextAreaMoreLinesFormat
+	ErrTextTooLong = dict.ErrTextTooLong
+	LineTextAreaHint = dict.LineTextAreaHint
+	FilePickerHelp = dict.FilePickerHelp
+	FilePickerMultiHelp = dict.FilePickerMultiHelp
+	FilePickerDirHelp = dict.FilePickerDirHelp
+	FilePickerKeysHelp = dict.FilePickerKeysHelp
+	FilePickerCurrentDir = dict.FilePickerCurrentDir
+	FilePickerEmpty = dict.FilePickerEmpty
+	FilePickerMarkedFormat = dict.FilePickerMarkedFormat
+	ErrPathOutsideRoot = dict.ErrPathOutsideRoot
+	ErrPathNotFound = dict.ErrPathNotFound
+	ErrPathNotDir = dict.ErrPathNotDir
+	ErrPathIsDir = dict.ErrPathIsDir
+	ErrPathFiltered = dict.ErrPathFiltered
+	ErrDirRead = dict.ErrDirRead
+	LinePathHint = dict.LinePathHint
+	LinePathsHint = dict.LinePathsHint
+	MonthNames = append([]string(nil), dict.MonthNames...)
+	MonthNamesGenitive = append([]string(nil), dict.MonthNamesGenitive...)
+	WeekdayNames = append([]string(nil), dict.WeekdayNames...)
+	WeekdayShortNames = append([]string(nil), dict.WeekdayShortNames...)
+	DurationHoursUnit = dict.DurationHoursUnit
+	DurationMinutesUnit = dict.DurationMinutesUnit
+	DurationSecondsUnit = dict.DurationSecondsUnit
+	DateTimeHelp = dict.DateTimeHelp
+	DateTimeRangeFormat = dict.DateTimeRangeFormat
+	ErrValueBelowMin = dict.ErrValueBelowMin
+	ErrValueAboveMax = dict.ErrValueAboveMax
+	ErrDateTimeFormat = dict.ErrDateTimeFormat
+	DateFormatHint = dict.DateFormatHint
+	TimeFormatHint = dict.TimeFormatHint
+	TimeSecondsFormatHint = dict.TimeSecondsFormatHint
+	DurationFormatHint = dict.DurationFormatHint
+	NumberHelp = dict.NumberHelp
+	LineNumberHint = dict.LineNumberHint
 }
 
 // SetLanguage обновляет текущий язык и возвращает фактически установленное значение.
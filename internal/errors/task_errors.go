@@ -110,6 +110,33 @@ func (et ErrorType) String() string {
 	}
 }
 
+// Code возвращает стабильный (не зависящий от языка) код типа ошибки
+func (et ErrorType) Code() string {
+	switch et {
+	case ErrorTypeValidation:
+		return "validation"
+	case ErrorTypeUserCancel:
+		return "user_cancel"
+	case ErrorTypeTimeout:
+		return "timeout"
+	case ErrorTypeNetwork:
+		return "network"
+	case ErrorTypeFileSystem:
+		return "filesystem"
+	case ErrorTypePermission:
+		return "permission"
+	case ErrorTypeConfiguration:
+		return "configuration"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText сериализует тип ошибки в виде кода (используется при выводе в JSON)
+func (et ErrorType) MarshalText() ([]byte, error) {
+	return []byte(et.Code()), nil
+}
+
 // NewTaskError создает новую ошибку задачи
 func NewTaskError(taskTitle string, err error, errorType ErrorType) *TaskError {
 	return &TaskError{
@@ -232,3 +232,13 @@ func TestErrorContextEdgeCases(t *testing.T) {
 	_, exists = taskErr.GetContext("nonexistent")
 	assert.False(t, exists)
 }
+
+func TestErrorTypeCode(t *testing.T) {
+	assert.Equal(t, "validation", ErrorTypeValidation.Code())
+	assert.Equal(t, "user_cancel", ErrorTypeUserCancel.Code())
+	assert.Equal(t, "unknown", ErrorType(100).Code())
+
+	text, err := ErrorTypeTimeout.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "timeout", string(text))
+}
@@ -0,0 +1,516 @@
+// Package form строит описание очереди задач по полям структуры с тегами ziva
+// и записывает ответы очереди обратно в поля структуры.
+//
+// Пример структуры:
+//
+//	type Config struct {
+//		Env   string   `ziva:"title=Среда,choices=dev:Разработка|prod:Боевая"`
+//		Port  int      `ziva:"title=Порт,validator=port,default=8080"`
+//		Debug bool     `ziva:"title=Отладка"`
+//		Tags  []string `ziva:"choices=web|db|cache,default=web"`
+//		Addr  net.IP   `ziva:"title=Адрес"`
+//	}
+package form
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/spec"
+	"github.com/qzeleza/ziva/internal/validation"
+)
+
+// TagName — имя тега структуры, описывающего задачу формы
+const TagName = "ziva"
+
+// fieldKind определяет способ преобразования ответа в значение поля
+type fieldKind int
+
+const (
+	boolField    fieldKind = iota // bool — задача Да/Нет
+	stringField                   // string — ввод или одиночный выбор
+	intField                      // int, int8…int64 — ввод числа или одиночный выбор
+	uintField                     // uint, uint8…uint64 — ввод числа или одиночный выбор
+	stringsField                  // []string — множественный выбор
+	ipField                       // net.IP — ввод IP-адреса
+)
+
+// ipType — тип net.IP (срез байтов, поэтому распознаётся до проверки срезов)
+var ipType = reflect.TypeOf(net.IP{})
+
+// tagOptions — параметры тега ziva и соответствующие им поля описания задачи
+// (используются для сообщений об ошибках проверки описания)
+var tagOptions = map[string]string{
+	"title":       "title",
+	"id":          "id",
+	"question":    "question",
+	"prompt":      "prompt",
+	"placeholder": "placeholder",
+	"validator":   "validator",
+	"input":       "input_type",
+	"choices":     "items",
+	"default":     "default",
+	"timeout":     "timeout",
+	"optional":    "allow_empty",
+	"required":    "require_selection",
+}
+
+// Form — описание задач формы и привязка их ответов к полям структуры
+type Form struct {
+	Title  string      // Имя типа структуры
+	Tasks  []spec.Task // Описания задач в порядке полей структуры
+	fields []field     // Поля структуры, соответствующие задачам
+}
+
+// field — поле структуры, заполняемое ответом задачи
+type field struct {
+	name      string               // Имя поля в структуре
+	value     reflect.Value        // Значение поля (адресуемое)
+	kind      fieldKind            // Способ преобразования ответа
+	check     validation.Validator // Проверка преобразования ответа в тип поля (nil — не требуется)
+	validator validation.Validator // Валидатор из тега (nil — не задан)
+}
+
+// Parse строит описание формы по полям структуры.
+// Неэкспортируемые поля, поля с тегом "-" и поля неподдерживаемых типов без тега
+// пропускаются. Ненулевые значения полей становятся значениями по умолчанию.
+//
+// @param target Указатель на структуру
+// @return Описание формы или ошибка: spec.Errors с путями вида "Config.Port.validator"
+func Parse(target interface{}) (*Form, error) {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf(defaults.ErrFormTarget, target)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	f := &Form{Title: rt.Name()}
+	var errs spec.Errors
+	fail := func(path, format string, args ...interface{}) {
+		errs = append(errs, &spec.Error{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag, tagged := sf.Tag.Lookup(TagName)
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		path := f.path(sf.Name)
+
+		kind, ok := kindOf(sf.Type)
+		if !ok {
+			if tagged {
+				fail(path, defaults.ErrFormUnsupportedType, sf.Type)
+			}
+			continue
+		}
+
+		options, err := parseTag(tag)
+		if err != nil {
+			fail(path, "%s", err.Error())
+			continue
+		}
+		fd := field{name: sf.Name, value: rv.Field(i), kind: kind, check: checkFor(sf.Type, kind)}
+		t, tagErr := fd.task(options)
+		if tagErr != nil {
+			fail(path+"."+tagErr.option, "%s", tagErr.message)
+			continue
+		}
+		if t.Validator != "" {
+			fd.validator, _ = t.TaskValidator()
+		}
+		if fd.check != nil {
+			fd.checkValues(t, path, fail)
+		}
+		f.Tasks = append(f.Tasks, t)
+		f.fields = append(f.fields, fd)
+	}
+
+	if len(errs) == 0 {
+		queue := spec.Queue{Title: f.Title, Tasks: f.Tasks}
+		if err, ok := queue.Validate().(spec.Errors); ok {
+			errs = f.remap(err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return f, nil
+}
+
+// Validator возвращает валидатор задачи ввода: проверку преобразования
+// в тип поля, затем валидатор из тега. Для необязательного поля (optional)
+// пустое значение проверку проходит.
+//
+// @param index Индекс задачи в Tasks
+// @return Валидатор (nil — используется валидатор типа ввода)
+func (f *Form) Validator(index int) validation.Validator {
+	fd := f.fields[index]
+	var validator validation.Validator
+	switch {
+	case fd.check != nil && fd.validator != nil:
+		validator = validation.NewCompositeValidator(validation.FirstError, fd.check, fd.validator)
+	case fd.check != nil:
+		validator = fd.check
+	default:
+		return fd.validator
+	}
+	if !f.Tasks[index].AllowEmpty {
+		return validator
+	}
+	return validation.ValidatorFunc(func(input string) error {
+		if strings.TrimSpace(input) == "" {
+			return nil
+		}
+		return validator.Validate(input)
+	})
+}
+
+// Assign записывает ответы очереди в поля структуры.
+// Результаты сопоставляются с задачами по идентификатору; поля задач,
+// завершённых неуспешно или не выполненных, не изменяются.
+//
+// @param results Результаты очереди
+// @return Ошибка валидации, если ответ не удалось преобразовать в тип поля
+func (f *Form) Assign(results common.Results) error {
+	for i, fd := range f.fields {
+		result, ok := results.ByID(f.Tasks[i].ID)
+		if !ok || result.Status != common.ResultSuccess {
+			continue
+		}
+		if err := fd.assign(result); err != nil {
+			return terrors.NewValidationError(f.Tasks[i].Title, err).WithContext("field", fd.name)
+		}
+	}
+	return nil
+}
+
+// kindOf определяет способ заполнения поля по его типу
+func kindOf(t reflect.Type) (fieldKind, bool) {
+	if t == ipType {
+		return ipField, true
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return boolField, true
+	case reflect.String:
+		return stringField, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intField, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uintField, true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return stringsField, true
+		}
+	}
+	return 0, false
+}
+
+// checkFor возвращает проверку преобразования ответа в тип поля
+func checkFor(t reflect.Type, kind fieldKind) validation.Validator {
+	switch kind {
+	case intField:
+		return validation.NewIntegerValidator(t.Bits(), false)
+	case uintField:
+		return validation.NewIntegerValidator(t.Bits(), true)
+	case ipField:
+		return validation.NewIPValidator(true, true)
+	}
+	return nil
+}
+
+// tagError — ошибка значения параметра тега
+type tagError struct {
+	option  string // Параметр тега
+	message string // Описание ошибки
+}
+
+// parseTag разбирает тег вида "title=Порт,validator=range(1, 100),optional".
+// Запятые внутри скобок не разделяют параметры; параметр без значения — флаг.
+//
+// @param tag Значение тега
+// @return Параметры тега или ошибка неизвестного параметра
+func parseTag(tag string) (map[string]string, error) {
+	options := make(map[string]string)
+	depth, start := 0, 0
+	parts := []string{}
+	for i, r := range tag {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, tag[start:])
+
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		key, value, hasValue := strings.Cut(part, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, ok := tagOptions[key]; !ok {
+			return nil, fmt.Errorf(defaults.ErrFormUnknownOption, key)
+		}
+		if !hasValue {
+			value = "true"
+		}
+		options[key] = strings.TrimSpace(value)
+	}
+	return options, nil
+}
+
+// task создаёт описание задачи для поля по параметрам тега.
+// Тип задачи выбирается по типу поля: bool — yesno, []string — multi_select,
+// поле с параметром choices — single_select, остальные — input.
+//
+// @param options Параметры тега
+// @return Описание задачи или ошибка значения параметра
+func (fd field) task(options map[string]string) (spec.Task, *tagError) {
+	t := spec.Task{
+		ID:          options["id"],
+		Title:       options["title"],
+		Question:    options["question"],
+		Prompt:      options["prompt"],
+		Placeholder: options["placeholder"],
+		Validator:   options["validator"],
+		InputType:   options["input"],
+	}
+	if t.ID == "" {
+		t.ID = fd.name
+	}
+	if t.Title == "" {
+		t.Title = fd.name
+	}
+
+	for _, flag := range []string{"optional", "required"} {
+		value, ok := options[flag]
+		if !ok {
+			continue
+		}
+		set, err := strconv.ParseBool(value)
+		if err != nil {
+			return t, &tagError{flag, fmt.Sprintf(defaults.ErrSpecInvalidValue, value, defaults.ErrSpecExpectedBool)}
+		}
+		if flag == "optional" {
+			t.AllowEmpty = set
+		} else {
+			t.RequireSelection = set
+		}
+	}
+	if value, ok := options["timeout"]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return t, &tagError{"timeout", fmt.Sprintf(defaults.ErrSpecInvalidValue, value, err)}
+		}
+		t.Timeout = timeout
+	}
+	if choices, ok := options["choices"]; ok {
+		for _, choice := range strings.Split(choices, "|") {
+			key, name, _ := strings.Cut(choice, ":")
+			t.Items = append(t.Items, spec.Item{Key: strings.TrimSpace(key), Name: strings.TrimSpace(name)})
+		}
+	}
+
+	switch {
+	case fd.kind == boolField:
+		t.Type = common.KindYesNo
+	case fd.kind == stringsField:
+		t.Type = common.KindMultiSelect
+		if len(t.Items) == 0 {
+			return t, &tagError{"choices", defaults.ErrFormChoicesRequired}
+		}
+	case len(t.Items) > 0 && fd.kind != ipField:
+		t.Type = common.KindSingleSelect
+	default:
+		t.Type = common.KindInput
+		if t.InputType == "" && (fd.kind == intField || fd.kind == uintField) {
+			t.InputType = "number"
+		}
+		if t.InputType == "" && fd.kind == ipField {
+			t.InputType = "ip"
+		}
+	}
+
+	if value, ok := options["default"]; ok {
+		t.Default = fd.parseDefault(value)
+	} else {
+		t.Default = fd.current()
+	}
+	return t, nil
+}
+
+// parseDefault преобразует значение параметра default в значение описания задачи
+func (fd field) parseDefault(value string) interface{} {
+	if fd.kind != stringsField {
+		return value
+	}
+	list := []interface{}{}
+	for _, key := range strings.Split(value, "|") {
+		if key = strings.TrimSpace(key); key != "" {
+			list = append(list, key)
+		}
+	}
+	return list
+}
+
+// current возвращает ненулевое значение поля как значение по умолчанию (nil — не задано)
+func (fd field) current() interface{} {
+	v := fd.value
+	if v.IsZero() {
+		return nil
+	}
+	switch fd.kind {
+	case boolField:
+		return true
+	case intField:
+		return strconv.FormatInt(v.Int(), 10)
+	case uintField:
+		return strconv.FormatUint(v.Uint(), 10)
+	case ipField:
+		return net.IP(v.Bytes()).String()
+	case stringsField:
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = v.Index(i).String()
+		}
+		return list
+	}
+	return v.String()
+}
+
+// checkValues проверяет, что значение по умолчанию и ключи вариантов
+// преобразуются в тип поля
+func (fd field) checkValues(t spec.Task, path string, fail func(path, format string, args ...interface{})) {
+	if value, ok := t.DefaultText(); ok && value != "" {
+		if err := fd.check.Validate(value); err != nil {
+			fail(path+".default", defaults.ErrSpecInvalidValue, value, err)
+		}
+	}
+	for _, item := range t.Items {
+		if err := fd.check.Validate(item.Key); err != nil {
+			fail(path+".choices", defaults.ErrSpecInvalidValue, item.Key, err)
+		}
+	}
+}
+
+// assign записывает ответ задачи в поле структуры
+func (fd field) assign(result common.TaskResult) error {
+	switch fd.kind {
+	case boolField:
+		yes, _ := result.Value.(bool)
+		fd.value.SetBool(yes)
+		return nil
+	case stringsField:
+		values := append([]string{}, result.Values...)
+		fd.value.Set(reflect.ValueOf(values).Convert(fd.value.Type()))
+		return nil
+	}
+
+	raw, _ := result.Value.(string)
+	text := strings.TrimSpace(raw)
+	if text == "" && fd.kind != stringField {
+		// Пустой ответ необязательного поля
+		fd.value.Set(reflect.Zero(fd.value.Type()))
+		return nil
+	}
+	if fd.check != nil {
+		if err := fd.check.Validate(text); err != nil {
+			return err
+		}
+	}
+
+	switch fd.kind {
+	case intField:
+		number, err := strconv.ParseInt(text, 10, fd.value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fd.value.SetInt(number)
+	case uintField:
+		number, err := strconv.ParseUint(text, 10, fd.value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fd.value.SetUint(number)
+	case ipField:
+		fd.value.Set(reflect.ValueOf(net.ParseIP(text)).Convert(fd.value.Type()))
+	default:
+		fd.value.SetString(raw)
+	}
+	return nil
+}
+
+// remap заменяет в ошибках проверки описания очереди пути "tasks[1].items"
+// путями полей структуры "Config.Env.choices"
+func (f *Form) remap(errs spec.Errors) spec.Errors {
+	for _, err := range errs {
+		index, rest, ok := taskPath(err.Field)
+		if !ok || index >= len(f.fields) {
+			err.Field = f.Title
+			continue
+		}
+		path := f.path(f.fields[index].name)
+		name, tail, _ := strings.Cut(strings.TrimPrefix(rest, "."), ".")
+		if name != "" {
+			base, suffix, _ := strings.Cut(name, "[")
+			for option, specName := range tagOptions {
+				if specName == base {
+					base = option
+					break
+				}
+			}
+			path += "." + base
+			if suffix != "" {
+				path += "[" + suffix
+			}
+			if tail != "" {
+				path += "." + tail
+			}
+		}
+		err.Field = path
+	}
+	return errs
+}
+
+// path возвращает путь к полю структуры для сообщений об ошибках
+// (у анонимной структуры — имя поля)
+func (f *Form) path(name string) string {
+	if f.Title == "" {
+		return name
+	}
+	return f.Title + "." + name
+}
+
+// taskPath разбирает путь вида "tasks[1].items[0].key" на индекс задачи и остаток пути
+func taskPath(path string) (int, string, bool) {
+	inner, ok := strings.CutPrefix(path, "tasks[")
+	if !ok {
+		return 0, "", false
+	}
+	number, rest, ok := strings.Cut(inner, "]")
+	if !ok {
+		return 0, "", false
+	}
+	index, err := strconv.Atoi(number)
+	if err != nil {
+		return 0, "", false
+	}
+	return index, rest, true
+}
@@ -0,0 +1,156 @@
+package form
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/common"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Config — структура формы установки
+type Config struct {
+	Env     string   `ziva:"title=Среда,choices=dev:Разработка|prod:Боевая,default=dev"`
+	Port    uint16   `ziva:"title=Порт,validator=range(1024, 65535),timeout=5s"`
+	Debug   bool     `ziva:"title=Отладка,question=Включить отладку?"`
+	Tags    []string `ziva:"title=Компоненты,choices=web|db|cache,default=web|db"`
+	Addr    net.IP   `ziva:"title=Адрес,optional"`
+	Retries int8
+	Name    string
+	Cache   string `ziva:"-"`
+	Hook    func()
+	secret  string
+}
+
+// TestParseBuildsTasks проверяет выбор типа задачи и параметры тегов
+func TestParseBuildsTasks(t *testing.T) {
+	cfg := Config{Retries: 3}
+	f, err := Parse(&cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Config", f.Title)
+	require.Len(t, f.Tasks, 7, "пропускаются поле с тегом \"-\", функция и неэкспортируемое поле")
+
+	env := f.Tasks[0]
+	assert.Equal(t, common.KindSingleSelect, env.Type)
+	assert.Equal(t, "Env", env.ID, "идентификатор по умолчанию — имя поля")
+	assert.Equal(t, []spec.Item{{Key: "dev", Name: "Разработка"}, {Key: "prod", Name: "Боевая"}}, env.Items)
+	assert.Equal(t, "dev", env.Default)
+
+	port := f.Tasks[1]
+	assert.Equal(t, common.KindInput, port.Type)
+	assert.Equal(t, "number", port.InputType)
+	assert.Equal(t, 5*time.Second, port.Timeout)
+	assert.Equal(t, "range(1024, 65535)", port.Validator, "запятая в скобках не разделяет параметры")
+
+	assert.Equal(t, common.KindYesNo, f.Tasks[2].Type)
+	assert.Equal(t, "Включить отладку?", f.Tasks[2].Question)
+
+	assert.Equal(t, common.KindMultiSelect, f.Tasks[3].Type)
+	assert.Equal(t, []interface{}{"web", "db"}, f.Tasks[3].Default)
+
+	assert.Equal(t, "ip", f.Tasks[4].InputType)
+	assert.True(t, f.Tasks[4].AllowEmpty)
+
+	retries := f.Tasks[5]
+	assert.Equal(t, "Retries", retries.Title, "заголовок по умолчанию — имя поля")
+	assert.Equal(t, "3", retries.Default, "ненулевое значение поля становится значением по умолчанию")
+	assert.Equal(t, common.KindInput, f.Tasks[6].Type)
+}
+
+// TestParseReportsFieldPaths проверяет ошибки тегов с путями полей структуры
+func TestParseReportsFieldPaths(t *testing.T) {
+	var broken struct {
+		Port  int      `ziva:"validator=nope"`
+		Mode  string   `ziva:"choices=a|b,default=c"`
+		Tags  []string `ziva:"title=Метки"`
+		Size  int      `ziva:"default=big"`
+		Limit int      `ziva:"colour=red"`
+		Ratio float64  `ziva:"title=Доля"`
+	}
+	_, err := Parse(&broken)
+	require.Error(t, err)
+
+	var errs spec.Errors
+	require.True(t, errors.As(err, &errs))
+	fields := make([]string, 0, len(errs))
+	for _, specErr := range errs {
+		fields = append(fields, specErr.Field)
+	}
+	assert.ElementsMatch(t, []string{"Tags.choices", "Size.default", "Limit", "Ratio"}, fields,
+		"ошибки разбора тегов собираются до проверки описания очереди")
+
+	var invalid struct {
+		Port int    `ziva:"validator=nope"`
+		Mode string `ziva:"choices=a|b,default=c"`
+	}
+	_, err = Parse(&invalid)
+	require.True(t, errors.As(err, &errs))
+	fields = fields[:0]
+	for _, specErr := range errs {
+		fields = append(fields, specErr.Field)
+	}
+	assert.ElementsMatch(t, []string{"Port.validator", "Mode.default"}, fields)
+
+	_, err = Parse(Config{})
+	assert.Error(t, err, "форма принимает только указатель на структуру")
+}
+
+// TestValidatorChecksFieldType проверяет разбор числа до валидатора из тега
+func TestValidatorChecksFieldType(t *testing.T) {
+	var cfg Config
+	f, err := Parse(&cfg)
+	require.NoError(t, err)
+
+	port := f.Validator(1)
+	assert.NoError(t, port.Validate("8080"))
+	assert.Error(t, port.Validate("80"), "валидатор из тега проверяет диапазон")
+	assert.Error(t, port.Validate("70000"), "значение не помещается в uint16")
+	assert.Error(t, port.Validate("порт"))
+
+	addr := f.Validator(4)
+	assert.NoError(t, addr.Validate(""), "необязательное поле может быть пустым")
+	assert.Error(t, addr.Validate("10.0.0"))
+
+	assert.Nil(t, f.Validator(6), "для строки проверка преобразования не нужна")
+}
+
+// TestAssignWritesTypedValues проверяет запись ответов в поля структуры
+func TestAssignWritesTypedValues(t *testing.T) {
+	cfg := Config{Name: "старое", Retries: 1}
+	f, err := Parse(&cfg)
+	require.NoError(t, err)
+
+	results := common.Results{
+		{ID: "Env", Status: common.ResultSuccess, Value: "prod"},
+		{ID: "Port", Status: common.ResultSuccess, Value: "8443"},
+		{ID: "Debug", Status: common.ResultSuccess, Value: true},
+		{ID: "Tags", Status: common.ResultSuccess, Values: []string{"db", "cache"}},
+		{ID: "Addr", Status: common.ResultSuccess, Value: "192.168.1.10"},
+		{ID: "Retries", Status: common.ResultSuccess, Value: "-5"},
+		{ID: "Name", Status: common.ResultError, Value: "новое"},
+	}
+	require.NoError(t, f.Assign(results))
+
+	assert.Equal(t, "prod", cfg.Env)
+	assert.Equal(t, uint16(8443), cfg.Port)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, []string{"db", "cache"}, cfg.Tags)
+	assert.Equal(t, "192.168.1.10", cfg.Addr.String())
+	assert.Equal(t, int8(-5), cfg.Retries)
+	assert.Equal(t, "старое", cfg.Name, "поле задачи с ошибкой не изменяется")
+
+	results[5].Value = "300"
+	err = f.Assign(results)
+	require.Error(t, err)
+	var taskErr *terrors.TaskError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, terrors.ErrorTypeValidation, taskErr.Type)
+	field, _ := taskErr.GetContext("field")
+	assert.Equal(t, "Retries", field)
+}
@@ -0,0 +1,145 @@
+package query
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AnswerSource предоставляет заранее подготовленные ответы для неинтерактивного режима.
+// Ответ ищется по идентификатору задачи, а если он не задан или не найден — по заголовку.
+type AnswerSource interface {
+	// Lookup возвращает ответ для задачи и признак его наличия.
+	Lookup(id, title string) (interface{}, bool)
+}
+
+// MapAnswers — источник ответов на основе карты "идентификатор или заголовок → ответ".
+type MapAnswers map[string]interface{}
+
+// Lookup ищет ответ сначала по идентификатору, затем по заголовку: вначале точное
+// совпадение, затем без учёта регистра. Если без учёта регистра подходят несколько
+// ключей, выбирается первый в лексикографическом порядке.
+func (a MapAnswers) Lookup(id, title string) (interface{}, bool) {
+	for _, key := range []string{id, title} {
+		if key == "" {
+			continue
+		}
+		if value, ok := a[key]; ok {
+			return value, true
+		}
+	}
+
+	keys := make([]string, 0, len(a))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, want := range []string{id, title} {
+		if want == "" {
+			continue
+		}
+		for _, key := range keys {
+			if strings.EqualFold(key, want) {
+				return a[key], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// EnvAnswers — источник ответов из переменных окружения.
+// Имя переменной строится из префикса и идентификатора (или заголовка) задачи:
+// буквы переводятся в верхний регистр, остальные символы заменяются на "_".
+// Например, задача с ID "db-port" и префиксом "APP_" читает переменную APP_DB_PORT.
+type EnvAnswers struct {
+	Prefix string
+}
+
+// Lookup ищет переменную окружения для задачи.
+func (a EnvAnswers) Lookup(id, title string) (interface{}, bool) {
+	for _, key := range []string{id, title} {
+		if key == "" {
+			continue
+		}
+		if value, ok := os.LookupEnv(a.Prefix + EnvKey(key)); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// EnvKey преобразует идентификатор или заголовок задачи в имя переменной окружения.
+//
+// @param key Идентификатор или заголовок задачи
+// @return Имя переменной окружения (без префикса)
+func EnvKey(key string) string {
+	var sb strings.Builder
+	underscore := false
+	for _, r := range strings.TrimSpace(key) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(unicode.ToUpper(r))
+			underscore = false
+			continue
+		}
+		if !underscore && sb.Len() > 0 {
+			sb.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "_")
+}
+
+// ChainAnswers объединяет несколько источников: используется первый найденный ответ.
+type ChainAnswers []AnswerSource
+
+// Lookup опрашивает источники по порядку.
+func (c ChainAnswers) Lookup(id, title string) (interface{}, bool) {
+	for _, source := range c {
+		if source == nil {
+			continue
+		}
+		if value, ok := source.Lookup(id, title); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// LoadAnswers читает ответы в формате YAML или JSON (JSON является подмножеством YAML).
+//
+// @param r Источник данных
+// @return Карта ответов или ошибка разбора
+func LoadAnswers(r io.Reader) (MapAnswers, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	answers := MapAnswers{}
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("answers: %w", err)
+	}
+	return answers, nil
+}
+
+// LoadAnswersFile читает ответы из файла в формате YAML или JSON.
+//
+// @param path Путь к файлу
+// @return Карта ответов или ошибка чтения/разбора
+func LoadAnswersFile(path string) (MapAnswers, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	answers, err := LoadAnswers(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return answers, nil
+}
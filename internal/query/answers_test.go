@@ -0,0 +1,84 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapAnswersLookup(t *testing.T) {
+	answers := MapAnswers{"port": 22, "Имя хоста": "router"}
+
+	value, ok := answers.Lookup("port", "Порт")
+	assert.True(t, ok)
+	assert.Equal(t, 22, value)
+
+	value, ok = answers.Lookup("", "имя хоста")
+	assert.True(t, ok, "поиск по заголовку должен быть нечувствителен к регистру")
+	assert.Equal(t, "router", value)
+
+	_, ok = answers.Lookup("missing", "Нет такой задачи")
+	assert.False(t, ok)
+}
+
+// TestMapAnswersLookupIsDeterministic проверяет порядок поиска без учёта регистра
+func TestMapAnswersLookupIsDeterministic(t *testing.T) {
+	answers := MapAnswers{"PORT": 1, "Port": 2, "порт": 3}
+	for i := 0; i < 20; i++ {
+		value, ok := answers.Lookup("port", "Порт")
+		assert.True(t, ok)
+		assert.Equal(t, 1, value, "совпадение по идентификатору важнее совпадения по заголовку")
+	}
+
+	value, ok := MapAnswers{"Порт": 3}.Lookup("port", "ПОРТ")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+}
+
+func TestEnvAnswersLookup(t *testing.T) {
+	t.Setenv("APP_DB_PORT", "5432")
+	t.Setenv("APP_HOST_NAME", "router")
+
+	source := EnvAnswers{Prefix: "APP_"}
+	value, ok := source.Lookup("db-port", "Порт БД")
+	assert.True(t, ok)
+	assert.Equal(t, "5432", value)
+
+	value, ok = source.Lookup("", "Host name")
+	assert.True(t, ok)
+	assert.Equal(t, "router", value)
+}
+
+func TestEnvKey(t *testing.T) {
+	assert.Equal(t, "DB_PORT", EnvKey("db-port"))
+	assert.Equal(t, "HOST_NAME", EnvKey("  host name "))
+	assert.Equal(t, "A_B", EnvKey("a..b!"))
+}
+
+func TestChainAnswers(t *testing.T) {
+	chain := ChainAnswers{MapAnswers{"a": 1}, nil, MapAnswers{"a": 2, "b": 3}}
+	value, ok := chain.Lookup("a", "")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	value, ok = chain.Lookup("b", "")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+}
+
+func TestLoadAnswersYAMLAndJSON(t *testing.T) {
+	answers, err := LoadAnswers(strings.NewReader("confirm: true\nitems: [a, b]\nport: 8080\n"))
+	require.NoError(t, err)
+	assert.Equal(t, true, answers["confirm"])
+	assert.Equal(t, []interface{}{"a", "b"}, answers["items"])
+	assert.Equal(t, 8080, answers["port"])
+
+	answers, err = LoadAnswers(strings.NewReader(`{"confirm": false, "env": "prod"}`))
+	require.NoError(t, err)
+	assert.Equal(t, false, answers["confirm"])
+	assert.Equal(t, "prod", answers["env"])
+
+	_, err = LoadAnswers(strings.NewReader("- not a map"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"github.com/qzeleza/ziva/internal/common"
+)
+
+// clockSetter — задачи, принимающие источник времени очереди
+type clockSetter interface {
+	SetClock(common.Clock)
+}
+
+// WithClock задаёт источник времени очереди и её задач: тайм-ауты, тикеры,
+// задержки повторов и анимацию. Поддельные часы позволяют тестам
+// детерминированно проверять поведение по тайм-ауту.
+//
+// @param clock Источник времени (nil — системное время)
+// @return Указатель на очередь задач
+func (m *Model) WithClock(clock common.Clock) *Model {
+	m.clock = clock
+	m.applyClock(m.tasks)
+	return m
+}
+
+// Clock возвращает источник времени очереди: заданный WithClock или системный.
+func (m *Model) Clock() common.Clock {
+	return common.ClockOrSystem(m.clock)
+}
+
+// applyClock передаёт источник времени очереди задачам
+func (m *Model) applyClock(tasks []common.Task) {
+	for _, task := range tasks {
+		if setter, ok := task.(clockSetter); ok {
+			setter.SetClock(m.clock)
+		}
+	}
+}
@@ -80,8 +80,7 @@ func TestSetErrorColorInQueue(t *testing.T) {
 	assert.Contains(t, view, "(1/2)", "View должен показывать правильную статистику")
 
 	// Восстанавливаем исходные стили
-	ui.ErrorMessageStyle = originalMessageStyle
-	ui.ErrorStatusStyle = originalStatusStyle
+	ui.SetErrorColor(originalMessageStyle.GetForeground(), originalStatusStyle.GetForeground())
 }
 
 // TestSetErrorColorChaining проверяет цепочку вызовов методов
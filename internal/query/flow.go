@@ -0,0 +1,163 @@
+package query
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// taskState хранит сведения очереди о задаче
+type taskState struct {
+	startedAt time.Time     // Момент запуска задачи
+	duration  time.Duration // Длительность выполнения завершённой задачи
+	skipped   bool          // Задача пропущена, так как её условие не выполнено
+	restored  bool          // Задача отмечена выполненной по файлу состояния прерванного запуска
+	followUps int           // Количество задач, добавленных сразу после неё
+}
+
+// conditional описывает задачи с условием выполнения
+type conditional interface {
+	ShouldRun(results common.Results) bool
+}
+
+// branching описывает задачи, добавляющие новые задачи после своего завершения
+type branching interface {
+	FollowUpTasks(results common.Results) []common.Task
+}
+
+// prefilled описывает задачи, берущие начальное значение из переменной окружения или флага
+type prefilled interface {
+	ApplyPrefill() bool
+}
+
+// ensureStates выравнивает срез состояний по количеству задач
+func (m *Model) ensureStates() {
+	for len(m.states) < len(m.tasks) {
+		m.states = append(m.states, taskState{})
+	}
+}
+
+// startTask запускает задачу с указанным индексом и запоминает момент запуска
+func (m *Model) startTask(index int) tea.Cmd {
+	m.ensureStates()
+	m.states[index].startedAt = m.Clock().Now()
+	if navigator, ok := m.tasks[index].(backNavigator); ok {
+		navigator.SetBackNavigation(m.canGoBack(index))
+	}
+	return m.tasks[index].Run()
+}
+
+// finishTask фиксирует длительность выполнения задачи с указанным индексом,
+// дописывает запись о ней в журнал сеанса и сохраняет состояние очереди
+func (m *Model) finishTask(index int) {
+	m.ensureStates()
+	if started := m.states[index].startedAt; !started.IsZero() {
+		m.states[index].duration = m.Clock().Now().Sub(started)
+	}
+	m.recordTranscript(index)
+	m.saveState()
+}
+
+// isSkipped сообщает, была ли задача пропущена по условию
+func (m *Model) isSkipped(index int) bool {
+	return index >= 0 && index < len(m.states) && m.states[index].skipped
+}
+
+// skipToRunnable пропускает задачи, начиная с текущей, условие которых не выполнено,
+// задачи, восстановленные из файла состояния, и задачи, принявшие значение
+// переменной окружения или флага без вопроса.
+// Условие проверяется по результатам уже завершённых задач.
+func (m *Model) skipToRunnable() {
+	m.ensureStates()
+	for m.current < len(m.tasks) {
+		if m.states[m.current].restored {
+			m.current++
+			continue
+		}
+		if task, ok := m.tasks[m.current].(conditional); ok && !task.ShouldRun(m.Results()) {
+			m.states[m.current].skipped = true
+			m.current++
+			continue
+		}
+		if task, ok := m.tasks[m.current].(prefilled); ok && task.ApplyPrefill() {
+			m.finishTask(m.current)
+			m.expandFollowUps(m.current)
+			m.current++
+			continue
+		}
+		return
+	}
+}
+
+// expandFollowUps добавляет задачи-продолжения сразу после завершённой задачи
+func (m *Model) expandFollowUps(index int) {
+	source, ok := m.tasks[index].(branching)
+	if !ok {
+		return
+	}
+
+	next := source.FollowUpTasks(m.Results())
+	added := make([]common.Task, 0, len(next))
+	for _, task := range next {
+		if task != nil {
+			added = append(added, task)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	m.ensureStates()
+	tail := append(added, m.tasks[index+1:]...)
+	m.tasks = append(m.tasks[:index+1], tail...)
+	states := append(make([]taskState, len(added)), m.states[index+1:]...)
+	m.states = append(m.states[:index+1], states...)
+	m.states[index].followUps = len(added)
+	m.applySelectionSeparatorFlag(added)
+	m.applyTheme(added)
+	m.applyClock(added)
+}
+
+// removeFollowUps удаляет задачи, добавленные после задачи с указанным индексом
+// (включая вложенные продолжения). Используется при возврате к этой задаче:
+// после повторного ответа продолжения формируются заново.
+func (m *Model) removeFollowUps(index int) {
+	span := m.followUpSpan(index)
+	if span == 0 {
+		return
+	}
+	m.tasks = append(m.tasks[:index+1], m.tasks[index+1+span:]...)
+	m.states = append(m.states[:index+1], m.states[index+1+span:]...)
+	m.states[index].followUps = 0
+}
+
+// followUpSpan возвращает количество задач, добавленных задачей, вместе с их продолжениями
+func (m *Model) followUpSpan(index int) int {
+	span := 0
+	for i := 0; i < m.states[index].followUps; i++ {
+		child := index + 1 + span
+		if child >= len(m.states) {
+			break
+		}
+		span += 1 + m.followUpSpan(child)
+	}
+	if index+1+span > len(m.tasks) {
+		return len(m.tasks) - index - 1
+	}
+	return span
+}
+
+// formatSkippedTask отображает задачу, пропущенную по условию
+func (m *Model) formatSkippedTask(task common.Task, index int, width int) string {
+	th := m.Theme()
+	prefix := th.CompletedTaskPrefix(true)
+	if m.numberCompletedTasks {
+		prefix = buildCompletedPrefix(index+1, m.numberFormat)
+	}
+	left := performance.FastConcat(prefix, "  ", th.Styles.Subtle.Render(task.Title()))
+	right := th.Styles.Subtle.Render(th.TaskStatusSkipped())
+	return ui.AlignTextToRight(left, right, width) + "\n"
+}
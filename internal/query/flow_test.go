@@ -0,0 +1,89 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// branchTask — задача с условием выполнения и продолжением для тестов очереди
+type branchTask struct {
+	*MockTask
+	condition func(common.Results) bool
+	followUp  func(common.Results) []common.Task
+}
+
+func (b *branchTask) ShouldRun(results common.Results) bool {
+	return b.condition == nil || b.condition(results)
+}
+
+func (b *branchTask) FollowUpTasks(results common.Results) []common.Task {
+	if b.followUp == nil {
+		return nil
+	}
+	return b.followUp(results)
+}
+
+// TestSkipToRunnable проверяет пропуск задач с невыполненным условием
+func TestSkipToRunnable(t *testing.T) {
+	model := New("Условия")
+	first := NewMockTask("Первая").CompleteSuccessfully()
+	skipped := &branchTask{MockTask: NewMockTask("Пропускаемая"), condition: func(common.Results) bool { return false }}
+	last := &branchTask{MockTask: NewMockTask("Последняя"), condition: func(r common.Results) bool {
+		result, ok := r.ByTitle("Первая")
+		return ok && result.Status == common.ResultSuccess
+	}}
+	model.AddTasks([]common.Task{first, skipped, last})
+
+	model.current = 1
+	model.skipToRunnable()
+	assert.Equal(t, 2, model.current, "очередь должна перейти к задаче с выполненным условием")
+	assert.True(t, model.isSkipped(1))
+
+	last.CompleteSuccessfully()
+	model.current = 3
+	model.updateTaskStats()
+	assert.Equal(t, 2, model.successCount)
+	assert.Equal(t, 1, model.skippedCount)
+
+	leftSummary, rightStatus := model.formatSummaryWithStats()
+	assert.Contains(t, leftSummary, defaults.DefaultSkippedSummaryLabel+" 1")
+	assert.Equal(t, defaults.StatusSuccess, rightStatus, "пропущенные задачи не мешают успешному статусу")
+
+	view := model.View()
+	assert.Contains(t, view, "Пропускаемая")
+	assert.Contains(t, view, defaults.TaskStatusSkipped)
+
+	assert.Equal(t, common.ResultSkipped, model.Results()[1].Status)
+}
+
+// TestExpandAndRemoveFollowUps проверяет вставку и удаление задач-продолжений
+func TestExpandAndRemoveFollowUps(t *testing.T) {
+	model := New("Ветвление")
+	child := &branchTask{MockTask: NewMockTask("Дочерняя"), followUp: func(common.Results) []common.Task {
+		return []common.Task{NewMockTask("Вложенная")}
+	}}
+	parent := &branchTask{MockTask: NewMockTask("Родитель").CompleteSuccessfully(), followUp: func(common.Results) []common.Task {
+		return []common.Task{child, nil, NewMockTask("Вторая дочерняя")}
+	}}
+	model.AddTasks([]common.Task{parent, NewMockTask("Финал")})
+
+	model.expandFollowUps(0)
+	require.Len(t, model.tasks, 4)
+	assert.Equal(t, "Дочерняя", model.tasks[1].Title())
+	assert.Equal(t, "Вторая дочерняя", model.tasks[2].Title())
+	assert.Equal(t, "Финал", model.tasks[3].Title())
+
+	model.expandFollowUps(1)
+	require.Len(t, model.tasks, 5)
+	assert.Equal(t, "Вложенная", model.tasks[2].Title())
+	assert.Equal(t, 3, model.followUpSpan(0))
+
+	model.removeFollowUps(0)
+	require.Len(t, model.tasks, 2)
+	assert.Equal(t, "Финал", model.tasks[1].Title())
+	assert.Len(t, model.states, 2)
+}
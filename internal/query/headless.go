@@ -0,0 +1,139 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+)
+
+// answerable описывает задачи, которые умеют принимать ответы в неинтерактивном режиме
+type answerable interface {
+	ApplyAnswer(answer interface{}) error
+	ApplyDefaultAnswer() bool
+	FailAnswer(err error)
+}
+
+// executable описывает задачи, которые выполняются синхронно без участия пользователя
+type executable interface {
+	Execute() error
+}
+
+// identifiable описывает задачи со стабильным идентификатором
+type identifiable interface {
+	ID() string
+}
+
+// taskID возвращает идентификатор задачи, если он задан
+func taskID(task common.Task) string {
+	if withID, ok := task.(identifiable); ok {
+		return withID.ID()
+	}
+	return ""
+}
+
+// WithHeadless включает неинтерактивный режим: очередь выполняется без терминала,
+// а ответы для задач берутся из указанного источника.
+//
+// @param answers Источник ответов (может быть nil — тогда используются только значения по умолчанию)
+// @return Указатель на очередь задач
+func (m *Model) WithHeadless(answers AnswerSource) *Model {
+	m.headless = true
+	m.answers = answers
+	return m
+}
+
+// IsHeadless сообщает, включён ли неинтерактивный режим.
+func (m *Model) IsHeadless() bool {
+	return m.headless
+}
+
+// runHeadless последовательно выполняет задачи без запуска программы bubbletea.
+// По завершении итоговое представление очереди выводится в m.output.
+//
+// @return Ошибка задачи, остановившей очередь, или ошибка получения ответа
+func (m *Model) runHeadless() error {
+	runErr := m.runSequential(m.resolveHeadlessTask)
+	fmt.Fprint(m.output, m.View())
+	return runErr
+}
+
+// runSequential выполняет задачи по порядку без программы bubbletea,
+// завершая каждую задачу функцией resolve.
+//
+// @param resolve Функция, завершающая задачу
+// @return Ошибка задачи, остановившей очередь, или ошибка resolve
+func (m *Model) runSequential(resolve func(task common.Task) error) error {
+	if m.prompt != nil {
+		err := resolve(m.prompt)
+		if err != nil {
+			m.prompt = nil
+			return err
+		}
+		m.finishPrompt()
+	}
+
+	var runErr error
+	m.skipToRunnable()
+	for m.current < len(m.tasks) {
+		task := m.tasks[m.current]
+		m.startTask(m.current)
+		err := resolve(task)
+		m.finishTask(m.current)
+		if err != nil {
+			if target, ok := task.(answerable); ok {
+				target.FailAnswer(err)
+			}
+			runErr = err
+			m.stoppedOnError = true
+			m.errorTask = task
+			break
+		}
+
+		if task.HasError() && task.StopOnError() {
+			m.stoppedOnError = true
+			m.errorTask = task
+			runErr = task.Error()
+			break
+		}
+		m.expandFollowUps(m.current)
+		m.current++
+		m.skipToRunnable()
+	}
+
+	m.updateTaskStats()
+	return runErr
+}
+
+// resolveHeadlessTask завершает задачу с помощью источника ответов или значения по умолчанию
+func (m *Model) resolveHeadlessTask(task common.Task) error {
+	if task.IsDone() {
+		return nil
+	}
+
+	if runner, ok := task.(executable); ok {
+		// Ошибка функции сохраняется в самой задаче и обрабатывается как в интерактивном режиме
+		_ = runner.Execute()
+		return nil
+	}
+
+	target, ok := task.(answerable)
+	if !ok {
+		return terrors.NewConfigurationError(task.Title(), errors.New(defaults.ErrAnswerUnsupportedTask), "headless")
+	}
+
+	if m.answers != nil {
+		if answer, found := m.answers.Lookup(taskID(task), task.Title()); found {
+			return target.ApplyAnswer(answer)
+		}
+	}
+
+	if target.ApplyDefaultAnswer() {
+		return nil
+	}
+
+	return terrors.NewConfigurationError(task.Title(), errors.New(defaults.ErrAnswerMissing), "answers").
+		WithContext("id", taskID(task))
+}
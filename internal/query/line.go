@@ -0,0 +1,179 @@
+package query
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// linePrompter описывает задачи, которые умеют задавать вопрос в построчном режиме
+type linePrompter interface {
+	LinePrompt() string
+	ApplyLineAnswer(line string) error
+}
+
+// multilinePrompter описывает задачи, ответ которых вводится несколькими строками
+// до строки, содержащей только lineAnswerEnd
+type multilinePrompter interface {
+	LineMultiline() bool
+}
+
+// lineAnswerEnd завершает многострочный ответ в построчном режиме
+const lineAnswerEnd = "."
+
+// lineResulter описывает задачи, которые сообщают итог без оформления
+type lineResulter interface {
+	LineResult() (string, string)
+}
+
+// WithLineMode включает построчный режим: вопросы задач выводятся обычными строками,
+// ответы читаются построчно, курсор терминала не перемещается.
+//
+// @param in Источник ответов (nil — os.Stdin)
+// @return Указатель на очередь задач
+func (m *Model) WithLineMode(in io.Reader) *Model {
+	if in == nil {
+		in = os.Stdin
+	}
+	m.lineMode = true
+	m.input = in
+	return m
+}
+
+// IsLineMode сообщает, включён ли построчный режим.
+func (m *Model) IsLineMode() bool {
+	return m.lineMode
+}
+
+// runLine последовательно выполняет задачи, задавая вопросы строками.
+// После каждой задачи выводится её итог, в конце — сводка очереди.
+//
+// @return Ошибка задачи, остановившей очередь, или ошибка чтения ответа
+func (m *Model) runLine() error {
+	if m.title != "" {
+		fmt.Fprintln(m.output, m.title)
+	}
+
+	reader := bufio.NewReader(m.input)
+	runErr := m.runSequential(func(task common.Task) error {
+		return m.resolveLineTask(task, reader)
+	})
+
+	if m.showSummary {
+		left, right := m.formatSummaryWithStats()
+		fmt.Fprintln(m.output, ui.StripANSI(left)+" "+ui.StripANSI(right))
+	}
+	return runErr
+}
+
+// resolveLineTask завершает задачу ответом, прочитанным из m.input.
+// Некорректный ответ сопровождается сообщением, и вопрос задаётся повторно.
+//
+// @param task Задача
+// @param reader Источник строк ответа
+// @return Ошибка, если ввод закрыт до получения ответа или задача не поддерживает режим
+func (m *Model) resolveLineTask(task common.Task, reader *bufio.Reader) error {
+	if task.IsDone() {
+		return nil
+	}
+
+	th := m.Theme()
+	if runner, ok := task.(executable); ok {
+		fmt.Fprintln(m.output, ui.StripANSI(th.Glyphs.InProgress)+" "+task.Title())
+		// Ошибка функции сохраняется в самой задаче и обрабатывается как в интерактивном режиме
+		_ = runner.Execute()
+		m.writeLineResult(task)
+		return nil
+	}
+
+	prompter, ok := task.(linePrompter)
+	if !ok {
+		return terrors.NewConfigurationError(task.Title(), errors.New(defaults.ErrAnswerUnsupportedTask), "line")
+	}
+
+	for {
+		fmt.Fprint(m.output, prompter.LinePrompt())
+		line, readErr := readLineAnswer(task, reader)
+		if readErr != nil && line == "" {
+			fmt.Fprintln(m.output)
+			target, ok := task.(answerable)
+			if ok && target.ApplyDefaultAnswer() {
+				m.writeLineResult(task)
+				return nil
+			}
+			err := terrors.NewConfigurationError(task.Title(), errors.New(defaults.ErrLineInputClosed), "input")
+			if ok {
+				target.FailAnswer(err)
+				m.writeLineResult(task)
+			}
+			return err
+		}
+
+		if err := prompter.ApplyLineAnswer(strings.TrimRight(line, "\r\n")); err != nil {
+			fmt.Fprintf(m.output, "  %s %s\n", ui.StripANSI(th.Icons.Error), lineErrorMessage(err))
+			continue
+		}
+		m.writeLineResult(task)
+		return nil
+	}
+}
+
+// readLineAnswer читает ответ задачи: одну строку или, для многострочных задач,
+// строки до строки lineAnswerEnd либо до конца ввода.
+//
+// @param task Задача
+// @param reader Источник строк ответа
+// @return Ответ и ошибка чтения (ошибка при пустом ответе означает закрытый ввод)
+func readLineAnswer(task common.Task, reader *bufio.Reader) (string, error) {
+	if multi, ok := task.(multilinePrompter); !ok || !multi.LineMultiline() {
+		return reader.ReadString('\n')
+	}
+
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			if len(lines) == 0 {
+				return "", err
+			}
+			return strings.Join(lines, "\n"), nil
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == lineAnswerEnd {
+			return strings.Join(lines, "\n"), nil
+		}
+		lines = append(lines, line)
+	}
+}
+
+// writeLineResult выводит итог задачи строкой вида "✔ Заголовок: значение"
+func (m *Model) writeLineResult(task common.Task) {
+	reporter, ok := task.(lineResulter)
+	if !ok {
+		return
+	}
+	icon, value := reporter.LineResult()
+	value = strings.ReplaceAll(strings.TrimSpace(value), "\n", "\n    ")
+	if value == "" {
+		fmt.Fprintf(m.output, "%s %s\n", icon, task.Title())
+		return
+	}
+	fmt.Fprintf(m.output, "%s %s: %s\n", icon, task.Title(), value)
+}
+
+// lineErrorMessage возвращает текст ошибки ответа без типа ошибки и заголовка задачи
+func lineErrorMessage(err error) string {
+	var taskErr *terrors.TaskError
+	if errors.As(err, &taskErr) && taskErr.Err != nil {
+		return taskErr.Err.Error()
+	}
+	return err.Error()
+}
@@ -0,0 +1,85 @@
+package query
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+)
+
+// backNavigator описывает задачи, которые умеют запрашивать возврат к предыдущей задаче
+type backNavigator interface {
+	SetBackNavigation(enabled bool)
+	BackRequested() bool
+}
+
+// reopenable описывает задачи, которые можно повторно открыть после завершения
+type reopenable interface {
+	Reopen()
+}
+
+// WithBackNavigation включает возврат к предыдущей задаче (режим мастера).
+// Стрелка влево (в задаче ввода — при пустом поле) или выбор пункта "Назад"
+// повторно открывает предыдущую задачу с её прежним ответом.
+// Возврат к задачам-функциям не выполняется, так как их действия уже произведены.
+//
+// @param enable true - возврат разрешён
+// @return Указатель на очередь задач
+func (m *Model) WithBackNavigation(enable bool) *Model {
+	m.backNavigation = enable
+	return m
+}
+
+// previousIndex возвращает индекс предыдущей выполненной (не пропущенной) задачи или -1
+func (m *Model) previousIndex(index int) int {
+	for i := index - 1; i >= 0; i-- {
+		if !m.isSkipped(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// canGoBack проверяет, можно ли из задачи с указанным индексом вернуться к предыдущей
+func (m *Model) canGoBack(index int) bool {
+	if !m.backNavigation || m.headless || index >= len(m.tasks) {
+		return false
+	}
+	previous := m.previousIndex(index)
+	if previous < 0 {
+		return false
+	}
+	_, ok := m.tasks[previous].(reopenable)
+	return ok
+}
+
+// backRequested проверяет, запросила ли задача возврат к предыдущей задаче
+func (m *Model) backRequested(task common.Task) bool {
+	navigator, ok := task.(backNavigator)
+	return ok && navigator.BackRequested()
+}
+
+// goBack повторно открывает предыдущую задачу очереди.
+// Текущая задача также сбрасывается, чтобы при повторном переходе к ней
+// она снова ожидала ответа пользователя.
+//
+// @return Команда запуска предыдущей задачи
+func (m *Model) goBack() tea.Cmd {
+	if current, ok := m.tasks[m.current].(reopenable); ok {
+		current.Reopen()
+	}
+	if !m.canGoBack(m.current) {
+		return nil
+	}
+
+	// Пропущенные задачи снова проверят условие после повторного ответа,
+	// а добавленные продолжения будут сформированы заново
+	previous := m.previousIndex(m.current)
+	for i := previous + 1; i <= m.current; i++ {
+		m.states[i].skipped = false
+	}
+	m.current = previous
+	m.states[m.current].restored = false
+	m.removeFollowUps(previous)
+	m.tasks[m.current].(reopenable).Reopen()
+	m.updateTaskStats()
+	return m.startTask(m.current)
+}
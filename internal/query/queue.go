@@ -2,6 +2,7 @@ package query
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 	"github.com/qzeleza/ziva/internal/common"
 	"github.com/qzeleza/ziva/internal/defaults"
 	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/sink"
 	"github.com/qzeleza/ziva/internal/ui"
 )
 
@@ -135,17 +137,103 @@ type Model struct {
 	resultFormattingEnabled bool   // Включает форматирование результатов с разделительными линиями
 	resultLinePrefix        string // Префикс для разделительной линии (по умолчанию "  │  ")
 	resultLineLength        int    // Количество символов "─" в разделительной линии
+
+	// Параметры неинтерактивного режима
+	headless bool         // Выполнение без терминала с ответами из источника
+	answers  AnswerSource // Источник заранее подготовленных ответов
+	output   io.Writer    // Поток вывода очереди (по умолчанию os.Stdout)
+
+	// Построчный режим для последовательных консолей и простых терминалов
+	lineMode bool      // Вопросы выводятся строками, ответы читаются построчно
+	input    io.Reader // Источник ответов построчного режима (по умолчанию os.Stdin)
+
+	// Возврат к предыдущей задаче (режим мастера)
+	backNavigation bool // Разрешает задачам открывать предыдущую задачу очереди
+
+	// Тема оформления очереди и её задач (nil — глобальные стили пакета ui)
+	theme *ui.Theme
+
+	// Журнал сеанса: запись о каждой завершённой задаче
+	transcript       io.Writer        // Поток журнала (nil — журнал отключён)
+	transcriptFormat TranscriptFormat // Формат записей журнала
+	transcriptErr    error            // Первая ошибка записи журнала
+
+	// Источник времени очереди и её задач (nil — системное время)
+	clock common.Clock
+
+	// Файл состояния для возобновления прерванной очереди
+	stateFile    string       // Путь к файлу состояния ("" — состояние не сохраняется)
+	stateKey     []byte       // Ключ шифрования паролей (nil — пароли не сохраняются)
+	stateErr     error        // Первая ошибка записи файла состояния
+	resumePrompt ResumePrompt // Фабрика вопроса о возобновлении
+	resumeReady  bool         // Файл состояния уже прочитан
+	saved        *savedState  // Состояние прерванного запуска до ответа на вопрос
+	prompt       common.Task  // Вопрос о возобновлении, ожидающий ответа
+
+	// Файлы конфигурации, в которые записываются ответы после выполнения очереди
+	sinks []*sink.Sink
+
+	// Состояние задач в очереди (индексы совпадают с m.tasks)
+	states       []taskState // Время выполнения, пропуск по условию, добавленные задачи
+	skippedCount int         // Количество задач, пропущенных по условию
+
+	// Результаты и записи файла состояния задач, удалённых из m.tasks при очистке памяти
+	archived      common.Results
+	archivedState []savedTask
 }
 
 type selectionSeparatorSetter interface {
 	SetSelectionSeparatorEnabled(bool)
 }
 
+// themeSetter — задачи, принимающие тему оформления очереди
+type themeSetter interface {
+	SetTheme(*ui.Theme)
+}
+
+// canceller описывает задачи, выполнение которых можно прервать
+type canceller interface {
+	Cancel()
+}
+
+// cancelRunning отменяет выполнение текущей задачи, если она это поддерживает
+func (m *Model) cancelRunning() {
+	if m.current >= len(m.tasks) {
+		return
+	}
+	if task, ok := m.tasks[m.current].(canceller); ok {
+		task.Cancel()
+	}
+}
+
+// quit завершает очередь по Ctrl+C. Клавиша сначала передаётся текущей задаче,
+// чтобы она отметила себя отменённой и последний кадр не показывал активный ввод.
+func (m *Model) quit(key tea.KeyMsg) tea.Cmd {
+	m.quitting = true
+	if m.current >= len(m.tasks) {
+		return tea.Quit
+	}
+	// Отменяем ту задачу, что выполнялась до нажатия: Update может вернуть другой объект
+	running := m.tasks[m.current]
+	var cmd tea.Cmd
+	if m.prompt == nil && !running.IsDone() {
+		m.tasks[m.current], cmd = running.Update(key)
+		if m.tasks[m.current].IsDone() {
+			m.finishTask(m.current)
+			m.updateTaskStats()
+		}
+	}
+	if task, ok := running.(canceller); ok {
+		task.Cancel()
+	}
+	return tea.Batch(cmd, tea.Quit)
+}
+
 const defauiltNumberFormat = "[%02d]" // формат по умолчанию для отображения номеров задач
 
 // New создает новую модель очереди с заданным заголовком и задачами.
 func New(title string) *Model {
-	return &Model{
+	m := &Model{
 		title:           title,
 		summary:         defaults.SummaryCompleted,
 		width:           common.DefaultWidth, // Начальная ширина
@@ -160,7 +248,14 @@ func New(title string) *Model {
 		resultFormattingEnabled: true,                           // По умолчанию отключено
 		resultLinePrefix:        "  │  ",                        // Префикс по умолчанию с символом │
 		resultLineLength:        common.DefaultWidth * 93 / 100, // Длина линии перед выводом результатов задачи по умолчанию
+		output:                  os.Stdout,
 	}
+
+	// Файл темы пользователя и NO_COLOR/CLICOLOR действуют и на очереди без WithTheme
+	if theme := ui.EnvironmentTheme(nil); theme != nil {
+		m.setTheme(theme)
+	}
+	return m
 }
 
 // Добавляет список задач для выполнения.
@@ -180,9 +275,12 @@ func (m *Model) AddTasks(tasks []common.Task) {
 
 	// Добавляем все валидные задачи в основной срез m.tasks одним вызовом append.
 	m.tasks = append(m.tasks, validTasks...)
+	m.states = append(m.states, make([]taskState, len(validTasks))...)
 
 	if len(validTasks) > 0 {
 		m.applySelectionSeparatorFlag(validTasks)
+		m.applyTheme(validTasks)
+		m.applyClock(validTasks)
 	}
 }
 
@@ -205,6 +303,7 @@ func (m *Model) WithTasksNumbered(enable bool, keepFirstSymbol bool, numberForma
 func (m *Model) updateTaskStats() {
 	m.successCount = 0
 	m.errorCount = 0
+	m.skippedCount = 0
 
 	// Подсчитываем все задачи - просматриваем все до текущей позиции или все задачи если завершены
 	tasksToCheck := m.current
@@ -214,6 +313,10 @@ func (m *Model) updateTaskStats() {
 
 	for i := 0; i < tasksToCheck; i++ {
 		task := m.tasks[i]
+		if m.isSkipped(i) {
+			m.skippedCount++
+			continue
+		}
 		if task.IsDone() {
 			if task.HasError() {
 				m.errorCount++
@@ -238,7 +341,9 @@ func (m *Model) updateTaskStats() {
 
 // formatSummaryWithStats форматирует сводку с учетом статистики
 func (m *Model) formatSummaryWithStats() (string, string) {
-	totalTasks := len(m.tasks)
+	th := m.Theme()
+	// Пропущенные по условию задачи учитываются отдельно
+	totalTasks := len(m.tasks) - m.skippedCount
 	completedTasks := m.successCount + m.errorCount
 
 	// Формируем левую часть: summary + (успешных/всего)
@@ -255,16 +360,26 @@ func (m *Model) formatSummaryWithStats() (string, string) {
 		" ",
 		defaults.DefaultTasksSummaryLabel,
 	)
+	if m.skippedCount > 0 {
+		leftSummary = performance.FastConcat(
+			leftSummary,
+			" (",
+			defaults.DefaultSkippedSummaryLabel,
+			" ",
+			performance.IntToString(m.skippedCount),
+			")",
+		)
+	}
 
 	// Формируем правую часть: УСПЕШНО или С ОШИБКАМИ
 	var rightStatus string
 	if m.errorCount > 0 {
-		rightStatus = defaults.StatusProblem
+		rightStatus = th.StatusProblem()
 	} else if completedTasks == totalTasks && completedTasks > 0 {
-		rightStatus = defaults.StatusSuccess
+		rightStatus = th.StatusSuccess()
 	} else {
 		// Для состояния "В ПРОЦЕССЕ" или когда нет завершенных задач
-		rightStatus = defaults.StatusInProgress
+		rightStatus = th.StatusInProgress()
 	}
 
 	return leftSummary, rightStatus
@@ -272,20 +387,68 @@ func (m *Model) formatSummaryWithStats() (string, string) {
 
 // Запускает очередь задач
 func (m *Model) Run() error {
+	if err := m.prepareResume(); err != nil {
+		return err
+	}
+
+	var err error
+	switch {
+	case m.headless:
+		// В неинтерактивном режиме терминал не используется
+		err = m.runHeadless()
+	case m.lineMode:
+		err = m.runLine()
+	default:
+		err = m.runProgram()
+	}
+	m.clearCompletedState()
+	if err = m.runError(err); err != nil {
+		return err
+	}
+	return m.writeSinks()
+}
+
+// runError возвращает ошибку выполнения очереди или, если её нет,
+// первую ошибку записи журнала сеанса или файла состояния
+func (m *Model) runError(err error) error {
+	if err != nil {
+		return err
+	}
+	if m.transcriptErr != nil {
+		return m.transcriptErr
+	}
+	return m.stateErr
+}
+
+// runProgram выполняет очередь в программе bubbletea
+func (m *Model) runProgram() error {
 	// Если установлен флаг очистки экрана, очищаем экран перед запуском
 	if m.clearScreen {
 		// Используем ANSI-последовательность для очистки экрана
 		if m.hardClearScreen {
-			fmt.Print(defaults.HardClearScreen) // Очищаем весь экран и его буфер
+			fmt.Fprint(m.output, defaults.HardClearScreen) // Очищаем весь экран и его буфер
 		} else {
-			fmt.Print(defaults.ClearScreen) // Очищаем только область вывода
+			fmt.Fprint(m.output, defaults.ClearScreen) // Очищаем только область вывода
 		}
 	}
 
-	_, err := tea.NewProgram(m).Run()
+	_, err := tea.NewProgram(m, tea.WithOutput(m.output)).Run()
+	// Прерываем функцию, которая могла остаться выполняться после выхода из программы
+	m.cancelRunning()
 	return err
 }
 
+// WithOutput задаёт поток вывода очереди (по умолчанию os.Stdout).
+//
+// @param w Поток вывода
+// @return Указатель на очередь задач
+func (m *Model) WithOutput(w io.Writer) *Model {
+	if w != nil {
+		m.output = w
+	}
+	return m
+}
+
 // WithTitleColor устанавливает цвет заголовка.
 func (m *Model) WithTitleColor(titleColor lipgloss.TerminalColor, bold bool) *Model {
 	m.titleStyle = lipgloss.NewStyle().Foreground(titleColor).Bold(bold)
@@ -322,7 +485,7 @@ func (m *Model) WithAppName(appName string, version ...string) *Model {
 // @param bold Флаг, указывающий, нужно ли сделать текст курсивом
 // @return Указатель на очередь задач
 func (m *Model) WithAppNameColor(textColor lipgloss.TerminalColor, bold bool) *Model {
-	m.appNameStyle = lipgloss.NewStyle().Foreground(textColor).Bold(bold).Background(ui.ColorBrightWhite)
+	m.appNameStyle = lipgloss.NewStyle().Foreground(textColor).Bold(bold).Background(m.Theme().Palette.Badge)
 	return m
 }
 
@@ -374,18 +537,66 @@ func (m *Model) applySelectionSeparatorFlag(tasks []common.Task) {
 // @param color Цвет ошибки
 // @return Указатель на очередь задач
 func (m *Model) SetErrorColor(color ErrorColor) *Model {
+	// Без темы меняются глобальные стили (прежнее поведение), с темой — только тема очереди
+	setErrorColor := ui.SetErrorColor
+	if m.theme != nil {
+		setErrorColor = m.theme.SetErrorColor
+	}
 
 	switch color {
 	case Yellow:
-		ui.SetErrorColor(ui.ColorDarkYellow, ui.ColorBrightYellow)
+		setErrorColor(ui.ColorDarkYellow, ui.ColorBrightYellow)
 	case Red:
-		ui.SetErrorColor(ui.ColorDarkRed, ui.ColorBrightRed)
+		setErrorColor(ui.ColorDarkRed, ui.ColorBrightRed)
 	case Orange:
-		ui.SetErrorColor(ui.ColorDarkOrange, ui.ColorBrightOrange)
+		setErrorColor(ui.ColorDarkOrange, ui.ColorBrightOrange)
 	}
 	return m
 }
 
+// WithTheme задаёт тему оформления очереди и всех её задач.
+// Очередь хранит копию темы, поэтому глобальные стили пакета ui
+// и другие очереди не затрагиваются. Стили заголовка очереди
+// берутся из темы; WithTitleColor и WithAppNameColor, вызванные позже,
+// их переопределяют. Файл темы пользователя и NO_COLOR/CLICOLOR
+// применяются поверх переданной темы (см. ui.EnvironmentTheme).
+//
+// @param theme Тема оформления (nil — глобальные стили пакета ui)
+// @return Указатель на очередь задач
+func (m *Model) WithTheme(theme *ui.Theme) *Model {
+	m.setTheme(ui.EnvironmentTheme(theme))
+	return m
+}
+
+// setTheme устанавливает итоговую тему очереди, стили заголовка и передаёт тему задачам
+func (m *Model) setTheme(theme *ui.Theme) {
+	m.theme = theme
+
+	th := m.Theme()
+	m.titleStyle = th.Styles.QueueTitle
+	m.appNameStyle = th.Styles.AppName
+	m.appVersionStyle = th.Styles.AppVersion
+	m.applyTheme(m.tasks)
+}
+
+// Theme возвращает тему оформления очереди: заданную WithTheme
+// или собранную из глобальных стилей пакета ui.
+func (m *Model) Theme() *ui.Theme {
+	if m.theme != nil {
+		return m.theme
+	}
+	return ui.CurrentTheme()
+}
+
+// applyTheme передаёт тему очереди задачам
+func (m *Model) applyTheme(tasks []common.Task) {
+	for _, task := range tasks {
+		if setter, ok := task.(themeSetter); ok {
+			setter.SetTheme(m.theme)
+		}
+	}
+}
+
 // layoutWidth вычисляет ширину для рендеринга задач.
 // Использует функцию из пакета common.
 //
@@ -398,8 +609,19 @@ func (m *Model) layoutWidth() int {
 //
 // @return Команда для запуска первой задачи
 func (m *Model) Init() tea.Cmd {
-	if len(m.tasks) > 0 {
-		return m.tasks[0].Run()
+	if err := m.prepareResume(); err != nil {
+		m.stateErr = err
+		return tea.Quit
+	}
+	// Сначала пользователь отвечает на вопрос о возобновлении
+	if m.prompt != nil {
+		return m.prompt.Run()
+	}
+
+	// Пропускаем начальные задачи, условие которых не выполнено
+	m.skipToRunnable()
+	if m.current < len(m.tasks) {
+		return m.startTask(m.current)
 	}
 	return tea.Quit
 }
@@ -439,9 +661,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = size.Width
 	}
 
-	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "Ctrl+c" {
-		m.quitting = true
-		return m, tea.Quit
+	if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyCtrlC {
+		return m, m.quit(key)
+	}
+
+	if m.prompt != nil {
+		return m, m.updatePrompt(msg)
 	}
 
 	if m.current >= len(m.tasks) {
@@ -455,8 +680,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	updatedTask, cmd := currentTask.Update(msg)
 	m.tasks[m.current] = updatedTask
 
+	// Пользователь запросил возврат к предыдущей задаче
+	if m.backRequested(updatedTask) {
+		return m, tea.Batch(cmd, m.goBack())
+	}
+
 	// Проверяем завершение уже ОБНОВЛЁННОЙ задачи
 	if m.tasks[m.current].IsDone() {
+		m.finishTask(m.current)
+
 		// Обновляем статистику выполненных задач
 		m.updateTaskStats()
 
@@ -478,10 +710,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
-		// Иначе переходим к следующей задаче
+		// Иначе добавляем задачи-продолжения и переходим к следующей задаче
+		m.expandFollowUps(m.current)
 		m.current++
+		m.skipToRunnable()
 		if m.current < len(m.tasks) {
-			nextCmd := m.tasks[m.current].Run()
+			nextCmd := m.startTask(m.current)
 			return m, tea.Batch(cmd, nextCmd)
 		}
 		// Финальное обновление статистики при завершении всех задач
@@ -496,11 +730,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 //
 // @return string - отображаемый список задач
 func (m *Model) View() string {
+	if m.prompt != nil {
+		return m.promptView()
+	}
+
+	th := m.Theme()
 
 	// Если очередь завершена, отображаем просто надпись о завершении
 	// без прорисовки задач
 	// if m.quitting {
-	// 	return ui.CancelStyle.Render(task.DefaultCancelLabel) + "\n"
+	// 	return th.Styles.Cancel.Render(task.DefaultCancelLabel) + "\n"
 	// }
 
 	var sb strings.Builder
@@ -508,14 +747,18 @@ func (m *Model) View() string {
 	layoutWidth := m.layoutWidth()
 
 	// Используем настроенный заголовок.
-	sb.WriteString(ui.DrawLine(layoutWidth))
+	sb.WriteString(th.DrawLine(layoutWidth))
 	sb.WriteString(m.setTitle(layoutWidth))
-	sb.WriteString(ui.DrawLine(layoutWidth) + "\n")
+	sb.WriteString(th.DrawLine(layoutWidth) + "\n")
 
 	lastTaskIndex := len(m.tasks) - 1
 	for i, t := range m.tasks {
 		// Если задача завершена, отображаем её с форматированием
-		if i < m.current {
+		if i < m.current && m.isSkipped(i) {
+			// Задача пропущена по условию: выводим её заголовок со статусом "пропущено"
+			sb.WriteString(m.formatSkippedTask(t, i, layoutWidth))
+			sb.WriteString(th.TaskBelowPrefix() + "\n")
+		} else if i < m.current {
 			// Проверяем, есть ли ошибка в задаче
 			hasError := t.HasError()
 			stripPrefixes := !m.showSummary && lastTaskIndex >= 0 && i == lastTaskIndex
@@ -524,7 +767,7 @@ func (m *Model) View() string {
 			// Завершенные задачи: отображаем их с форматированием (или без, если отключено)
 			sb.WriteString(m.formatTaskResult(t, layoutWidth, stripPrefixes))
 			// Добавляем префикс для следующей задачи в виде пустой строки с префиксом "  │"
-			sb.WriteString(ui.GetTaskBelowPrefix() + "\n")
+			sb.WriteString(th.TaskBelowPrefix() + "\n")
 
 		} else if i == m.current {
 			// Если задача не завершена, отображаем её в интерактивном виде
@@ -545,70 +788,70 @@ func (m *Model) View() string {
 			}
 			// Добавляем разделитель, если есть ожидающие задачи и нет ошибки
 			if i+1 < len(m.tasks) && (!m.stoppedOnError && !hasError) {
-				sb.WriteString(ui.DrawLine(layoutWidth))
+				sb.WriteString(th.DrawLine(layoutWidth))
 			}
 		}
 	}
 
 	// Убираем крайнюю линию, если она есть
-	removeDuplicateLines(&sb)
+	removeDuplicateLines(th, &sb)
 
 	// Добавляем финальную разделительную линию
 	// Если есть активная задача, добавляем обычную линию
 	// Иначе добавляем специальную линию
 	if m.current < len(m.tasks) && !m.stoppedOnError {
-		sb.WriteString(ui.DrawLine(layoutWidth))
+		sb.WriteString(th.DrawLine(layoutWidth))
 	} else {
 
 		// Отображаем сводку только если включен флаг showSummary
 		if m.showSummary {
-			ensureTrailingPrefixLine(&sb)
+			ensureTrailingPrefixLine(th, &sb)
 			// Получаем форматированную сводку с статистикой
 			leftSummary, rightStatus := m.formatSummaryWithStats()
 
 			// Определяем стиль для правой части в зависимости от статуса
 			var rightStyle lipgloss.Style
 			switch rightStatus {
-			case defaults.StatusSuccess:
-				rightStyle = ui.SuccessLabelStyle
-			case defaults.StatusProblem:
-				rightStyle = ui.GetErrorStatusStyle()
-			case defaults.StatusInProgress:
-				rightStyle = ui.SubtleStyle
+			case th.StatusSuccess():
+				rightStyle = th.Styles.SuccessLabel
+			case th.StatusProblem():
+				rightStyle = th.Styles.ErrorStatus
+			case th.StatusInProgress():
+				rightStyle = th.Styles.Subtle
 			default:
-				rightStyle = ui.SubtleStyle
+				rightStyle = th.Styles.Subtle
 			}
 
 			// Определяем стиль для левой части (summary) - используем те же стили что и для правой части
 			var summaryStyle lipgloss.Style
 			switch rightStatus {
-			case defaults.StatusSuccess:
-				summaryStyle = ui.SuccessLabelStyle // Тот же стиль что и для правой части "SUCCESS"
-			case defaults.StatusProblem:
-				summaryStyle = ui.GetErrorStatusStyle() // Тот же стиль что и для правой части при ошибках
-			case defaults.StatusInProgress:
+			case th.StatusSuccess():
+				summaryStyle = th.Styles.SuccessLabel // Тот же стиль что и для правой части "SUCCESS"
+			case th.StatusProblem():
+				summaryStyle = th.Styles.ErrorStatus // Тот же стиль что и для правой части при ошибках
+			case th.StatusInProgress():
 				// Для состояния "В ПРОЦЕССЕ" используем тот же стиль что и справа
-				summaryStyle = ui.SubtleStyle
+				summaryStyle = th.Styles.Subtle
 			default:
-				summaryStyle = ui.SubtleStyle
+				summaryStyle = th.Styles.Subtle
 			}
 
 			// Разделяем линию только если есть задачи с результатом
 			var separator string
 			// separator = performance.FastConcat(
-			// 	"  ", ui.VerticalLineSymbol, "\n",
+			// 	"  ", th.Glyphs.Vertical, "\n",
 			// )
 			// // Добавляем разделитель перед итоговой строкой
 			// if hasHiddenResultLine(m.tasks) {
 			// 	separator = performance.FastConcat(
 			// 		separator,
-			// 		"  ", ui.VerticalLineSymbol, "\n",
+			// 		"  ", th.Glyphs.Vertical, "\n",
 			// 	)
 			// }
 
 			// Создаем левую часть футера
 			leftPart := performance.FastConcat(
-				"  ", ui.FinishedLabelStyle.Render(ui.TaskCompletedSymbol), "  ",
+				"  ", th.Styles.FinishedLabel.Render(th.Glyphs.Completed), "  ",
 				summaryStyle.Render(leftSummary), "  ",
 			)
 
@@ -621,17 +864,17 @@ func (m *Model) View() string {
 				separator,
 				footerLine,
 				"\n\n",
-				ui.DrawLine(layoutWidth),
+				th.DrawLine(layoutWidth),
 				"\n",
 			)
 			sb.WriteString(footer)
 		} else {
 			// Убираем висящий префикс вертикальной линии перед пустой строкой
-			removeTrailingTaskBelowPrefix(&sb)
+			removeTrailingTaskBelowPrefix(th, &sb)
 			// Заменяем вертикальные линии перед символами задач ПЕРЕД добавлением финальных элементов
-			removeVerticalLinesBeforeTaskSymbols(&sb)
+			removeVerticalLinesBeforeTaskSymbols(th, &sb)
 			ensureSingleBlankLine(&sb)
-			sb.WriteString(ui.DrawLine(layoutWidth))
+			sb.WriteString(th.DrawLine(layoutWidth))
 		}
 	}
 
@@ -702,6 +945,7 @@ func (m *Model) applyInProgressTaskPrefix(task common.Task, index int, hasError
 // calculateResultLinePrefix вычисляет префикс для разделительной линии и строк результата
 // в зависимости от настроек нумерации
 func (m *Model) calculateResultLinePrefix() string {
+	th := m.Theme()
 	if m.numberCompletedTasks {
 		// При включенной нумерации нужно учесть ширину номера
 		// Например, для "[1]  " нужно "     │  " (5 пробелов + │ + 2 пробела)
@@ -714,12 +958,12 @@ func (m *Model) calculateResultLinePrefix() string {
 		totalSpaces := numberWidth - 1 // +2 после номера, +1 перед │
 		return performance.FastConcat(
 			performance.RepeatEfficient(" ", totalSpaces),
-			ui.VerticalLineSymbol,
+			th.Glyphs.Vertical,
 			performance.RepeatEfficient(" ", 3), // 2 пробела после │
 		)
 	} else {
 		// При отключенной нумерации используем стандартный префикс
-		return m.resultLinePrefix
+		return strings.Replace(m.resultLinePrefix, ui.VerticalLineSymbol, th.Glyphs.Vertical, 1)
 	}
 }
 
@@ -727,11 +971,12 @@ func (m *Model) calculateResultLinePrefix() string {
 // Создает линию из префикса и символов "─", затем выводит результат задачи с новой строки
 // stripVerticalPrefixes управляет заменой вертикальной линии на пробел для последнего блока без сводки
 func (m *Model) formatTaskResult(task common.Task, width int, stripVerticalPrefixes bool) string {
+	th := m.Theme()
 	if !m.resultFormattingEnabled {
 		// Если форматирование отключено, возвращаем обычное представление
 		view := task.FinalView(width)
 		if stripVerticalPrefixes {
-			return stripResultPrefixes(view)
+			return stripResultPrefixes(th, view)
 		}
 		return view
 	}
@@ -758,10 +1003,10 @@ func (m *Model) formatTaskResult(task common.Task, width int, stripVerticalPrefi
 		var lineStyle lipgloss.Style
 		if task.HasError() {
 			// Для ошибок используем очень приглушенный желтый цвет (более приглушенный чем текст ошибки)
-			lineStyle = ui.VerySubtleErrorStyle
+			lineStyle = th.Styles.VerySubtleError
 		} else {
 			// Для успешных результатов используем очень приглушенный стиль (едва заметный)
-			lineStyle = ui.VerySubtleStyle
+			lineStyle = th.Styles.VerySubtle
 		}
 
 		// Создаем стилизованную разделительную линию той же ширины, что и у активной задачи
@@ -770,7 +1015,7 @@ func (m *Model) formatTaskResult(task common.Task, width int, stripVerticalPrefi
 		if lineLength < 0 {
 			lineLength = 0
 		}
-		separatorContent := performance.RepeatEfficient(ui.HorizontalLineSymbol, lineLength)
+		separatorContent := performance.RepeatEfficient(th.Glyphs.Horizontal, lineLength)
 		styledSeparator := lineStyle.Render(separatorContent)
 
 		// Используем динамический префикс для разделительной линии
@@ -810,7 +1055,7 @@ func (m *Model) formatTaskResult(task common.Task, width int, stripVerticalPrefi
 	}
 
 	if stripVerticalPrefixes {
-		finalResult = stripResultPrefixes(finalResult)
+		finalResult = stripResultPrefixes(th, finalResult)
 		if cancelDetected {
 			finalResult = ensureTrailingSingleNewline(finalResult)
 		}
@@ -879,7 +1124,7 @@ func formatTaskNumber(number int, format string) string {
 }
 
 // Убираем из потока вывода крайнюю линию, если она есть
-func removeDuplicateLines(sb *strings.Builder) {
+func removeDuplicateLines(th *ui.Theme, sb *strings.Builder) {
 
 	// Обработка финальной разделительной линии
 	// Получаем текущее содержимое Builder для анализа
@@ -902,7 +1147,7 @@ func removeDuplicateLines(sb *strings.Builder) {
 
 			// Проверяем, является ли строка горизонтальной линией
 			// (состоит только из символов HorizontalLineSymbol)
-			isHorizontalLine := strings.Contains(lastLine, ui.HorizontalLineSymbol)
+			isHorizontalLine := strings.Contains(lastLine, th.Glyphs.Horizontal)
 
 			// Если строка - горизонтальная линия и не пустая, удаляем её
 			if isHorizontalLine && len(lastLine) > 0 {
@@ -916,13 +1161,13 @@ func removeDuplicateLines(sb *strings.Builder) {
 
 // removeTrailingTaskBelowPrefix убирает последнюю строку, состоящую только из префикса вертикальной линии
 // Это необходимо когда итоговая сводка отключена и перед финальной линией должна быть пустая строка
-func removeTrailingTaskBelowPrefix(sb *strings.Builder) {
+func removeTrailingTaskBelowPrefix(th *ui.Theme, sb *strings.Builder) {
 	if sb == nil {
 		return
 	}
 
 	content := sb.String()
-	suffix := ui.GetTaskBelowPrefix() + "\n"
+	suffix := th.TaskBelowPrefix() + "\n"
 	if strings.HasSuffix(content, suffix) {
 		sb.Reset()
 		sb.WriteString(content[:len(content)-len(suffix)])
@@ -931,24 +1176,24 @@ func removeTrailingTaskBelowPrefix(sb *strings.Builder) {
 
 // stripResultPrefixes заменяет вертикальную линию на пробел в строках результатов
 // Используется для последнего блока при отключенной сводке, чтобы не рисовать хвост линии
-func stripResultPrefixes(block string) string {
+func stripResultPrefixes(th *ui.Theme, block string) string {
 	if block == "" {
 		return block
 	}
 
 	lines := strings.Split(block, "\n")
 	for i := 1; i < len(lines)-1; i++ {
-		lines[i] = replaceFirstVerticalSymbol(lines[i])
+		lines[i] = replaceFirstVerticalSymbol(th, lines[i])
 	}
 	return strings.Join(lines, "\n")
 }
 
-func replaceFirstVerticalSymbol(line string) string {
-	idx := strings.Index(line, ui.VerticalLineSymbol)
+func replaceFirstVerticalSymbol(th *ui.Theme, line string) string {
+	idx := strings.Index(line, th.Glyphs.Vertical)
 	if idx == -1 {
 		return line
 	}
-	return performance.FastConcat(line[:idx], " ", line[idx+len(ui.VerticalLineSymbol):])
+	return performance.FastConcat(line[:idx], " ", line[idx+len(th.Glyphs.Vertical):])
 }
 
 func ensureTrailingSingleNewline(value string) string {
@@ -970,13 +1215,13 @@ func ensureSingleBlankLine(sb *strings.Builder) {
 	sb.WriteString("\n\n")
 }
 
-func ensureTrailingPrefixLine(sb *strings.Builder) {
+func ensureTrailingPrefixLine(th *ui.Theme, sb *strings.Builder) {
 	if sb == nil {
 		return
 	}
 	content := sb.String()
 	trimmed := strings.TrimRight(content, "\n")
-	prefix := ui.GetTaskBelowPrefix()
+	prefix := th.TaskBelowPrefix()
 	var lines []string
 	if trimmed != "" {
 		lines = strings.Split(trimmed, "\n")
@@ -1003,7 +1248,7 @@ func ensureTrailingPrefixLine(sb *strings.Builder) {
 // Алгоритм:
 //  1. Ищем строку и колонку (в рунах) последнего символа задачи
 //  2. Ищем самый нижний вертикальный сегмент в той же колонке и меняем его на пробел
-func removeVerticalLinesBeforeTaskSymbols(sb *strings.Builder) {
+func removeVerticalLinesBeforeTaskSymbols(th *ui.Theme, sb *strings.Builder) {
 	// Конвертируем буфер в строки
 	content := sb.String()
 	lines := strings.Split(content, "\n")
@@ -1013,8 +1258,8 @@ func removeVerticalLinesBeforeTaskSymbols(sb *strings.Builder) {
 	col := -1
 	for i := len(lines) - 1; i >= 0; i-- {
 		line := lines[i]
-		completed := strings.Index(line, ui.TaskCompletedSymbol)
-		progress := strings.Index(line, ui.TaskInProgressSymbol)
+		completed := strings.Index(line, th.Glyphs.Completed)
+		progress := strings.Index(line, th.Glyphs.InProgress)
 		if completed == -1 && progress == -1 {
 			continue
 		}
@@ -1034,7 +1279,7 @@ func removeVerticalLinesBeforeTaskSymbols(sb *strings.Builder) {
 	// 2. Ищем все вертикальные сегменты в той же колонке после последней задачи и заменяем их на пробелы
 	for i := lastLine + 1; i < len(lines); i++ {
 		runes := []rune(lines[i])
-		if col < len(runes) && string(runes[col]) == ui.VerticalLineSymbol {
+		if col < len(runes) && string(runes[col]) == th.Glyphs.Vertical {
 			// Заменяем вертикальную линию на пробел
 			runes[col] = ' '
 			lines[i] = string(runes)
@@ -1093,9 +1338,20 @@ func (m *Model) cleanupOldTasks() {
 		keepFrom = 0
 	}
 
+	// Ответы и результаты удаляемых задач переносим в архив, чтобы файл
+	// состояния и Results() их не теряли
+	m.ensureStates()
+	m.archiveState(keepFrom)
+	archived := make(common.Results, 0, keepFrom)
+	for i := 0; i < keepFrom; i++ {
+		archived = append(archived, m.taskResult(i, m.tasks[i]))
+	}
+	m.archived = append(m.archived, archived...)
+
 	// Создаем новый срез с ограниченным количеством задач
 	newTasks := make([]common.Task, len(m.tasks)-keepFrom)
 	copy(newTasks, m.tasks[keepFrom:])
+	m.states = append([]taskState(nil), m.states[keepFrom:]...)
 
 	// Обновляем индекс текущей задачи
 	m.current -= keepFrom
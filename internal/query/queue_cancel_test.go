@@ -12,7 +12,7 @@ import (
 
 func TestStripResultPrefixes_RemovesVerticalLineFromCancelMessage(t *testing.T) {
 	input := "Задача\n  │────\n  │    отменено пользователем\n"
-	got := stripResultPrefixes(input)
+	got := stripResultPrefixes(ui.CurrentTheme(), input)
 	want := "Задача\n   ────\n       отменено пользователем\n"
 	if got != want {
 		t.Fatalf("unexpected result: %q", got)
@@ -47,7 +47,7 @@ func TestFormatTaskResultCancelWithoutSummary(t *testing.T) {
 	prefixWidth := lipgloss.Width(linePrefix)
 	spaces := strings.Repeat(" ", prefixWidth)
 	separator := strings.Repeat(ui.HorizontalLineSymbol, width-prefixWidth-1)
-	comment := replaceFirstVerticalSymbol("  │    отменено пользователем")
+	comment := replaceFirstVerticalSymbol(ui.CurrentTheme(), "  │    отменено пользователем")
 	want := strings.Join([]string{
 		"Задача",
 		spaces + separator,
@@ -62,7 +62,7 @@ func TestFormatTaskResultCancelWithoutSummary(t *testing.T) {
 func TestEnsureTrailingPrefixLineAddsPrefix(t *testing.T) {
 	var sb strings.Builder
 	sb.WriteString("Задача\n")
-	ensureTrailingPrefixLine(&sb)
+	ensureTrailingPrefixLine(ui.CurrentTheme(), &sb)
 	wantSuffix := ui.GetTaskBelowPrefix() + "\n"
 	if !strings.HasSuffix(sb.String(), wantSuffix) {
 		t.Fatalf("expected suffix %q, got %q", wantSuffix, sb.String())
@@ -75,9 +75,31 @@ func TestEnsureTrailingPrefixLineKeepsSinglePrefix(t *testing.T) {
 	sb.WriteString("Задача\n")
 	sb.WriteString(prefix)
 	sb.WriteString("\n\n")
-	ensureTrailingPrefixLine(&sb)
+	ensureTrailingPrefixLine(ui.CurrentTheme(), &sb)
 	res := sb.String()
 	if strings.Count(res, prefix+"\n") != 1 {
 		t.Fatalf("expected single prefix line, got %q", res)
 	}
 }
+
+// cancellableTask — задача, фиксирующая отмену выполнения
+type cancellableTask struct {
+	*MockTask
+	cancelled bool
+}
+
+func (c *cancellableTask) Cancel() { c.cancelled = true }
+
+func TestQuitCancelsRunningTask(t *testing.T) {
+	model := New("test")
+	running := &cancellableTask{MockTask: NewMockTask("Загрузка")}
+	model.AddTasks([]common.Task{running})
+
+	_, cmd := model.Update(te.KeyMsg{Type: te.KeyCtrlC})
+	if cmd == nil || !model.quitting {
+		t.Fatalf("очередь должна завершиться")
+	}
+	if !running.cancelled {
+		t.Fatalf("выполняющаяся задача должна быть отменена при выходе из очереди")
+	}
+}
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"fmt"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -8,6 +10,7 @@ import (
 	"github.com/qzeleza/ziva/internal/common"
 	"github.com/qzeleza/ziva/internal/ui"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Дополнительные тесты для покрытия функций управления памятью в queue.go
@@ -102,6 +105,82 @@ func TestCleanupOldTasksWithinLimit(t *testing.T) {
 	assert.Equal(t, taskCount, model.current, "Индекс current не должен изменяться")
 }
 
+// TestCleanupOldTasksKeepsResults проверяет, что результаты удалённых задач остаются в отчёте
+func TestCleanupOldTasksKeepsResults(t *testing.T) {
+	model := New("Тест результатов после очистки")
+
+	var tasks []common.Task
+	taskCount := MaxCompletedTasks + 20
+	for i := 0; i < taskCount; i++ {
+		tasks = append(tasks, NewMockTask(fmt.Sprintf("Задача %d", i)).CompleteSuccessfully())
+	}
+	model.AddTasks(tasks)
+	model.current = taskCount
+
+	model.cleanupOldTasks()
+	assert.LessOrEqual(t, len(model.tasks), MaxCompletedTasks)
+
+	results := model.Results()
+	assert.Len(t, results, taskCount, "результаты удалённых задач сохраняются")
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.Equal(t, fmt.Sprintf("Задача %d", i), result.Title)
+		assert.Equal(t, common.ResultSuccess, result.Status)
+	}
+}
+
+// answerTask — задача, ответ которой сохраняется в файле состояния
+type answerTask struct {
+	*MockTask
+	answer interface{}
+}
+
+func (a *answerTask) StateAnswer() (interface{}, bool) { return a.answer, a.done }
+
+func (a *answerTask) RestoreAnswer(answer interface{}) error {
+	a.answer = answer
+	a.done = true
+	return nil
+}
+
+// newAnswerQueue создаёт очередь из count задач с сохраняемыми ответами
+func newAnswerQueue(path string, count int) (*Model, []*answerTask) {
+	model := New("Очередь с состоянием").WithStateFile(path, nil)
+	var tasks []*answerTask
+	var queue []common.Task
+	for i := 0; i < count; i++ {
+		task := &answerTask{MockTask: NewMockTask(fmt.Sprintf("Задача %d", i))}
+		tasks = append(tasks, task)
+		queue = append(queue, task)
+	}
+	model.AddTasks(queue)
+	return model, tasks
+}
+
+// TestCleanupOldTasksKeepsState проверяет возобновление по файлу состояния, записанному после очистки
+func TestCleanupOldTasksKeepsState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.state")
+	taskCount := MaxCompletedTasks + 20
+
+	first, tasks := newAnswerQueue(path, taskCount+1)
+	for i := 0; i < taskCount; i++ {
+		tasks[i].answer = fmt.Sprintf("ответ %d", i)
+		tasks[i].done = true
+	}
+	first.current = taskCount
+	first.cleanupOldTasks()
+	first.saveState()
+	require.NoError(t, first.stateErr)
+
+	second, restored := newAnswerQueue(path, taskCount+1)
+	require.NoError(t, second.prepareResume())
+	for i := 0; i < taskCount; i++ {
+		assert.True(t, restored[i].IsDone(), "задача %d восстанавливается", i)
+		assert.Equal(t, fmt.Sprintf("ответ %d", i), restored[i].answer)
+	}
+	assert.False(t, restored[taskCount].IsDone(), "незавершённая задача выполняется заново")
+}
+
 func TestWithAppNameColor(t *testing.T) {
 	model := New("Тест цвета названия приложения")
 
@@ -183,7 +262,7 @@ func TestRemoveVerticalLinesBeforeTaskSymbolsMemory(t *testing.T) {
 	sb.WriteString(content)
 
 	// Применяем функцию
-	removeVerticalLinesBeforeTaskSymbols(&sb)
+	removeVerticalLinesBeforeTaskSymbols(ui.CurrentTheme(), &sb)
 
 	result := sb.String()
 
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/qzeleza/ziva/internal/common"
 	"github.com/qzeleza/ziva/internal/defaults"
+	"github.com/qzeleza/ziva/internal/ui"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -232,7 +233,7 @@ func TestRemoveVerticalLinesBeforeTaskSymbols(t *testing.T) {
 	sb.WriteString("  │                                   \n") // Эта тоже
 
 	// Применяем функцию
-	removeVerticalLinesBeforeTaskSymbols(&sb)
+	removeVerticalLinesBeforeTaskSymbols(ui.CurrentTheme(), &sb)
 
 	result := sb.String()
 
@@ -0,0 +1,146 @@
+package query
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/qzeleza/ziva/internal/common"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+)
+
+// resultReporter описывает задачи, умеющие сформировать собственный результат
+type resultReporter interface {
+	Result() common.TaskResult
+}
+
+// secretMask заменяет секретные значения при выводе отчёта
+const secretMask = "********"
+
+// Results возвращает упорядоченный список результатов всех задач очереди.
+// Задачи, до которых очередь не дошла, имеют состояние ResultPending.
+// Результаты задач, удалённых при очистке памяти, берутся из архива.
+//
+// @return Список результатов задач
+func (m *Model) Results() common.Results {
+	m.ensureStates()
+	results := make(common.Results, 0, len(m.archived)+len(m.tasks))
+	results = append(results, m.archived...)
+	for i, task := range m.tasks {
+		results = append(results, m.taskResult(i, task))
+	}
+	return results
+}
+
+// taskResult формирует результат одной задачи. Индекс результата учитывает
+// задачи, удалённые из очереди при очистке памяти.
+func (m *Model) taskResult(index int, task common.Task) common.TaskResult {
+	var result common.TaskResult
+	if reporter, ok := task.(resultReporter); ok {
+		result = reporter.Result()
+	} else {
+		result = common.TaskResult{ID: taskID(task), Title: task.Title(), Kind: common.KindUnknown, Err: task.Error()}
+	}
+	result.Index = len(m.archived) + index
+	result.Duration = m.states[index].duration
+
+	switch {
+	case m.states[index].skipped || result.Status == common.ResultSkipped:
+		result.Status = common.ResultSkipped
+	case !task.IsDone():
+		result.Status = common.ResultPending
+	case result.Err != nil || task.HasError():
+		if result.Err == nil {
+			result.Err = task.Error()
+		}
+		result.ErrorType = terrors.DefaultErrorHandler.Handle(result.Title, result.Err).Type
+		result.Status = common.ResultError
+		if result.ErrorType == terrors.ErrorTypeUserCancel {
+			result.Status = common.ResultCancelled
+		}
+	default:
+		result.Status = common.ResultSuccess
+	}
+	return result
+}
+
+// jsonReport — структура отчёта очереди в формате JSON
+type jsonReport struct {
+	Title   string              `json:"title"`
+	Status  common.ResultStatus `json:"status"`
+	Results []jsonTaskResult    `json:"tasks"`
+}
+
+// jsonTaskResult — результат задачи в формате JSON
+type jsonTaskResult struct {
+	Index      int                 `json:"index"`
+	ID         string              `json:"id,omitempty"`
+	Title      string              `json:"title"`
+	Kind       string              `json:"kind"`
+	Status     common.ResultStatus `json:"status"`
+	Value      interface{}         `json:"value,omitempty"`
+	Values     []string            `json:"values,omitempty"`
+	TimedOut   bool                `json:"timed_out"`
+	Error      string              `json:"error,omitempty"`
+	ErrorType  *terrors.ErrorType  `json:"error_type,omitempty"`
+	DurationMs int64               `json:"duration_ms"`
+	Attempts   int                 `json:"attempts,omitempty"`
+}
+
+// WriteJSON записывает результаты очереди в формате JSON.
+// Значения секретных задач (например, паролей) заменяются маской.
+//
+// @param w Поток вывода
+// @return Ошибка записи
+func (m *Model) WriteJSON(w io.Writer) error {
+	results := m.Results()
+	report := jsonReport{
+		Title:   m.title,
+		Status:  m.overallStatus(results),
+		Results: make([]jsonTaskResult, 0, len(results)),
+	}
+
+	for _, result := range results {
+		record := jsonTaskResult{
+			Index:      result.Index,
+			ID:         result.ID,
+			Title:      result.Title,
+			Kind:       result.Kind,
+			Status:     result.Status,
+			Value:      result.Value,
+			Values:     result.Values,
+			TimedOut:   result.TimedOut,
+			DurationMs: result.Duration.Milliseconds(),
+			Attempts:   result.Attempts,
+		}
+		if result.Secret && result.Value != nil {
+			record.Value = secretMask
+		}
+		if result.Err != nil {
+			errorType := result.ErrorType
+			record.Error = result.Err.Error()
+			record.ErrorType = &errorType
+		}
+		report.Results = append(report.Results, record)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// overallStatus вычисляет итоговое состояние очереди по результатам задач
+func (m *Model) overallStatus(results common.Results) common.ResultStatus {
+	if m.quitting {
+		return common.ResultCancelled
+	}
+	status := common.ResultSuccess
+	for _, result := range results {
+		switch result.Status {
+		case common.ResultError, common.ResultCancelled:
+			return result.Status
+		case common.ResultPending:
+			status = common.ResultPending
+		}
+	}
+	return status
+}
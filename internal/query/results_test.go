@@ -0,0 +1,73 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResultsStatuses проверяет состояние задач в результатах очереди
+func TestResultsStatuses(t *testing.T) {
+	model := New("Результаты")
+	ok := NewMockTask("Успех").CompleteSuccessfully()
+	failed := NewMockTask("Ошибка").CompleteWithError(terrors.NewNetworkError("Ошибка", errors.New("нет связи")))
+	cancelled := NewMockTask("Отмена").CompleteWithError(terrors.NewCancelError("Отмена"))
+	pending := NewMockTask("Ожидание")
+	model.AddTasks([]common.Task{ok, failed, cancelled, pending})
+
+	results := model.Results()
+	require.Len(t, results, 4)
+
+	assert.Equal(t, 0, results[0].Index)
+	assert.Equal(t, common.KindUnknown, results[0].Kind)
+	assert.Equal(t, common.ResultSuccess, results[0].Status)
+
+	assert.Equal(t, common.ResultError, results[1].Status)
+	assert.Equal(t, terrors.ErrorTypeNetwork, results[1].ErrorType)
+
+	assert.Equal(t, common.ResultCancelled, results[2].Status)
+	assert.Equal(t, common.ResultPending, results[3].Status)
+	assert.True(t, results.HasErrors())
+
+	found, exists := results.ByTitle("Ошибка")
+	assert.True(t, exists)
+	assert.Equal(t, 1, found.Index)
+}
+
+// TestWriteJSON проверяет формат JSON-отчёта очереди
+func TestWriteJSON(t *testing.T) {
+	model := New("Отчёт")
+	model.AddTasks([]common.Task{
+		NewMockTask("Успех").CompleteSuccessfully(),
+		NewMockTask("Ошибка").CompleteWithError(errors.New("нет прав доступа")),
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, model.WriteJSON(&buf))
+
+	var report struct {
+		Title  string `json:"title"`
+		Status string `json:"status"`
+		Tasks  []struct {
+			Title     string `json:"title"`
+			Status    string `json:"status"`
+			Error     string `json:"error"`
+			ErrorType string `json:"error_type"`
+		} `json:"tasks"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, "Отчёт", report.Title)
+	assert.Equal(t, "error", report.Status)
+	require.Len(t, report.Tasks, 2)
+	assert.Equal(t, "success", report.Tasks[0].Status)
+	assert.Empty(t, report.Tasks[0].ErrorType)
+	assert.Equal(t, "error", report.Tasks[1].Status)
+	assert.Contains(t, report.Tasks[1].Error, "нет прав доступа")
+	assert.NotEmpty(t, report.Tasks[1].ErrorType)
+}
@@ -0,0 +1,39 @@
+package query
+
+import (
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/sink"
+)
+
+// WithSink добавляет файлы конфигурации, в которые записываются ответы
+// после выполнения очереди до конца без ошибок. Файлы записываются
+// атомарно в порядке добавления; очередь, прерванная пользователем
+// или остановленная ошибкой, файлы не изменяет.
+//
+// @param sinks Приёмники ответов
+// @return Указатель на очередь задач
+func (m *Model) WithSink(sinks ...*sink.Sink) *Model {
+	m.sinks = append(m.sinks, sinks...)
+	return m
+}
+
+// writeSinks записывает ответы в файлы конфигурации.
+//
+// @return Ошибка файловой системы первого файла, который не удалось записать
+func (m *Model) writeSinks() error {
+	if len(m.sinks) == 0 || !m.completed() {
+		return nil
+	}
+	results := m.Results()
+	for _, s := range m.sinks {
+		if _, err := s.Write(results); err != nil {
+			return terrors.NewFileSystemError(m.title, err, s.Path())
+		}
+	}
+	return nil
+}
+
+// completed сообщает, выполнена ли очередь до конца без ошибок и отмены
+func (m *Model) completed() bool {
+	return m.prompt == nil && m.current >= len(m.tasks) && !m.stoppedOnError && !m.quitting
+}
@@ -0,0 +1,389 @@
+package query
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/sink"
+)
+
+// stateVersion — версия формата файла состояния
+const stateVersion = 1
+
+// ResumePrompt создаёт вопрос о возобновлении прерванной очереди.
+// Очередь возобновляется, если пользователь ответил "Да".
+//
+// @param step Номер шага (с 1), с которого продолжится очередь
+// @return Задача Да/Нет
+type ResumePrompt func(step int) common.Task
+
+// restorable описывает задачи, ответ которых сохраняется в файле состояния
+type restorable interface {
+	StateAnswer() (interface{}, bool)
+	RestoreAnswer(answer interface{}) error
+}
+
+// confirmer описывает задачи Да/Нет
+type confirmer interface {
+	IsYes() bool
+}
+
+// savedState — содержимое файла состояния
+type savedState struct {
+	Version int         `json:"version"`
+	Queue   string      `json:"queue"`
+	SavedAt time.Time   `json:"saved_at"`
+	Tasks   []savedTask `json:"tasks"`
+}
+
+// savedTask — запись файла состояния о задаче. Задача без признаков done и skipped
+// не завершена: при возобновлении она выполняется заново.
+type savedTask struct {
+	ID      string      `json:"id,omitempty"`
+	Title   string      `json:"title"`
+	Kind    string      `json:"kind"`
+	Done    bool        `json:"done,omitempty"`
+	Skipped bool        `json:"skipped,omitempty"`
+	Answer  interface{} `json:"answer,omitempty"`
+	Sealed  string      `json:"sealed,omitempty"` // Секретный ответ, зашифрованный ключом очереди
+}
+
+// WithStateFile включает сохранение состояния очереди: после каждого шага
+// ответы завершённых задач записываются в файл path. Если при следующем запуске
+// файл содержит ответы, очередь задаёт вопрос prompt и при согласии отмечает
+// эти задачи выполненными с сохранёнными ответами. После успешного завершения
+// очереди файл удаляется.
+//
+// @param path Путь к файлу состояния ("" — состояние не сохраняется)
+// @param prompt Фабрика вопроса о возобновлении (nil — очередь возобновляется без вопроса)
+// @return Указатель на очередь задач
+func (m *Model) WithStateFile(path string, prompt ResumePrompt) *Model {
+	m.stateFile = path
+	m.resumePrompt = prompt
+	return m
+}
+
+// WithStateKey задаёт ключ, которым шифруются значения паролей в файле состояния.
+// Без ключа пароли не сохраняются и запрашиваются заново при возобновлении.
+//
+// @param key Ключ произвольной длины (nil — пароли не сохраняются)
+// @return Указатель на очередь задач
+func (m *Model) WithStateKey(key []byte) *Model {
+	m.stateKey = nil
+	if len(key) > 0 {
+		sum := sha256.Sum256(key)
+		m.stateKey = sum[:]
+	}
+	return m
+}
+
+// prepareResume читает файл состояния и готовит вопрос о возобновлении.
+// Выполняется один раз перед запуском очереди.
+//
+// @return Ошибка чтения или разбора файла состояния
+func (m *Model) prepareResume() error {
+	if m.stateFile == "" || m.resumeReady {
+		return nil
+	}
+	m.resumeReady = true
+
+	data, err := os.ReadFile(m.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return terrors.NewFileSystemError(m.title, err, m.stateFile)
+	}
+
+	var state savedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return terrors.NewFileSystemError(m.title, fmt.Errorf(defaults.ErrStateFileCorrupt, err), m.stateFile)
+	}
+	// Состояние другой очереди или другого формата не восстанавливается
+	if state.Version != stateVersion || state.Queue != m.title || !state.hasAnswers() {
+		return nil
+	}
+
+	m.saved = &state
+	if m.resumePrompt == nil {
+		m.restoreState()
+		return nil
+	}
+	m.prompt = m.resumePrompt(state.resumeStep())
+	m.applyTheme([]common.Task{m.prompt})
+	m.applyClock([]common.Task{m.prompt})
+	return nil
+}
+
+// hasAnswers сообщает, есть ли в состоянии выполненные задачи
+func (s *savedState) hasAnswers() bool {
+	for _, task := range s.Tasks {
+		if task.Done {
+			return true
+		}
+	}
+	return false
+}
+
+// resumeStep возвращает номер шага (с 1), с которого продолжится очередь
+func (s *savedState) resumeStep() int {
+	for i, task := range s.Tasks {
+		if !task.Done && !task.Skipped {
+			return i + 1
+		}
+	}
+	return len(s.Tasks) + 1
+}
+
+// updatePrompt передаёт сообщение вопросу о возобновлении и после ответа
+// запускает первую невыполненную задачу
+func (m *Model) updatePrompt(msg tea.Msg) tea.Cmd {
+	updated, cmd := m.prompt.Update(msg)
+	m.prompt = updated
+	if !updated.IsDone() {
+		return cmd
+	}
+
+	m.finishPrompt()
+	m.skipToRunnable()
+	if m.current < len(m.tasks) {
+		return tea.Batch(cmd, m.startTask(m.current))
+	}
+	m.updateTaskStats()
+	return tea.Quit
+}
+
+// promptView отображает заголовок очереди и вопрос о возобновлении
+func (m *Model) promptView() string {
+	th := m.Theme()
+	layoutWidth := m.layoutWidth()
+
+	var sb strings.Builder
+	sb.WriteString(th.DrawLine(layoutWidth))
+	sb.WriteString(m.setTitle(layoutWidth))
+	sb.WriteString(th.DrawLine(layoutWidth) + "\n")
+	m.applyInProgressTaskPrefix(m.prompt, 0, false)
+	sb.WriteString(m.prompt.View(layoutWidth) + "\n")
+	sb.WriteString(th.DrawLine(layoutWidth))
+	return sb.String()
+}
+
+// finishPrompt применяет ответ на вопрос о возобновлении: восстанавливает
+// сохранённые ответы или удаляет файл состояния, чтобы начать заново
+func (m *Model) finishPrompt() {
+	prompt := m.prompt
+	m.prompt = nil
+	if answer, ok := prompt.(confirmer); ok && answer.IsYes() && !prompt.HasError() {
+		m.restoreState()
+		return
+	}
+	m.saved = nil
+	if err := os.Remove(m.stateFile); err != nil && !errors.Is(err, os.ErrNotExist) && m.stateErr == nil {
+		m.stateErr = err
+	}
+}
+
+// restoreState отмечает задачи выполненными с ответами из файла состояния.
+// Восстановление прекращается на первой задаче, не совпадающей с сохранённой
+// по заголовку, идентификатору или виду. Задачи, которые не удалось восстановить
+// (FuncTask с ResumeRerun, пароль без ключа, вариант, которого больше нет),
+// остаются невыполненными и запускаются очередью.
+func (m *Model) restoreState() {
+	state := m.saved
+	m.saved = nil
+	m.ensureStates()
+
+	for i := 0; i < len(state.Tasks) && i < len(m.tasks); i++ {
+		saved := state.Tasks[i]
+		task := m.tasks[i]
+		result := m.taskResult(i, task)
+		if saved.Title != result.Title || saved.ID != result.ID || saved.Kind != result.Kind {
+			break
+		}
+		if !saved.Done || task.IsDone() {
+			continue
+		}
+
+		target, ok := task.(restorable)
+		if !ok {
+			continue
+		}
+		answer, ok := m.openAnswer(saved)
+		if !ok || target.RestoreAnswer(answer) != nil || !task.IsDone() {
+			continue
+		}
+		m.states[i].restored = true
+		m.expandFollowUps(i)
+	}
+	m.updateTaskStats()
+}
+
+// openAnswer возвращает сохранённый ответ, расшифровывая секретный
+//
+// @return Ответ и признак того, что он доступен
+func (m *Model) openAnswer(saved savedTask) (interface{}, bool) {
+	if saved.Sealed == "" {
+		return saved.Answer, true
+	}
+	if m.stateKey == nil {
+		return nil, false
+	}
+	data, err := base64.StdEncoding.DecodeString(saved.Sealed)
+	if err != nil {
+		return nil, false
+	}
+	gcm, err := newStateCipher(m.stateKey)
+	if err != nil || len(data) < gcm.NonceSize() {
+		return nil, false
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, false
+	}
+	return string(plain), true
+}
+
+// sealAnswer шифрует секретный ответ ключом очереди
+//
+// @return Зашифрованный ответ в base64
+func (m *Model) sealAnswer(answer interface{}) (string, error) {
+	gcm, err := newStateCipher(m.stateKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(fmt.Sprint(answer)), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// newStateCipher создаёт шифр AES-GCM для секретных ответов
+func newStateCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// saveState записывает в файл состояния ответы задач вплоть до последней завершённой,
+// включая задачи, удалённые из очереди при очистке памяти.
+// Первая ошибка записи сохраняется и возвращается из Run.
+func (m *Model) saveState() {
+	if m.stateFile == "" || m.stateErr != nil {
+		return
+	}
+	m.ensureStates()
+
+	last := -1
+	for i, task := range m.tasks {
+		if m.states[i].skipped || task.IsDone() {
+			last = i
+		}
+	}
+
+	state := savedState{
+		Version: stateVersion,
+		Queue:   m.title,
+		SavedAt: m.Clock().Now(),
+		Tasks:   make([]savedTask, 0, len(m.archivedState)+last+1),
+	}
+	state.Tasks = append(state.Tasks, m.archivedState...)
+	for i := 0; i <= last; i++ {
+		saved, err := m.savedTask(i)
+		if err != nil {
+			m.stateErr = err
+			return
+		}
+		state.Tasks = append(state.Tasks, saved)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err == nil {
+		err = sink.WriteFileAtomic(m.stateFile, data, 0o600)
+	}
+	m.stateErr = err
+}
+
+// savedTask формирует запись файла состояния о задаче с указанным индексом.
+// Пароль без ключа шифрования не сохраняется: задача записывается как невыполненная.
+func (m *Model) savedTask(index int) (savedTask, error) {
+	task := m.tasks[index]
+	result := m.taskResult(index, task)
+	saved := savedTask{ID: result.ID, Title: result.Title, Kind: result.Kind}
+	if m.states[index].skipped {
+		saved.Skipped = true
+		return saved, nil
+	}
+
+	target, ok := task.(restorable)
+	if !ok {
+		return saved, nil
+	}
+	answer, done := target.StateAnswer()
+	if !done {
+		return saved, nil
+	}
+	if !result.Secret {
+		saved.Done = true
+		saved.Answer = answer
+		return saved, nil
+	}
+	if m.stateKey == nil {
+		return saved, nil
+	}
+
+	sealed, err := m.sealAnswer(answer)
+	if err != nil {
+		return saved, err
+	}
+	saved.Done = true
+	saved.Sealed = sealed
+	return saved, nil
+}
+
+// archiveState сохраняет записи файла состояния о первых count задачах
+// перед их удалением из очереди при очистке памяти
+func (m *Model) archiveState(count int) {
+	if m.stateFile == "" || m.stateErr != nil {
+		return
+	}
+	for i := 0; i < count; i++ {
+		saved, err := m.savedTask(i)
+		if err != nil {
+			m.stateErr = err
+			return
+		}
+		m.archivedState = append(m.archivedState, saved)
+	}
+}
+
+// clearCompletedState удаляет файл состояния, если очередь выполнена до конца без ошибок
+func (m *Model) clearCompletedState() {
+	if m.stateFile == "" || m.stateErr != nil || m.prompt != nil {
+		return
+	}
+	if !m.completed() {
+		return
+	}
+	if err := os.Remove(m.stateFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		m.stateErr = err
+	}
+}
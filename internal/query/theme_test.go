@@ -0,0 +1,67 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+// themedMockTask — задача, запоминающая тему очереди
+type themedMockTask struct {
+	*MockErrorTask
+	theme *ui.Theme
+}
+
+func (m *themedMockTask) SetTheme(theme *ui.Theme) { m.theme = theme }
+
+// TestWithThemeAppliesToTasks проверяет передачу темы задачам, добавленным до и после WithTheme
+func TestWithThemeAppliesToTasks(t *testing.T) {
+	before := &themedMockTask{MockErrorTask: NewMockErrorTask("До", false)}
+	after := &themedMockTask{MockErrorTask: NewMockErrorTask("После", false)}
+
+	model := New("Темы")
+	model.AddTasks([]common.Task{before})
+	model.WithTheme(ui.EmbeddedTheme())
+	model.AddTasks([]common.Task{after})
+
+	assert.Equal(t, ui.ThemeNameEmbedded, before.theme.Name)
+	assert.Same(t, before.theme, after.theme)
+	assert.Same(t, model.Theme(), before.theme)
+}
+
+// TestQueuesWithDifferentThemes проверяет, что две очереди с разными темами выглядят по-разному
+func TestQueuesWithDifferentThemes(t *testing.T) {
+	ui.ResetErrorColors()
+	render := func(theme *ui.Theme) string {
+		model := New("Очередь").WithSummary(true).WithTheme(theme)
+		model.AddTasks([]common.Task{NewMockErrorTask("Задача", false)})
+		model.current = 1
+		model.updateTaskStats()
+		return model.View()
+	}
+
+	plain := render(nil)
+	embedded := render(ui.EmbeddedTheme())
+
+	assert.NotEqual(t, plain, embedded)
+	assert.Contains(t, plain, ui.HorizontalLineSymbol)
+	assert.NotContains(t, embedded, ui.HorizontalLineSymbol)
+	assert.NotContains(t, embedded, ui.VerticalLineSymbol)
+}
+
+// TestThemedSetErrorColorKeepsGlobals проверяет, что цвет ошибок очереди с темой не меняет глобальные стили
+func TestThemedSetErrorColorKeepsGlobals(t *testing.T) {
+	ui.ResetErrorColors()
+	globalMessage := ui.GetErrorMessageStyle().GetForeground()
+	globalStatus := ui.GetErrorStatusStyle().GetForeground()
+
+	theme := ui.DefaultTheme()
+	model := New("Тема").WithTheme(theme).SetErrorColor(Red)
+
+	assert.Equal(t, ui.ColorBrightRed, model.Theme().Styles.ErrorStatus.GetForeground())
+	assert.Equal(t, globalMessage, ui.GetErrorMessageStyle().GetForeground())
+	assert.Equal(t, globalStatus, ui.GetErrorStatusStyle().GetForeground())
+	assert.NotEqual(t, ui.ColorBrightRed, theme.Styles.ErrorStatus.GetForeground(), "очередь хранит копию темы")
+}
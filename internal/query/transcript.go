@@ -0,0 +1,143 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/common"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+)
+
+// TranscriptFormat определяет формат записей журнала сеанса
+type TranscriptFormat int
+
+const (
+	// TranscriptText — одна строка "ключ=значение" на задачу
+	TranscriptText TranscriptFormat = iota
+	// TranscriptJSONLines — один JSON-объект на строку (JSON Lines)
+	TranscriptJSONLines
+)
+
+// transcriptEntry — запись журнала сеанса о завершённой задаче:
+// value — отображаемое значение, key — исходное (ключ, bool, список ключей)
+type transcriptEntry struct {
+	Time      time.Time           `json:"time"`
+	Index     int                 `json:"index"`
+	ID        string              `json:"id,omitempty"`
+	Title     string              `json:"title"`
+	Kind      string              `json:"kind"`
+	Status    common.ResultStatus `json:"status"`
+	Value     string              `json:"value,omitempty"`
+	Key       interface{}         `json:"key,omitempty"`
+	TimedOut  bool                `json:"timed_out"`
+	Error     string              `json:"error,omitempty"`
+	ErrorType *terrors.ErrorType  `json:"error_type,omitempty"`
+}
+
+// WithTranscript включает журнал сеанса: после завершения каждой задачи в w
+// дописывается запись с моментом завершения, заголовком, отображаемым
+// и исходным значением, признаком выбора по тайм-ауту и типом ошибки.
+// Значения паролей заменяются маской.
+//
+// @param w Поток журнала (nil — журнал отключён)
+// @param format Формат записей
+// @return Указатель на очередь задач
+func (m *Model) WithTranscript(w io.Writer, format TranscriptFormat) *Model {
+	m.transcript = w
+	m.transcriptFormat = format
+	m.transcriptErr = nil
+	return m
+}
+
+// recordTranscript дописывает в журнал запись о завершённой задаче.
+// Первая ошибка записи сохраняется и возвращается из Run.
+//
+// @param index Индекс задачи
+func (m *Model) recordTranscript(index int) {
+	if m.transcript == nil || m.transcriptErr != nil {
+		return
+	}
+
+	entry := m.transcriptEntry(index)
+	var err error
+	if m.transcriptFormat == TranscriptJSONLines {
+		err = json.NewEncoder(m.transcript).Encode(entry)
+	} else {
+		_, err = io.WriteString(m.transcript, formatTranscriptLine(entry))
+	}
+	m.transcriptErr = err
+}
+
+// transcriptEntry формирует запись журнала из результата задачи.
+// Номер записи берётся из результата и не сбивается после очистки памяти.
+func (m *Model) transcriptEntry(index int) transcriptEntry {
+	task := m.tasks[index]
+	result := m.taskResult(index, task)
+
+	entry := transcriptEntry{
+		Time:     m.Clock().Now(),
+		Index:    result.Index,
+		ID:       result.ID,
+		Title:    result.Title,
+		Kind:     result.Kind,
+		Status:   result.Status,
+		Key:      result.Value,
+		TimedOut: result.TimedOut,
+	}
+	if result.Values != nil {
+		entry.Key = result.Values
+	}
+	if reporter, ok := task.(lineResulter); ok {
+		_, entry.Value = reporter.LineResult()
+		entry.Value = strings.TrimSpace(entry.Value)
+	}
+	if result.Err != nil {
+		errorType := result.ErrorType
+		entry.Error = result.Err.Error()
+		entry.ErrorType = &errorType
+		// Текст ошибки уже записан в поле error
+		entry.Value = ""
+	}
+	if result.Secret {
+		if entry.Value != "" {
+			entry.Value = secretMask
+		}
+		if entry.Key != nil {
+			entry.Key = secretMask
+		}
+	}
+	return entry
+}
+
+// formatTranscriptLine форматирует запись журнала строкой вида
+// 2024-05-01T10:00:00Z task="Среда" kind=single_select status=success value="Боевая" key="prod" timed_out=false
+func formatTranscriptLine(entry transcriptEntry) string {
+	var b strings.Builder
+	b.WriteString(entry.Time.Format(time.RFC3339))
+	if entry.ID != "" {
+		fmt.Fprintf(&b, " id=%s", strconv.Quote(entry.ID))
+	}
+	fmt.Fprintf(&b, " task=%s kind=%s status=%s", strconv.Quote(entry.Title), entry.Kind, entry.Status)
+	if entry.Value != "" {
+		fmt.Fprintf(&b, " value=%s", strconv.Quote(entry.Value))
+	}
+	switch key := entry.Key.(type) {
+	case nil:
+	case string:
+		fmt.Fprintf(&b, " key=%s", strconv.Quote(key))
+	case []string:
+		fmt.Fprintf(&b, " key=%s", strconv.Quote(strings.Join(key, ",")))
+	default:
+		fmt.Fprintf(&b, " key=%v", key)
+	}
+	fmt.Fprintf(&b, " timed_out=%t", entry.TimedOut)
+	if entry.ErrorType != nil {
+		fmt.Fprintf(&b, " error_type=%s error=%s", entry.ErrorType.Code(), strconv.Quote(entry.Error))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingWriter — поток, запись в который всегда завершается ошибкой
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
+
+// TestTranscriptRecordsCompletedTasks проверяет запись журнала при завершении задач в интерактивном режиме
+func TestTranscriptRecordsCompletedTasks(t *testing.T) {
+	var log bytes.Buffer
+	model := New("Журнал").WithTranscript(&log, TranscriptText)
+	model.AddTasks([]common.Task{NewMockTask("Первая").CompleteSuccessfully(), NewMockTask("Вторая")})
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Contains(t, log.String(), `task="Первая" kind=task status=success`)
+	assert.NotContains(t, log.String(), "Вторая", "незавершённая задача не записывается")
+}
+
+// TestTranscriptWriteErrorIsReported проверяет сохранение первой ошибки записи журнала
+func TestTranscriptWriteErrorIsReported(t *testing.T) {
+	writeErr := errors.New("диск заполнен")
+	model := New("Журнал").WithTranscript(failingWriter{err: writeErr}, TranscriptJSONLines)
+	model.AddTasks([]common.Task{NewMockTask("Первая").CompleteSuccessfully(), NewMockTask("Вторая").CompleteSuccessfully()})
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	require.ErrorIs(t, model.runError(nil), writeErr)
+	runErr := errors.New("ошибка очереди")
+	assert.Equal(t, runErr, model.runError(runErr), "ошибка выполнения очереди важнее ошибки журнала")
+}
+
+// TestTranscriptIndexAfterCleanup проверяет номер записи журнала после удаления старых задач
+func TestTranscriptIndexAfterCleanup(t *testing.T) {
+	var log bytes.Buffer
+	model := New("Журнал").WithTranscript(&log, TranscriptJSONLines)
+	var tasks []common.Task
+	taskCount := MaxCompletedTasks + 5
+	for i := 0; i < taskCount; i++ {
+		tasks = append(tasks, NewMockTask(fmt.Sprintf("Задача %d", i)).CompleteSuccessfully())
+	}
+	model.AddTasks(tasks)
+	model.current = taskCount - 1
+	model.cleanupOldTasks()
+
+	model.recordTranscript(model.current)
+
+	var entry transcriptEntry
+	require.NoError(t, json.Unmarshal(log.Bytes(), &entry))
+	assert.Equal(t, taskCount-1, entry.Index)
+	assert.Equal(t, fmt.Sprintf("Задача %d", taskCount-1), entry.Title)
+}
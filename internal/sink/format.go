@@ -0,0 +1,173 @@
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/qzeleza/ziva/internal/defaults"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// envKeyPattern — допустимое имя переменной окружения
+	envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// uciNamePattern — допустимое имя опции, типа и секции UCI
+	uciNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+)
+
+// Env — файл переменных окружения (.env): KEY="value" по одной на строку.
+// При Export строки записываются как команды оболочки: export KEY='value'.
+// Логические значения записываются как true/false, списки — через пробел.
+type Env struct {
+	Export bool // Записывать строки в виде export KEY='value'
+}
+
+// Encode формирует содержимое файла переменных окружения
+func (f Env) Encode(values []Value) ([]byte, error) {
+	var b strings.Builder
+	for _, value := range values {
+		if !envKeyPattern.MatchString(value.Key) {
+			return nil, fmt.Errorf(defaults.ErrSinkInvalidKey, value.Key, "env")
+		}
+		text := scalarText(value.Value, "true", "false")
+		if list, ok := value.Value.([]string); ok {
+			text = strings.Join(list, " ")
+		}
+		if f.Export {
+			fmt.Fprintf(&b, "export %s=%s\n", value.Key, singleQuote(text))
+		} else {
+			fmt.Fprintf(&b, "%s=%s\n", value.Key, doubleQuote(text))
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// JSON — JSON-объект с ключами в порядке значений
+type JSON struct{}
+
+// Encode формирует JSON-объект с отступом в два пробела
+func (JSON) Encode(values []Value) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, value := range values {
+		key, err := json.Marshal(value.Key)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value.Value)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, "\n  %s: %s", key, data)
+	}
+	if len(values) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+	return b.Bytes(), nil
+}
+
+// YAML — отображение YAML с ключами в порядке значений
+type YAML struct{}
+
+// Encode формирует документ YAML
+func (YAML) Encode(values []Value) ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, value := range values {
+		var node yaml.Node
+		if err := node.Encode(value.Value); err != nil {
+			return nil, err
+		}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: value.Key}, &node)
+	}
+	if len(values) == 0 {
+		root.Style = yaml.FlowStyle
+	}
+
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// UCI — файл конфигурации OpenWrt (/etc/config/<имя>) с одной секцией:
+//
+//	config <Type> '<Name>'
+//		option key 'value'
+//		list key 'value'
+//
+// Логические значения записываются как '1'/'0', списки — строками list.
+// Файл перезаписывается целиком, другие секции не сохраняются.
+type UCI struct {
+	Type string // Тип секции
+	Name string // Имя секции (пустое — анонимная секция)
+}
+
+// Encode формирует секцию конфигурации UCI
+func (f UCI) Encode(values []Value) ([]byte, error) {
+	if !uciNamePattern.MatchString(f.Type) {
+		return nil, fmt.Errorf(defaults.ErrSinkInvalidKey, f.Type, "uci")
+	}
+	if f.Name != "" && !uciNamePattern.MatchString(f.Name) {
+		return nil, fmt.Errorf(defaults.ErrSinkInvalidKey, f.Name, "uci")
+	}
+
+	var b strings.Builder
+	b.WriteString("config " + f.Type)
+	if f.Name != "" {
+		b.WriteString(" " + singleQuote(f.Name))
+	}
+	b.WriteString("\n")
+	for _, value := range values {
+		if !uciNamePattern.MatchString(value.Key) {
+			return nil, fmt.Errorf(defaults.ErrSinkInvalidKey, value.Key, "uci")
+		}
+		if list, ok := value.Value.([]string); ok {
+			for _, item := range list {
+				fmt.Fprintf(&b, "\tlist %s %s\n", value.Key, singleQuote(item))
+			}
+			continue
+		}
+		fmt.Fprintf(&b, "\toption %s %s\n", value.Key, singleQuote(scalarText(value.Value, "1", "0")))
+	}
+	return []byte(b.String()), nil
+}
+
+// scalarText возвращает строковое представление значения
+func scalarText(value interface{}, yes, no string) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if v {
+			return yes
+		}
+		return no
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+// singleQuote заключает строку в одинарные кавычки по правилам оболочки
+func singleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// doubleQuote заключает строку в двойные кавычки, экранируя \, ", $ и `
+func doubleQuote(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "`", "\\`", "\n", `\n`)
+	return `"` + replacer.Replace(s) + `"`
+}
@@ -0,0 +1,351 @@
+// Package sink записывает ответы очереди задач в файлы конфигурации
+// (.env, сценарий с export, JSON, YAML, UCI OpenWrt).
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+)
+
+// secretMask заменяет секретные значения в предпросмотре изменений
+const secretMask = "********"
+
+// Value — значение, записываемое в файл под ключом Key
+type Value struct {
+	Key    string      // Ключ в файле конфигурации
+	Value  interface{} // Значение: string, bool или []string
+	Secret bool        // Значение скрывается в предпросмотре (например, пароль)
+}
+
+// Format преобразует значения в содержимое файла конфигурации
+type Format interface {
+	// Encode возвращает содержимое файла для значений в заданном порядке
+	Encode(values []Value) ([]byte, error)
+}
+
+// mapping связывает задачу очереди с ключом в файле
+type mapping struct {
+	task string // Идентификатор или заголовок задачи
+	key  string // Ключ в файле конфигурации
+}
+
+// Sink — приёмник ответов: файл, формат и соответствие задач ключам
+type Sink struct {
+	path    string                       // Путь к файлу
+	format  Format                       // Формат файла
+	keys    []mapping                    // Соответствие задач ключам (пусто — все задачи с идентификаторами)
+	perm    os.FileMode                  // Права доступа к файлу (0 — по умолчанию, см. fileMode)
+	confirm func(path, diff string) bool // Подтверждение перезаписи изменённого файла (nil — без подтверждения)
+}
+
+// New создаёт приёмник ответов.
+// Без Map в файл записываются ответы всех задач с идентификатором под ключами, равными идентификатору.
+// Файл с секретными значениями по умолчанию доступен только владельцу (0600).
+//
+// @param path Путь к файлу
+// @param format Формат файла
+// @return Указатель на приёмник
+func New(path string, format Format) *Sink {
+	return &Sink{path: path, format: format}
+}
+
+// Map записывает ответ задачи под указанным ключом.
+// Ключи записываются в порядке вызовов Map.
+//
+// @param task Идентификатор задачи (или её заголовок, если идентификатор не задан)
+// @param key Ключ в файле конфигурации
+// @return Указатель на приёмник
+func (s *Sink) Map(task, key string) *Sink {
+	s.keys = append(s.keys, mapping{task: task, key: key})
+	return s
+}
+
+// WithMode задаёт права доступа к файлу (по умолчанию 0600, если среди
+// значений есть секретные, иначе 0644).
+//
+// @param perm Права доступа
+// @return Указатель на приёмник
+func (s *Sink) WithMode(perm os.FileMode) *Sink {
+	s.perm = perm
+	return s
+}
+
+// WithConfirm задаёт подтверждение перезаписи: перед заменой существующего
+// файла с другим содержимым функция получает путь и построчные изменения.
+// Если она возвращает false, файл не изменяется.
+//
+// @param confirm Функция подтверждения
+// @return Указатель на приёмник
+func (s *Sink) WithConfirm(confirm func(path, diff string) bool) *Sink {
+	s.confirm = confirm
+	return s
+}
+
+// Path возвращает путь к файлу приёмника
+func (s *Sink) Path() string {
+	return s.path
+}
+
+// Values собирает значения для записи из результатов очереди.
+// Ответы пропущенных и не завершённых успешно задач не записываются.
+//
+// @param results Результаты очереди
+// @return Значения или ошибка, если задача из Map отсутствует в очереди
+func (s *Sink) Values(results common.Results) ([]Value, error) {
+	var values []Value
+	if len(s.keys) == 0 {
+		for _, result := range results {
+			if result.ID != "" && hasAnswer(result) {
+				values = append(values, valueOf(result.ID, result))
+			}
+		}
+		return values, nil
+	}
+
+	for _, m := range s.keys {
+		result, ok := results.ByID(m.task)
+		if !ok {
+			result, ok = results.ByTitle(m.task)
+		}
+		if !ok {
+			return nil, fmt.Errorf(defaults.ErrSinkUnknownTask, m.task)
+		}
+		if hasAnswer(result) {
+			values = append(values, valueOf(m.key, result))
+		}
+	}
+	return values, nil
+}
+
+// Render возвращает новое содержимое файла
+//
+// @param results Результаты очереди
+// @return Содержимое файла или ошибка преобразования
+func (s *Sink) Render(results common.Results) ([]byte, error) {
+	values, err := s.Values(results)
+	if err != nil {
+		return nil, err
+	}
+	return s.format.Encode(values)
+}
+
+// Preview возвращает построчные изменения файла без записи.
+// Секретные значения в изменениях заменяются маской.
+//
+// @param results Результаты очереди
+// @return Изменения, признак того, что содержимое файла изменится, и ошибка
+func (s *Sink) Preview(results common.Results) (string, bool, error) {
+	values, err := s.Values(results)
+	if err != nil {
+		return "", false, err
+	}
+	data, err := s.format.Encode(values)
+	if err != nil {
+		return "", false, err
+	}
+	old, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", false, err
+	}
+	if bytes.Equal(old, data) {
+		return "", false, nil
+	}
+	return s.maskSecrets(Diff(string(old), string(data)), values), true, nil
+}
+
+// Write атомарно записывает файл: содержимое пишется во временный файл
+// в том же каталоге, который затем переименовывается.
+// Неизменённый файл не перезаписывается; перезапись существующего файла
+// выполняется только после подтверждения (WithConfirm).
+//
+// @param results Результаты очереди
+// @return Признак записи файла и ошибка
+func (s *Sink) Write(results common.Results) (bool, error) {
+	values, err := s.Values(results)
+	if err != nil {
+		return false, err
+	}
+	data, err := s.format.Encode(values)
+	if err != nil {
+		return false, err
+	}
+
+	old, err := os.ReadFile(s.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return false, err
+	case bytes.Equal(old, data):
+		return false, nil
+	case s.confirm != nil:
+		if !s.confirm(s.path, s.maskSecrets(Diff(string(old), string(data)), values)) {
+			return false, nil
+		}
+	}
+	return true, WriteFileAtomic(s.path, data, s.fileMode(values))
+}
+
+// fileMode возвращает права доступа к файлу: заданные WithMode или
+// 0600 для файла с секретными значениями и 0644 для остальных
+func (s *Sink) fileMode(values []Value) os.FileMode {
+	if s.perm != 0 {
+		return s.perm
+	}
+	for _, value := range values {
+		if value.Secret {
+			return 0o600
+		}
+	}
+	return 0o644
+}
+
+// WriteFileAtomic записывает файл через временный файл в том же каталоге,
+// чтобы прерывание записи не оставило повреждённый файл. Временный файл и
+// каталог сбрасываются на диск, чтобы после сбоя питания файл не оказался пустым.
+//
+// @param path Путь к файлу
+// @param data Содержимое файла
+// @param perm Права доступа к файлу
+// @return Ошибка записи
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir сбрасывает на диск запись каталога, чтобы переименование файла сохранилось
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// ConfirmPrompt возвращает подтверждение перезаписи, которое выводит изменения
+// в out и читает ответ из in. Перезапись подтверждается ответом y/yes или «да».
+//
+// @param in Источник ответа
+// @param out Поток вывода изменений и вопроса
+// @return Функция подтверждения для WithConfirm
+func ConfirmPrompt(in io.Reader, out io.Writer) func(path, diff string) bool {
+	reader := bufio.NewReader(in)
+	return func(path, diff string) bool {
+		fmt.Fprintf(out, "--- %s\n%s", path, diff)
+		fmt.Fprintf(out, defaults.SinkOverwriteQuestion+" ", path)
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return false
+		}
+		yes := strings.ToLower(defaults.DefaultYes)
+		first, _ := utf8.DecodeRuneInString(yes)
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes", yes, string(first):
+			return true
+		}
+		return false
+	}
+}
+
+// hasAnswer сообщает, завершилась ли задача ответом, который можно записать
+func hasAnswer(result common.TaskResult) bool {
+	if result.Status != common.ResultSuccess {
+		return false
+	}
+	switch result.Kind {
+	case common.KindYesNo, common.KindSingleSelect, common.KindMultiSelect, common.KindInput, common.KindPath,
+		common.KindDate, common.KindTime, common.KindDuration, common.KindNumber:
+		return true
+	}
+	return false
+}
+
+// valueOf возвращает значение результата задачи под ключом key
+func valueOf(key string, result common.TaskResult) Value {
+	value := Value{Key: key, Value: result.Value, Secret: result.Secret}
+	if result.Kind == common.KindMultiSelect || (result.Kind == common.KindPath && result.Values != nil) {
+		value.Value = append([]string{}, result.Values...)
+	}
+	return value
+}
+
+// maskSecrets заменяет в изменениях строки секретных значений строкой с маской.
+// Строка секретного значения распознаётся по началу, которое формат записывает
+// перед значением (например, PASSWORD=" или option password '), поэтому
+// скрывается и прежнее значение из удаляемой строки.
+func (s *Sink) maskSecrets(diff string, values []Value) string {
+	lines := strings.Split(diff, "\n")
+	for _, value := range values {
+		if !value.Secret {
+			continue
+		}
+		prefix, masked, ok := s.secretLine(value.Key)
+		if !ok {
+			continue
+		}
+		for i, line := range lines {
+			if len(line) > 2 && strings.HasPrefix(line[2:], prefix) {
+				lines[i] = line[:2] + masked
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// secretLine возвращает начало строки значения с ключом key и строку с маской.
+// Начало строки — общая часть строк, записанных форматом для двух разных масок.
+func (s *Sink) secretLine(key string) (string, string, bool) {
+	first, err := s.format.Encode([]Value{{Key: key, Value: secretMask}})
+	if err != nil {
+		return "", "", false
+	}
+	second, err := s.format.Encode([]Value{{Key: key, Value: "########"}})
+	if err != nil {
+		return "", "", false
+	}
+	a, b := splitLines(string(first)), splitLines(string(second))
+	for i := range a {
+		if i >= len(b) || a[i] == b[i] {
+			continue
+		}
+		n := 0
+		for n < len(a[i]) && n < len(b[i]) && a[i][n] == b[i][n] {
+			n++
+		}
+		return a[i][:n], a[i], n > 0
+	}
+	return "", "", false
+}
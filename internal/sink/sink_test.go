@@ -0,0 +1,157 @@
+package sink
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// installResults — результаты очереди установки
+func installResults() common.Results {
+	return common.Results{
+		{ID: "env", Kind: common.KindSingleSelect, Status: common.ResultSuccess, Value: "prod"},
+		{ID: "debug", Kind: common.KindYesNo, Status: common.ResultSuccess, Value: true},
+		{ID: "tags", Kind: common.KindMultiSelect, Status: common.ResultSuccess, Values: []string{"web", "db"}},
+		{ID: "password", Kind: common.KindInput, Status: common.ResultSuccess, Value: "it's $ecret", Secret: true},
+		{ID: "proxy", Kind: common.KindInput, Status: common.ResultSkipped},
+		{Title: "Загрузка", Kind: common.KindFunc, Status: common.ResultSuccess},
+	}
+}
+
+// TestFormatsEncodeValues проверяет содержимое файлов всех форматов
+func TestFormatsEncodeValues(t *testing.T) {
+	values, err := New("", nil).Values(installResults())
+	require.NoError(t, err)
+	require.Len(t, values, 4, "пропущенная задача и задача без идентификатора не записываются")
+
+	env, err := Env{}.Encode(values)
+	require.NoError(t, err)
+	assert.Equal(t, "env=\"prod\"\ndebug=\"true\"\ntags=\"web db\"\npassword=\"it's \\$ecret\"\n", string(env))
+
+	export, err := Env{Export: true}.Encode(values[3:])
+	require.NoError(t, err)
+	assert.Equal(t, "export password='it'\\''s $ecret'\n", string(export))
+
+	data, err := JSON{}.Encode(values[:3])
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"env\": \"prod\",\n  \"debug\": true,\n  \"tags\": [\"web\",\"db\"]\n}\n", string(data))
+
+	data, err = YAML{}.Encode(values[:3])
+	require.NoError(t, err)
+	assert.Equal(t, "env: prod\ndebug: true\ntags:\n  - web\n  - db\n", string(data))
+
+	data, err = UCI{Type: "app", Name: "main"}.Encode(values[:3])
+	require.NoError(t, err)
+	assert.Equal(t, "config app 'main'\n\toption env 'prod'\n\toption debug '1'\n\tlist tags 'web'\n\tlist tags 'db'\n", string(data))
+
+	_, err = Env{}.Encode([]Value{{Key: "BAD-KEY", Value: "x"}})
+	assert.Error(t, err, "имя переменной окружения проверяется")
+	_, err = UCI{Type: "app"}.Encode([]Value{{Key: "a.b", Value: "x"}})
+	assert.Error(t, err, "имя опции UCI проверяется")
+}
+
+// TestMapSelectsTasksAndKeys проверяет сопоставление задач ключам файла
+func TestMapSelectsTasksAndKeys(t *testing.T) {
+	s := New("", Env{}).Map("debug", "DEBUG").Map("env", "APP_ENV").Map("proxy", "PROXY")
+	data, err := s.Render(installResults())
+	require.NoError(t, err)
+	assert.Equal(t, "DEBUG=\"true\"\nAPP_ENV=\"prod\"\n", string(data), "ключи записываются в порядке Map")
+
+	_, err = New("", Env{}).Map("missing", "X").Render(installResults())
+	assert.Error(t, err, "задача из Map должна существовать в очереди")
+}
+
+// TestWriteIsAtomicAndConfirmed проверяет запись, пропуск неизменённого файла и подтверждение перезаписи
+func TestWriteIsAtomicAndConfirmed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.env")
+	var diffs []string
+	answer := false
+	s := New(path, Env{}).Map("env", "APP_ENV").Map("password", "PASSWORD").WithMode(0o600).
+		WithConfirm(func(_, diff string) bool {
+			diffs = append(diffs, diff)
+			return answer
+		})
+
+	results := installResults()
+	written, err := s.Write(results)
+	require.NoError(t, err)
+	assert.True(t, written)
+	assert.Empty(t, diffs, "новый файл записывается без подтверждения")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	written, err = s.Write(results)
+	require.NoError(t, err)
+	assert.False(t, written, "неизменённый файл не перезаписывается")
+	assert.Empty(t, diffs)
+
+	results[0].Value = "dev"
+	preview, changed, err := s.Preview(results)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "- APP_ENV=\"prod\"\n+ APP_ENV=\"dev\"\n  PASSWORD=\"********\"\n", preview,
+		"секретные значения в предпросмотре заменяются маской")
+
+	results[3].Value = "N3w-Pass"
+	preview, _, err = s.Preview(results)
+	require.NoError(t, err)
+	assert.NotContains(t, preview, "ecret", "прежнее значение пароля также скрывается")
+	assert.NotContains(t, preview, "N3w-Pass")
+	results[3].Value = "it's $ecret"
+
+	written, err = s.Write(results)
+	require.NoError(t, err)
+	assert.False(t, written, "без подтверждения файл не изменяется")
+	require.Len(t, diffs, 1)
+	data, _ := os.ReadFile(path)
+	assert.Contains(t, string(data), "prod")
+
+	answer = true
+	written, err = s.Write(results)
+	require.NoError(t, err)
+	assert.True(t, written)
+	data, _ = os.ReadFile(path)
+	assert.Contains(t, string(data), "dev")
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "временные файлы не остаются в каталоге")
+}
+
+// TestWriteDefaultMode проверяет права доступа к файлу по умолчанию
+func TestWriteDefaultMode(t *testing.T) {
+	dir := t.TempDir()
+	secret := New(filepath.Join(dir, "secret.env"), Env{}).Map("password", "PASSWORD")
+	_, err := secret.Write(installResults())
+	require.NoError(t, err)
+	info, err := os.Stat(secret.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "файл с секретом доступен только владельцу")
+
+	plain := New(filepath.Join(dir, "plain.env"), Env{}).Map("env", "APP_ENV")
+	_, err = plain.Write(installResults())
+	require.NoError(t, err)
+	info, err = os.Stat(plain.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+}
+
+// TestDiffAndConfirmPrompt проверяет построчные изменения и вопрос о перезаписи
+func TestDiffAndConfirmPrompt(t *testing.T) {
+	assert.Equal(t, "  a\n- b\n+ c\n  d\n+ e\n", Diff("a\nb\nd\n", "a\nc\nd\ne\n"))
+	assert.Equal(t, "+ a\n", Diff("", "a\n"))
+
+	var out bytes.Buffer
+	confirm := ConfirmPrompt(strings.NewReader("да\nn\n"), &out)
+	assert.True(t, confirm("/etc/config/app", "+ a\n"))
+	assert.False(t, confirm("/etc/config/app", "+ a\n"))
+	assert.False(t, confirm("/etc/config/app", "+ a\n"), "закрытый ввод не подтверждает перезапись")
+	assert.Contains(t, out.String(), "--- /etc/config/app\n+ a\n")
+}
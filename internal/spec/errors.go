@@ -0,0 +1,212 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/qzeleza/ziva/internal/defaults"
+	"gopkg.in/yaml.v3"
+)
+
+// Error описывает ошибку схемы с позицией в документе.
+type Error struct {
+	Line    int    // Номер строки (с единицы; 0 — позиция неизвестна)
+	Column  int    // Номер столбца (с единицы; 0 — позиция неизвестна)
+	Field   string // Путь к полю, например "tasks[1].validator"
+	Message string // Описание ошибки
+}
+
+// Error форматирует ошибку в виде "строка:столбец: поле: сообщение"
+func (e *Error) Error() string {
+	var sb strings.Builder
+	if e.Line > 0 {
+		sb.WriteString(strconv.Itoa(e.Line))
+		if e.Column > 0 {
+			sb.WriteString(":")
+			sb.WriteString(strconv.Itoa(e.Column))
+		}
+		sb.WriteString(": ")
+	}
+	if e.Field != "" {
+		sb.WriteString(e.Field)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(e.Message)
+	return sb.String()
+}
+
+// Errors — список ошибок схемы, упорядоченный по позиции в документе.
+type Errors []*Error
+
+// Error объединяет ошибки по одной на строку
+func (e Errors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sort упорядочивает ошибки по строке и столбцу
+func (e Errors) sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		if e[i].Line != e[j].Line {
+			return e[i].Line < e[j].Line
+		}
+		return e[i].Column < e[j].Column
+	})
+}
+
+var (
+	// yamlLinePattern выделяет номер строки из сообщений yaml.v3
+	yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	// yamlUnknownFieldPattern распознаёт сообщение о неизвестном поле
+	yamlUnknownFieldPattern = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+)
+
+// convertYAMLError преобразует ошибку yaml.v3 в ошибки схемы с позициями.
+//
+// @param err Ошибка разбора
+// @param root Корневой узел документа (nil, если документ не разобран)
+// @return Errors с позициями или исходная ошибка, если позиция не распознана
+func convertYAMLError(err error, root *yaml.Node) error {
+	var messages []string
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	} else {
+		messages = []string{err.Error()}
+	}
+
+	result := make(Errors, 0, len(messages))
+	for _, message := range messages {
+		match := yamlLinePattern.FindStringSubmatch(message)
+		if match == nil {
+			return err
+		}
+		line, _ := strconv.Atoi(match[1])
+		specErr := &Error{Line: line, Message: match[2]}
+
+		if field := yamlUnknownFieldPattern.FindStringSubmatch(match[2]); field != nil {
+			specErr.Message = fmt.Sprintf(defaults.ErrSpecUnknownField, field[1])
+			if path, node := findKey(root, line, field[1]); node != nil {
+				specErr.Field = path
+				specErr.Column = node.Column
+			}
+		}
+		result = append(result, specErr)
+	}
+	result.sort()
+	return result
+}
+
+// findKey ищет ключ отображения с указанным именем на заданной строке документа.
+//
+// @param root Корневой узел документа
+// @param line Номер строки
+// @param name Имя ключа
+// @return Путь к полю и узел ключа (nil, если ключ не найден)
+func findKey(root *yaml.Node, line int, name string) (string, *yaml.Node) {
+	if root == nil {
+		return "", nil
+	}
+	var walk func(node *yaml.Node, path string) (string, *yaml.Node)
+	walk = func(node *yaml.Node, path string) (string, *yaml.Node) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				if p, found := walk(child, path); found != nil {
+					return p, found
+				}
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				childPath := joinPath(path, key.Value)
+				if key.Line == line && key.Value == name {
+					return childPath, key
+				}
+				if p, found := walk(value, childPath); found != nil {
+					return p, found
+				}
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				if p, found := walk(child, fmt.Sprintf("%s[%d]", path, i)); found != nil {
+					return p, found
+				}
+			}
+		}
+		return "", nil
+	}
+	return walk(root, "")
+}
+
+// joinPath добавляет имя поля к пути
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// formatPath формирует строковый путь из имён полей и индексов
+func formatPath(path []interface{}) string {
+	var sb strings.Builder
+	for _, part := range path {
+		switch p := part.(type) {
+		case int:
+			sb.WriteString("[" + strconv.Itoa(p) + "]")
+		default:
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(fmt.Sprint(p))
+		}
+	}
+	return sb.String()
+}
+
+// locate находит узел документа по пути из имён полей и индексов.
+// Если путь обрывается, возвращается ближайший найденный родительский узел.
+//
+// @param root Корневой узел документа
+// @param path Путь к узлу
+// @return Узел (nil, если документ не задан)
+func locate(root *yaml.Node, path []interface{}) *yaml.Node {
+	if root == nil {
+		return nil
+	}
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, part := range path {
+		var next *yaml.Node
+		switch p := part.(type) {
+		case int:
+			if node.Kind == yaml.SequenceNode && p >= 0 && p < len(node.Content) {
+				next = node.Content[p]
+			}
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == p {
+						next = node.Content[i+1]
+						break
+					}
+				}
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return node
+}
@@ -0,0 +1,341 @@
+// Package spec описывает декларативное определение очереди задач в формате YAML или JSON.
+package spec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/qzeleza/ziva/internal/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// Queue описывает очередь задач: заголовок, оформление и список задач.
+type Queue struct {
+	Title          string     `yaml:"title"`           // Заголовок очереди
+	AppName        string     `yaml:"app_name"`        // Название приложения
+	Version        string     `yaml:"version"`         // Версия приложения
+	Numbering      *Numbering `yaml:"numbering"`       // Нумерация задач (nil — без нумерации)
+	Summary        *bool      `yaml:"summary"`         // Показывать итоговую сводку (по умолчанию да)
+	ResultLine     *bool      `yaml:"result_line"`     // Показывать итоговую линию (по умолчанию да)
+	ClearScreen    bool       `yaml:"clear_screen"`    // Очищать экран перед запуском
+	BackNavigation bool       `yaml:"back_navigation"` // Разрешить возврат к предыдущей задаче
+	Tasks          []Task     `yaml:"tasks"`           // Задачи очереди
+
+	node *yaml.Node // Корневой узел документа для указания позиций ошибок
+}
+
+// Numbering описывает нумерацию задач очереди.
+type Numbering struct {
+	Format          string `yaml:"format"`            // Формат номера, например "[%d]"
+	KeepFirstSymbol bool   `yaml:"keep_first_symbol"` // Сохранять символ перед номером
+}
+
+// Task описывает одну задачу очереди.
+// Набор допустимых полей зависит от типа задачи (Type).
+type Task struct {
+	Type  string `yaml:"type"`  // Тип задачи: yesno, single_select, multi_select, input
+	ID    string `yaml:"id"`    // Идентификатор задачи
+	Title string `yaml:"title"` // Заголовок задачи
+
+	Default interface{}   `yaml:"default"` // Значение по умолчанию
+	Timeout time.Duration `yaml:"timeout"` // Тайм-аут, после которого выбирается значение по умолчанию
+
+	// Поля задачи Да/Нет
+	Question string `yaml:"question"`  // Вопрос (по умолчанию — заголовок)
+	YesLabel string `yaml:"yes_label"` // Текст варианта «Да»
+	NoLabel  string `yaml:"no_label"`  // Текст варианта «Нет»
+
+	// Поля задач выбора
+	Items            []Item                `yaml:"items"`             // Элементы списка
+	Viewport         int                   `yaml:"viewport"`          // Число одновременно видимых элементов
+	Disabled         []string              `yaml:"disabled"`          // Ключи недоступных элементов
+	SelectAll        interface{}           `yaml:"select_all"`        // Пункт «Выбрать все»: true или текст пункта
+	RequireSelection bool                  `yaml:"require_selection"` // Требовать выбор хотя бы одного элемента
+	Dependencies     map[string]Dependency `yaml:"dependencies"`      // Зависимости между элементами по ключу
+
+	// Поля задачи ввода
+	Prompt      string `yaml:"prompt"`      // Подсказка для ввода
+	InputType   string `yaml:"input_type"`  // Тип ввода: text, password, email, number, ip, domain
+	Validator   string `yaml:"validator"`   // Валидатор фабрики, например "port" или "range(1, 100)"
+	Placeholder string `yaml:"placeholder"` // Текст-заполнитель
+	AllowEmpty  bool   `yaml:"allow_empty"` // Разрешить пустое значение
+}
+
+// Item описывает элемент списка задачи выбора.
+type Item struct {
+	Key         string `yaml:"key"`         // Ключ (по умолчанию — название)
+	Name        string `yaml:"name"`        // Название (по умолчанию — ключ)
+	Description string `yaml:"description"` // Описание
+}
+
+// Dependency описывает действия при выборе и снятии отметки с элемента мультивыбора.
+type Dependency struct {
+	OnSelect   DependencyActions `yaml:"on_select"`   // Действия при выборе элемента
+	OnDeselect DependencyActions `yaml:"on_deselect"` // Действия при снятии отметки
+}
+
+// DependencyActions перечисляет ключи элементов, затрагиваемых зависимостью.
+type DependencyActions struct {
+	Enable      []string `yaml:"enable"`       // Сделать доступными
+	Disable     []string `yaml:"disable"`      // Сделать недоступными
+	ForceSelect []string `yaml:"force_select"` // Отметить
+	ForceClear  []string `yaml:"force_clear"`  // Снять отметку
+}
+
+// TaskTypes — допустимые типы задач (совпадают с видами задач в результатах очереди)
+var TaskTypes = []string{common.KindYesNo, common.KindSingleSelect, common.KindMultiSelect, common.KindInput}
+
+// inputTypes — допустимые значения поля input_type
+var inputTypes = map[string]task.InputType{
+	"text":     task.InputTypeText,
+	"password": task.InputTypePassword,
+	"email":    task.InputTypeEmail,
+	"number":   task.InputTypeNumber,
+	"ip":       task.InputTypeIP,
+	"domain":   task.InputTypeDomain,
+}
+
+// inputTypeNames — значения поля input_type в порядке вывода в сообщениях об ошибках
+var inputTypeNames = []string{"text", "password", "email", "number", "ip", "domain"}
+
+// Parse читает описание очереди в формате YAML или JSON (JSON является подмножеством YAML)
+// и проверяет его. Ошибки схемы возвращаются как Errors с номерами строк и путями полей.
+//
+// @param r Источник данных
+// @return Описание очереди или ошибка разбора/проверки
+func Parse(r io.Reader) (*Queue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, convertYAMLError(err, nil)
+	}
+
+	var queue Queue
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&queue); err != nil && !errors.Is(err, io.EOF) {
+		return nil, convertYAMLError(err, &root)
+	}
+
+	queue.node = &root
+	if err := queue.Validate(); err != nil {
+		return nil, err
+	}
+	return &queue, nil
+}
+
+// ParseFile читает описание очереди из файла в формате YAML или JSON.
+//
+// @param path Путь к файлу
+// @return Описание очереди или ошибка чтения/разбора/проверки
+func ParseFile(path string) (*Queue, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	queue, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return queue, nil
+}
+
+// Kind возвращает тип задачи в нижнем регистре
+func (t Task) Kind() string {
+	return strings.ToLower(strings.TrimSpace(t.Type))
+}
+
+// TaskItems возвращает элементы списка задачи выбора.
+// Пустой ключ заменяется названием, пустое название — ключом.
+func (t Task) TaskItems() []task.Item {
+	items := make([]task.Item, len(t.Items))
+	for i, item := range t.Items {
+		items[i] = task.Item{Key: item.key(), Name: item.Name, Description: item.Description}
+		if items[i].Name == "" {
+			items[i].Name = items[i].Key
+		}
+	}
+	return items
+}
+
+// key возвращает ключ элемента (или его название, если ключ не задан)
+func (i Item) key() string {
+	if i.Key != "" {
+		return i.Key
+	}
+	return i.Name
+}
+
+// DefaultYes возвращает ответ по умолчанию задачи Да/Нет.
+//
+// @return Ответ и признак того, что значение задано
+func (t Task) DefaultYes() (bool, bool) {
+	if t.Default == nil {
+		return false, false
+	}
+	return parseYesNo(t.Default)
+}
+
+// DefaultKeys возвращает ключи элементов по умолчанию задачи мультивыбора.
+// Значение может быть списком или строкой с ключами через запятую.
+func (t Task) DefaultKeys() []string {
+	keys, _ := stringList(t.Default)
+	return keys
+}
+
+// DefaultText возвращает значение по умолчанию задачи ввода или ключ по умолчанию задачи выбора.
+//
+// @return Значение и признак того, что оно задано
+func (t Task) DefaultText() (string, bool) {
+	switch value := t.Default.(type) {
+	case nil:
+		return "", false
+	case string:
+		return value, true
+	case int, int64, float64, bool:
+		return fmt.Sprint(value), true
+	}
+	return "", false
+}
+
+// SelectAllLabel возвращает признак пункта «Выбрать все» и его текст (пустой — текст по умолчанию)
+func (t Task) SelectAllLabel() (bool, string) {
+	switch value := t.SelectAll.(type) {
+	case bool:
+		return value, ""
+	case string:
+		return strings.TrimSpace(value) != "", strings.TrimSpace(value)
+	}
+	return false, ""
+}
+
+// InputKind возвращает тип поля ввода задачи (по умолчанию текст)
+func (t Task) InputKind() task.InputType {
+	return inputTypes[strings.ToLower(strings.TrimSpace(t.InputType))]
+}
+
+// TaskValidator создаёт валидатор задачи ввода по полю validator.
+//
+// @return Валидатор (nil, если поле не задано) или ошибка разбора
+func (t Task) TaskValidator() (validation.Validator, error) {
+	if strings.TrimSpace(t.Validator) == "" {
+		return nil, nil
+	}
+	return ParseValidator(t.Validator)
+}
+
+// DependencyRules возвращает правила зависимостей задачи мультивыбора
+func (t Task) DependencyRules() map[string]task.MultiSelectDependencyRule {
+	if len(t.Dependencies) == 0 {
+		return nil
+	}
+	rules := make(map[string]task.MultiSelectDependencyRule, len(t.Dependencies))
+	for key, dependency := range t.Dependencies {
+		rules[key] = task.MultiSelectDependencyRule{
+			OnSelect:   dependency.OnSelect.actions(),
+			OnDeselect: dependency.OnDeselect.actions(),
+		}
+	}
+	return rules
+}
+
+// actions преобразует описание действий в действия задачи мультивыбора
+func (a DependencyActions) actions() task.MultiSelectDependencyActions {
+	return task.MultiSelectDependencyActions{
+		Enable:      a.Enable,
+		Disable:     a.Disable,
+		ForceSelect: a.ForceSelect,
+		ForceClear:  a.ForceClear,
+	}
+}
+
+// ParseValidator создаёт валидатор по записи вида "port", "Port()",
+// "DefaultValidators.Port()" или "range(1, 100)".
+//
+// @param expr Запись валидатора
+// @return Валидатор или ошибка разбора
+func ParseValidator(expr string) (validation.Validator, error) {
+	trimmed := strings.TrimSpace(expr)
+	trimmed = strings.TrimPrefix(trimmed, "DefaultValidators.")
+
+	name, rest, hasArgs := strings.Cut(trimmed, "(")
+	var args []int
+	if hasArgs {
+		inner, ok := strings.CutSuffix(strings.TrimSpace(rest), ")")
+		if !ok {
+			return nil, fmt.Errorf(defaults.ErrValidatorSyntax, expr)
+		}
+		for _, part := range strings.Split(inner, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			var value int
+			if _, err := fmt.Sscan(part, &value); err != nil {
+				return nil, fmt.Errorf(defaults.ErrValidatorSyntax, expr)
+			}
+			args = append(args, value)
+		}
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf(defaults.ErrValidatorSyntax, expr)
+	}
+	return validation.DefaultFactory.ByName(name, args...)
+}
+
+// parseYesNo распознаёт логическое значение ответа Да/Нет
+func parseYesNo(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "yes", "y", "true", "да":
+			return true, true
+		case "no", "n", "false", "нет":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// stringList преобразует список или строку через запятую в список строк
+func stringList(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, true
+	case string:
+		var list []string
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				list = append(list, part)
+			}
+		}
+		return list, true
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			switch item.(type) {
+			case string, int, int64, float64:
+				list = append(list, fmt.Sprint(item))
+			default:
+				return nil, false
+			}
+		}
+		return list, true
+	}
+	return nil, false
+}
@@ -0,0 +1,182 @@
+package spec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const installerYAML = `title: Установка
+app_name: Жива
+version: "1.0"
+numbering:
+  format: "[%d]"
+tasks:
+  - type: yesno
+    id: confirm
+    title: Продолжить?
+    default: no
+    timeout: 5s
+  - type: single_select
+    id: env
+    title: Среда
+    items:
+      - {key: dev, name: Разработка}
+      - {key: prod, name: Боевая}
+    default: prod
+  - type: multi_select
+    id: diag
+    title: Диагностика
+    select_all: true
+    items:
+      - key: logging
+      - key: debug
+    default: [logging]
+    dependencies:
+      logging:
+        on_deselect: {force_clear: [debug]}
+  - type: input
+    id: port
+    title: Порт
+    input_type: number
+    validator: DefaultValidators.Range(1024, 65535)
+`
+
+// TestParseQueue проверяет разбор корректного описания очереди
+func TestParseQueue(t *testing.T) {
+	queue, err := Parse(strings.NewReader(installerYAML))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Установка", queue.Title)
+	assert.Equal(t, "[%d]", queue.Numbering.Format)
+	require.Len(t, queue.Tasks, 4)
+
+	yes, ok := queue.Tasks[0].DefaultYes()
+	assert.True(t, ok)
+	assert.False(t, yes)
+	assert.Equal(t, 5*time.Second, queue.Tasks[0].Timeout)
+
+	assert.Equal(t, []task.Item{{Key: "dev", Name: "Разработка"}, {Key: "prod", Name: "Боевая"}}, queue.Tasks[1].TaskItems())
+	key, _ := queue.Tasks[1].DefaultText()
+	assert.Equal(t, "prod", key)
+
+	assert.Equal(t, []string{"logging"}, queue.Tasks[2].DefaultKeys())
+	enabled, _ := queue.Tasks[2].SelectAllLabel()
+	assert.True(t, enabled)
+	assert.Equal(t, []string{"debug"}, queue.Tasks[2].DependencyRules()["logging"].OnDeselect.ForceClear)
+
+	assert.Equal(t, task.InputTypeNumber, queue.Tasks[3].InputKind())
+	validator, err := queue.Tasks[3].TaskValidator()
+	require.NoError(t, err)
+	assert.Error(t, validator.Validate("80"))
+	assert.NoError(t, validator.Validate("8080"))
+}
+
+// TestParseQueueJSON проверяет разбор описания в формате JSON
+func TestParseQueueJSON(t *testing.T) {
+	queue, err := Parse(strings.NewReader(`{"title": "JSON", "tasks": [{"type": "input", "title": "Имя", "validator": "required"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "JSON", queue.Title)
+	assert.Equal(t, "required", queue.Tasks[0].Validator)
+}
+
+// TestParseQueueErrorPositions проверяет, что ошибки схемы содержат строку, столбец и путь к полю
+func TestParseQueueErrorPositions(t *testing.T) {
+	doc := `tasks:
+  - type: single_select
+    title: Среда
+    items:
+      - key: dev
+    default: prod
+  - type: input
+    title: Порт
+    validator: zip
+  - type: wizard
+    title: Мастер
+`
+	_, err := Parse(strings.NewReader(doc))
+	require.Error(t, err)
+
+	var errs Errors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 3)
+
+	assert.Equal(t, 6, errs[0].Line)
+	assert.Equal(t, 14, errs[0].Column)
+	assert.Equal(t, "tasks[0].default", errs[0].Field)
+	assert.Contains(t, errs[0].Message, `"prod"`)
+
+	assert.Equal(t, 9, errs[1].Line)
+	assert.Equal(t, "tasks[1].validator", errs[1].Field)
+	assert.Contains(t, errs[1].Message, `"zip"`)
+
+	assert.Equal(t, 10, errs[2].Line)
+	assert.Equal(t, "tasks[2].type", errs[2].Field)
+
+	assert.True(t, strings.HasPrefix(err.Error(), "6:14: tasks[0].default: "))
+}
+
+// TestParseQueueUnknownField проверяет сообщение о неизвестном поле с его позицией
+func TestParseQueueUnknownField(t *testing.T) {
+	doc := `tasks:
+  - type: yesno
+    title: Да?
+    colour: red
+`
+	_, err := Parse(strings.NewReader(doc))
+	require.Error(t, err)
+
+	var errs Errors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 1)
+	assert.Equal(t, 4, errs[0].Line)
+	assert.Equal(t, 5, errs[0].Column)
+	assert.Equal(t, "tasks[0].colour", errs[0].Field)
+	assert.Contains(t, errs[0].Message, `"colour"`)
+}
+
+// TestValidateDependenciesAndApplicability проверяет ключи зависимостей и применимость полей
+func TestValidateDependenciesAndApplicability(t *testing.T) {
+	queue := &Queue{Tasks: []Task{
+		{
+			Type:  "multi_select",
+			Title: "Опции",
+			Items: []Item{{Key: "a"}, {Key: "a"}},
+			Dependencies: map[string]Dependency{
+				"a": {OnSelect: DependencyActions{Enable: []string{"missing"}}},
+			},
+		},
+		{Type: "yesno", Title: "Вопрос", Validator: "port"},
+	}}
+
+	err := queue.Validate()
+	require.Error(t, err)
+
+	fields := make([]string, 0)
+	for _, specErr := range err.(Errors) {
+		assert.Zero(t, specErr.Line, "у описания из кода нет позиций")
+		fields = append(fields, specErr.Field)
+	}
+	assert.ElementsMatch(t, []string{
+		"tasks[0].items[1]",
+		"tasks[0].dependencies.a.on_select.enable[0]",
+		"tasks[1].validator",
+	}, fields)
+}
+
+// TestParseValidator проверяет разбор записи валидатора
+func TestParseValidator(t *testing.T) {
+	for _, expr := range []string{"port", "Port()", "DefaultValidators.Port()", "range(1, 10)", "min_length(2)"} {
+		_, err := ParseValidator(expr)
+		assert.NoError(t, err, expr)
+	}
+	for _, expr := range []string{"", "range(1,", "range(a, b)", "length()"} {
+		_, err := ParseValidator(expr)
+		assert.Error(t, err, expr)
+	}
+}
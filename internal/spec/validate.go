@@ -0,0 +1,217 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"gopkg.in/yaml.v3"
+)
+
+// checker накапливает ошибки проверки описания очереди
+type checker struct {
+	root *yaml.Node // Корневой узел документа (nil для описаний, созданных в коде)
+	errs Errors     // Найденные ошибки
+}
+
+// fail добавляет ошибку для поля по указанному пути
+func (c *checker) fail(path []interface{}, format string, args ...interface{}) {
+	err := &Error{Field: formatPath(path), Message: fmt.Sprintf(format, args...)}
+	if node := locate(c.root, path); node != nil {
+		err.Line, err.Column = node.Line, node.Column
+	}
+	c.errs = append(c.errs, err)
+}
+
+// at формирует путь к полю задачи
+func at(base []interface{}, parts ...interface{}) []interface{} {
+	path := make([]interface{}, 0, len(base)+len(parts))
+	path = append(path, base...)
+	return append(path, parts...)
+}
+
+// Validate проверяет описание очереди: обязательные поля, типы задач,
+// применимость полей, ключи элементов, значения по умолчанию и валидаторы.
+//
+// @return Errors со всеми найденными ошибками или nil
+func (q *Queue) Validate() error {
+	c := &checker{root: q.node}
+	if len(q.Tasks) == 0 {
+		c.fail([]interface{}{"tasks"}, defaults.ErrSpecRequiredField, "tasks")
+	}
+	for i := range q.Tasks {
+		q.Tasks[i].validate(c, []interface{}{"tasks", i})
+	}
+
+	if len(c.errs) == 0 {
+		return nil
+	}
+	c.errs.sort()
+	return c.errs
+}
+
+// validate проверяет описание задачи
+func (t *Task) validate(c *checker, base []interface{}) {
+	if strings.TrimSpace(t.Title) == "" {
+		c.fail(at(base, "title"), defaults.ErrSpecRequiredField, "title")
+	}
+
+	kind := t.Kind()
+	switch kind {
+	case "":
+		c.fail(at(base, "type"), defaults.ErrSpecRequiredField, "type")
+		return
+	case common.KindYesNo, common.KindSingleSelect, common.KindMultiSelect, common.KindInput:
+	default:
+		c.fail(at(base, "type"), defaults.ErrSpecUnknownTaskType, t.Type, strings.Join(TaskTypes, ", "))
+		return
+	}
+
+	t.checkApplicable(c, base, kind)
+	if t.Timeout < 0 {
+		c.fail(at(base, "timeout"), defaults.ErrSpecInvalidValue, t.Timeout, "timeout < 0")
+	}
+
+	switch kind {
+	case common.KindYesNo:
+		if _, ok := t.DefaultYes(); t.Default != nil && !ok {
+			c.fail(at(base, "default"), defaults.ErrSpecInvalidValue, t.Default, defaults.ErrSpecExpectedBool)
+		}
+	case common.KindSingleSelect, common.KindMultiSelect:
+		t.validateSelect(c, base, kind)
+	case common.KindInput:
+		t.validateInput(c, base)
+	}
+}
+
+// checkApplicable проверяет, что заданы только поля, применимые к типу задачи
+func (t *Task) checkApplicable(c *checker, base []interface{}, kind string) {
+	isSelect := kind == common.KindSingleSelect || kind == common.KindMultiSelect
+	fields := []struct {
+		name       string
+		set        bool
+		applicable bool
+	}{
+		{"question", t.Question != "", kind == common.KindYesNo},
+		{"yes_label", t.YesLabel != "", kind == common.KindYesNo},
+		{"no_label", t.NoLabel != "", kind == common.KindYesNo},
+		{"items", len(t.Items) > 0, isSelect},
+		{"viewport", t.Viewport != 0, isSelect},
+		{"disabled", len(t.Disabled) > 0, isSelect},
+		{"select_all", t.SelectAll != nil, kind == common.KindMultiSelect},
+		{"require_selection", t.RequireSelection, kind == common.KindMultiSelect},
+		{"dependencies", len(t.Dependencies) > 0, kind == common.KindMultiSelect},
+		{"prompt", t.Prompt != "", kind == common.KindInput},
+		{"input_type", t.InputType != "", kind == common.KindInput},
+		{"validator", t.Validator != "", kind == common.KindInput},
+		{"placeholder", t.Placeholder != "", kind == common.KindInput},
+		{"allow_empty", t.AllowEmpty, kind == common.KindInput},
+	}
+	for _, field := range fields {
+		if field.set && !field.applicable {
+			c.fail(at(base, field.name), defaults.ErrSpecFieldNotApplicable, field.name, kind)
+		}
+	}
+}
+
+// validateSelect проверяет элементы, значения по умолчанию и зависимости задач выбора
+func (t *Task) validateSelect(c *checker, base []interface{}, kind string) {
+	if len(t.Items) == 0 {
+		c.fail(at(base, "items"), defaults.ErrSpecRequiredField, "items")
+		return
+	}
+
+	keys := make(map[string]bool, len(t.Items))
+	for i, item := range t.Items {
+		key := item.key()
+		if key == "" {
+			c.fail(at(base, "items", i, "key"), defaults.ErrSpecRequiredField, "key")
+			continue
+		}
+		if keys[key] {
+			c.fail(at(base, "items", i), defaults.ErrSpecDuplicateKey, key)
+		}
+		keys[key] = true
+	}
+
+	checkKeys := func(path []interface{}, list []string) {
+		for i, key := range list {
+			if !keys[key] {
+				c.fail(at(path, i), defaults.ErrSpecUnknownItem, key)
+			}
+		}
+	}
+
+	if t.Viewport < 0 {
+		c.fail(at(base, "viewport"), defaults.ErrSpecInvalidValue, t.Viewport, "viewport < 0")
+	}
+	checkKeys(at(base, "disabled"), t.Disabled)
+
+	if t.SelectAll != nil {
+		switch t.SelectAll.(type) {
+		case bool, string:
+		default:
+			c.fail(at(base, "select_all"), defaults.ErrSpecInvalidValue, t.SelectAll, defaults.ErrSpecExpectedBool)
+		}
+	}
+
+	// Значение по умолчанию: ключ элемента (для мультивыбора — список ключей)
+	switch {
+	case t.Default == nil:
+	case kind == common.KindSingleSelect:
+		if key, ok := t.DefaultText(); !ok {
+			c.fail(at(base, "default"), defaults.ErrSpecInvalidValue, t.Default, defaults.ErrSpecExpectedString)
+		} else if !keys[key] {
+			c.fail(at(base, "default"), defaults.ErrSpecUnknownItem, key)
+		}
+	default:
+		list, ok := stringList(t.Default)
+		if !ok {
+			c.fail(at(base, "default"), defaults.ErrSpecInvalidValue, t.Default, defaults.ErrSpecExpectedList)
+			break
+		}
+		_, isList := t.Default.([]interface{})
+		for i, key := range list {
+			if keys[key] {
+				continue
+			}
+			if isList {
+				c.fail(at(base, "default", i), defaults.ErrSpecUnknownItem, key)
+			} else {
+				c.fail(at(base, "default"), defaults.ErrSpecUnknownItem, key)
+			}
+		}
+	}
+
+	for key, dependency := range t.Dependencies {
+		path := at(base, "dependencies", key)
+		if !keys[key] {
+			c.fail(path, defaults.ErrSpecUnknownItem, key)
+		}
+		for _, group := range []struct {
+			name    string
+			actions DependencyActions
+		}{{"on_select", dependency.OnSelect}, {"on_deselect", dependency.OnDeselect}} {
+			checkKeys(at(path, group.name, "enable"), group.actions.Enable)
+			checkKeys(at(path, group.name, "disable"), group.actions.Disable)
+			checkKeys(at(path, group.name, "force_select"), group.actions.ForceSelect)
+			checkKeys(at(path, group.name, "force_clear"), group.actions.ForceClear)
+		}
+	}
+}
+
+// validateInput проверяет тип ввода, валидатор и значение по умолчанию задачи ввода
+func (t *Task) validateInput(c *checker, base []interface{}) {
+	if name := strings.ToLower(strings.TrimSpace(t.InputType)); name != "" {
+		if _, ok := inputTypes[name]; !ok {
+			c.fail(at(base, "input_type"), defaults.ErrSpecUnknownInputType, t.InputType, strings.Join(inputTypeNames, ", "))
+		}
+	}
+	if _, err := t.TaskValidator(); err != nil {
+		c.fail(at(base, "validator"), "%s", err.Error())
+	}
+	if _, ok := t.DefaultText(); t.Default != nil && !ok {
+		c.fail(at(base, "default"), defaults.ErrSpecInvalidValue, t.Default, defaults.ErrSpecExpectedString)
+	}
+}
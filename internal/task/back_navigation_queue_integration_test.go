@@ -0,0 +1,102 @@
+package task_test
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueueBackNavigationReopensPreviousTask проверяет возврат к предыдущей задаче по стрелке влево
+func TestQueueBackNavigationReopensPreviousTask(t *testing.T) {
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "stage"}, {Key: "prod"}})
+	name := task.NewInputTaskNew("Имя", "Введите имя")
+
+	model := query.New("Мастер").WithBackNavigation(true)
+	model.AddTasks([]common.Task{env, name})
+	model.Init()
+
+	// Выбираем "stage"
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, env.IsDone())
+
+	// Стрелка влево в пустом поле ввода возвращает к выбору среды
+	model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.False(t, env.IsDone(), "предыдущая задача должна быть открыта повторно")
+	assert.False(t, name.IsDone())
+	assert.False(t, name.HasError(), "возврат не должен считаться отменой ввода")
+	assert.Equal(t, "stage", env.GetSelected(), "прежний ответ должен остаться выделенным")
+	assert.NotContains(t, model.View(), "Имя", "следующая задача не отображается после возврата")
+
+	// Исправляем ответ и продолжаем
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, env.IsDone())
+	assert.Equal(t, "prod", env.GetSelected())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("router")})
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, name.IsDone())
+	assert.Equal(t, "router", name.GetValue())
+}
+
+// TestQueueBackNavigationKeepsInputValue проверяет сохранение введённого значения при возврате
+func TestQueueBackNavigationKeepsInputValue(t *testing.T) {
+	name := task.NewInputTaskNew("Имя", "Введите имя")
+	features := task.NewMultiSelectTask("Компоненты", []task.Item{{Key: "api"}, {Key: "web"}})
+
+	model := query.New("Мастер").WithBackNavigation(true)
+	model.AddTasks([]common.Task{name, features})
+	model.Init()
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("router")})
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, name.IsDone())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.False(t, name.IsDone())
+	assert.Contains(t, name.View(80), "router", "прежнее значение должно остаться в поле ввода")
+
+	// Повторное подтверждение сохраняет прежнее значение
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, name.IsDone())
+	assert.Equal(t, "router", name.GetValue())
+}
+
+// TestQueueWithoutBackNavigationKeepsExitBehaviour проверяет, что без режима мастера поведение не меняется
+func TestQueueWithoutBackNavigationKeepsExitBehaviour(t *testing.T) {
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}})
+	name := task.NewInputTaskNew("Имя", "Введите имя")
+
+	model := query.New("Без возврата")
+	model.AddTasks([]common.Task{env, name})
+	model.Init()
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.True(t, env.IsDone())
+	assert.True(t, name.IsDone())
+	assert.True(t, name.HasError(), "без режима мастера стрелка влево отменяет ввод")
+}
+
+// TestQueueBackNavigationSkipsFuncTask проверяет, что возврат через выполненную функцию невозможен
+func TestQueueBackNavigationSkipsFuncTask(t *testing.T) {
+	deploy := task.NewFuncTask("Подготовка", func() error { return nil })
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}})
+
+	model := query.New("Функция").WithBackNavigation(true)
+	model.AddTasks([]common.Task{deploy, env})
+	model.Init()
+	require.NoError(t, deploy.Execute())
+	model.Update(nil)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.True(t, deploy.IsDone())
+	assert.False(t, env.BackRequested())
+	assert.True(t, env.IsDone(), "без возможности возврата стрелка влево работает как раньше")
+}
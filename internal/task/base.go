@@ -19,6 +19,7 @@ type Task = common.Task
 // BaseTask contains common fields for all tasks.
 type BaseTask struct {
 	title       string
+	id          string // Необязательный стабильный идентификатор задачи
 	done        bool
 	icon        string // Icon to show when done (e.g., check or cross)
 	finalValue  string // The final value to display (e.g., "Yes", "Option 1")
@@ -32,6 +33,15 @@ type BaseTask struct {
 	// Флаг отображения разделительной линии в активных задачах выбора
 	showSelectionSeparator bool
 
+	// Тема оформления, заданная очередью (nil — глобальные стили пакета ui)
+	theme *ui.Theme
+
+	// Источник времени, заданный очередью (nil — системное время)
+	clock common.Clock
+
+	// Начальное значение из переменной окружения или флага (nil — не задано)
+	prefill *prefill
+
 	// Флаг, указывающий, нужно ли сохранять переносы строк в сообщениях об ошибках
 	preserveErrorNewLines bool
 
@@ -40,6 +50,15 @@ type BaseTask struct {
 	timeoutEnabled bool            // Флаг, указывающий, включен ли тайм-аут
 	defaultValue   interface{}     // Значение по умолчанию, которое будет выбрано при тайм-ауте
 	showTimeout    bool            // Флаг, указывающий, нужно ли отображать оставшееся время
+	timedOut       bool            // Флаг, указывающий, что значение выбрано по истечении тайм-аута
+
+	// Поля для возврата к предыдущей задаче очереди
+	backNavigation bool // Очередь разрешила возврат к предыдущей задаче
+	backRequested  bool // Пользователь запросил возврат к предыдущей задаче
+
+	// Условное выполнение и ветвление
+	condition func(common.Results) bool          // Условие выполнения задачи
+	followUp  func(common.Results) []common.Task // Задачи, добавляемые после завершения
 }
 
 func NewBaseTask(title string) BaseTask {
@@ -56,6 +75,13 @@ func NewBaseTask(title string) BaseTask {
 func (t *BaseTask) Title() string { return t.title }
 func (t *BaseTask) IsDone() bool  { return t.done }
 
+// SetID задаёт стабильный идентификатор задачи.
+// Идентификатор используется для сопоставления заранее подготовленных ответов и результатов.
+func (t *BaseTask) SetID(id string) { t.id = strings.TrimSpace(id) }
+
+// ID возвращает идентификатор задачи или пустую строку, если он не задан.
+func (t *BaseTask) ID() string { return t.id }
+
 func (t *BaseTask) Run() tea.Cmd {
 	// Если тайм-аут включен, запускаем таймер
 	if t.timeoutEnabled && t.timeoutManager != nil {
@@ -95,6 +121,33 @@ func (t *BaseTask) WithNewLinesInErrors(preserve bool) common.Task {
 // SetError устанавливает ошибку для задачи
 func (t *BaseTask) SetError(err error) { t.err = err }
 
+// SetTheme задаёт тему оформления задачи (используется очередью).
+// nil возвращает задачу к глобальным стилям пакета ui.
+func (t *BaseTask) SetTheme(theme *ui.Theme) { t.theme = theme }
+
+// Theme возвращает тему оформления задачи: заданную очередью
+// или собранную из глобальных стилей пакета ui.
+func (t *BaseTask) Theme() *ui.Theme {
+	if t.theme != nil {
+		return t.theme
+	}
+	return ui.CurrentTheme()
+}
+
+// SetClock задаёт источник времени задачи (используется очередью).
+// nil возвращает задачу к системному времени.
+func (t *BaseTask) SetClock(clock common.Clock) {
+	t.clock = clock
+	if t.timeoutManager != nil {
+		t.timeoutManager.SetClock(t.Clock())
+	}
+}
+
+// Clock возвращает источник времени задачи: заданный очередью или системный.
+func (t *BaseTask) Clock() common.Clock {
+	return common.ClockOrSystem(t.clock)
+}
+
 // View provides a defauilt implementation for active tasks.
 func (t *BaseTask) View(_ int) string {
 	// Most active tasks manage their own view, so this is a fallback.
@@ -107,6 +160,7 @@ func (t *BaseTask) View(_ int) string {
 // @return Указатель на текущую задачу для цепочки вызовов
 func (t *BaseTask) WithTimeout(duration time.Duration, defaultValue interface{}) *BaseTask {
 	t.timeoutManager = NewTimeoutManager(duration)
+	t.timeoutManager.SetClock(t.Clock())
 	t.timeoutEnabled = true
 	t.defaultValue = defaultValue
 	return t
@@ -167,7 +221,7 @@ func (t *BaseTask) RenderTimer() string {
 		return ""
 	}
 
-	return ui.SubtleStyle.Render(fmt.Sprintf("[%s]", remaining))
+	return t.Theme().Styles.Subtle.Render(fmt.Sprintf("[%s]", remaining))
 }
 
 // FinalView handles right-alignment for all tasks and formats error messages.
@@ -175,6 +229,7 @@ func (t *BaseTask) RenderTimer() string {
 // @param width Ширина макета для выравнивания текста
 // @return Отформатированное представление задачи с выравниванием
 func (t *BaseTask) FinalView(width int) string {
+	th := t.Theme()
 	// Используем константы из пакета common для расчета оптимальной ширины
 	// если переданная ширина меньше минимальной
 	if width < common.DefaultWidth {
@@ -190,9 +245,9 @@ func (t *BaseTask) FinalView(width int) string {
 	// Создаем префикс для завершенной задачи с новой системой отображения
 	var prefix string
 	if isTextInputTask {
-		prefix = ui.GetCompletedInputTaskPrefix(success)
+		prefix = th.CompletedInputTaskPrefix(success)
 	} else {
-		prefix = ui.GetCompletedTaskPrefix(success)
+		prefix = th.CompletedTaskPrefix(success)
 	}
 	if t.completedPrefix != "" {
 		prefix = t.completedPrefix
@@ -205,28 +260,28 @@ func (t *BaseTask) FinalView(width int) string {
 		if t.finalValue == defaults.DefaultYes {
 			styledTitle = t.title
 		} else {
-			styledTitle = ui.GetErrorStatusStyle().Render(t.title)
+			styledTitle = th.Styles.ErrorStatus.Render(t.title)
 		}
 
 		left := fmt.Sprintf("%s %s", prefix, styledTitle)
 		var right string
 		if t.finalValue == defaults.DefaultYes {
-			right = ui.TaskStatusSuccessStyle.Render(t.finalValue)
+			right = th.Styles.TaskStatusSuccess.Render(t.finalValue)
 		} else {
-			right = ui.GetErrorStatusStyle().Render(t.finalValue)
+			right = th.Styles.ErrorStatus.Render(t.finalValue)
 		}
 		return ui.AlignTextToRight(left, right, width)
 	}
 
 	// Для ошибок выводим текст ошибки с отступом и слово "Ошибка" справа
-	if t.icon == ui.IconError {
+	if t.icon == th.Icons.Error {
 		// Создаем левую часть с заголовком и префиксом (prefix уже содержит ✕)
 		// Заголовок окрашиваем в цвет ошибки
-		styledTitle := ui.GetErrorStatusStyle().Render(t.title)
+		styledTitle := th.Styles.ErrorStatus.Render(t.title)
 		left := fmt.Sprintf("%s  %s", prefix, styledTitle)
 
 		// Создаем правую часть со словом "Ошибка"
-		right := ui.GetErrorStatusStyle().Render(defaults.TaskStatusError)
+		right := th.Styles.ErrorStatus.Render(th.TaskStatusError())
 
 		// Создаем верхнюю строку с выравниванием
 		result := ui.AlignTextToRight(left, right, width) + "\n"
@@ -237,13 +292,13 @@ func (t *BaseTask) FinalView(width int) string {
 		// Получаем текст ошибки из finalValue, так как это уже отрендеренный текст
 		if t.finalValue != "" {
 			// Убираем стилизацию из текста ошибки
-			errText = strings.ReplaceAll(t.finalValue, ui.IconError, "")
+			errText = strings.ReplaceAll(t.finalValue, th.Icons.Error, "")
 			errText = strings.TrimSpace(errText)
 		}
 
 		// Добавляем отформатированный текст ошибки
 		// Используем параметр preserveErrorNewLines для управления форматированием
-		errorMsg := ui.FormatErrorMessage(errText, common.CalculateLayoutWidth(width), t.preserveErrorNewLines)
+		errorMsg := th.FormatErrorMessage(errText, common.CalculateLayoutWidth(width), t.preserveErrorNewLines)
 		result += errorMsg
 
 		return result
@@ -256,27 +311,27 @@ func (t *BaseTask) FinalView(width int) string {
 		if success {
 			styledTitle = t.title
 		} else {
-			styledTitle = ui.GetErrorStatusStyle().Render(t.title)
+			styledTitle = th.Styles.ErrorStatus.Render(t.title)
 		}
 
 		left := fmt.Sprintf("%s  %s", prefix, styledTitle)
 		statusLabel := strings.ToUpper(defaults.DefaultSuccessLabel)
-		statusStyle := ui.TaskStatusSuccessStyle
-		if t.icon == ui.IconCancelled {
+		statusStyle := th.Styles.TaskStatusSuccess
+		if t.icon == th.Icons.Cancelled {
 			statusLabel = defaults.ErrorTypeUserCancel
-			statusStyle = ui.ErrorMessageStyle
+			statusStyle = th.Styles.ErrorMessage
 		} else if !success {
-			statusLabel = defaults.TaskStatusError
-			statusStyle = ui.GetErrorStatusStyle()
+			statusLabel = th.TaskStatusError()
+			statusStyle = th.Styles.ErrorStatus
 		}
 
 		right := statusStyle.Render(statusLabel)
 		result := ui.AlignTextToRight(left, right, width)
 
-		if t.icon == ui.IconCancelled {
+		if t.icon == th.Icons.Cancelled {
 			trimmedValue := strings.TrimSpace(t.finalValue)
 			if trimmedValue != "" {
-				valueLine := strings.Repeat(" ", ui.MainLeftIndent) + ui.VerticalLineSymbol + ui.GetResultIndentWhenNumberingEnabled() + trimmedValue
+				valueLine := strings.Repeat(" ", ui.MainLeftIndent) + th.Glyphs.Vertical + ui.GetResultIndentWhenNumberingEnabled() + trimmedValue
 				result = result + "\n" + valueLine + "\n"
 			}
 		}
@@ -295,7 +350,7 @@ func (t *BaseTask) FinalView(width int) string {
 	if success {
 		styledTitle = t.title
 	} else {
-		styledTitle = ui.GetErrorStatusStyle().Render(t.title)
+		styledTitle = th.Styles.ErrorStatus.Render(t.title)
 	}
 	return fmt.Sprintf("%s  %s", prefix, styledTitle)
 }
@@ -317,10 +372,11 @@ func (t *BaseTask) SetInProgressPrefix(prefix string) {
 
 // InProgressPrefix возвращает текущий префикс активной задачи (с учётом значения по умолчанию)
 func (t *BaseTask) InProgressPrefix() string {
+	th := t.Theme()
 	if strings.TrimSpace(t.inProgressPrefix) != "" {
 		return t.inProgressPrefix + " "
 	}
-	return ui.GetCurrentTaskPrefix()
+	return th.CurrentTaskPrefix()
 }
 
 // IsTextInputTask определяет, является ли задача текстовой задачей ввода
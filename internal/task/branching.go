@@ -0,0 +1,47 @@
+// task/branching.go
+
+package task
+
+import (
+	"github.com/qzeleza/ziva/internal/common"
+)
+
+// Условное выполнение и ветвление задач очереди.
+// Условие проверяется очередью перед запуском задачи по результатам уже
+// завершённых задач; обработчик продолжения вызывается после завершения
+// задачи и может добавить новые задачи сразу за ней.
+
+// SetCondition задаёт условие выполнения задачи.
+// Если условие возвращает false, очередь пропускает задачу.
+//
+// @param condition Функция-условие (nil — задача выполняется всегда)
+func (t *BaseTask) SetCondition(condition func(common.Results) bool) {
+	t.condition = condition
+}
+
+// ShouldRun сообщает, нужно ли выполнять задачу при указанных результатах.
+//
+// @param results Результаты задач очереди
+// @return true, если задача должна быть выполнена
+func (t *BaseTask) ShouldRun(results common.Results) bool {
+	return t.condition == nil || t.condition(results)
+}
+
+// SetFollowUp задаёт обработчик, который после завершения задачи
+// возвращает задачи для вставки в очередь сразу за ней.
+//
+// @param followUp Обработчик продолжения (nil — без продолжения)
+func (t *BaseTask) SetFollowUp(followUp func(common.Results) []common.Task) {
+	t.followUp = followUp
+}
+
+// FollowUpTasks возвращает задачи-продолжения для указанных результатов.
+//
+// @param results Результаты задач очереди (включая только что завершённую задачу)
+// @return Задачи для вставки в очередь
+func (t *BaseTask) FollowUpTasks(results common.Results) []common.Task {
+	if t.followUp == nil {
+		return nil
+	}
+	return t.followUp(results)
+}
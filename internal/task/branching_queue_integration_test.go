@@ -0,0 +1,85 @@
+package task_test
+
+import (
+	"bytes"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chosen возвращает условие "в задаче id выбран вариант value"
+func chosen(id, value string) func(common.Results) bool {
+	return func(results common.Results) bool {
+		result, ok := results.ByID(id)
+		return ok && result.Value == value
+	}
+}
+
+// TestConditionalTaskHeadless проверяет пропуск задачи по условию в неинтерактивном режиме
+func TestConditionalTaskHeadless(t *testing.T) {
+	for _, tc := range []struct {
+		db      string
+		skipped bool
+	}{{"postgres", false}, {"sqlite", true}} {
+		db := task.NewSingleSelectTask("База данных", []task.Item{{Key: "postgres"}, {Key: "sqlite"}})
+		db.SetID("db")
+		password := task.NewInputTaskNew("Пароль БД", "Введите пароль")
+		password.SetCondition(chosen("db", "postgres"))
+
+		var out bytes.Buffer
+		model := query.New("Условия").WithOutput(&out).WithHeadless(query.MapAnswers{"db": tc.db, "Пароль БД": "secret"})
+		model.AddTasks([]common.Task{db, password})
+		require.NoError(t, model.Run())
+
+		results := model.Results()
+		if tc.skipped {
+			assert.Equal(t, common.ResultSkipped, results[1].Status)
+			assert.False(t, password.IsDone())
+			assert.Contains(t, out.String(), defaults.TaskStatusSkipped)
+		} else {
+			assert.Equal(t, common.ResultSuccess, results[1].Status)
+			assert.Equal(t, "secret", password.GetValue())
+		}
+	}
+}
+
+// TestFollowUpTasksInteractive проверяет вставку задач после ответа и их пересоздание при возврате
+func TestFollowUpTasksInteractive(t *testing.T) {
+	created := 0
+	db := task.NewSingleSelectTask("База данных", []task.Item{{Key: "postgres"}, {Key: "sqlite"}})
+	db.SetFollowUp(func(results common.Results) []common.Task {
+		created++
+		if result, _ := results.ByTitle("База данных"); result.Value == "postgres" {
+			return []common.Task{task.NewInputTaskNew("Хост БД", "Введите хост")}
+		}
+		return nil
+	})
+	done := task.NewYesNoTask("Готово", "Завершить?")
+
+	model := query.New("Ветвление").WithBackNavigation(true)
+	model.AddTasks([]common.Task{db, done})
+	model.Init()
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, 1, created)
+	assert.Contains(t, model.View(), "Хост БД", "после выбора postgres должна появиться задача хоста")
+
+	// Возврат удаляет добавленную задачу, выбор sqlite её не создаёт
+	model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	require.False(t, db.IsDone())
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, 2, created)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, done.IsDone())
+	results := model.Results()
+	require.Len(t, results, 2, "задача хоста не должна остаться в очереди")
+	assert.Equal(t, "sqlite", results[0].Value)
+}
@@ -0,0 +1,29 @@
+package task
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+)
+
+/**
+ * @brief Переводит спиннер на следующий кадр и планирует очередной кадр по часам задачи.
+ * @param clock Источник времени задачи.
+ * @param s Спиннер.
+ * @param msg Сообщение спиннера.
+ * @return Обновленный спиннер и команда следующего кадра.
+ * @details Спиннер bubbles планирует кадры по системному времени, поэтому
+ * команда следующего кадра пересоздаётся через часы задачи: с поддельными
+ * часами анимация не зависит от реального времени.
+ */
+func spinnerTick(clock common.Clock, s spinner.Model, msg spinner.TickMsg) (spinner.Model, tea.Cmd) {
+	s, cmd := s.Update(msg)
+	if cmd == nil {
+		return s, nil
+	}
+	// Tick возвращает сообщение с текущей меткой спиннера, как и его собственная команда
+	next := s.Tick()
+	return s, clock.Tick(s.Spinner.FPS, func(time.Time) tea.Msg { return next })
+}
@@ -0,0 +1,315 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+const (
+	// defaultOutputLines число последних строк вывода команды, отображаемых под заголовком
+	defaultOutputLines = 5
+	// maxPartialLine ограничивает длину незавершённой строки вывода (например, строки прогресса с \r)
+	maxPartialLine = 4096
+	// commandWaitDelay время ожидания закрытия потоков вывода после завершения команды
+	commandWaitDelay = time.Second
+	// maxCommandOutput ограничивает объём хранимого вывода команды (сохраняются последние байты)
+	maxCommandOutput = 1 << 20
+)
+
+/**
+ * @brief Задача, запускающая внешнюю команду и показывающая её вывод.
+ * @details Пока команда выполняется, под заголовком выводятся последние строки
+ * stdout и stderr. Нулевой код завершения означает успех, ненулевой — ошибку
+ * TaskError с кодом в контексте "exit_code". Вывод доступен через Output.
+ * Все возможности FuncTask (срок выполнения, повтор, отмена) сохраняются.
+ */
+type CommandTask struct {
+	*FuncTask
+	name string   // Имя или путь исполняемого файла
+	args []string // Аргументы команды
+	dir  string   // Рабочий каталог (пусто — текущий)
+	env  []string // Дополнительные переменные окружения KEY=value
+}
+
+/**
+ * @brief Потокобезопасный буфер вывода команды.
+ * @details Хранит полный вывод и последние строки для области вывода под заголовком.
+ */
+type commandOutput struct {
+	mu        sync.Mutex
+	limit     int          // Число строк в области вывода
+	onFailure bool         // Показывать область вывода в итоговом виде при ошибке
+	full      bytes.Buffer // Вывод команды (не более maxCommandOutput последних байт)
+	lines     []string     // Последние завершённые строки (не более limit)
+	partial   string       // Незавершённая строка
+	exitCode  int          // Код завершения (-1 — команда не завершилась)
+}
+
+/**
+ * @brief Создает задачу выполнения внешней команды.
+ * @param title Заголовок задачи.
+ * @param name Имя или путь исполняемого файла.
+ * @param args Аргументы команды.
+ * @return Указатель на созданную задачу CommandTask.
+ * @details Команда запускается без оболочки, stdout и stderr выводятся вместе.
+ *
+ * task := NewCommandTask("Проверка связи", "ping", "-c", "3", "8.8.8.8").
+ *     WithOutputLines(3).
+ *     WithOutputOnFailure()
+ */
+func NewCommandTask(title, name string, args ...string) *CommandTask {
+	t := &CommandTask{name: name, args: args}
+	t.FuncTask = NewFuncTaskCtx(title, t.run)
+	t.output = &commandOutput{limit: defaultOutputLines, exitCode: -1}
+	return t
+}
+
+/**
+ * @brief Задает рабочий каталог команды.
+ * @param dir Рабочий каталог.
+ * @return Указатель на задачу для возможности цепочки вызовов.
+ */
+func (t *CommandTask) WithDir(dir string) *CommandTask {
+	t.dir = dir
+	return t
+}
+
+/**
+ * @brief Добавляет переменные окружения команды к окружению текущего процесса.
+ * @param env Переменные в виде KEY=value.
+ * @return Указатель на задачу для возможности цепочки вызовов.
+ */
+func (t *CommandTask) WithEnv(env ...string) *CommandTask {
+	t.env = append(t.env, env...)
+	return t
+}
+
+/**
+ * @brief Задает число последних строк вывода, отображаемых под заголовком.
+ * @param lines Число строк (0 — вывод не отображается).
+ * @return Указатель на задачу для возможности цепочки вызовов.
+ */
+func (t *CommandTask) WithOutputLines(lines int) *CommandTask {
+	if lines >= 0 {
+		t.output.mu.Lock()
+		t.output.limit = lines
+		t.output.lines = tailLines(t.output.lines, lines)
+		t.output.mu.Unlock()
+	}
+	return t
+}
+
+/**
+ * @brief Сохраняет область вывода в итоговом виде задачи, если команда завершилась ошибкой.
+ * @return Указатель на задачу для возможности цепочки вызовов.
+ * @details При успешном завершении область вывода скрывается.
+ */
+func (t *CommandTask) WithOutputOnFailure() *CommandTask {
+	t.output.mu.Lock()
+	t.output.onFailure = true
+	t.output.mu.Unlock()
+	return t
+}
+
+/**
+ * @brief Возвращает вывод последнего запуска команды (stdout и stderr).
+ * @details Хранится не более 1 МиБ последнего вывода.
+ */
+func (t *CommandTask) Output() string {
+	t.output.mu.Lock()
+	defer t.output.mu.Unlock()
+	return t.output.full.String()
+}
+
+/**
+ * @brief Возвращает код завершения последнего запуска команды.
+ * @return Код завершения или -1, если команда не запускалась, не завершилась или была прервана.
+ */
+func (t *CommandTask) ExitCode() int {
+	t.output.mu.Lock()
+	defer t.output.mu.Unlock()
+	return t.output.exitCode
+}
+
+/**
+ * @brief Запускает команду и дожидается её завершения.
+ * @param ctx Контекст выполнения; его отмена завершает процесс.
+ * @return Ошибка запуска или ошибка с ненулевым кодом завершения.
+ */
+func (t *CommandTask) run(ctx context.Context) error {
+	t.output.reset()
+
+	cmd := exec.CommandContext(ctx, t.name, t.args...)
+	cmd.Dir = t.dir
+	if len(t.env) > 0 {
+		cmd.Env = append(os.Environ(), t.env...)
+	}
+	cmd.Stdout = t.output
+	cmd.Stderr = t.output
+	cmd.WaitDelay = commandWaitDelay
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		t.output.setExitCode(0)
+		return nil
+	case errors.As(err, &exitErr):
+		code := exitErr.ExitCode()
+		t.output.setExitCode(code)
+		if ctx.Err() != nil {
+			// Процесс завершён отменой контекста: ошибку приводит к типу FuncTask.execute
+			return err
+		}
+		// Ненулевой код завершения повторяется политикой WithRetry по умолчанию (см. isCommandExit)
+		return terrors.NewTaskError(t.title, fmt.Errorf(defaults.ErrCommandExitCode, t.name, code), terrors.ErrorTypeUnknown).
+			WithContext("exit_code", code).
+			WithContext("command", t.name)
+	case errors.Is(err, os.ErrPermission):
+		return terrors.NewPermissionError(t.title, t.name)
+	}
+	return terrors.NewConfigurationError(t.title, fmt.Errorf(defaults.ErrCommandStart, t.name, err), "command")
+}
+
+// isCommandExit сообщает, что ошибка вызвана ненулевым кодом завершения команды
+func isCommandExit(err error) bool {
+	var taskErr *terrors.TaskError
+	if !errors.As(err, &taskErr) {
+		return false
+	}
+	_, ok := taskErr.GetContext("exit_code")
+	return ok
+}
+
+/**
+ * @brief Очищает вывод перед очередным запуском команды.
+ */
+func (o *commandOutput) reset() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.full.Reset()
+	o.lines = o.lines[:0]
+	o.partial = ""
+	o.exitCode = -1
+}
+
+/**
+ * @brief Сохраняет код завершения команды.
+ */
+func (o *commandOutput) setExitCode(code int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.exitCode = code
+}
+
+/**
+ * @brief Принимает очередную часть вывода команды.
+ * @param p Данные из stdout или stderr.
+ * @return Число принятых байт и nil.
+ */
+func (o *commandOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.full.Write(p)
+	if extra := o.full.Len() - maxCommandOutput; extra > 0 {
+		o.full.Next(extra)
+		// Не начинаем вывод с середины многобайтового символа
+		for o.full.Len() > 0 && !utf8.RuneStart(o.full.Bytes()[0]) {
+			o.full.Next(1)
+		}
+	}
+
+	parts := strings.Split(o.partial+string(p), "\n")
+	for _, line := range parts[:len(parts)-1] {
+		o.lines = tailLines(append(o.lines, cleanOutputLine(line)), o.limit)
+	}
+	o.partial = parts[len(parts)-1]
+	if len(o.partial) > maxPartialLine {
+		o.partial = strings.ToValidUTF8(o.partial[len(o.partial)-maxPartialLine:], "")
+	}
+	return len(p), nil
+}
+
+/**
+ * @brief Возвращает последние строки вывода, включая незавершённую строку.
+ */
+func (o *commandOutput) tail() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	lines := append([]string{}, o.lines...)
+	if partial := cleanOutputLine(o.partial); partial != "" {
+		lines = append(lines, partial)
+	}
+	return tailLines(lines, o.limit)
+}
+
+/**
+ * @brief Формирует область вывода команды под заголовком задачи.
+ * @param width Ширина области отображения.
+ * @param final Признак итогового вида задачи.
+ * @return Строки области вывода или пустая строка.
+ */
+func (t *FuncTask) outputView(width int, final bool) string {
+	if t.output == nil {
+		return ""
+	}
+	if final {
+		t.output.mu.Lock()
+		onFailure := t.output.onFailure
+		t.output.mu.Unlock()
+		if !onFailure || !t.HasError() {
+			return ""
+		}
+	}
+
+	th := t.Theme()
+	prefix := performance.FastConcat(
+		performance.RepeatEfficient(" ", ui.MainLeftIndent),
+		th.Glyphs.Vertical,
+		ui.GetResultIndentWhenNumberingEnabled(),
+		"  ",
+	)
+	available := width - lipgloss.Width(prefix)
+
+	result := ""
+	for _, line := range t.output.tail() {
+		result += prefix + th.Styles.Subtle.Render(truncateRunes(line, available)) + "\n"
+	}
+	return result
+}
+
+// tailLines возвращает не более n последних строк
+func tailLines(lines []string, n int) []string {
+	if n <= 0 {
+		return lines[:0]
+	}
+	if len(lines) > n {
+		return append(lines[:0], lines[len(lines)-n:]...)
+	}
+	return lines
+}
+
+// cleanOutputLine оставляет текст строки после последнего возврата каретки
+// и удаляет управляющие последовательности терминала
+func cleanOutputLine(line string) string {
+	line = strings.TrimRight(line, "\r")
+	if i := strings.LastIndex(line, "\r"); i >= 0 {
+		line = line[i+1:]
+	}
+	line = ui.StripANSI(line)
+	return strings.ReplaceAll(line, "\t", "    ")
+}
@@ -0,0 +1,37 @@
+package task_test
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCommandTaskInQueue проверяет выполнение команд в очереди и остановку очереди по коду завершения
+func TestCommandTaskInQueue(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("оболочка sh недоступна")
+	}
+	build := task.NewCommandTask("Сборка", "sh", "-c", "echo built")
+	check := task.NewCommandTask("Проверка", "sh", "-c", "echo 'test failed'; exit 2").WithOutputOnFailure()
+	deploy := task.NewCommandTask("Развёртывание", "sh", "-c", "echo deployed")
+
+	var out bytes.Buffer
+	model := query.New("Выпуск").WithOutput(&out).WithHeadless(query.MapAnswers{})
+	model.AddTasks([]common.Task{build, check, deploy})
+	require.Error(t, model.Run())
+
+	assert.Equal(t, "built\n", build.Output())
+	assert.Equal(t, 2, check.ExitCode())
+	assert.Equal(t, -1, deploy.ExitCode(), "очередь останавливается на ошибке команды")
+
+	results := model.Results()
+	assert.Equal(t, common.ResultSuccess, results[0].Status)
+	assert.Equal(t, common.ResultError, results[1].Status)
+	assert.Contains(t, out.String(), "test failed")
+}
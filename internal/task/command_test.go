@@ -0,0 +1,149 @@
+// task/command_test.go
+
+package task
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireShell пропускает тест, если оболочка sh недоступна
+func requireShell(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("оболочка sh недоступна")
+	}
+}
+
+// TestCommandTaskSuccess проверяет успешное выполнение команды и сохранение полного вывода
+func TestCommandTaskSuccess(t *testing.T) {
+	requireShell(t)
+	cmdTask := NewCommandTask("Сборка", "sh", "-c", `echo "$GREETING"; echo warning >&2`).WithEnv("GREETING=hello")
+
+	require.NoError(t, cmdTask.Execute())
+	assert.Equal(t, 0, cmdTask.ExitCode())
+	assert.Equal(t, "hello\nwarning\n", cmdTask.Output())
+	assert.False(t, cmdTask.HasError())
+	assert.NotContains(t, cmdTask.FinalView(80), "warning", "вывод успешной команды в итоговом виде не показывается")
+}
+
+// TestCommandTaskExitCode проверяет ошибку TaskError с кодом завершения
+func TestCommandTaskExitCode(t *testing.T) {
+	requireShell(t)
+	cmdTask := NewCommandTask("Проверка", "sh", "-c", "echo checking; echo broken >&2; exit 3")
+
+	err := cmdTask.Execute()
+	var taskErr *terrors.TaskError
+	require.True(t, errors.As(err, &taskErr))
+	code, ok := taskErr.GetContext("exit_code")
+	assert.True(t, ok)
+	assert.Equal(t, 3, code)
+	assert.Equal(t, 3, cmdTask.ExitCode())
+	assert.True(t, cmdTask.HasError())
+	assert.NotContains(t, cmdTask.FinalView(80), "broken", "без WithOutputOnFailure вывод не показывается")
+
+	cmdTask.WithOutputOnFailure()
+	view := cmdTask.FinalView(80)
+	assert.Contains(t, view, "checking")
+	assert.Contains(t, view, "broken")
+}
+
+// TestCommandTaskStartError проверяет ошибку запуска несуществующей команды
+func TestCommandTaskStartError(t *testing.T) {
+	cmdTask := NewCommandTask("Запуск", "ziva-command-that-does-not-exist")
+
+	err := cmdTask.Execute()
+	var taskErr *terrors.TaskError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, terrors.ErrorTypeConfiguration, taskErr.Type)
+	assert.Equal(t, -1, cmdTask.ExitCode())
+}
+
+// TestCommandTaskDeadline проверяет завершение процесса по истечении срока
+func TestCommandTaskDeadline(t *testing.T) {
+	requireShell(t)
+	cmdTask := NewCommandTask("Ожидание", "sh", "-c", "sleep 5")
+	cmdTask.WithDeadline(50 * time.Millisecond)
+
+	started := time.Now()
+	err := cmdTask.Execute()
+	var taskErr *terrors.TaskError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, terrors.ErrorTypeTimeout, taskErr.Type)
+	assert.Less(t, time.Since(started), 3*time.Second)
+}
+
+// TestCommandTaskLiveOutput проверяет вывод последних строк во время выполнения и отмену команды
+func TestCommandTaskLiveOutput(t *testing.T) {
+	requireShell(t)
+	cmdTask := NewCommandTask("Загрузка", "sh", "-c", "for i in 1 2 3 4 5; do echo line$i; done; printf 'partial'; sleep 5").
+		WithOutputLines(3)
+
+	cmd := cmdTask.Run()
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- runFuncCommand(cmd) }()
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(cmdTask.View(80), "partial")
+	}, 3*time.Second, 10*time.Millisecond)
+
+	view := cmdTask.View(80)
+	assert.Contains(t, view, "line4")
+	assert.Contains(t, view, "line5")
+	assert.NotContains(t, view, "line3", "область вывода ограничена тремя строками")
+
+	cmdTask.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	select {
+	case msg := <-msgs:
+		cmdTask.Update(msg)
+	case <-time.After(3 * time.Second):
+		t.Fatal("команда не была завершена после отмены")
+	}
+	assert.True(t, cmdTask.IsDone())
+	assert.True(t, cmdTask.HasError())
+	assert.Contains(t, cmdTask.Output(), "line1\n")
+}
+
+// TestCleanOutputLine проверяет обработку возврата каретки и управляющих последовательностей
+func TestCleanOutputLine(t *testing.T) {
+	assert.Equal(t, "100%", cleanOutputLine(" 10%\r 50%\r100%\r"))
+	assert.Equal(t, "ok done", cleanOutputLine("\x1b[32mok\x1b[0m done"))
+	assert.Equal(t, "a    b", cleanOutputLine("a\tb"))
+	assert.Equal(t, "progress", cleanOutputLine("\x1b[?25l\x1b]0;title\aprogress\x1b[K"))
+}
+
+// TestCommandTaskRetriesExitCode проверяет повтор команды с ненулевым кодом завершения политикой по умолчанию
+func TestCommandTaskRetriesExitCode(t *testing.T) {
+	requireShell(t)
+	cmdTask := NewCommandTask("Подключение", "sh", "-c", "test -f marker || { touch marker; exit 1; }").
+		WithDir(t.TempDir())
+	cmdTask.WithRetry(RetryPolicy{MaxAttempts: 3})
+
+	require.NoError(t, cmdTask.Execute())
+	assert.Equal(t, 2, cmdTask.Attempts(), "ненулевой код завершения повторяется без RetryOn")
+	assert.Equal(t, 0, cmdTask.ExitCode())
+}
+
+// TestCommandOutputKeepsTail проверяет ограничение объёма хранимого вывода
+func TestCommandOutputKeepsTail(t *testing.T) {
+	output := &commandOutput{limit: defaultOutputLines, exitCode: -1}
+	chunk := strings.Repeat("я", maxCommandOutput/4)
+	for i := 0; i < 4; i++ {
+		_, _ = output.Write([]byte(chunk))
+	}
+	_, _ = output.Write([]byte("конец\n"))
+
+	full := output.full.String()
+	assert.LessOrEqual(t, len(full), maxCommandOutput)
+	assert.True(t, strings.HasSuffix(full, "конец\n"), "сохраняются последние байты вывода")
+	assert.True(t, utf8.ValidString(full), "вывод не начинается с середины символа")
+}
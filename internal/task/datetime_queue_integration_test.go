@@ -0,0 +1,58 @@
+package task_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDateTimeQueueHeadless проверяет ответы неинтерактивного режима для даты, времени и длительности
+func TestDateTimeQueueHeadless(t *testing.T) {
+	date := task.NewDateTask("Дата начала")
+	date.SetID("start")
+	reboot := task.NewTimeTask("Время перезагрузки").WithValue(time.Date(0, 1, 1, 4, 0, 0, 0, time.UTC))
+	reboot.SetID("reboot")
+	interval := task.NewDurationTask("Интервал").WithDurationRange(time.Minute, 24*time.Hour)
+	interval.SetID("interval")
+
+	model := query.New("Расписание").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{
+		"start":    "2025-03-15",
+		"interval": "6h",
+	})
+	model.AddTasks([]common.Task{date, reboot, interval})
+
+	require.NoError(t, model.Run())
+	assert.Equal(t, time.Date(2025, time.March, 15, 0, 0, 0, 0, time.Local), date.GetTime())
+	assert.Equal(t, 6*time.Hour, interval.GetDuration())
+
+	results := model.Results()
+	start, ok := results.ByID("start")
+	require.True(t, ok)
+	assert.Equal(t, common.KindDate, start.Kind)
+	assert.Equal(t, "2025-03-15", start.Value)
+
+	at, ok := results.ByID("reboot")
+	require.True(t, ok)
+	assert.Equal(t, "04:00", at.Value, "без ответа выбирается начальное значение")
+}
+
+// TestDateTimeQueueOutOfRange проверяет, что ответ вне диапазона останавливает очередь
+func TestDateTimeQueueOutOfRange(t *testing.T) {
+	interval := task.NewDurationTask("Интервал").WithDurationRange(time.Minute, time.Hour)
+	after := task.NewFuncTask("После", func() error { return nil })
+
+	model := query.New("Расписание").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{
+		"Интервал": "2h",
+	})
+	model.AddTasks([]common.Task{interval, after})
+
+	require.Error(t, model.Run())
+	assert.True(t, interval.HasError())
+	assert.False(t, after.IsDone())
+}
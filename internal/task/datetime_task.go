@@ -0,0 +1,866 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// dateTimeMode определяет вид значения задачи выбора даты и времени
+type dateTimeMode int
+
+const (
+	modeDate     dateTimeMode = iota // Дата: день, месяц, год
+	modeTime                         // Время суток: часы, минуты, секунды
+	modeDuration                     // Длительность: часы, минуты, секунды
+)
+
+// durationLimit — наибольшая длительность, которую можно выбрать
+const durationLimit = defaults.MaxDurationHours*time.Hour + 59*time.Minute + 59*time.Second
+
+// DateTimeTask - задача выбора даты, времени суток или длительности.
+// Значение выбирается по полям: стрелки влево/вправо переключают поле,
+// вверх/вниз изменяют его с переносом в соседние поля, цифры вводят значение поля.
+type DateTimeTask struct {
+	BaseTask
+	mode          dateTimeMode
+	value         time.Time      // Дата (полночь по местному времени) или время суток (на нулевую дату, UTC)
+	duration      time.Duration  // Длительность
+	minValue      time.Time      // Нижняя граница даты или времени
+	maxValue      time.Time      // Верхняя граница даты или времени
+	minDuration   time.Duration  // Нижняя граница длительности
+	maxDuration   time.Duration  // Верхняя граница длительности
+	hasMin        bool           // Нижняя граница задана
+	hasMax        bool           // Верхняя граница задана
+	seconds       bool           // Показывать поле секунд
+	initial       bool           // Начальное значение задано явно
+	ready         bool           // Начальное значение вычислено и приведено к границам
+	field         int            // Активное поле
+	typed         int            // Число, набранное цифрами в активном поле
+	typedDigits   int            // Количество набранных цифр
+	validationErr error          // Ошибка проверки значения при подтверждении
+	activeStyle   lipgloss.Style // Стиль активного поля и выбранного дня
+}
+
+// NewDateTask создает задачу выбора даты. По умолчанию выбрана текущая дата.
+//
+// @param title Заголовок задачи
+// @return Указатель на новую задачу выбора даты
+func NewDateTask(title string) *DateTimeTask {
+	return newDateTimeTask(title, modeDate)
+}
+
+// NewTimeTask создает задачу выбора времени суток (часы и минуты).
+// По умолчанию выбрано текущее время с точностью до минуты.
+//
+// @param title Заголовок задачи
+// @return Указатель на новую задачу выбора времени
+func NewTimeTask(title string) *DateTimeTask {
+	return newDateTimeTask(title, modeTime)
+}
+
+// NewDurationTask создает задачу выбора длительности (часы, минуты и секунды).
+// По умолчанию выбрана нулевая длительность или нижняя граница диапазона.
+//
+// @param title Заголовок задачи
+// @return Указатель на новую задачу выбора длительности
+func NewDurationTask(title string) *DateTimeTask {
+	task := newDateTimeTask(title, modeDuration)
+	task.seconds = true
+	return task
+}
+
+// newDateTimeTask создает задачу выбора указанного вида
+func newDateTimeTask(title string, mode dateTimeMode) *DateTimeTask {
+	return &DateTimeTask{
+		BaseTask:    NewBaseTask(title),
+		mode:        mode,
+		activeStyle: ui.ActiveStyle,
+	}
+}
+
+// WithValue задаёт начальную дату или время суток.
+// Для даты учитываются только год, месяц и день, для времени - часы, минуты и секунды.
+//
+// @param value Начальное значение
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTimeTask) WithValue(value time.Time) *DateTimeTask {
+	t.value = t.normalize(value)
+	t.initial = true
+	t.ready = false
+	return t
+}
+
+// WithDuration задаёт начальную длительность (отрицательная считается нулевой).
+//
+// @param value Начальная длительность
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTimeTask) WithDuration(value time.Duration) *DateTimeTask {
+	t.duration = t.normalizeDuration(value)
+	t.initial = true
+	t.ready = false
+	return t
+}
+
+// WithRange ограничивает выбор даты или времени диапазоном [low, high].
+// Нулевое значение time.Time снимает соответствующую границу.
+//
+// @param low Нижняя граница
+// @param high Верхняя граница
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTimeTask) WithRange(low, high time.Time) *DateTimeTask {
+	t.hasMin, t.hasMax = !low.IsZero(), !high.IsZero()
+	t.minValue, t.maxValue = t.normalize(low), t.normalize(high)
+	t.ready = false
+	return t
+}
+
+// WithDurationRange ограничивает выбор длительности диапазоном [low, high].
+// Неположительная граница не действует.
+//
+// @param low Нижняя граница
+// @param high Верхняя граница
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTimeTask) WithDurationRange(low, high time.Duration) *DateTimeTask {
+	t.hasMin, t.hasMax = low > 0, high > 0
+	t.minDuration, t.maxDuration = t.normalizeDuration(low), t.normalizeDuration(high)
+	t.ready = false
+	return t
+}
+
+// WithSeconds показывает или скрывает поле секунд.
+// Без поля секунд значение округляется вниз до минуты. Для даты настройка не действует.
+//
+// @param show Показывать поле секунд
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTimeTask) WithSeconds(show bool) *DateTimeTask {
+	if t.mode == modeDate {
+		return t
+	}
+	t.seconds = show
+	t.value = t.normalize(t.value)
+	t.duration = t.normalizeDuration(t.duration)
+	t.field = min(t.field, t.fieldCount()-1)
+	return t
+}
+
+// WithTimeout устанавливает тайм-аут для задачи выбора даты и времени
+// @param duration Длительность тайм-аута
+// @param defaultValue Значение по умолчанию (time.Time, time.Duration или строка)
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTimeTask) WithTimeout(duration time.Duration, defaultValue interface{}) *DateTimeTask {
+	t.BaseTask.WithTimeout(duration, defaultValue)
+	return t
+}
+
+// SetTheme задаёт тему оформления задачи и обновляет стиль активного поля
+func (t *DateTimeTask) SetTheme(theme *ui.Theme) {
+	t.BaseTask.SetTheme(theme)
+	if theme != nil {
+		t.activeStyle = theme.Styles.Active
+	}
+}
+
+// GetTime возвращает выбранную дату (полночь по местному времени)
+// или время суток (на нулевую дату в UTC, как после time.Parse).
+//
+// @return Выбранное значение
+func (t *DateTimeTask) GetTime() time.Time {
+	t.ensureValue()
+	return t.value
+}
+
+// GetDuration возвращает выбранную длительность
+//
+// @return Выбранная длительность
+func (t *DateTimeTask) GetDuration() time.Duration {
+	t.ensureValue()
+	return t.duration
+}
+
+// GetValue возвращает выбранное значение строкой: дату в формате "2006-01-02",
+// время в формате "15:04" (или "15:04:05"), длительность в формате time.Duration
+//
+// @return Выбранное значение строкой
+func (t *DateTimeTask) GetValue() string {
+	t.ensureValue()
+	return t.format()
+}
+
+// ensureValue вычисляет начальное значение по часам задачи и приводит его к границам
+func (t *DateTimeTask) ensureValue() {
+	if t.ready {
+		return
+	}
+	t.ready = true
+	if !t.initial {
+		now := t.Clock().Now()
+		switch t.mode {
+		case modeDate:
+			t.value = t.normalize(now)
+		case modeTime:
+			t.value = t.normalize(now.Truncate(time.Minute))
+		default:
+			t.duration = 0
+		}
+	}
+	t.clamp()
+}
+
+// normalize приводит дату или время к виду, в котором их хранит задача
+func (t *DateTimeTask) normalize(value time.Time) time.Time {
+	if t.mode == modeDate {
+		y, m, d := value.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+	sec := value.Second()
+	if !t.seconds {
+		sec = 0
+	}
+	return time.Date(0, time.January, 1, value.Hour(), value.Minute(), sec, 0, time.UTC)
+}
+
+// normalizeDuration приводит длительность к допустимому диапазону и точности полей
+func (t *DateTimeTask) normalizeDuration(value time.Duration) time.Duration {
+	value = max(min(value, durationLimit), 0)
+	if t.seconds {
+		return value.Truncate(time.Second)
+	}
+	return value.Truncate(time.Minute)
+}
+
+// clamp приводит значение к границам диапазона
+func (t *DateTimeTask) clamp() {
+	if t.mode == modeDuration {
+		if t.hasMin && t.duration < t.minDuration {
+			t.duration = t.minDuration
+		}
+		if t.hasMax && t.duration > t.maxDuration {
+			t.duration = t.maxDuration
+		}
+		return
+	}
+	if t.hasMin && t.value.Before(t.minValue) {
+		t.value = t.minValue
+	}
+	if t.hasMax && t.value.After(t.maxValue) {
+		t.value = t.maxValue
+	}
+}
+
+// checkRange проверяет, что значение находится внутри диапазона
+//
+// @return Ошибка с допустимой границей или nil
+func (t *DateTimeTask) checkRange(value time.Time, duration time.Duration) error {
+	if t.mode == modeDuration {
+		switch {
+		case t.hasMin && duration < t.minDuration:
+			return fmt.Errorf(defaults.ErrValueBelowMin, formatDurationUnits(t.minDuration))
+		case t.hasMax && duration > t.maxDuration:
+			return fmt.Errorf(defaults.ErrValueAboveMax, formatDurationUnits(t.maxDuration))
+		}
+		return nil
+	}
+	switch {
+	case t.hasMin && value.Before(t.minValue):
+		return fmt.Errorf(defaults.ErrValueBelowMin, t.formatTime(t.minValue))
+	case t.hasMax && value.After(t.maxValue):
+		return fmt.Errorf(defaults.ErrValueAboveMax, t.formatTime(t.maxValue))
+	}
+	return nil
+}
+
+// fieldCount возвращает количество полей значения
+func (t *DateTimeTask) fieldCount() int {
+	if t.mode == modeDate || t.seconds {
+		return 3
+	}
+	return 2
+}
+
+// fieldLimits возвращает допустимый диапазон значения поля
+func (t *DateTimeTask) fieldLimits(field int) (int, int) {
+	switch t.mode {
+	case modeDate:
+		switch field {
+		case 0:
+			return 1, daysIn(t.value.Year(), t.value.Month())
+		case 1:
+			return 1, 12
+		}
+		return 1, 9999
+	case modeTime:
+		if field == 0 {
+			return 0, 23
+		}
+		return 0, 59
+	}
+	if field == 0 {
+		return 0, defaults.MaxDurationHours
+	}
+	return 0, 59
+}
+
+// fieldValue возвращает текущее значение поля
+func (t *DateTimeTask) fieldValue(field int) int {
+	switch t.mode {
+	case modeDate:
+		return [3]int{t.value.Day(), int(t.value.Month()), t.value.Year()}[field]
+	case modeTime:
+		return [3]int{t.value.Hour(), t.value.Minute(), t.value.Second()}[field]
+	}
+	parts := [3]int{
+		int(t.duration / time.Hour),
+		int(t.duration % time.Hour / time.Minute),
+		int(t.duration % time.Minute / time.Second),
+	}
+	return parts[field]
+}
+
+// setField записывает значение поля, если оно входит в допустимый диапазон.
+// При смене месяца или года день ограничивается длиной месяца.
+//
+// @return true, если значение записано
+func (t *DateTimeTask) setField(field, number int) bool {
+	low, high := t.fieldLimits(field)
+	if field == 0 && t.mode == modeDate {
+		high = 31
+	}
+	if number < low || number > high {
+		return false
+	}
+
+	parts := [3]int{t.fieldValue(0), t.fieldValue(1), 0}
+	if t.fieldCount() == 3 {
+		parts[2] = t.fieldValue(2)
+	}
+	parts[field] = number
+
+	switch t.mode {
+	case modeDate:
+		day, month, year := parts[0], time.Month(parts[1]), parts[2]
+		t.value = time.Date(year, month, min(day, daysIn(year, month)), 0, 0, 0, 0, time.Local)
+	case modeTime:
+		t.value = time.Date(0, time.January, 1, parts[0], parts[1], parts[2], 0, time.UTC)
+	default:
+		t.duration = time.Duration(parts[0])*time.Hour + time.Duration(parts[1])*time.Minute +
+			time.Duration(parts[2])*time.Second
+	}
+	return true
+}
+
+// step изменяет активное поле на delta единиц с переносом в соседние поля
+// и приводит результат к границам диапазона.
+func (t *DateTimeTask) step(delta int) {
+	t.resetTyped()
+	switch t.mode {
+	case modeDate:
+		switch t.field {
+		case 0:
+			t.value = t.value.AddDate(0, 0, delta)
+		case 1:
+			t.value = addMonths(t.value, delta)
+		default:
+			t.value = addMonths(t.value, 12*delta)
+		}
+	case modeTime:
+		unit := [3]time.Duration{time.Hour, time.Minute, time.Second}[t.field]
+		day := 24 * time.Hour
+		offset := time.Duration(t.value.Hour())*time.Hour + time.Duration(t.value.Minute())*time.Minute +
+			time.Duration(t.value.Second())*time.Second + time.Duration(delta)*unit
+		if t.hasMin || t.hasMax {
+			// С границами время не переходит через полночь
+			offset = max(min(offset, day-time.Second), 0)
+		} else {
+			offset = (offset%day + day) % day
+		}
+		t.value = time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC).Add(offset)
+	default:
+		unit := [3]time.Duration{time.Hour, time.Minute, time.Second}[t.field]
+		t.duration = max(min(t.duration+time.Duration(delta)*unit, durationLimit), 0)
+	}
+	t.clamp()
+	t.validationErr = nil
+}
+
+// typeDigit добавляет цифру к значению активного поля. Когда число не помещается
+// в поле, ввод начинается заново с этой цифры; заполненное поле передаёт ввод следующему.
+// Границы диапазона проверяются при подтверждении.
+func (t *DateTimeTask) typeDigit(digit int) {
+	_, high := t.fieldLimits(t.field)
+	width := len(strconv.Itoa(high))
+
+	number := t.typed*10 + digit
+	if t.typedDigits == 0 || t.typedDigits >= width || !t.setField(t.field, number) {
+		t.typed, t.typedDigits = 0, 0
+		number = digit
+		if !t.setField(t.field, number) {
+			// Например, ноль в поле дня: запоминаем цифру до следующей
+			t.typed, t.typedDigits = digit, 1
+			return
+		}
+	}
+	t.typed = number
+	t.typedDigits++
+	t.validationErr = nil
+
+	if t.typedDigits >= width && t.field < t.fieldCount()-1 {
+		t.field++
+		t.resetTyped()
+	}
+}
+
+// resetTyped сбрасывает набор цифр
+func (t *DateTimeTask) resetTyped() {
+	t.typed, t.typedDigits = 0, 0
+}
+
+// submit завершает задачу текущим значением, если оно входит в диапазон
+func (t *DateTimeTask) submit() {
+	if err := t.checkRange(t.value, t.duration); err != nil {
+		t.validationErr = err
+		return
+	}
+	t.finish()
+}
+
+// finish завершает задачу текущим значением
+func (t *DateTimeTask) finish() {
+	th := t.Theme()
+	t.ready = true
+	t.done = true
+	t.icon = th.Icons.Done
+	t.validationErr = nil
+	t.resetTyped()
+	t.finalValue = t.display()
+	t.SetError(nil)
+}
+
+// handleCancel обрабатывает отмену выбора
+func (t *DateTimeTask) handleCancel() (Task, tea.Cmd) {
+	th := t.Theme()
+	t.SetError(terrors.NewCancelError(t.title).WithContext("value", t.format()))
+	t.done = true
+	t.icon = th.Icons.Cancelled
+	t.finalValue = th.Styles.ErrorMessage.Render(defaults.CancelShort)
+	return t, nil
+}
+
+// applyDefaultValue применяет значение по умолчанию при истечении таймера
+func (t *DateTimeTask) applyDefaultValue() {
+	if t.defaultValue == nil {
+		return
+	}
+	th := t.Theme()
+	if err := t.ApplyAnswer(t.defaultValue); err != nil {
+		t.SetError(err)
+		t.done = true
+		t.icon = th.Icons.Error
+		t.finalValue = th.Styles.ErrorMessage.Render(defaults.ErrDefaultValueInvalid)
+		return
+	}
+	t.timedOut = true
+}
+
+// parseAnswer разбирает ответ: time.Time или строку с датой ("2006-01-02", "02.01.2006")
+// и временем ("15:04", "15:04:05"), time.Duration или строку в формате time.ParseDuration.
+//
+// @param answer Ответ
+// @return Дата или время, длительность и ошибка разбора
+func (t *DateTimeTask) parseAnswer(answer interface{}) (time.Time, time.Duration, error) {
+	text, isText := answer.(string)
+	text = strings.TrimSpace(text)
+
+	switch t.mode {
+	case modeDuration:
+		switch v := answer.(type) {
+		case time.Duration:
+			return time.Time{}, v, nil
+		case string:
+			duration, err := time.ParseDuration(text)
+			if err != nil {
+				return time.Time{}, 0, fmt.Errorf(defaults.ErrDateTimeFormat, defaults.DurationFormatHint)
+			}
+			return time.Time{}, duration, nil
+		}
+	case modeDate:
+		if v, ok := answer.(time.Time); ok {
+			return v, 0, nil
+		}
+		if isText {
+			for _, layout := range []string{defaults.DateLayout, "02.01.2006"} {
+				if value, err := time.ParseInLocation(layout, text, time.Local); err == nil {
+					return value, 0, nil
+				}
+			}
+			return time.Time{}, 0, fmt.Errorf(defaults.ErrDateTimeFormat, defaults.DateFormatHint)
+		}
+	default:
+		if v, ok := answer.(time.Time); ok {
+			return v, 0, nil
+		}
+		if isText {
+			for _, layout := range []string{defaults.TimeLayout, defaults.TimeSecondsLayout} {
+				if value, err := time.Parse(layout, text); err == nil {
+					return value, 0, nil
+				}
+			}
+			return time.Time{}, 0, fmt.Errorf(defaults.ErrDateTimeFormat, t.formatHint())
+		}
+	}
+	return time.Time{}, 0, fmt.Errorf(defaults.ErrAnswerUnsupportedType, answer)
+}
+
+// resolveAnswer разбирает ответ, приводит его к точности полей и проверяет границы диапазона
+//
+// @param answer Ответ
+// @return Дата или время, длительность и ошибка разбора или выхода за границы
+func (t *DateTimeTask) resolveAnswer(answer interface{}) (time.Time, time.Duration, error) {
+	value, duration, err := t.parseAnswer(answer)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	if t.mode == modeDuration && duration < 0 {
+		return time.Time{}, 0, fmt.Errorf(defaults.ErrValueBelowMin, formatDurationUnits(0))
+	}
+	value, duration = t.normalize(value), t.normalizeDuration(duration)
+	if err := t.checkRange(value, duration); err != nil {
+		return time.Time{}, 0, err
+	}
+	return value, duration, nil
+}
+
+// Run запускает задачу и таймер тайм-аута
+func (t *DateTimeTask) Run() tea.Cmd {
+	t.ensureValue()
+	if t.timeoutEnabled && t.timeoutManager != nil {
+		return t.timeoutManager.StartTickerAndTimeout()
+	}
+	return nil
+}
+
+// Update обрабатывает нажатия клавиш для выбора поля и изменения значения.
+func (t *DateTimeTask) Update(msg tea.Msg) (Task, tea.Cmd) {
+	if t.done {
+		return t, nil
+	}
+	t.ensureValue()
+
+	switch msg := msg.(type) {
+	case TimeoutMsg:
+		t.applyDefaultValue()
+		return t, nil
+	case TickMsg:
+		if t.timeoutEnabled && t.timeoutManager != nil && t.timeoutManager.IsActive() {
+			return t, t.timeoutManager.StartTicker()
+		}
+		return t, nil
+	case tea.KeyMsg:
+		pressed := msg.String()
+		// Любое изменение значения отключает таймер
+		if t.timeoutEnabled && t.timeoutManager != nil && t.timeoutManager.IsActive() {
+			if pressed != "ctrl+c" && pressed != "esc" && pressed != "enter" {
+				t.DisableTimeout()
+			}
+		}
+
+		if msg.Type == tea.KeyRunes && !msg.Alt && len(msg.Runes) == 1 && msg.Runes[0] >= '0' && msg.Runes[0] <= '9' {
+			t.typeDigit(int(msg.Runes[0] - '0'))
+			return t, nil
+		}
+
+		switch pressed {
+		case "up", "Up":
+			t.step(1)
+		case "down", "Down":
+			t.step(-1)
+		case "pgup":
+			t.step(10)
+		case "pgdown":
+			t.step(-10)
+		case "left", "Left", "shift+tab":
+			t.resetTyped()
+			if t.field > 0 {
+				t.field--
+			} else if isBackKey(pressed) {
+				t.requestBack()
+			}
+		case "right", "Right":
+			t.resetTyped()
+			t.field = min(t.field+1, t.fieldCount()-1)
+		case "tab":
+			t.resetTyped()
+			t.field = (t.field + 1) % t.fieldCount()
+		case "backspace":
+			t.resetTyped()
+		case "enter":
+			t.submit()
+		case "esc", "Esc", "ctrl+c", "Ctrl+C":
+			return t.handleCancel()
+		}
+	}
+	return t, nil
+}
+
+// View отрисовывает поля значения, для даты - ещё и календарь месяца.
+//
+// @param width Ширина макета для отображения
+// @return Строка с отформатированным представлением задачи
+func (t *DateTimeTask) View(width int) string {
+	th := t.Theme()
+	if t.done {
+		return t.FinalView(width)
+	}
+	t.ensureValue()
+
+	var sb strings.Builder
+	titlePrefix := t.InProgressPrefix()
+	titleView := titlePrefix + th.Styles.ActiveTitle.Render(t.title)
+	if timer := t.RenderTimer(); timer != "" {
+		titleView = ui.AlignTextToRight(titleView, timer, width)
+	}
+	sb.WriteString(titleView + "\n")
+	sb.WriteString(renderSelectionSeparator(th, width, t.showSelectionSeparator, titlePrefix))
+
+	linePrefix := performance.FastConcat(performance.RepeatEfficient(" ", ui.MainLeftIndent), th.Glyphs.Vertical, "  ")
+	sb.WriteString(linePrefix + t.renderFields(th) + "\n")
+	if t.mode == modeDate {
+		for _, line := range t.renderCalendar(th) {
+			sb.WriteString(linePrefix + line + "\n")
+		}
+	}
+	if t.hasMin || t.hasMax {
+		sb.WriteString(linePrefix + th.Styles.Subtle.Render(t.rangeText()) + "\n")
+	}
+
+	helpIndent := performance.RepeatEfficient(" ", ui.MainLeftIndent)
+	sb.WriteString("\n" + th.DrawLine(width))
+	if t.validationErr != nil {
+		sb.WriteString(th.Styles.ErrorMessage.Render(helpIndent+t.validationErr.Error()) + "\n")
+	}
+	sb.WriteString(th.Styles.Subtle.Render(indentLines(formatNavigationHelpText(defaults.DateTimeHelp, width), helpIndent)))
+	return sb.String()
+}
+
+// renderFields отрисовывает строку полей значения с выделенным активным полем
+func (t *DateTimeTask) renderFields(th *ui.Theme) string {
+	texts := make([]string, t.fieldCount())
+	for i := range texts {
+		number := t.fieldValue(i)
+		switch {
+		case t.mode == modeDate && i == 1:
+			texts[i] = nameAt(defaults.MonthNamesGenitive, number-1, strconv.Itoa(number))
+		case t.mode == modeDate && i == 2:
+			texts[i] = fmt.Sprintf("%04d", number)
+		case t.mode == modeDuration && i == 0, t.mode == modeDate:
+			texts[i] = strconv.Itoa(number)
+		default:
+			texts[i] = fmt.Sprintf("%02d", number)
+		}
+		switch {
+		case i == t.field:
+			texts[i] = t.activeStyle.Render("[" + texts[i] + "]")
+		case t.mode != modeTime:
+			texts[i] = " " + texts[i] + " "
+		}
+	}
+
+	switch t.mode {
+	case modeDate:
+		weekday := nameAt(defaults.WeekdayNames, weekdayIndex(t.value), t.value.Weekday().String())
+		return strings.Join(texts, "") + "  " + th.Styles.Subtle.Render(weekday)
+	case modeTime:
+		return strings.Join(texts, ":")
+	}
+	units := []string{defaults.DurationHoursUnit, defaults.DurationMinutesUnit, defaults.DurationSecondsUnit}
+	var sb strings.Builder
+	for i, text := range texts {
+		sb.WriteString(strings.TrimRight(text, " ") + " " + th.Styles.Subtle.Render(units[i]) + "  ")
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// renderCalendar отрисовывает календарь месяца выбранной даты: заголовок, дни недели
+// и недели с понедельника. Выбранный день выделяется, дни вне диапазона приглушаются.
+func (t *DateTimeTask) renderCalendar(th *ui.Theme) []string {
+	year, month := t.value.Year(), t.value.Month()
+	lines := []string{
+		nameAt(defaults.MonthNames, int(month)-1, month.String()) + " " + strconv.Itoa(year),
+	}
+
+	header := make([]string, 7)
+	for i := range header {
+		header[i] = fmt.Sprintf("%-2s", nameAt(defaults.WeekdayShortNames, i, ""))
+	}
+	lines = append(lines, th.Styles.Subtle.Render(strings.Join(header, " ")))
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	cells := make([]string, weekdayIndex(first))
+	for i := range cells {
+		cells[i] = "  "
+	}
+	for day := 1; day <= daysIn(year, month); day++ {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+		cell := fmt.Sprintf("%2d", day)
+		switch {
+		case day == t.value.Day():
+			cell = t.activeStyle.Render(cell)
+		case t.checkRange(date, 0) != nil:
+			cell = th.Styles.Disabled.Render(cell)
+		}
+		cells = append(cells, cell)
+	}
+	for start := 0; start < len(cells); start += 7 {
+		lines = append(lines, strings.Join(cells[start:min(start+7, len(cells))], " "))
+	}
+	return lines
+}
+
+// rangeText возвращает строку с допустимым диапазоном значений
+func (t *DateTimeTask) rangeText() string {
+	low, high := "…", "…"
+	if t.mode == modeDuration {
+		if t.hasMin {
+			low = formatDurationUnits(t.minDuration)
+		}
+		if t.hasMax {
+			high = formatDurationUnits(t.maxDuration)
+		}
+	} else {
+		if t.hasMin {
+			low = t.formatTime(t.minValue)
+		}
+		if t.hasMax {
+			high = t.formatTime(t.maxValue)
+		}
+	}
+	return fmt.Sprintf(defaults.DateTimeRangeFormat, low, high)
+}
+
+// FinalView отображает итог задачи с выбранным значением
+func (t *DateTimeTask) FinalView(width int) string {
+	th := t.Theme()
+	result := t.BaseTask.FinalView(width)
+	if t.icon == th.Icons.Done {
+		result += "\n" + th.DrawSummaryLine(t.display()+t.prefill.note(t.StateAnswer()))
+	}
+	return result
+}
+
+// format возвращает значение в формате ответов и файла состояния
+func (t *DateTimeTask) format() string {
+	if t.mode == modeDuration {
+		return t.duration.String()
+	}
+	return t.formatTime(t.value)
+}
+
+// formatTime возвращает дату или время в формате ответов
+func (t *DateTimeTask) formatTime(value time.Time) string {
+	switch {
+	case t.mode == modeDate:
+		return value.Format(defaults.DateLayout)
+	case t.seconds:
+		return value.Format(defaults.TimeSecondsLayout)
+	}
+	return value.Format(defaults.TimeLayout)
+}
+
+// formatHint возвращает подсказку о формате значения
+func (t *DateTimeTask) formatHint() string {
+	switch {
+	case t.mode == modeDate:
+		return defaults.DateFormatHint
+	case t.mode == modeDuration:
+		return defaults.DurationFormatHint
+	case t.seconds:
+		return defaults.TimeSecondsFormatHint
+	}
+	return defaults.TimeFormatHint
+}
+
+// display возвращает значение для итогового вида: дату с названиями месяца
+// и дня недели на языке интерфейса, длительность - с единицами измерения
+func (t *DateTimeTask) display() string {
+	switch t.mode {
+	case modeDate:
+		month := nameAt(defaults.MonthNamesGenitive, int(t.value.Month())-1, t.value.Month().String())
+		weekday := nameAt(defaults.WeekdayNames, weekdayIndex(t.value), t.value.Weekday().String())
+		return fmt.Sprintf("%d %s %d, %s", t.value.Day(), month, t.value.Year(), weekday)
+	case modeDuration:
+		return formatDurationUnits(t.duration)
+	}
+	return t.formatTime(t.value)
+}
+
+// kind возвращает вид задачи для результатов очереди
+func (t *DateTimeTask) kind() string {
+	switch t.mode {
+	case modeDate:
+		return common.KindDate
+	case modeTime:
+		return common.KindTime
+	}
+	return common.KindDuration
+}
+
+// formatDurationUnits возвращает длительность с единицами измерения на языке интерфейса ("1 ч 30 мин")
+func formatDurationUnits(duration time.Duration) string {
+	hours := int(duration / time.Hour)
+	minutes := int(duration % time.Hour / time.Minute)
+	seconds := int(duration % time.Minute / time.Second)
+
+	parts := make([]string, 0, 3)
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s", hours, defaults.DurationHoursUnit))
+	}
+	if minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s", minutes, defaults.DurationMinutesUnit))
+	}
+	if seconds > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s", seconds, defaults.DurationSecondsUnit))
+	}
+	if len(parts) == 0 {
+		return "0 " + defaults.DurationMinutesUnit
+	}
+	return strings.Join(parts, " ")
+}
+
+// addMonths сдвигает дату на указанное число месяцев, ограничивая день длиной месяца
+// (31 января + 1 месяц = 28 или 29 февраля)
+func addMonths(value time.Time, months int) time.Time {
+	year, month, day := value.Date()
+	total := year*12 + int(month) - 1 + months
+	year, month = total/12, time.Month(total%12+1)
+	if year < 1 {
+		year, month = 1, time.January
+	}
+	return time.Date(year, month, min(day, daysIn(year, month)), 0, 0, 0, 0, value.Location())
+}
+
+// daysIn возвращает количество дней в месяце
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// weekdayIndex возвращает номер дня недели, начиная с понедельника (0)
+func weekdayIndex(value time.Time) int {
+	return (int(value.Weekday()) + 6) % 7
+}
+
+// nameAt возвращает элемент списка названий или запасное значение, если его нет
+func nameAt(names []string, index int, fallback string) string {
+	if index < 0 || index >= len(names) {
+		return fallback
+	}
+	return names[index]
+}
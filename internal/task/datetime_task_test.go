@@ -0,0 +1,156 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// typeDigits набирает цифры в задаче выбора даты и времени
+func typeDigits(task *DateTimeTask, digits string) {
+	for _, r := range digits {
+		task.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// TestDateTaskNavigation проверяет изменение полей даты с переносом и календарь месяца
+func TestDateTaskNavigation(t *testing.T) {
+	clock := &manualClock{Clock: common.SystemClock, now: time.Date(2024, time.January, 31, 15, 4, 5, 0, time.Local)}
+	date := NewDateTask("Дата начала")
+	date.SetClock(clock)
+	date.Run()
+	assert.Equal(t, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local), date.GetTime(), "по умолчанию - текущая дата")
+
+	date.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, "2024-02-01", date.GetValue(), "день переносится в следующий месяц")
+
+	date.Update(tea.KeyMsg{Type: tea.KeyDown})
+	date.Update(tea.KeyMsg{Type: tea.KeyRight})
+	date.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, "2024-02-29", date.GetValue(), "день ограничивается длиной месяца")
+
+	date.Update(tea.KeyMsg{Type: tea.KeyRight})
+	date.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, "2025-02-28", date.GetValue())
+
+	view := date.View(80)
+	assert.Contains(t, view, defaults.MonthNames[1]+" 2025", "заголовок календаря")
+	assert.Contains(t, view, defaults.MonthNamesGenitive[1])
+	assert.Contains(t, view, defaults.WeekdayShortNames[0])
+	assert.Contains(t, view, defaults.WeekdayNames[4], "28 февраля 2025 - пятница")
+
+	date.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, date.IsDone())
+	assert.Contains(t, date.FinalView(80), "28 "+defaults.MonthNamesGenitive[1]+" 2025")
+	result := date.Result()
+	assert.Equal(t, common.KindDate, result.Kind)
+	assert.Equal(t, "2025-02-28", result.Value)
+}
+
+// TestTimeTaskDigitsAndWrap проверяет ввод цифрами и переход времени через полночь
+func TestTimeTaskDigitsAndWrap(t *testing.T) {
+	clock := &manualClock{Clock: common.SystemClock, now: time.Date(2024, time.May, 1, 23, 59, 30, 0, time.UTC)}
+	reboot := NewTimeTask("Время перезагрузки")
+	reboot.SetClock(clock)
+	reboot.Run()
+	assert.Equal(t, "23:59", reboot.GetValue(), "текущее время с точностью до минуты")
+
+	reboot.Update(tea.KeyMsg{Type: tea.KeyRight})
+	reboot.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, "00:00", reboot.GetValue(), "минуты переносятся в часы по кругу")
+
+	reboot.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	typeDigits(reboot, "07")
+	assert.Equal(t, 1, reboot.field, "заполненное поле передаёт ввод следующему")
+	typeDigits(reboot, "3")
+	typeDigits(reboot, "0")
+	assert.Equal(t, "07:30", reboot.GetValue())
+
+	typeDigits(reboot, "9")
+	assert.Equal(t, "07:09", reboot.GetValue(), "число, не помещающееся в поле, начинает ввод заново")
+
+	reboot.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, reboot.IsDone())
+	assert.Equal(t, time.Date(0, time.January, 1, 7, 9, 0, 0, time.UTC), reboot.GetTime())
+	assert.Equal(t, common.KindTime, reboot.Result().Kind)
+}
+
+// TestDurationTaskRange проверяет границы диапазона длительности
+func TestDurationTaskRange(t *testing.T) {
+	interval := NewDurationTask("Интервал").WithDurationRange(time.Minute, 2*time.Hour)
+	interval.Run()
+	assert.Equal(t, time.Minute, interval.GetDuration(), "значение приводится к нижней границе")
+
+	interval.Update(tea.KeyMsg{Type: tea.KeyRight})
+	interval.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, time.Minute, interval.GetDuration(), "стрелки не выводят значение за границы")
+
+	interval.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	typeDigits(interval, "5")
+	interval.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, interval.IsDone(), "значение вне диапазона не подтверждается")
+	assert.Contains(t, interval.View(80), formatDurationUnits(2*time.Hour))
+
+	interval.Update(tea.KeyMsg{Type: tea.KeyDown})
+	interval.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, interval.IsDone())
+	assert.Equal(t, 2*time.Hour, interval.GetDuration(), "шаг стрелкой возвращает значение к верхней границе")
+}
+
+// TestDateTimeApplyAnswer проверяет разбор ответов и проверку границ
+func TestDateTimeApplyAnswer(t *testing.T) {
+	date := NewDateTask("Дата")
+	require.NoError(t, date.ApplyAnswer("15.03.2025"))
+	assert.Equal(t, "2025-03-15", date.Result().Value)
+
+	err := NewDateTask("Дата").ApplyAnswer("2025-13-01")
+	var taskErr *terrors.TaskError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, terrors.ErrorTypeValidation, taskErr.Type)
+
+	bounded := NewDateTask("Дата").WithRange(time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local), time.Time{})
+	assert.Error(t, bounded.ApplyAnswer("2024-12-31"))
+	assert.NoError(t, bounded.ApplyAnswer(time.Date(2025, 1, 1, 18, 0, 0, 0, time.Local)))
+
+	clock := NewTimeTask("Время")
+	require.NoError(t, clock.ApplyAnswer("12:34:56"))
+	assert.Equal(t, "12:34", clock.GetValue(), "без поля секунд время округляется до минуты")
+	withSeconds := NewTimeTask("Время").WithSeconds(true)
+	require.NoError(t, withSeconds.ApplyAnswer("12:34:56"))
+	assert.Equal(t, "12:34:56", withSeconds.GetValue())
+	assert.Error(t, NewTimeTask("Время").ApplyAnswer(42))
+
+	duration := NewDurationTask("Интервал")
+	require.NoError(t, duration.ApplyAnswer("1h30m"))
+	assert.Equal(t, "1h30m0s", duration.Result().Value)
+	assert.Error(t, NewDurationTask("Интервал").ApplyAnswer(-time.Second))
+
+	line := NewDurationTask("Интервал").WithDuration(6 * time.Hour)
+	assert.Contains(t, line.LinePrompt(), "[6h0m0s]")
+	require.NoError(t, line.ApplyLineAnswer(""))
+	assert.Equal(t, 6*time.Hour, line.GetDuration())
+}
+
+// TestDateTimeTimeoutAndCancel проверяет значение тайм-аута и отмену выбора
+func TestDateTimeTimeoutAndCancel(t *testing.T) {
+	date := NewDateTask("Дата").WithTimeout(time.Second, "2024-05-01")
+	date.Run()
+	date.Update(TimeoutMsg{})
+	require.True(t, date.IsDone())
+	assert.False(t, date.HasError())
+	assert.Equal(t, "2024-05-01", date.GetValue())
+
+	cancelled := NewTimeTask("Время")
+	cancelled.Run()
+	cancelled.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, cancelled.IsDone())
+	assert.True(t, cancelled.HasError())
+	assert.Nil(t, cancelled.Result().Value)
+}
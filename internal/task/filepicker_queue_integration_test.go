@@ -0,0 +1,67 @@
+package task_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makePickerDir создаёт каталог с файлами конфигурации для задачи выбора файла
+func makePickerDir(t *testing.T) string {
+	t.Helper()
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	for _, name := range []string{"network", "wireless.conf"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o644))
+	}
+	return dir
+}
+
+// TestFilePickerQueueHeadless проверяет выбор файлов ответами неинтерактивного режима и результаты очереди
+func TestFilePickerQueueHeadless(t *testing.T) {
+	dir := makePickerDir(t)
+	single := task.NewFilePickerTask("Конфигурация", dir).WithRoot(dir)
+	single.SetID("config")
+	multiple := task.NewFilePickerTask("Резервная копия", dir).WithRoot(dir).WithMultiple(true)
+	multiple.SetID("backup")
+
+	model := query.New("Файлы").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{
+		"config": "wireless.conf",
+		"backup": []interface{}{"network", filepath.Join(dir, "wireless.conf")},
+	})
+	model.AddTasks([]common.Task{single, multiple})
+
+	require.NoError(t, model.Run())
+	results := model.Results()
+	config, ok := results.ByID("config")
+	require.True(t, ok)
+	assert.Equal(t, common.KindPath, config.Kind)
+	assert.Equal(t, filepath.Join(dir, "wireless.conf"), config.Value)
+
+	backup, ok := results.ByID("backup")
+	require.True(t, ok)
+	assert.Equal(t, []string{filepath.Join(dir, "network"), filepath.Join(dir, "wireless.conf")}, backup.Values)
+}
+
+// TestFilePickerQueueOutsideRoot проверяет, что ответ с путём вне корня останавливает очередь
+func TestFilePickerQueueOutsideRoot(t *testing.T) {
+	dir := makePickerDir(t)
+	picker := task.NewFilePickerTask("Конфигурация", dir).WithRoot(dir)
+	after := task.NewFuncTask("После", func() error { return nil })
+
+	model := query.New("Файлы").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{
+		"Конфигурация": "../../etc/passwd",
+	})
+	model.AddTasks([]common.Task{picker, after})
+
+	require.Error(t, model.Run())
+	assert.True(t, picker.HasError())
+	assert.False(t, after.IsDone())
+}
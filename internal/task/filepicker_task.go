@@ -0,0 +1,834 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// hiddenToggleKey — клавиша, показывающая или скрывающая скрытые файлы (как в Midnight Commander)
+const hiddenToggleKey = "alt+."
+
+// fileEntry описывает элемент списка задачи выбора файлов
+type fileEntry struct {
+	name    string // Имя элемента в каталоге
+	path    string // Полный путь с разрешёнными символическими ссылками
+	isDir   bool   // Элемент является каталогом (или ссылкой на каталог)
+	current bool   // Пункт выбора текущего каталога в режиме выбора каталогов
+	outside bool   // Ссылка ведёт за пределы корневого каталога или никуда
+}
+
+// FilePickerTask - задача выбора файла или каталога в файловой системе.
+type FilePickerTask struct {
+	BaseTask
+	rootDir     string         // Корневой каталог, за пределы которого нельзя выйти ("" - без ограничений)
+	startDir    string         // Каталог, открываемый при запуске
+	root        string         // Корневой каталог с разрешёнными символическими ссылками
+	dir         string         // Текущий каталог
+	loaded      bool           // Текущий каталог прочитан с учётом настроек задачи
+	entries     []fileEntry    // Элементы текущего каталога
+	cursor      int            // Текущая позиция курсора
+	activeStyle lipgloss.Style // Стиль для активного элемента
+	// Viewport (окно просмотра) для ограничения количества отображаемых элементов
+	viewportSize  int
+	viewportStart int
+	showCounters  bool
+	patterns      []string  // Шаблоны имён файлов (glob)
+	extensions    []string  // Расширения файлов в нижнем регистре с точкой
+	showHidden    bool      // Показывать скрытые файлы
+	dirsOnly      bool      // Выбираются только каталоги
+	multiple      bool      // Разрешён выбор нескольких элементов
+	marked        []string  // Отмеченные пути в порядке отметки
+	selected      []string  // Выбранные пути
+	typed         []rune    // Символы быстрого перехода к имени
+	typedAt       time.Time // Время ввода последнего символа быстрого перехода
+	notice        error     // Ошибка последнего действия (например, чтения каталога)
+}
+
+// NewFilePickerTask создает новую задачу выбора файла или каталога.
+//
+// @param title Заголовок задачи
+// @param startDir Каталог, открываемый при запуске ("" - текущий рабочий каталог)
+// @return Указатель на новую задачу выбора файла
+func NewFilePickerTask(title, startDir string) *FilePickerTask {
+	return &FilePickerTask{
+		BaseTask:     NewBaseTask(title),
+		startDir:     startDir,
+		activeStyle:  ui.ActiveStyle,
+		viewportSize: defaults.DefaultFilePickerViewport,
+		showCounters: true,
+	}
+}
+
+// WithRoot ограничивает просмотр каталогом root и его подкаталогами.
+// Подняться выше корня нельзя, а символические ссылки за его пределы недоступны.
+//
+// @param root Корневой каталог
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithRoot(root string) *FilePickerTask {
+	t.rootDir = root
+	t.loaded = false
+	return t
+}
+
+// WithExtensions оставляет в списке только файлы с указанными расширениями (без учёта регистра).
+// Каталоги показываются всегда, чтобы по ним можно было перемещаться.
+//
+// @param extensions Расширения с точкой или без неё ("conf", ".json")
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithExtensions(extensions ...string) *FilePickerTask {
+	t.extensions = t.extensions[:0]
+	for _, ext := range extensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" || ext == "." {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		t.extensions = append(t.extensions, ext)
+	}
+	t.loaded = false
+	return t
+}
+
+// WithPatterns оставляет в списке только файлы, имена которых подходят под шаблоны
+// (синтаксис filepath.Match, например "*.conf" или "eth?"). Некорректные шаблоны игнорируются.
+// Вместе с WithExtensions файл показывается, если подходит под любое из условий.
+//
+// @param patterns Шаблоны имён файлов
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithPatterns(patterns ...string) *FilePickerTask {
+	t.patterns = t.patterns[:0]
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil || pattern == "" {
+			continue
+		}
+		t.patterns = append(t.patterns, pattern)
+	}
+	t.loaded = false
+	return t
+}
+
+// WithHidden показывает или скрывает файлы, имена которых начинаются с точки.
+// Во время выбора скрытые файлы переключаются клавишами Alt+.
+//
+// @param show Показывать скрытые файлы
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithHidden(show bool) *FilePickerTask {
+	t.showHidden = show
+	t.loaded = false
+	return t
+}
+
+// WithDirsOnly переключает задачу на выбор каталогов: файлы не показываются,
+// а первым пунктом списка становится текущий каталог.
+//
+// @param only Выбирать только каталоги
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithDirsOnly(only bool) *FilePickerTask {
+	t.dirsOnly = only
+	t.loaded = false
+	return t
+}
+
+// WithMultiple разрешает отметить несколько элементов пробелом и подтвердить выбор клавишей Enter.
+//
+// @param multiple Разрешить выбор нескольких элементов
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithMultiple(multiple bool) *FilePickerTask {
+	t.multiple = multiple
+	return t
+}
+
+// WithViewport устанавливает количество одновременно видимых элементов списка (0 = показать все).
+//
+// @param size Количество видимых элементов
+// @param showCounters Показывать количество скрытых элементов
+// @return Указатель на задачу для цепочки вызовов
+func (t *FilePickerTask) WithViewport(size int, showCounters ...bool) *FilePickerTask {
+	if size < 0 {
+		size = 0
+	}
+	t.viewportSize = size
+	t.showCounters = true
+	if len(showCounters) > 0 {
+		t.showCounters = showCounters[0]
+	}
+	t.updateViewport()
+	return t
+}
+
+// SetTheme задаёт тему оформления задачи и обновляет стиль активного элемента
+func (t *FilePickerTask) SetTheme(theme *ui.Theme) {
+	t.BaseTask.SetTheme(theme)
+	if theme != nil {
+		t.activeStyle = theme.Styles.Active
+	}
+}
+
+// GetSelected возвращает выбранные пути
+//
+// @return Список выбранных путей
+func (t *FilePickerTask) GetSelected() []string {
+	return append([]string(nil), t.selected...)
+}
+
+// GetPath возвращает выбранный путь (первый при выборе нескольких элементов)
+//
+// @return Выбранный путь или пустая строка
+func (t *FilePickerTask) GetPath() string {
+	if len(t.selected) == 0 {
+		return ""
+	}
+	return t.selected[0]
+}
+
+// resolveDirs возвращает корневой и стартовый каталоги с разрешёнными символическими ссылками.
+// Стартовый каталог за пределами корня или недоступный заменяется корнем.
+//
+// @return Корневой каталог, стартовый каталог и ошибка доступа к корню
+func (t *FilePickerTask) resolveDirs() (string, string, error) {
+	root := ""
+	if t.rootDir != "" {
+		resolved, err := realPath(t.rootDir)
+		if err != nil {
+			return "", "", terrors.NewConfigurationError(t.title, err, "root")
+		}
+		root = resolved
+	}
+
+	start := t.startDir
+	if start == "" && root == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", "", terrors.NewConfigurationError(t.title, err, "start_dir")
+		}
+		start = wd
+	}
+	if start != "" {
+		if resolved, err := realPath(start); err == nil && isWithin(root, resolved) {
+			return root, resolved, nil
+		}
+		if root == "" {
+			return "", "", terrors.NewConfigurationError(t.title, fmt.Errorf(defaults.ErrPathNotFound, start), "start_dir")
+		}
+	}
+	return root, root, nil
+}
+
+// ensureLoaded читает стартовый каталог, если настройки задачи изменились
+func (t *FilePickerTask) ensureLoaded() {
+	if t.loaded {
+		return
+	}
+	t.loaded = true
+	t.entries = nil
+	t.cursor = 0
+	t.viewportStart = 0
+
+	root, start, err := t.resolveDirs()
+	if err != nil {
+		t.notice = err
+		return
+	}
+	t.root = root
+	if t.dir == "" || !isWithin(root, t.dir) {
+		t.dir = start
+	}
+	if !t.openDir(t.dir, "") && t.dir != start {
+		t.openDir(start, "")
+	}
+}
+
+// openDir читает каталог и делает его текущим.
+// Курсор устанавливается на элемент с путём focus (если он есть в каталоге).
+//
+// @param dir Каталог с разрешёнными символическими ссылками
+// @param focus Путь элемента для установки курсора
+// @return true, если каталог удалось прочитать
+func (t *FilePickerTask) openDir(dir, focus string) bool {
+	entries, err := t.readEntries(dir)
+	if err != nil {
+		t.notice = terrors.NewFileSystemError(t.title, fmt.Errorf(defaults.ErrDirRead, dir), dir)
+		return false
+	}
+
+	t.dir = dir
+	t.entries = entries
+	t.notice = nil
+	t.typed = nil
+	t.cursor = 0
+	for i, entry := range entries {
+		if focus != "" && entry.path == focus && !entry.current {
+			t.cursor = i
+			break
+		}
+	}
+	t.viewportStart = 0
+	t.updateViewport()
+	return true
+}
+
+// readEntries возвращает отсортированные элементы каталога с учётом фильтров:
+// сначала каталоги, затем файлы, по имени без учёта регистра
+func (t *FilePickerTask) readEntries(dir string) ([]fileEntry, error) {
+	list, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]fileEntry, 0, len(list)+1)
+	for _, item := range list {
+		name := item.Name()
+		if !t.showHidden && strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		entry := fileEntry{name: name, path: filepath.Join(dir, name), isDir: item.IsDir()}
+		if item.Type()&fs.ModeSymlink != 0 {
+			target, err := filepath.EvalSymlinks(entry.path)
+			if err != nil {
+				// Ссылка никуда не ведёт: показываем её недоступной
+				entry.outside = true
+			} else {
+				entry.path = target
+				entry.outside = !isWithin(t.root, target)
+				if info, err := os.Stat(target); err == nil {
+					entry.isDir = info.IsDir()
+				}
+			}
+		}
+
+		if !entry.isDir && (t.dirsOnly || !t.matchesFilter(name)) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].isDir != entries[j].isDir {
+			return entries[i].isDir
+		}
+		return strings.ToLower(entries[i].name) < strings.ToLower(entries[j].name)
+	})
+
+	if t.dirsOnly {
+		current := fileEntry{name: ".", path: dir, isDir: true, current: true}
+		entries = append([]fileEntry{current}, entries...)
+	}
+	return entries, nil
+}
+
+// matchesFilter проверяет имя файла по расширениям и шаблонам задачи
+func (t *FilePickerTask) matchesFilter(name string) bool {
+	if len(t.extensions) == 0 && len(t.patterns) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, allowed := range t.extensions {
+		if ext == allowed {
+			return true
+		}
+	}
+	for _, pattern := range t.patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isSelectable сообщает, можно ли выбрать элемент в текущем режиме
+func (t *FilePickerTask) isSelectable(entry fileEntry) bool {
+	if entry.outside {
+		return false
+	}
+	return entry.isDir == t.dirsOnly
+}
+
+// currentEntry возвращает элемент под курсором
+func (t *FilePickerTask) currentEntry() (fileEntry, bool) {
+	if t.cursor < 0 || t.cursor >= len(t.entries) {
+		return fileEntry{}, false
+	}
+	return t.entries[t.cursor], true
+}
+
+// isMarked проверяет, отмечен ли путь
+func (t *FilePickerTask) isMarked(path string) bool {
+	for _, marked := range t.marked {
+		if marked == path {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleMark отмечает путь или снимает с него отметку
+func (t *FilePickerTask) toggleMark(path string) {
+	for i, marked := range t.marked {
+		if marked == path {
+			t.marked = append(t.marked[:i], t.marked[i+1:]...)
+			return
+		}
+	}
+	t.marked = append(t.marked, path)
+}
+
+// enterDir открывает каталог под курсором
+func (t *FilePickerTask) enterDir(entry fileEntry) {
+	if entry.outside {
+		t.notice = fmt.Errorf(defaults.ErrPathOutsideRoot, entry.name, t.root)
+		return
+	}
+	if entry.current {
+		return
+	}
+	t.openDir(entry.path, "")
+}
+
+// goUp открывает родительский каталог, если он не выше корня.
+//
+// @return true, если каталог сменился
+func (t *FilePickerTask) goUp() bool {
+	parent := filepath.Dir(t.dir)
+	if t.dir == t.root || parent == t.dir || !isWithin(t.root, parent) {
+		return false
+	}
+	return t.openDir(parent, t.dir)
+}
+
+// activate обрабатывает Enter на элементе под курсором: каталог открывается,
+// а файл (или текущий каталог в режиме выбора каталогов) завершает задачу
+func (t *FilePickerTask) activate() {
+	entry, ok := t.currentEntry()
+	if !ok {
+		return
+	}
+	if entry.isDir && !entry.current {
+		t.enterDir(entry)
+		return
+	}
+	t.choose(entry)
+}
+
+// choose завершает задачу выбранным элементом или отмечает его при множественном выборе
+func (t *FilePickerTask) choose(entry fileEntry) {
+	if !t.isSelectable(entry) {
+		if entry.outside {
+			t.notice = fmt.Errorf(defaults.ErrPathOutsideRoot, entry.name, t.root)
+		}
+		return
+	}
+	t.finish([]string{entry.path})
+}
+
+// confirm завершает множественный выбор отмеченными элементами.
+// Без отметок Enter действует как при одиночном выборе.
+func (t *FilePickerTask) confirm() {
+	if len(t.marked) == 0 {
+		t.activate()
+		return
+	}
+	t.finish(t.marked)
+}
+
+// finish завершает задачу выбранными путями
+func (t *FilePickerTask) finish(paths []string) {
+	th := t.Theme()
+	t.selected = append([]string(nil), paths...)
+	t.done = true
+	t.icon = th.Icons.Done
+	t.notice = nil
+	t.typed = nil
+	t.finalValue = strings.Join(t.selected, defaults.DefaultSeparator)
+	t.SetError(nil)
+}
+
+// handleCancel обрабатывает отмену выбора
+func (t *FilePickerTask) handleCancel() (Task, tea.Cmd) {
+	th := t.Theme()
+	t.SetError(terrors.NewCancelError(t.title).WithContext("dir", t.dir))
+	t.done = true
+	t.icon = th.Icons.Cancelled
+	t.finalValue = th.Styles.ErrorMessage.Render(defaults.CancelShort)
+	return t, nil
+}
+
+// jumpTo перемещает курсор к следующему элементу, имя которого начинается с набранных символов.
+// Символы, набранные после паузы TypeAheadResetDelay, начинают новый поиск;
+// повтор одной и той же буквы перебирает подходящие элементы по кругу.
+func (t *FilePickerTask) jumpTo(runes []rune) {
+	now := t.Clock().Now()
+	if now.Sub(t.typedAt) > defaults.TypeAheadResetDelay {
+		t.typed = nil
+	}
+	t.typedAt = now
+	t.typed = append(t.typed, runes...)
+
+	prefix := strings.ToLower(string(t.typed))
+	start := t.cursor
+	if allSameRune(t.typed) {
+		// Новая буква или её повтор: ищем начиная со следующего элемента
+		prefix = strings.ToLower(string(t.typed[:1]))
+		start = t.cursor + 1
+	}
+
+	for offset := 0; offset < len(t.entries); offset++ {
+		index := (start + offset) % len(t.entries)
+		entry := t.entries[index]
+		if !entry.current && strings.HasPrefix(strings.ToLower(entry.name), prefix) {
+			t.cursor = index
+			t.updateViewport()
+			return
+		}
+	}
+}
+
+// allSameRune проверяет, состоит ли набор из одного повторяющегося символа
+func allSameRune(runes []rune) bool {
+	for _, r := range runes {
+		if r != runes[0] {
+			return false
+		}
+	}
+	return len(runes) > 0
+}
+
+// updateViewport обновляет позицию viewport на основе текущего положения курсора
+func (t *FilePickerTask) updateViewport() {
+	if t.viewportSize <= 0 {
+		return
+	}
+	if t.cursor < t.viewportStart {
+		t.viewportStart = t.cursor
+	}
+	if t.cursor >= t.viewportStart+t.viewportSize {
+		t.viewportStart = t.cursor - t.viewportSize + 1
+	}
+	maxStart := len(t.entries) - t.viewportSize
+	if maxStart < 0 {
+		maxStart = 0
+	}
+	if t.viewportStart > maxStart {
+		t.viewportStart = maxStart
+	}
+	if t.viewportStart < 0 {
+		t.viewportStart = 0
+	}
+}
+
+// getVisibleRange возвращает диапазон видимых элементов с учетом viewport
+func (t *FilePickerTask) getVisibleRange() (int, int) {
+	if t.viewportSize <= 0 {
+		return 0, len(t.entries)
+	}
+	end := t.viewportStart + t.viewportSize
+	if end > len(t.entries) {
+		end = len(t.entries)
+	}
+	return t.viewportStart, end
+}
+
+// Run открывает стартовый каталог
+func (t *FilePickerTask) Run() tea.Cmd {
+	t.ensureLoaded()
+	return nil
+}
+
+// Update обрабатывает нажатия клавиш для навигации по каталогам и выбора.
+func (t *FilePickerTask) Update(msg tea.Msg) (Task, tea.Cmd) {
+	if t.done {
+		return t, nil
+	}
+	t.ensureLoaded()
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return t, nil
+	}
+
+	pressed := keyMsg.String()
+	if keyMsg.Type == tea.KeyRunes && !keyMsg.Alt && pressed != " " {
+		// Буквы и цифры переносят курсор к подходящему имени
+		t.jumpTo(keyMsg.Runes)
+		return t, nil
+	}
+
+	t.typed = nil
+	switch pressed {
+	case "up":
+		if t.cursor > 0 {
+			t.cursor--
+		} else if len(t.entries) > 0 {
+			t.cursor = len(t.entries) - 1
+		}
+		t.updateViewport()
+	case "down":
+		if t.cursor < len(t.entries)-1 {
+			t.cursor++
+		} else {
+			t.cursor = 0
+		}
+		t.updateViewport()
+	case "pgup", "home":
+		step := t.viewportSize
+		if step <= 0 || pressed == "home" {
+			step = len(t.entries)
+		}
+		t.cursor = max(t.cursor-step, 0)
+		t.updateViewport()
+	case "pgdown", "end":
+		step := t.viewportSize
+		if step <= 0 || pressed == "end" {
+			step = len(t.entries)
+		}
+		t.cursor = max(min(t.cursor+step, len(t.entries)-1), 0)
+		t.updateViewport()
+	case "right", "Right":
+		// Стрелка вправо открывает каталог, а при одиночном выборе ещё и выбирает файл
+		if entry, ok := t.currentEntry(); ok && entry.isDir && !entry.current {
+			t.enterDir(entry)
+		} else if ok && !t.multiple && !entry.isDir {
+			t.choose(entry)
+		}
+	case "enter":
+		if t.multiple {
+			t.confirm()
+		} else {
+			t.activate()
+		}
+	case " ":
+		if entry, ok := t.currentEntry(); ok {
+			if t.multiple && t.isSelectable(entry) {
+				t.toggleMark(entry.path)
+			} else {
+				t.choose(entry)
+			}
+		}
+	case "left", "Left", "backspace":
+		// В корне стрелка влево открывает предыдущую задачу очереди (если это разрешено)
+		if !t.goUp() && isBackKey(pressed) {
+			t.requestBack()
+		}
+	case hiddenToggleKey:
+		t.showHidden = !t.showHidden
+		focus := ""
+		if entry, ok := t.currentEntry(); ok {
+			focus = entry.path
+		}
+		t.openDir(t.dir, focus)
+	case "esc", "Esc", "ctrl+c", "Ctrl+C":
+		return t.handleCancel()
+	}
+	return t, nil
+}
+
+// View отрисовывает содержимое текущего каталога с выделением активного элемента.
+//
+// @param width Ширина макета для отображения
+// @return Строка с отформатированным представлением задачи
+func (t *FilePickerTask) View(width int) string {
+	th := t.Theme()
+	if t.done {
+		return t.FinalView(width)
+	}
+	t.ensureLoaded()
+
+	var sb strings.Builder
+	titlePrefix := t.InProgressPrefix()
+	sb.WriteString(titlePrefix + th.Styles.ActiveTitle.Render(t.title) + "\n")
+	sb.WriteString(renderSelectionSeparator(th, width, t.showSelectionSeparator, titlePrefix))
+
+	// Строка с текущим каталогом, набранными символами и числом отмеченных элементов
+	linePrefix := performance.FastConcat(performance.RepeatEfficient(" ", ui.MainLeftIndent), th.Glyphs.Vertical, "  ")
+	pathLine := linePrefix + th.Styles.Subtle.Render(t.dir)
+	if len(t.typed) > 0 {
+		pathLine += "  " + th.Styles.Input.Render(string(t.typed))
+	}
+	if t.multiple && len(t.marked) > 0 {
+		pathLine += "  " + th.Styles.Subtle.Render(fmt.Sprintf(defaults.FilePickerMarkedFormat, len(t.marked)))
+	}
+	sb.WriteString(pathLine + "\n")
+
+	startIdx, endIdx := t.getVisibleRange()
+	if t.viewportSize > 0 && startIdx > 0 {
+		indentPrefix := th.SelectItemPrefix("above")
+		indicator := fmt.Sprintf("%s %s", indentPrefix, th.Glyphs.UpArrow)
+		if t.showCounters {
+			indicator = fmt.Sprintf(defaults.ScrollAboveFormat, indentPrefix, th.Glyphs.UpArrow+" ", startIdx)
+		}
+		appendIndicatorWithPlainPipe(th, &sb, indicator)
+		sb.WriteString("\n")
+	}
+
+	for i := startIdx; i < endIdx; i++ {
+		sb.WriteString(t.renderEntry(th, i) + "\n")
+	}
+
+	if len(t.entries) == 0 {
+		sb.WriteString(linePrefix + th.Styles.Subtle.Render(defaults.FilePickerEmpty) + "\n")
+	}
+
+	if t.viewportSize > 0 && endIdx < len(t.entries) {
+		indentPrefix := th.SelectItemPrefix("below")
+		indicator := fmt.Sprintf("%s %s", indentPrefix, th.Glyphs.DownArrow)
+		if t.showCounters {
+			indicator = fmt.Sprintf(defaults.ScrollBelowFormat, indentPrefix, th.Glyphs.DownArrow+" ", len(t.entries)-endIdx)
+		}
+		appendIndicatorWithPlainPipe(th, &sb, indicator)
+		sb.WriteString("\n")
+	}
+
+	helpIndent := performance.RepeatEfficient(" ", ui.MainLeftIndent)
+	sb.WriteString("\n" + th.DrawLine(width))
+	if t.notice != nil {
+		sb.WriteString(th.Styles.ErrorMessage.Render(helpIndent+t.notice.Error()) + "\n")
+	}
+
+	helpText := defaults.FilePickerHelp
+	switch {
+	case t.multiple:
+		helpText = defaults.FilePickerMultiHelp
+	case t.dirsOnly:
+		helpText = defaults.FilePickerDirHelp
+	}
+	sb.WriteString(th.Styles.Subtle.Render(indentLines(formatNavigationHelpText(helpText, width), helpIndent)))
+	sb.WriteString("\n")
+	sb.WriteString(th.Styles.Subtle.Render(indentLines(formatNavigationHelpText(defaults.FilePickerKeysHelp, width), helpIndent)))
+	return sb.String()
+}
+
+// renderEntry отрисовывает строку элемента списка
+func (t *FilePickerTask) renderEntry(th *ui.Theme, index int) string {
+	entry := t.entries[index]
+
+	var itemPrefix string
+	switch {
+	case index == t.cursor:
+		itemPrefix = th.SelectItemPrefix("active")
+	case index < t.cursor:
+		itemPrefix = th.SelectItemPrefix("above")
+	default:
+		itemPrefix = th.SelectItemPrefix("below")
+	}
+
+	label := entry.name
+	if entry.isDir {
+		label += "/"
+	}
+	switch {
+	case entry.outside:
+		label = th.Styles.Disabled.Render(label)
+	case index == t.cursor:
+		label = t.activeStyle.Render(label)
+	}
+	if entry.current {
+		label += " " + th.Styles.Subtle.Render(defaults.FilePickerCurrentDir)
+	}
+
+	if !t.multiple {
+		return itemPrefix + label
+	}
+
+	// При множественном выборе выбираемые элементы отмечаются флажком
+	checkbox := "    "
+	if t.isSelectable(entry) {
+		checked := " "
+		if t.isMarked(entry.path) {
+			checked = th.Icons.Selected
+		}
+		checkbox = "[" + checked + "] "
+		if index == t.cursor {
+			checkbox = t.activeStyle.Render(checkbox)
+		}
+	}
+	return itemPrefix + checkbox + label
+}
+
+// FinalView отображает итог задачи: выбранные пути по одному в строке
+func (t *FilePickerTask) FinalView(width int) string {
+	th := t.Theme()
+	result := t.BaseTask.FinalView(width)
+	if t.icon == th.Icons.Done && len(t.selected) > 0 {
+		result += "\n"
+		for _, path := range t.selected {
+			result += th.DrawSummaryLine(path)
+		}
+	}
+	return result
+}
+
+// resolveAnswerPath проверяет путь из ответа так же, как выбор в списке:
+// путь должен существовать, находиться внутри корня и подходить под режим и фильтры задачи.
+// Относительный путь отсчитывается от стартового каталога.
+//
+// @param value Путь из ответа
+// @param start Стартовый каталог
+// @return Путь с разрешёнными символическими ссылками или ошибка
+func (t *FilePickerTask) resolveAnswerPath(value, start string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errors.New(defaults.ErrPathEmpty)
+	}
+
+	path := value
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(start, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf(defaults.ErrPathNotFound, value)
+	}
+	if !isWithin(t.root, resolved) {
+		return "", fmt.Errorf(defaults.ErrPathOutsideRoot, value, t.root)
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", fmt.Errorf(defaults.ErrPathNotFound, value)
+	}
+	switch {
+	case t.dirsOnly && !info.IsDir():
+		return "", fmt.Errorf(defaults.ErrPathNotDir, value)
+	case !t.dirsOnly && info.IsDir():
+		return "", fmt.Errorf(defaults.ErrPathIsDir, value)
+	case !t.dirsOnly && !t.matchesFilter(filepath.Base(path)):
+		return "", fmt.Errorf(defaults.ErrPathFiltered, value)
+	}
+	return resolved, nil
+}
+
+// realPath возвращает абсолютный путь с разрешёнными символическими ссылками
+func realPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
+
+// isWithin проверяет, находится ли путь внутри корневого каталога ("" - без ограничений)
+func isWithin(root, path string) bool {
+	if root == "" {
+		return true
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,227 @@
+package task
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock — часы, которые тест переводит вручную
+type manualClock struct {
+	common.Clock
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time { return c.now }
+
+// makeFileTree создаёт дерево каталогов для тестов и возвращает путь к "etc"
+// с разрешёнными символическими ссылками:
+//
+//	etc/config/{network, wireless.conf, .hidden}, etc/banner.txt,
+//	etc/escape -> ../outside, outside/secret
+func makeFileTree(t *testing.T) string {
+	t.Helper()
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	etc := filepath.Join(base, "etc")
+	require.NoError(t, os.MkdirAll(filepath.Join(etc, "config"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(base, "outside"), 0o755))
+	for _, name := range []string{"config/network", "config/wireless.conf", "config/.hidden", "banner.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(etc, name), []byte("x"), 0o644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(base, "outside", "secret"), []byte("x"), 0o644))
+	if err := os.Symlink(filepath.Join(base, "outside"), filepath.Join(etc, "escape")); err != nil {
+		t.Skipf("символические ссылки недоступны: %v", err)
+	}
+	return etc
+}
+
+// entryNames возвращает имена элементов текущего каталога задачи
+func entryNames(picker *FilePickerTask) []string {
+	names := make([]string, 0, len(picker.entries))
+	for _, entry := range picker.entries {
+		names = append(names, entry.name)
+	}
+	return names
+}
+
+// TestFilePickerNavigation проверяет переход по каталогам и выбор файла
+func TestFilePickerNavigation(t *testing.T) {
+	etc := makeFileTree(t)
+	picker := NewFilePickerTask("Конфигурация", etc)
+	picker.Run()
+
+	assert.Equal(t, []string{"config", "escape", "banner.txt"}, entryNames(picker), "каталоги перед файлами")
+	assert.Contains(t, picker.View(80), "config/")
+
+	picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, filepath.Join(etc, "config"), picker.dir)
+	assert.Equal(t, []string{"network", "wireless.conf"}, entryNames(picker), "скрытые файлы не показываются")
+
+	picker.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, etc, picker.dir)
+	assert.Equal(t, "config", picker.entries[picker.cursor].name, "курсор остаётся на покинутом каталоге")
+
+	picker.Update(tea.KeyMsg{Type: tea.KeyRight})
+	picker.Update(tea.KeyMsg{Type: tea.KeyDown})
+	picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, picker.IsDone())
+	assert.Equal(t, filepath.Join(etc, "config", "wireless.conf"), picker.GetPath())
+	assert.Contains(t, picker.FinalView(120), "wireless.conf")
+	assert.Equal(t, common.KindPath, picker.Result().Kind)
+}
+
+// TestFilePickerRoot проверяет, что за пределы корня нельзя выйти ни в списке, ни ответом
+func TestFilePickerRoot(t *testing.T) {
+	etc := makeFileTree(t)
+	outside := filepath.Join(filepath.Dir(etc), "outside")
+
+	picker := NewFilePickerTask("Конфигурация", outside).WithRoot(etc)
+	picker.Run()
+	assert.Equal(t, etc, picker.dir, "стартовый каталог вне корня заменяется корнем")
+
+	picker.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, etc, picker.dir, "подняться выше корня нельзя")
+
+	picker.Update(tea.KeyMsg{Type: tea.KeyDown})
+	require.Equal(t, "escape", picker.entries[picker.cursor].name)
+	picker.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, etc, picker.dir, "ссылка за пределы корня не открывается")
+	assert.Contains(t, picker.View(80), "escape")
+	assert.NotNil(t, picker.notice)
+
+	for _, answer := range []string{"../outside/secret", "escape/secret", filepath.Join(outside, "secret")} {
+		err := NewFilePickerTask("Конфигурация", etc).WithRoot(etc).ApplyAnswer(answer)
+		var taskErr *terrors.TaskError
+		require.True(t, errors.As(err, &taskErr), answer)
+		assert.Equal(t, terrors.ErrorTypeValidation, taskErr.Type)
+	}
+
+	accepted := NewFilePickerTask("Конфигурация", etc).WithRoot(etc)
+	require.NoError(t, accepted.ApplyAnswer("config/network"))
+	assert.Equal(t, filepath.Join(etc, "config", "network"), accepted.GetPath())
+}
+
+// TestFilePickerFilters проверяет фильтры по расширению и шаблону и переключение скрытых файлов
+func TestFilePickerFilters(t *testing.T) {
+	etc := makeFileTree(t)
+	config := filepath.Join(etc, "config")
+
+	byExt := NewFilePickerTask("Файл", config).WithExtensions("CONF")
+	byExt.Run()
+	assert.Equal(t, []string{"wireless.conf"}, entryNames(byExt))
+	assert.Error(t, byExt.ApplyAnswer("network"), "файл вне фильтра не принимается ответом")
+
+	byPattern := NewFilePickerTask("Файл", config).WithPatterns("net*", "[")
+	byPattern.Run()
+	assert.Equal(t, []string{"network"}, entryNames(byPattern), "некорректный шаблон игнорируется")
+
+	hidden := NewFilePickerTask("Файл", config)
+	hidden.Run()
+	hidden.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'.'}, Alt: true})
+	assert.Equal(t, []string{".hidden", "network", "wireless.conf"}, entryNames(hidden))
+	hidden.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'.'}, Alt: true})
+	assert.Equal(t, []string{"network", "wireless.conf"}, entryNames(hidden))
+}
+
+// TestFilePickerDirsOnly проверяет выбор каталогов
+func TestFilePickerDirsOnly(t *testing.T) {
+	etc := makeFileTree(t)
+
+	picker := NewFilePickerTask("Каталог", etc).WithRoot(etc).WithDirsOnly(true)
+	picker.Run()
+	assert.Equal(t, []string{".", "config", "escape"}, entryNames(picker))
+	assert.Contains(t, picker.View(80), defaults.FilePickerCurrentDir)
+
+	picker.Update(tea.KeyMsg{Type: tea.KeyDown})
+	picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, picker.IsDone(), "Enter открывает каталог")
+	assert.Equal(t, filepath.Join(etc, "config"), picker.dir)
+
+	picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, picker.IsDone(), "Enter на текущем каталоге выбирает его")
+	assert.Equal(t, filepath.Join(etc, "config"), picker.GetPath())
+
+	space := NewFilePickerTask("Каталог", etc).WithDirsOnly(true)
+	space.Run()
+	space.Update(tea.KeyMsg{Type: tea.KeyDown})
+	space.Update(tea.KeyMsg{Type: tea.KeySpace})
+	require.True(t, space.IsDone(), "пробел выбирает каталог под курсором")
+	assert.Equal(t, filepath.Join(etc, "config"), space.GetPath())
+
+	assert.Error(t, NewFilePickerTask("Каталог", etc).WithDirsOnly(true).ApplyAnswer("banner.txt"))
+	assert.Error(t, NewFilePickerTask("Файл", etc).ApplyAnswer("config"), "каталог не принимается как файл")
+}
+
+// TestFilePickerMultiple проверяет отметку нескольких файлов
+func TestFilePickerMultiple(t *testing.T) {
+	etc := makeFileTree(t)
+	config := filepath.Join(etc, "config")
+
+	picker := NewFilePickerTask("Файлы", config).WithMultiple(true)
+	picker.Run()
+	picker.Update(tea.KeyMsg{Type: tea.KeySpace})
+	picker.Update(tea.KeyMsg{Type: tea.KeyDown})
+	picker.Update(tea.KeyMsg{Type: tea.KeySpace})
+	assert.Contains(t, picker.View(80), "2")
+	picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	require.True(t, picker.IsDone())
+	expected := []string{filepath.Join(config, "network"), filepath.Join(config, "wireless.conf")}
+	assert.Equal(t, expected, picker.GetSelected())
+	assert.Equal(t, expected, picker.Result().Values)
+
+	answered := NewFilePickerTask("Файлы", config).WithMultiple(true)
+	require.NoError(t, answered.ApplyAnswer("network, wireless.conf"))
+	assert.Equal(t, expected, answered.GetSelected())
+	assert.Error(t, NewFilePickerTask("Файлы", config).WithMultiple(true).ApplyAnswer([]string{}))
+}
+
+// TestFilePickerTypeAhead проверяет быстрый переход к имени по набранным символам
+func TestFilePickerTypeAhead(t *testing.T) {
+	etc := makeFileTree(t)
+	require.NoError(t, os.WriteFile(filepath.Join(etc, "config", "wan"), []byte("x"), 0o644))
+	clock := &manualClock{Clock: common.SystemClock, now: time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)}
+
+	picker := NewFilePickerTask("Файл", filepath.Join(etc, "config"))
+	picker.SetClock(clock)
+	picker.Run()
+	typeRune := func(r rune) {
+		picker.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	typeRune('w')
+	assert.Equal(t, "wan", picker.entries[picker.cursor].name)
+	typeRune('i')
+	assert.Equal(t, "wireless.conf", picker.entries[picker.cursor].name)
+
+	clock.now = clock.now.Add(2 * defaults.TypeAheadResetDelay)
+	typeRune('n')
+	assert.Equal(t, "network", picker.entries[picker.cursor].name, "после паузы поиск начинается заново")
+
+	clock.now = clock.now.Add(2 * defaults.TypeAheadResetDelay)
+	typeRune('w')
+	typeRune('w')
+	assert.Equal(t, "wireless.conf", picker.entries[picker.cursor].name, "повтор буквы перебирает совпадения")
+}
+
+// TestFilePickerCancel проверяет отмену выбора
+func TestFilePickerCancel(t *testing.T) {
+	etc := makeFileTree(t)
+	picker := NewFilePickerTask("Файл", etc)
+	picker.Run()
+	picker.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, picker.IsDone())
+	assert.True(t, picker.HasError())
+	assert.Empty(t, picker.GetSelected())
+}
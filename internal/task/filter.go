@@ -0,0 +1,261 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// filterKey — клавиша, включающая режим фильтра в задачах выбора
+const filterKey = "/"
+
+// itemFilter сужает список задачи выбора по нечёткому совпадению запроса
+// с названием, ключом или описанием элемента.
+// Порядок элементов сохраняется, поэтому индексы остаются сопоставимыми с курсором.
+type itemFilter struct {
+	editing   bool             // Включён режим ввода запроса
+	query     []rune           // Текущий запрос
+	matches   []int            // Индексы подходящих элементов по возрастанию
+	matched   map[int]struct{} // Набор подходящих индексов для быстрой проверки
+	positions map[int][]int    // Позиции совпавших символов в отображаемом названии
+}
+
+// applied сообщает, сужает ли фильтр список в данный момент
+func (f *itemFilter) applied() bool {
+	return len(f.query) > 0
+}
+
+// active сообщает, нужно ли показывать строку фильтра
+func (f *itemFilter) active() bool {
+	return f.editing || f.applied()
+}
+
+// includes проверяет, виден ли элемент с учётом фильтра
+func (f *itemFilter) includes(index int) bool {
+	if !f.applied() {
+		return true
+	}
+	_, ok := f.matched[index]
+	return ok
+}
+
+// count возвращает количество видимых элементов
+func (f *itemFilter) count(total int) int {
+	if !f.applied() {
+		return total
+	}
+	return len(f.matches)
+}
+
+// position возвращает позицию элемента в отфильтрованном списке (-1, если элемент скрыт)
+func (f *itemFilter) position(index int) int {
+	if !f.applied() {
+		return index
+	}
+	for pos, idx := range f.matches {
+		if idx == index {
+			return pos
+		}
+	}
+	return -1
+}
+
+// indexAt возвращает индекс элемента, находящегося на позиции pos отфильтрованного списка
+func (f *itemFilter) indexAt(pos int) int {
+	if !f.applied() {
+		return pos
+	}
+	if pos < 0 || pos >= len(f.matches) {
+		return -1
+	}
+	return f.matches[pos]
+}
+
+// highlighted возвращает позиции совпавших символов названия элемента
+func (f *itemFilter) highlighted(index int) []int {
+	if !f.applied() {
+		return nil
+	}
+	return f.positions[index]
+}
+
+// refresh пересчитывает подходящие элементы для текущего запроса
+func (f *itemFilter) refresh(items []choice) {
+	f.matches = f.matches[:0]
+	f.matched = make(map[int]struct{})
+	f.positions = make(map[int][]int)
+	if !f.applied() {
+		return
+	}
+
+	for idx, item := range items {
+		positions, ok := fuzzyMatch(f.query, item.name)
+		if !ok {
+			// Совпадение по ключу или описанию не подсвечивается в названии
+			if _, ok = fuzzyMatch(f.query, item.key); !ok {
+				_, ok = fuzzyMatch(f.query, item.description)
+			}
+		}
+		if !ok {
+			continue
+		}
+		f.matches = append(f.matches, idx)
+		f.matched[idx] = struct{}{}
+		if len(positions) > 0 {
+			f.positions[idx] = positions
+		}
+	}
+}
+
+// handleKey обрабатывает клавиши режима фильтра.
+// Во время ввода запроса все печатные символы, включая пробел, попадают в запрос,
+// Enter завершает ввод, оставляя список отфильтрованным. После ввода Esc сбрасывает фильтр.
+// Возвращает handled=true, если клавиша поглощена фильтром,
+// и changed=true, если изменился запрос.
+func (f *itemFilter) handleKey(msg tea.KeyMsg) (handled bool, changed bool) {
+	if !f.editing {
+		switch {
+		case msg.String() == filterKey:
+			f.editing = true
+			return true, false
+		case msg.Type == tea.KeyEsc && f.applied():
+			f.query = nil
+			return true, true
+		}
+		return false, false
+	}
+
+	switch msg.Type {
+	case tea.KeyEnter:
+		f.editing = false
+		return true, false
+	case tea.KeySpace:
+		f.query = append(f.query, ' ')
+		return true, true
+	case tea.KeyEsc:
+		// Сбрасываем фильтр и выходим из режима ввода
+		changed = f.applied()
+		f.editing = false
+		f.query = nil
+		return true, changed
+	case tea.KeyBackspace:
+		if len(f.query) == 0 {
+			f.editing = false
+			return true, false
+		}
+		f.query = f.query[:len(f.query)-1]
+		return true, true
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if unicode.IsPrint(r) {
+				f.query = append(f.query, r)
+			}
+		}
+		return true, true
+	}
+	return false, false
+}
+
+// fuzzyMatch проверяет, входят ли символы запроса в текст в том же порядке (без учёта регистра).
+// Возвращает позиции совпавших символов текста.
+func fuzzyMatch(query []rune, text string) ([]int, bool) {
+	if len(query) == 0 {
+		return nil, true
+	}
+	if text == "" {
+		return nil, false
+	}
+
+	positions := make([]int, 0, len(query))
+	q := 0
+	for pos, r := range []rune(text) {
+		if unicode.ToLower(r) == unicode.ToLower(query[q]) {
+			positions = append(positions, pos)
+			q++
+			if q == len(query) {
+				return positions, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// renderFilteredLabel отображает название элемента с подсветкой совпавших символов.
+// Без совпадений применяется только базовый стиль (если он задан).
+func renderFilteredLabel(th *ui.Theme, label string, base lipgloss.Style, styled bool, positions []int) string {
+	if len(positions) == 0 {
+		if styled {
+			return base.Render(label)
+		}
+		return label
+	}
+
+	match := th.Styles.FilterMatch.Inherit(base)
+	marked := make(map[int]struct{}, len(positions))
+	for _, pos := range positions {
+		marked[pos] = struct{}{}
+	}
+
+	var sb strings.Builder
+	var run []rune
+	runMatched := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runMatched {
+			sb.WriteString(match.Render(string(run)))
+		} else if styled {
+			sb.WriteString(base.Render(string(run)))
+		} else {
+			sb.WriteString(string(run))
+		}
+		run = run[:0]
+	}
+
+	for pos, r := range []rune(label) {
+		_, isMatch := marked[pos]
+		if isMatch != runMatched {
+			flush()
+			runMatched = isMatch
+		}
+		run = append(run, r)
+	}
+	flush()
+	return sb.String()
+}
+
+// renderFilterLine формирует строку ввода фильтра с количеством найденных элементов
+func (f *itemFilter) renderFilterLine(th *ui.Theme, total int) string {
+	prefix := performance.FastConcat(
+		performance.RepeatEfficient(" ", ui.MainLeftIndent),
+		th.Glyphs.Vertical,
+		"  ",
+	)
+
+	query := th.Styles.Input.Render(string(f.query))
+	if f.editing {
+		query += th.Styles.Subtle.Render("_")
+	}
+
+	status := fmt.Sprintf(defaults.FilterCountFormat, f.count(total), total)
+	if f.applied() && len(f.matches) == 0 {
+		status = defaults.FilterNoMatches
+	}
+
+	return performance.FastConcat(
+		prefix,
+		th.Styles.Subtle.Render(defaults.FilterPromptLabel),
+		" ",
+		query,
+		"  ",
+		th.Styles.Subtle.Render(status),
+		"\n",
+	)
+}
@@ -0,0 +1,143 @@
+package task
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// typeFilter включает режим фильтра и вводит запрос посимвольно
+func typeFilter(model Task, query string) Task {
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(filterKey)})
+	for _, r := range query {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return model
+}
+
+// TestFuzzyMatch проверяет нечёткое совпадение и позиции совпавших символов
+func TestFuzzyMatch(t *testing.T) {
+	positions, ok := fuzzyMatch([]rune("ngx"), "Nginx")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 1, 4}, positions)
+
+	positions, ok = fuzzyMatch([]rune("сть"), "Сеть")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 2, 3}, positions)
+
+	_, ok = fuzzyMatch([]rune("xn"), "Nginx")
+	assert.False(t, ok, "порядок символов запроса должен сохраняться")
+
+	_, ok = fuzzyMatch([]rune("a"), "")
+	assert.False(t, ok)
+}
+
+// TestSingleSelectTaskFilter проверяет сужение списка, счётчики и сброс фильтра
+func TestSingleSelectTaskFilter(t *testing.T) {
+	items := []Item{
+		{Key: "apache", Name: "Apache"},
+		{Key: "nginx", Name: "Nginx"},
+		{Key: "caddy", Name: "Caddy", Description: "сервер с автоматическим HTTPS"},
+		{Key: "lighttpd", Name: "Lighttpd"},
+		{Key: "traefik", Name: "Traefik"},
+	}
+	task := NewSingleSelectTask("Сервер", items).WithViewport(2)
+
+	model := typeFilter(task, "https")
+	task = model.(*SingleSelectTask)
+
+	assert.True(t, task.filter.editing)
+	assert.Equal(t, []int{2}, task.filter.matches, "совпадение по описанию тоже учитывается")
+	assert.Equal(t, 2, task.cursor, "курсор переходит на первый видимый элемент")
+
+	view := task.View(80)
+	assert.Contains(t, view, "Caddy")
+	assert.NotContains(t, view, "Apache")
+	assert.Contains(t, view, "(1 из 5)")
+
+	// Пробел во время ввода попадает в запрос и не выбирает элемент
+	model, _ = task.Update(tea.KeyMsg{Type: tea.KeySpace})
+	task = model.(*SingleSelectTask)
+	assert.False(t, task.IsDone())
+	assert.Equal(t, "https ", string(task.filter.query))
+	model, _ = task.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	task = model.(*SingleSelectTask)
+
+	// Enter завершает ввод запроса, список остаётся отфильтрованным
+	model, _ = task.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	task = model.(*SingleSelectTask)
+	assert.False(t, task.IsDone(), "Enter во время ввода не подтверждает выбор")
+	assert.False(t, task.filter.editing)
+	assert.Equal(t, []int{2}, task.filter.matches)
+
+	// Выбор отфильтрованного элемента возвращает его ключ
+	model, _ = task.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	task = model.(*SingleSelectTask)
+	assert.True(t, task.IsDone())
+	assert.Equal(t, "caddy", task.GetSelected())
+}
+
+// TestSingleSelectTaskFilterSkipsDisabled проверяет, что курсор не попадает на отключённые элементы под фильтром
+func TestSingleSelectTaskFilterSkipsDisabled(t *testing.T) {
+	task := NewSingleSelectTask("Выбор", makeTestItems([]string{"alpha", "beta", "alps", "gamma"}))
+	task.WithItemsDisabled([]int{0})
+
+	model := typeFilter(task, "al")
+	task = model.(*SingleSelectTask)
+	assert.Equal(t, []int{0, 2}, task.filter.matches)
+	assert.Equal(t, 2, task.cursor)
+
+	// Перемещение по кругу не выходит за пределы отфильтрованных доступных элементов
+	model, _ = task.Update(tea.KeyMsg{Type: tea.KeyDown})
+	task = model.(*SingleSelectTask)
+	assert.Equal(t, 2, task.cursor)
+
+	// Esc сбрасывает фильтр, но не завершает задачу
+	model, _ = task.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	task = model.(*SingleSelectTask)
+	assert.False(t, task.IsDone())
+	assert.False(t, task.filter.active())
+	assert.Contains(t, task.View(80), "gamma")
+}
+
+// TestMultiSelectTaskFilterKeepsDependencies проверяет, что переключение под фильтром применяет зависимости
+func TestMultiSelectTaskFilterKeepsDependencies(t *testing.T) {
+	items := []Item{
+		{Key: "logging", Name: "Logging"},
+		{Key: "debug", Name: "Debug"},
+		{Key: "metrics", Name: "Metrics"},
+	}
+	task := NewMultiSelectTask("Опции", items).WithSelectAll()
+	task.WithDependencies(map[string]MultiSelectDependencyRule{
+		"logging": {
+			OnSelect:   MultiSelectDependencyActions{Enable: []string{"debug"}},
+			OnDeselect: MultiSelectDependencyActions{Disable: []string{"debug"}, ForceClear: []string{"debug"}},
+		},
+	})
+
+	model := typeFilter(task, "log")
+	task = model.(*MultiSelectTask)
+	assert.False(t, task.selectAllShown(), "пункт «Выбрать все» скрыт при активном фильтре")
+	assert.Equal(t, 0, task.cursor)
+	assert.False(t, strings.Contains(task.View(80), "Metrics"))
+
+	model, _ = task.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	task = model.(*MultiSelectTask)
+	assert.False(t, task.IsDone(), "Enter завершает ввод запроса")
+	assert.False(t, task.filter.editing)
+
+	model, _ = task.Update(tea.KeyMsg{Type: tea.KeySpace})
+	task = model.(*MultiSelectTask)
+	assert.True(t, task.isSelected(0))
+	assert.False(t, task.isDisabled(1), "зависимый элемент включается и под фильтром")
+	assert.Equal(t, "log", string(task.filter.query))
+
+	// После ввода Esc сбрасывает фильтр, не отменяя задачу
+	model, _ = task.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	task = model.(*MultiSelectTask)
+	assert.False(t, task.IsDone())
+	assert.False(t, task.filter.active())
+	assert.True(t, task.isSelected(0))
+}
@@ -1,10 +1,13 @@
 package task
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	terrors "github.com/qzeleza/ziva/internal/errors"
 	"github.com/qzeleza/ziva/internal/performance"
 	"github.com/qzeleza/ziva/internal/ui"
 
@@ -32,6 +35,34 @@ type FuncTask struct {
 	// successLabel отображается справа от заголовка при успешном завершении.
 	// По умолчанию значение равно "ГОТОВО", но может быть переопределено методом WithSuccessLabel.
 	successLabel string
+	// ctxFunction — вариант функции с поддержкой отмены через контекст (NewFuncTaskCtx)
+	ctxFunction func(ctx context.Context) error
+	// deadline ограничивает время выполнения функции (0 — без ограничения)
+	deadline time.Duration
+	// cancel отменяет контекст выполняющейся функции
+	cancel context.CancelFunc
+	// progressFunction — вариант функции с передачей прогресса (NewFuncTaskWithProgress)
+	progressFunction func(p Progress) error
+	// progress хранит прогресс, переданный функцией, и время её выполнения
+	progress *progressTracker
+	// retry задаёт политику повтора функции (nil — без повторов)
+	retry *RetryPolicy
+	// attempt номер попытки в текущей серии, attempts — общее число попыток
+	attempt  int
+	attempts int
+	// retryAt момент запуска следующей попытки (нулевое значение — повтор не ожидается)
+	retryAt time.Time
+	// lastErr ошибка последней попытки
+	lastErr error
+	// deciding — попытки исчерпаны, ожидается выбор «Повторить / Пропустить / Прервать»
+	deciding bool
+	choice   int
+	// skipped — пользователь пропустил задачу после исчерпания попыток
+	skipped bool
+	// resume определяет поведение задачи при возобновлении очереди из файла состояния
+	resume ResumeMode
+	// output хранит вывод внешней команды (для NewCommandTask)
+	output *commandOutput
 }
 
 /**
@@ -74,6 +105,17 @@ func WithSuccessLabelOption(label string) FuncTaskOption {
 	}
 }
 
+/**
+ * @brief Ограничивает время выполнения функции задачи.
+ * @param deadline Максимальная длительность выполнения.
+ * @return Функциональная опция для NewFuncTask.
+ */
+func WithDeadlineOption(deadline time.Duration) FuncTaskOption {
+	return func(t *FuncTask) {
+		t.WithDeadline(deadline)
+	}
+}
+
 /**
  * @brief Создает новую задачу типа FuncTask с функциональными опциями.
  * @param title Заголовок задачи.
@@ -121,6 +163,52 @@ func NewFuncTask(title string, funcAction func() error, options ...FuncTaskOptio
 	return task
 }
 
+/**
+ * @brief Создает задачу FuncTask, функция которой принимает контекст.
+ * @param title Заголовок задачи.
+ * @param funcAction Функция, которую необходимо выполнить.
+ * @param options Функциональные опции для настройки задачи.
+ * @return Указатель на созданную задачу FuncTask.
+ * @details Контекст отменяется, когда пользователь прерывает задачу или очередь,
+ * а также по истечении срока, заданного WithDeadline. Истечение срока
+ * завершает задачу ошибкой типа ErrorTypeTimeout.
+ *
+ * task := NewFuncTaskCtx("Загрузка прошивки",
+ *     func(ctx context.Context) error { return download(ctx, url) },
+ *     WithDeadlineOption(5*time.Minute),
+ * )
+ */
+func NewFuncTaskCtx(title string, funcAction func(ctx context.Context) error, options ...FuncTaskOption) *FuncTask {
+	task := NewFuncTask(title, nil, options...)
+	task.ctxFunction = funcAction
+	return task
+}
+
+/**
+ * @brief Ограничивает время выполнения функции задачи.
+ * @param deadline Максимальная длительность выполнения (0 — без ограничения).
+ * @return Указатель на задачу для возможности цепочки вызовов.
+ * @details По истечении срока контекст функции отменяется, а задача завершается
+ * ошибкой типа ErrorTypeTimeout. Функция без контекста продолжит работу в фоне,
+ * но очередь не будет её дожидаться.
+ */
+func (t *FuncTask) WithDeadline(deadline time.Duration) *FuncTask {
+	if deadline > 0 {
+		t.deadline = deadline
+	}
+	return t
+}
+
+/**
+ * @brief Отменяет контекст выполняющейся функции.
+ * @details Вызывается при прерывании задачи пользователем и при выходе из очереди.
+ */
+func (t *FuncTask) Cancel() {
+	if t.cancel != nil {
+		t.cancel()
+	}
+}
+
 /**
  * @brief Устанавливает функцию для получения дополнительной информации при успешном завершении.
  * @param summaryFunc Функция, возвращающая массив строк для отображения под заголовком.
@@ -147,32 +235,37 @@ func (t *FuncTask) WithSuccessLabel(label string) *FuncTask {
 	return t
 }
 
+/**
+ * @brief Задает тему оформления задачи.
+ * @param theme Тема оформления (nil — глобальные стили пакета ui).
+ */
+func (t *FuncTask) SetTheme(theme *ui.Theme) {
+	t.BaseTask.SetTheme(theme)
+	if theme != nil {
+		t.spinner.Style = theme.Styles.Spinner
+	}
+}
+
 /**
  * @brief Запускает выполнение функции, связанной с задачей.
  * @return Команда для tea.Cmd.
  */
-// Определяем специальный тип сообщения для успешного завершения
-type funcTaskCompleteMsg struct{}
-
-func (t *FuncTask) Run() tea.Cmd {
-	return tea.Batch(t.spinner.Tick, func() tea.Msg {
-		// Выполняем функцию и проверяем на ошибку
-		err := t.function()
-		if err != nil {
-			// Устанавливаем ошибку в базовой задаче
-			t.BaseTask.err = err
-			return err
-		}
+// funcTaskCompleteMsg сообщает об успешном завершении попытки attempt задачи task
+type funcTaskCompleteMsg struct {
+	task    *FuncTask
+	attempt int
+}
 
-		// Делаем задержку перед завершением
-		// для лучшей визуальной анимации (если она включена)
-		if defaults.IsCompletionDelayEnabled() {
-			time.Sleep(defaults.DefaultCompletionDelay)
-		}
+// funcTaskErrorMsg сообщает об ошибке попытки attempt задачи task
+type funcTaskErrorMsg struct {
+	task    *FuncTask
+	attempt int
+	err     error
+}
 
-		// Возвращаем специальное сообщение об успешном завершении
-		return funcTaskCompleteMsg{}
-	})
+func (t *FuncTask) Run() tea.Cmd {
+	t.attempt = 0
+	return tea.Batch(t.spinner.Tick, t.attemptCmd())
 }
 
 /**
@@ -183,31 +276,27 @@ func (t *FuncTask) Run() tea.Cmd {
 func (t *FuncTask) Update(msg tea.Msg) (Task, tea.Cmd) {
 	switch msg := msg.(type) {
 	case funcTaskCompleteMsg:
-		// Получили сообщение об успешном завершении функции
-		t.done = true
-		t.icon = ui.IconDone
-
-		// Если определена функция для получения дополнительной информации, вызываем её
-		if t.summaryFunc != nil {
-			t.summaryLines = t.summaryFunc()
+		// Результат функции, прерванной пользователем, другой задачи
+		// или прежней попытки не учитываем
+		if t.done || !t.ownsAttempt(msg.task, msg.attempt) {
+			return t, nil
 		}
-
-		// Устанавливаем финальное значение для выравнивания по правому краю
-		t.finalValue = ui.SuccessLabelStyle.Render(t.successLabel)
-		return t, nil
-	case error:
-		// Получили ошибку от функции, помечаем задачу как завершенную с ошибкой
-		t.err = msg
-		// Устанавливаем ошибку в базовый тип
-		t.BaseTask.err = msg
-		t.done = true
-		// Добавляем крестик слева и устанавливаем иконку для отображения в FinalView
-		t.icon = ui.IconError
-
-		// Сохраняем текст ошибки с применением стиля ErrorMessageStyle
-		// Форматирование с отступом и переносами строк будет выполнено в FinalView
-		t.finalValue = ui.GetErrorMessageStyle().Render(t.err.Error())
+		// Получили сообщение об успешном завершении функции
+		t.markCompleted()
 		return t, nil
+	case funcTaskErrorMsg:
+		if t.done || !t.ownsAttempt(msg.task, msg.attempt) {
+			return t, nil
+		}
+		// Получили ошибку от функции: повторяем попытку по политике
+		// или помечаем задачу как завершенную с ошибкой
+		return t, t.handleAttemptError(msg.err)
+	case funcTaskRetryMsg:
+		// Задержка истекла — запускаем очередную попытку
+		if t.done || msg.task != t {
+			return t, nil
+		}
+		return t, t.attemptCmd()
 	case spinner.TickMsg:
 		// Если задача завершена, не обновляем спиннер
 		if t.done {
@@ -215,44 +304,157 @@ func (t *FuncTask) Update(msg tea.Msg) (Task, tea.Cmd) {
 		}
 		// Обновляем спиннер
 		var cmd tea.Cmd
-		t.spinner, cmd = t.spinner.Update(msg)
+		t.spinner, cmd = spinnerTick(t.Clock(), t.spinner, msg)
 		return t, cmd
 	case tea.KeyMsg:
 		// Обработка нажатия клавиш для возможности выхода из задачи
 		switch msg.String() {
 		case "q", "Q", "ctrl+c", "Esc", "esc":
-			// Помечаем задачу как выполненную с отменой
-			t.done = true
-			t.icon = ui.IconCancelled
-			cancelErr := fmt.Errorf(defaults.ErrorMsgCanceled)
-			t.SetError(cancelErr)
-			t.finalValue = ui.ErrorMessageStyle.Render(cancelErr.Error())
+			// Отменяем контекст функции и помечаем задачу как выполненную с отменой
+			t.Cancel()
+			t.markCancelled(fmt.Errorf(defaults.ErrorMsgCanceled))
 			return t, nil
 		}
+		// Выбор действия после исчерпания попыток
+		if t.deciding {
+			return t, t.updateRetryChoice(msg)
+		}
 	}
 	// Продолжаем выполнение для всех остальных сообщений
 	return t, nil
 }
 
+/**
+ * @brief Выполняет функцию задачи с учётом контекста и срока выполнения.
+ * @param ctx Контекст, отменяемый при прерывании задачи.
+ * @return Ошибка функции, ошибка тайм-аута или ошибка отмены.
+ */
+func (t *FuncTask) execute(ctx context.Context) error {
+	if t.ctxFunction == nil && t.progressFunction == nil && t.deadline <= 0 {
+		return t.function()
+	}
+
+	if t.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.deadline)
+		defer cancel()
+	}
+
+	var err error
+	switch {
+	case t.progressFunction != nil:
+		err = t.runWithProgress(ctx)
+	case t.ctxFunction != nil:
+		err = t.ctxFunction(ctx)
+	default:
+		err = runUntilDone(ctx, t.function)
+	}
+	if err == nil {
+		return nil
+	}
+
+	// Ошибку, вызванную отменой контекста, приводим к типизированной ошибке задачи
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return terrors.NewTimeoutError(t.title, t.deadline)
+	case errors.Is(ctx.Err(), context.Canceled):
+		return terrors.NewCancelError(t.title)
+	}
+	return err
+}
+
+/**
+ * @brief Выполняет функцию без контекста до её завершения или отмены контекста.
+ * @param ctx Контекст выполнения.
+ * @param fn Выполняемая функция.
+ * @return Ошибка функции или ошибка контекста, если он отменён раньше.
+ */
+func runUntilDone(ctx context.Context, fn func() error) error {
+	result := make(chan error, 1)
+	go func() { result <- fn() }()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+/**
+ * @brief Переводит задачу в состояние успешного завершения.
+ * @details Вызывает функцию сводки (если задана) и формирует метку успеха справа от заголовка.
+ */
+func (t *FuncTask) markCompleted() {
+	th := t.Theme()
+	t.done = true
+	t.icon = th.Icons.Done
+
+	// Если определена функция для получения дополнительной информации, вызываем её
+	if t.summaryFunc != nil {
+		t.summaryLines = t.summaryFunc()
+	}
+
+	// Устанавливаем финальное значение для выравнивания по правому краю
+	t.finalValue = th.Styles.SuccessLabel.Render(t.successLabel)
+}
+
+/**
+ * @brief Переводит задачу в состояние завершения с ошибкой.
+ * @param err Ошибка, возвращённая функцией задачи.
+ */
+func (t *FuncTask) markFailed(err error) {
+	th := t.Theme()
+	t.err = err
+	// Устанавливаем ошибку в базовый тип
+	t.BaseTask.err = err
+	t.done = true
+	// Добавляем крестик слева и устанавливаем иконку для отображения в FinalView
+	t.icon = th.Icons.Error
+
+	// Сохраняем текст ошибки с применением стиля ErrorMessageStyle
+	// Форматирование с отступом и переносами строк будет выполнено в FinalView
+	t.finalValue = th.Styles.ErrorMessage.Render(t.err.Error())
+}
+
+/**
+ * @brief Переводит задачу в состояние отмены.
+ * @param err Ошибка отмены, сохраняемая в задаче.
+ */
+func (t *FuncTask) markCancelled(err error) {
+	th := t.Theme()
+	t.done = true
+	t.icon = th.Icons.Cancelled
+	t.SetError(err)
+	t.finalValue = th.Styles.ErrorMessage.Render(err.Error())
+}
+
 /**
  * @brief Отображает текущее состояние задачи типа FuncTask.
  * @param width Ширина области отображения.
  * @return Строка с визуализацией состояния задачи.
  */
 func (t *FuncTask) View(width int) string {
+	th := t.Theme()
 	if t.IsDone() {
 		return t.FinalView(width)
 	}
 	// Используем новый префикс для активной задачи
 	prefix := performance.FastConcat(
 		performance.RepeatEfficient(" ", ui.MainLeftIndent),
-		ui.TaskInProgressSymbol,
+		th.Glyphs.InProgress,
 		" ",
 	)
-	result := fmt.Sprintf("%s%s%s\n", prefix, t.spinner.View(), ui.ActiveTaskStyle.Render(t.title))
+	result := fmt.Sprintf("%s%s%s\n", prefix, t.spinner.View(), th.Styles.ActiveTask.Render(t.title))
+	// Полоса прогресса и строка состояния (для NewFuncTaskWithProgress)
+	result += t.progressView()
+	// Последние строки вывода команды (для NewCommandTask)
+	result += t.outputView(width, false)
+	// Номер попытки, ожидание повтора или выбор действия (для WithRetry)
+	result += t.retryView()
 	// Добавляем подсказку о навигации с новым отступом
 	helpIndent := performance.RepeatEfficient(" ", ui.MainLeftIndent)
-	result += "\n" + ui.DrawLine(width) + ui.SubtleStyle.Render(fmt.Sprintf("%s%s", helpIndent, defaults.TaskExitHint))
+	result += "\n" + th.DrawLine(width) + th.Styles.Subtle.Render(fmt.Sprintf("%s%s", helpIndent, defaults.TaskExitHint))
 
 	return result
 }
@@ -265,16 +467,23 @@ func (t *FuncTask) View(width int) string {
  * при успешном завершении. Дополнительные строки выводятся с настраиваемым отступом.
  */
 func (t *FuncTask) FinalView(width int) string {
+	th := t.Theme()
 	// Получаем базовое финальное представление
 	result := t.BaseTask.FinalView(width)
 
 	// Если задача завершилась успешно и есть дополнительные строки для вывода
-	if t.icon == ui.IconDone && len(t.summaryLines) > 0 {
+	if t.icon == th.Icons.Done && (len(t.summaryLines) > 0 || t.progress != nil || t.attemptsLine() != "") {
 		result += t.drawSummaryLines(width)
 	} else {
 		// Если задача завершилась с ошибкой
 		// Добавляем перенос строки, если есть дополнительные строки под заголовком
 		result += "\n"
+		// Число попыток выводим и для неуспешного завершения
+		if line := t.attemptsLine(); line != "" {
+			result += th.DrawSummaryLine(line)
+		}
+		// Вывод команды при ошибке (для NewCommandTask с WithOutputOnFailure)
+		result += t.outputView(width, true)
 	}
 
 	return result
@@ -282,6 +491,7 @@ func (t *FuncTask) FinalView(width int) string {
 
 // drawSummaryLines рисует дополнительные строки под заголовком задачи
 func (t *FuncTask) drawSummaryLines(width int) string {
+	th := t.Theme()
 
 	// Добавляем верхнюю разделительную линию
 	result := "\n"
@@ -289,14 +499,24 @@ func (t *FuncTask) drawSummaryLines(width int) string {
 	// Добавляем каждую строку с настраиваемым отступом
 	for _, text_line := range t.summaryLines {
 		if strings.TrimSpace(text_line) != "" { // Пропускаем пустые строки
-			result += ui.DrawSummaryLine(text_line) // Добавляем дополнительные строки с отступом
+			result += th.DrawSummaryLine(text_line) // Добавляем дополнительные строки с отступом
 		}
 	}
 
+	// Общее время выполнения задачи с прогрессом
+	if line := t.elapsedLine(); line != "" {
+		result += th.DrawSummaryLine(line)
+	}
+
+	// Число попыток (если функция повторялась)
+	if line := t.attemptsLine(); line != "" {
+		result += th.DrawSummaryLine(line)
+	}
+
 	// Добавляем нижнюю разделительную линию
 	// result += performance.FastConcat(
 	// 	performance.RepeatEfficient(" ", ui.MainLeftIndent),
-	// 	ui.VerticalLineSymbol,
+	// 	th.Glyphs.Vertical,
 	// )
 
 	return result
@@ -0,0 +1,84 @@
+package task_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runQueueCmd выполняет команду очереди в фоне и передаёт её сообщения в msgs.
+// Пакеты команд разворачиваются, тики спиннера и выход из программы отбрасываются.
+func runQueueCmd(cmd tea.Cmd, msgs chan<- tea.Msg) {
+	if cmd == nil {
+		return
+	}
+	go func() {
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			for _, c := range msg {
+				runQueueCmd(c, msgs)
+			}
+		case spinner.TickMsg, tea.QuitMsg, nil:
+		default:
+			msgs <- msg
+		}
+	}()
+}
+
+// deliverQueueMsgs передаёт очереди поступающие сообщения, пока они приходят в течение wait
+func deliverQueueMsgs(model *query.Model, msgs chan tea.Msg, wait time.Duration) {
+	for {
+		select {
+		case msg := <-msgs:
+			_, cmd := model.Update(msg)
+			runQueueCmd(cmd, msgs)
+		case <-time.After(wait):
+			return
+		}
+	}
+}
+
+// TestFuncTaskLateResultAfterCancel проверяет, что результат прерванной функции
+// не завершает следующую задачу очереди
+func TestFuncTaskLateResultAfterCancel(t *testing.T) {
+	releaseFirst := make(chan struct{})
+	releaseSecond := make(chan struct{})
+	first := task.NewFuncTask("Загрузка", func() error {
+		<-releaseFirst
+		return errors.New("соединение разорвано")
+	})
+	first.SetStopOnError(false)
+	second := task.NewFuncTask("Установка", func() error {
+		<-releaseSecond
+		return nil
+	})
+
+	model := query.New("Обновление")
+	model.AddTasks([]common.Task{first, second})
+	msgs := make(chan tea.Msg, 16)
+	runQueueCmd(model.Init(), msgs)
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	runQueueCmd(cmd, msgs)
+	require.True(t, first.IsDone(), "первая задача прервана")
+
+	// Поздняя ошибка прерванной функции приходит, пока выполняется вторая задача
+	close(releaseFirst)
+	deliverQueueMsgs(model, msgs, 200*time.Millisecond)
+	assert.False(t, second.IsDone(), "чужой результат не завершает задачу")
+	assert.False(t, second.HasError())
+
+	// Успешное завершение приходит после задержки анимации завершения
+	close(releaseSecond)
+	deliverQueueMsgs(model, msgs, time.Second)
+	assert.True(t, second.IsDone())
+	assert.False(t, second.HasError())
+}
@@ -3,11 +3,20 @@
 package task
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/ui"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestFuncTaskCreation проверяет корректность создания задачи FuncTask
@@ -52,7 +61,7 @@ func TestFuncTaskWithSuccessLabel(t *testing.T) {
 	assert.NotNil(t, cmd, "Команда запуска задачи не должна быть nil")
 
 	// Симулируем выполнение команды
-	msg := funcTaskCompleteMsg{}
+	msg := funcTaskCompleteMsg{task: funcTask, attempt: funcTask.attempts}
 	updatedTask, _ := funcTask.Update(msg)
 
 	// Проверяем, что задача завершена успешно
@@ -75,7 +84,7 @@ func TestFuncTaskSuccessfulExecution(t *testing.T) {
 	assert.NotNil(t, cmd, "Команда запуска задачи не должна быть nil")
 
 	// Симулируем выполнение функции и отправку сообщения
-	msg := funcTaskCompleteMsg{}
+	msg := funcTaskCompleteMsg{task: funcTask, attempt: funcTask.attempts}
 	updatedTask, _ := funcTask.Update(msg)
 
 	// Проверяем, что задача завершена успешно
@@ -101,7 +110,7 @@ func TestFuncTaskErrorExecution(t *testing.T) {
 	assert.NotNil(t, cmd, "Команда запуска задачи не должна быть nil")
 
 	// Симулируем получение ошибки от функции
-	updatedTask, _ := funcTask.Update(expectedError)
+	updatedTask, _ := funcTask.Update(funcTaskErrorMsg{task: funcTask, attempt: funcTask.attempts, err: expectedError})
 
 	// Проверяем, что задача завершена с ошибкой
 	assert.True(t, updatedTask.IsDone(), "Задача должна быть отмечена как завершенная")
@@ -143,9 +152,212 @@ func TestFuncTaskView(t *testing.T) {
 	assert.NotNil(t, cmd, "Команда запуска задачи не должна быть nil")
 
 	// Симулируем успешное завершение
-	updatedTask, _ := funcTask.Update(funcTaskCompleteMsg{})
+	updatedTask, _ := funcTask.Update(funcTaskCompleteMsg{task: funcTask, attempt: funcTask.attempts})
 
 	// Проверяем, что View для завершенной задачи возвращает FinalView
 	view = updatedTask.View(80)
 	assert.Contains(t, view, "ГОТОВО", "View для завершенной задачи должен содержать метку успешного завершения")
 }
+
+// TestFuncTaskCtxDeadline проверяет завершение задачи ошибкой тайм-аута по истечении срока
+func TestFuncTaskCtxDeadline(t *testing.T) {
+	funcTask := NewFuncTaskCtx("Загрузка", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, WithDeadlineOption(20*time.Millisecond))
+
+	err := funcTask.Execute()
+	var taskErr *terrors.TaskError
+	assert.True(t, errors.As(err, &taskErr), "ошибка должна быть TaskError")
+	assert.Equal(t, terrors.ErrorTypeTimeout, taskErr.Type)
+	assert.True(t, funcTask.IsDone())
+	assert.True(t, funcTask.HasError())
+}
+
+// TestFuncTaskDeadlineWithoutContext проверяет срок выполнения для функции без контекста
+func TestFuncTaskDeadlineWithoutContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	funcTask := NewFuncTask("Зависшая функция", func() error {
+		<-release
+		return nil
+	}).WithDeadline(20 * time.Millisecond)
+
+	err := funcTask.Execute()
+	var taskErr *terrors.TaskError
+	assert.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, terrors.ErrorTypeTimeout, taskErr.Type)
+}
+
+// TestFuncTaskCtxCancelOnKey проверяет отмену контекста при прерывании задачи пользователем
+func TestFuncTaskCtxCancelOnKey(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan error, 1)
+	funcTask := NewFuncTaskCtx("Прошивка", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return ctx.Err()
+	})
+
+	cmd := funcTask.Run()
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- runFuncCommand(cmd) }()
+	<-started
+
+	funcTask.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	select {
+	case err := <-stopped:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("контекст функции не был отменён")
+	}
+
+	// Поздний результат прерванной функции не меняет состояние задачи
+	funcTask.Update(<-msgs)
+	assert.Equal(t, ui.IconCancelled, funcTask.icon)
+}
+
+// runFuncCommand выполняет команду FuncTask и возвращает сообщение функции (без тика спиннера)
+func runFuncCommand(cmd tea.Cmd) tea.Msg {
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		return nil
+	}
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		msg := c()
+		if _, isTick := msg.(spinner.TickMsg); !isTick {
+			return msg
+		}
+	}
+	return nil
+}
+
+// TestFuncTaskWithProgress проверяет отображение прогресса во время выполнения и общее время после завершения
+func TestFuncTaskWithProgress(t *testing.T) {
+	reported := make(chan struct{})
+	release := make(chan struct{})
+	funcTask := NewFuncTaskWithProgress("Загрузка", func(p Progress) error {
+		p.SetBytes(512*1024, 1024*1024)
+		p.SetStatus("firmware.bin")
+		close(reported)
+		<-release
+		p.SetPercent(100)
+		return nil
+	})
+
+	cmd := funcTask.Run()
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- runFuncCommand(cmd) }()
+	<-reported
+
+	view := funcTask.View(80)
+	assert.Contains(t, view, "50%")
+	assert.Contains(t, view, "512.0 KiB / 1.0 MiB")
+	assert.Contains(t, view, "firmware.bin")
+
+	close(release)
+	funcTask.Update(<-msgs)
+	assert.True(t, funcTask.IsDone())
+	assert.False(t, funcTask.HasError())
+	assert.Contains(t, funcTask.FinalView(80), strings.Split(defaults.ProgressElapsedFormat, "%")[0])
+}
+
+// TestFuncTaskWithProgressDeadline проверяет срок выполнения функции, не проверяющей контекст
+func TestFuncTaskWithProgressDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	funcTask := NewFuncTaskWithProgress("Загрузка", func(p Progress) error {
+		p.SetPercent(10)
+		<-release
+		return nil
+	})
+	funcTask.WithDeadline(50 * time.Millisecond)
+
+	started := time.Now()
+	err := funcTask.Execute()
+	var taskErr *terrors.TaskError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, terrors.ErrorTypeTimeout, taskErr.Type)
+	assert.Less(t, time.Since(started), 3*time.Second, "задача не ждёт завершения функции")
+}
+
+// TestProgressTrackerETA проверяет оценку оставшегося времени
+func TestProgressTrackerETA(t *testing.T) {
+	state := progressSnapshot{percent: 25, hasPercent: true, elapsed: 10 * time.Second}
+	eta, ok := state.eta()
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, eta)
+
+	_, ok = progressSnapshot{elapsed: time.Second}.eta()
+	assert.False(t, ok, "без процента оценка невозможна")
+}
+
+// TestFuncTaskRetryPolicyDelay проверяет экспоненциальную задержку с ограничением
+func TestFuncTaskRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialDelay: time.Second, MaxDelay: 5 * time.Second, Multiplier: 2}
+	assert.Equal(t, time.Second, policy.Delay(1))
+	assert.Equal(t, 2*time.Second, policy.Delay(2))
+	assert.Equal(t, 4*time.Second, policy.Delay(3))
+	assert.Equal(t, 5*time.Second, policy.Delay(4), "задержка не должна превышать MaxDelay")
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		delay := policy.Delay(2)
+		assert.GreaterOrEqual(t, delay, time.Second)
+		assert.LessOrEqual(t, delay, 3*time.Second)
+	}
+}
+
+// TestFuncTaskRetryFiltersErrorTypes проверяет повтор только для указанных типов ошибок
+func TestFuncTaskRetryFiltersErrorTypes(t *testing.T) {
+	calls := 0
+	funcTask := NewFuncTask("Загрузка", func() error {
+		calls++
+		if calls < 3 {
+			return terrors.NewNetworkError("Загрузка", errors.New("connection refused"))
+		}
+		return nil
+	}).WithRetry(RetryPolicy{MaxAttempts: 5, RetryOn: []terrors.ErrorType{terrors.ErrorTypeNetwork}})
+
+	assert.NoError(t, funcTask.Execute())
+	assert.Equal(t, 3, funcTask.Attempts())
+	assert.Contains(t, funcTask.FinalView(80), fmt.Sprintf(defaults.RetryAttemptsFormat, 3))
+
+	validation := NewFuncTask("Проверка", func() error {
+		return terrors.NewValidationError("Проверка", errors.New("неверный формат"))
+	}).WithRetry(RetryPolicy{MaxAttempts: 5, RetryOn: []terrors.ErrorType{terrors.ErrorTypeNetwork}})
+	assert.Error(t, validation.Execute())
+	assert.Equal(t, 1, validation.Attempts(), "ошибка валидации не должна повторяться")
+}
+
+// TestFuncTaskRetryView проверяет отображение ожидания повтора и выбор действия после исчерпания попыток
+func TestFuncTaskRetryView(t *testing.T) {
+	netErr := terrors.NewNetworkError("Загрузка", errors.New("connection refused"))
+	funcTask := NewFuncTask("Загрузка", func() error { return netErr }).
+		WithRetry(RetryPolicy{MaxAttempts: 2, InitialDelay: 4 * time.Second, Prompt: true})
+
+	funcTask.Run()
+	cmd := funcTask.handleAttemptError(netErr)
+	assert.NotNil(t, cmd, "должна быть запланирована повторная попытка")
+	assert.Contains(t, funcTask.View(80), fmt.Sprintf(defaults.RetryWaitFormat, 2, 2, 4*time.Second))
+
+	// Вторая попытка также неудачна — предлагается выбор действия
+	funcTask.Update(funcTaskRetryMsg{task: funcTask})
+	funcTask.Update(funcTaskErrorMsg{task: funcTask, attempt: 1, err: netErr})
+	assert.False(t, funcTask.deciding, "ошибка прежней попытки не учитывается")
+	funcTask.Update(funcTaskErrorMsg{task: funcTask, attempt: 2, err: netErr})
+	assert.False(t, funcTask.IsDone())
+	assert.Contains(t, funcTask.View(80), defaults.RetryExhaustedPrompt)
+
+	// Выбираем «Пропустить»: задача завершается без ошибки
+	funcTask.Update(tea.KeyMsg{Type: tea.KeyRight})
+	funcTask.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, funcTask.IsDone())
+	assert.False(t, funcTask.HasError())
+	assert.True(t, funcTask.Skipped())
+	assert.Equal(t, 2, funcTask.Attempts())
+}
@@ -0,0 +1,465 @@
+// task/headless.go
+
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+)
+
+// Поддержка неинтерактивного (headless) режима.
+// Вместо нажатий клавиш задачи получают заранее подготовленные ответы,
+// а при их отсутствии используют значение по умолчанию, заданное через тайм-аут
+// или явный выбор элемента по умолчанию.
+
+// FailAnswer завершает задачу с ошибкой получения ответа в неинтерактивном режиме.
+// Такая ошибка всегда останавливает очередь.
+//
+// @param err Ошибка получения или применения ответа
+func (t *BaseTask) FailAnswer(err error) {
+	th := t.Theme()
+	if t.timeoutManager != nil {
+		t.timeoutManager.StopTimeout()
+	}
+	t.done = true
+	t.icon = th.Icons.Error
+	t.err = err
+	t.stopOnError = true
+	t.finalValue = th.Styles.ErrorMessage.Render(err.Error())
+}
+
+// ApplyAnswer завершает задачу выбора указанным вариантом.
+// Поддерживает ключ или название элемента (string) и индекс (int, float64).
+//
+// @param answer Ответ из источника ответов
+// @return Ошибка, если вариант не найден или недоступен
+func (t *SingleSelectTask) ApplyAnswer(answer interface{}) error {
+	t.stopTimeout()
+
+	index, err := t.answerIndex(answer)
+	if err != nil {
+		return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+	}
+	if t.isDisabled(index) {
+		err := fmt.Errorf(defaults.ErrAnswerDisabledOption, t.items[index].valueKey())
+		return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+	}
+
+	t.cursor = index
+	t.finalizeSelection(index)
+	return nil
+}
+
+// ApplyDefaultAnswer завершает задачу значением по умолчанию.
+// Сначала используется значение тайм-аута, затем явно заданный элемент по умолчанию.
+//
+// @return true, если задача была завершена
+func (t *SingleSelectTask) ApplyDefaultAnswer() bool {
+	if t.defaultValue != nil {
+		t.applyDefaultValue()
+	}
+	if !t.done && t.hasDefaultItem {
+		t.finalizeSelection(t.cursor)
+	}
+	return t.done
+}
+
+// answerIndex преобразует ответ в индекс элемента списка
+func (t *SingleSelectTask) answerIndex(answer interface{}) (int, error) {
+	switch v := answer.(type) {
+	case int:
+		if v >= 0 && v < len(t.items) {
+			return v, nil
+		}
+	case float64:
+		if v == math.Trunc(v) && v >= 0 && int(v) < len(t.items) {
+			return int(v), nil
+		}
+	case string:
+		if idx := t.choiceIndex(v); idx != -1 {
+			return idx, nil
+		}
+	default:
+		return -1, fmt.Errorf(defaults.ErrAnswerUnsupportedType, answer)
+	}
+	return -1, fmt.Errorf(defaults.ErrAnswerUnknownOption, fmt.Sprint(answer))
+}
+
+// ApplyAnswer завершает задачу Да/Нет указанным ответом.
+// Поддерживает bool, YesNoOption, индекс и строки "да"/"yes"/"нет"/"no" на всех языках.
+//
+// @param answer Ответ из источника ответов
+// @return Ошибка, если ответ не удалось распознать
+func (t *YesNoTask) ApplyAnswer(answer interface{}) error {
+	var normalized interface{}
+	switch v := answer.(type) {
+	case bool:
+		normalized = 1
+		if v {
+			normalized = 0
+		}
+	case YesNoOption:
+		normalized = int(v)
+	case string:
+		normalized = t.normalizeStringToIndex(v)
+	default:
+		normalized = answer
+	}
+
+	if err := t.SingleSelectTask.ApplyAnswer(normalized); err != nil {
+		return err
+	}
+	t.syncSelectedOption()
+	return nil
+}
+
+// ApplyDefaultAnswer завершает задачу Да/Нет значением по умолчанию.
+//
+// @return true, если задача была завершена
+func (t *YesNoTask) ApplyDefaultAnswer() bool {
+	if !t.SingleSelectTask.ApplyDefaultAnswer() {
+		return false
+	}
+	t.syncSelectedOption()
+	return true
+}
+
+// ApplyAnswer завершает задачу множественного выбора указанным набором вариантов.
+// Поддерживает список ключей/названий/индексов или строку со значениями через запятую.
+//
+// @param answer Ответ из источника ответов
+// @return Ошибка, если хотя бы один вариант не найден или недоступен
+func (t *MultiSelectTask) ApplyAnswer(answer interface{}) error {
+	t.stopTimeout()
+
+	values, err := answerList(answer)
+	if err != nil {
+		return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+	}
+
+	t.clearAllSelections()
+	for _, value := range values {
+		index, err := t.answerIndex(value)
+		if err != nil {
+			return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+		}
+		if t.isDisabled(index) {
+			err := fmt.Errorf(defaults.ErrAnswerDisabledOption, t.items[index].valueKey())
+			return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+		}
+		t.setSelectedState(index, true)
+		// Применяем зависимости после каждого пункта, как при ручном выборе
+		t.applyDependencies()
+	}
+
+	if t.requireSelection {
+		if keys, _ := t.collectSelectionSnapshot(); len(keys) == 0 {
+			message := strings.TrimSpace(strings.TrimPrefix(defaults.NeedSelectAtLeastOne, "!"))
+			return terrors.NewValidationError(t.title, errors.New(message))
+		}
+	}
+
+	t.confirmSelection()
+	return nil
+}
+
+// ApplyDefaultAnswer завершает задачу множественного выбора значениями по умолчанию.
+//
+// @return true, если задача была завершена
+func (t *MultiSelectTask) ApplyDefaultAnswer() bool {
+	if t.defaultValue != nil {
+		t.applyDefaultValue()
+	}
+	if !t.done && t.hasDefaultItems {
+		t.confirmSelection()
+	}
+	return t.done
+}
+
+// answerIndex преобразует элемент ответа в индекс элемента списка
+func (t *MultiSelectTask) answerIndex(answer interface{}) (int, error) {
+	switch v := answer.(type) {
+	case int:
+		if v >= 0 && v < len(t.items) {
+			return v, nil
+		}
+	case float64:
+		if v == math.Trunc(v) && v >= 0 && int(v) < len(t.items) {
+			return int(v), nil
+		}
+	case string:
+		if idx := t.choiceIndex(v); idx != -1 {
+			return idx, nil
+		}
+	default:
+		return -1, fmt.Errorf(defaults.ErrAnswerUnsupportedType, answer)
+	}
+	return -1, fmt.Errorf(defaults.ErrAnswerUnknownOption, fmt.Sprint(answer))
+}
+
+// answerList приводит ответ к списку значений для множественного выбора
+func answerList(answer interface{}) ([]interface{}, error) {
+	switch v := answer.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		return v, nil
+	case []string:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, item)
+		}
+		return result, nil
+	case []int:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, item)
+		}
+		return result, nil
+	case string:
+		var result []interface{}
+		for _, part := range strings.Split(v, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				result = append(result, trimmed)
+			}
+		}
+		return result, nil
+	case int, float64:
+		return []interface{}{v}, nil
+	}
+	return nil, fmt.Errorf(defaults.ErrAnswerUnsupportedType, answer)
+}
+
+// ApplyAnswer завершает задачу ввода указанным значением.
+// Значение проходит те же проверки, что и при ручном вводе (валидатор, пустое значение).
+//
+// @param answer Ответ из источника ответов
+// @return Ошибка валидации, если значение не прошло проверку
+func (t *InputTaskNew) ApplyAnswer(answer interface{}) error {
+	if t.timeoutManager != nil {
+		t.timeoutManager.StopTimeout()
+	}
+
+	value, err := answerString(answer)
+	if err != nil {
+		return terrors.NewValidationError(t.title, err)
+	}
+
+	t.textInput.SetValue(value)
+	t.handleSubmit()
+	if !t.done {
+		return t.Error()
+	}
+	return nil
+}
+
+// ApplyDefaultAnswer завершает задачу ввода значением тайм-аута по умолчанию
+// или начальным значением поля (WithValue).
+//
+// @return true, если задача была завершена
+func (t *InputTaskNew) ApplyDefaultAnswer() bool {
+	t.applyDefaultValue()
+	if !t.done && t.initial != "" {
+		_ = t.ApplyAnswer(t.initial)
+	}
+	return t.done
+}
+
+// ApplyAnswer завершает задачу многострочного ввода указанным текстом.
+// Текст проходит те же проверки, что и при ручном вводе (длина, валидатор, пустое значение).
+//
+// @param answer Ответ из источника ответов
+// @return Ошибка валидации, если текст не прошёл проверку
+func (t *TextAreaTask) ApplyAnswer(answer interface{}) error {
+	if t.timeoutManager != nil {
+		t.timeoutManager.StopTimeout()
+	}
+
+	value, err := answerString(answer)
+	if err != nil {
+		return terrors.NewValidationError(t.title, err)
+	}
+
+	value = normalizeNewlines(value)
+	t.submit(value)
+	if !t.done {
+		return t.Error()
+	}
+	t.textArea.SetValue(value)
+	return nil
+}
+
+// ApplyDefaultAnswer завершает задачу значением тайм-аута по умолчанию
+// или начальным текстом поля (WithValue).
+//
+// @return true, если задача была завершена
+func (t *TextAreaTask) ApplyDefaultAnswer() bool {
+	t.applyDefaultValue()
+	if !t.done && t.initial != "" {
+		_ = t.ApplyAnswer(t.initial)
+	}
+	return t.done
+}
+
+// ApplyAnswer завершает задачу выбора файла указанным путём (string).
+// При множественном выборе поддерживается список путей или строка с путями через запятую.
+// Путь проверяется так же, как выбор в списке: он должен существовать, находиться
+// внутри корня и подходить под режим и фильтры задачи. Относительный путь
+// отсчитывается от стартового каталога.
+//
+// @param answer Ответ из источника ответов
+// @return Ошибка, если хотя бы один путь не прошёл проверку
+func (t *FilePickerTask) ApplyAnswer(answer interface{}) error {
+	root, start, err := t.resolveDirs()
+	if err != nil {
+		return err
+	}
+	t.root = root
+
+	values := []interface{}{answer}
+	if t.multiple {
+		if values, err = answerList(answer); err != nil {
+			return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+		}
+	}
+	if len(values) == 0 {
+		message := strings.TrimSpace(strings.TrimPrefix(defaults.NeedSelectAtLeastOne, "!"))
+		return terrors.NewValidationError(t.title, errors.New(message))
+	}
+
+	paths := make([]string, 0, len(values))
+	for _, value := range values {
+		text, ok := value.(string)
+		if !ok {
+			err := fmt.Errorf(defaults.ErrAnswerUnsupportedType, value)
+			return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+		}
+		path, err := t.resolveAnswerPath(text, start)
+		if err != nil {
+			return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+		}
+		paths = append(paths, path)
+	}
+
+	t.marked = paths
+	t.finish(paths)
+	return nil
+}
+
+// ApplyDefaultAnswer сообщает, что у задачи выбора файла нет значения по умолчанию:
+// путь должен быть задан ответом.
+//
+// @return true, если задача уже завершена
+func (t *FilePickerTask) ApplyDefaultAnswer() bool {
+	return t.done
+}
+
+// ApplyAnswer завершает задачу выбора даты, времени или длительности указанным значением:
+// time.Time или строкой "2006-01-02" ("02.01.2006") для даты, time.Time или строкой
+// "15:04" ("15:04:05") для времени, time.Duration или строкой "1h30m" для длительности.
+// Значение должно входить в диапазон задачи.
+//
+// @param answer Ответ из источника ответов
+// @return Ошибка разбора или выхода за границы диапазона
+func (t *DateTimeTask) ApplyAnswer(answer interface{}) error {
+	if t.timeoutManager != nil {
+		t.timeoutManager.StopTimeout()
+	}
+
+	value, duration, err := t.resolveAnswer(answer)
+	if err != nil {
+		return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+	}
+	if t.mode == modeDuration {
+		t.duration = duration
+	} else {
+		t.value = value
+	}
+	t.finish()
+	return nil
+}
+
+// ApplyDefaultAnswer завершает задачу значением тайм-аута по умолчанию
+// или начальным значением (WithValue, WithDuration).
+//
+// @return true, если задача была завершена
+func (t *DateTimeTask) ApplyDefaultAnswer() bool {
+	t.applyDefaultValue()
+	if !t.done && t.initial {
+		t.ensureValue()
+		t.submit()
+	}
+	return t.done
+}
+
+// ApplyAnswer завершает задачу выбора числа указанным значением: числом или строкой
+// ("512", "0,5", "512 MB"). Значение округляется до точности задачи и должно входить в диапазон.
+//
+// @param answer Ответ из источника ответов
+// @return Ошибка разбора или выхода за границы диапазона
+func (t *NumberTask) ApplyAnswer(answer interface{}) error {
+	if t.timeoutManager != nil {
+		t.timeoutManager.StopTimeout()
+	}
+
+	value, err := t.resolveAnswer(answer)
+	if err != nil {
+		return terrors.NewValidationError(t.title, err).WithContext("answer", answer)
+	}
+	t.value = value
+	t.finish()
+	return nil
+}
+
+// ApplyDefaultAnswer завершает задачу значением тайм-аута по умолчанию
+// или начальным значением (WithValue).
+//
+// @return true, если задача была завершена
+func (t *NumberTask) ApplyDefaultAnswer() bool {
+	t.applyDefaultValue()
+	if !t.done && t.initial {
+		t.finish()
+	}
+	return t.done
+}
+
+// answerString приводит скалярный ответ к строке
+func answerString(answer interface{}) (string, error) {
+	switch v := answer.(type) {
+	case string:
+		return v, nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf(defaults.ErrAnswerUnsupportedType, answer)
+}
+
+// Execute синхронно выполняет функцию задачи без цикла событий bubbletea.
+// Используется в неинтерактивном режиме, где анимация спиннера не нужна.
+//
+// @return Ошибка выполнения функции
+func (t *FuncTask) Execute() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	defer cancel()
+
+	if err := t.executeWithRetry(ctx); err != nil {
+		t.markFailed(err)
+		return err
+	}
+	t.markCompleted()
+	return nil
+}
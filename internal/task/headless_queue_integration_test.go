@@ -0,0 +1,106 @@
+package task_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/qzeleza/ziva/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHeadlessQueueAppliesAnswers проверяет выполнение очереди без терминала с ответами из карты
+func TestHeadlessQueueAppliesAnswers(t *testing.T) {
+	confirm := task.NewYesNoTask("Подтверждение", "Продолжить?")
+	confirm.SetID("confirm")
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}})
+	features := task.NewMultiSelectTask("Компоненты", []task.Item{{Key: "api"}, {Key: "web"}, {Key: "db"}})
+	port := task.NewInputTaskNew("Порт", "Введите порт").WithValidator(validation.Port())
+	port.SetID("port")
+
+	executed := false
+	deploy := task.NewFuncTask("Развёртывание", func() error {
+		executed = true
+		return nil
+	})
+
+	var out bytes.Buffer
+	model := query.New("Headless").WithOutput(&out).WithHeadless(query.MapAnswers{
+		"confirm":    true,
+		"Среда":      "prod",
+		"Компоненты": []interface{}{"api", "db"},
+		"port":       8080,
+	})
+	model.AddTasks([]common.Task{confirm, env, features, port, deploy})
+
+	require.NoError(t, model.Run())
+	assert.True(t, confirm.IsYes())
+	assert.Equal(t, "prod", env.GetSelected())
+	assert.Equal(t, []string{"api", "db"}, features.GetSelected())
+	assert.Equal(t, "8080", port.GetValue())
+	assert.True(t, executed, "FuncTask должна выполняться в неинтерактивном режиме")
+	assert.Contains(t, out.String(), "Headless")
+}
+
+// TestHeadlessQueueUsesDefaults проверяет использование значений тайм-аута и элемента по умолчанию
+func TestHeadlessQueueUsesDefaults(t *testing.T) {
+	confirm := task.NewYesNoTask("Подтверждение", "Продолжить?").WithTimeoutNo(time.Second)
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}}).WithDefaultItem("prod")
+	name := task.NewInputTaskNew("Имя", "Введите имя").WithTimeout(time.Second, "router")
+
+	model := query.New("Defaults").WithOutput(&bytes.Buffer{}).WithHeadless(nil)
+	model.AddTasks([]common.Task{confirm, env, name})
+
+	require.NoError(t, model.Run())
+	assert.True(t, confirm.IsNo())
+	assert.Equal(t, "prod", env.GetSelected())
+	assert.Equal(t, "router", name.GetValue())
+}
+
+// TestHeadlessQueueMissingAnswer проверяет понятную ошибку при отсутствии ответа
+func TestHeadlessQueueMissingAnswer(t *testing.T) {
+	name := task.NewInputTaskNew("Имя хоста", "Введите имя")
+	after := task.NewFuncTask("После", func() error { return nil })
+
+	model := query.New("Missing").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{})
+	model.AddTasks([]common.Task{name, after})
+
+	err := model.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Имя хоста")
+	assert.True(t, name.IsDone())
+	assert.True(t, name.HasError())
+	assert.False(t, after.IsDone(), "очередь должна остановиться на задаче без ответа")
+}
+
+// TestHeadlessQueueInvalidAnswer проверяет, что ответ проходит валидацию и проверку списка
+func TestHeadlessQueueInvalidAnswer(t *testing.T) {
+	port := task.NewInputTaskNew("Порт", "Введите порт").WithValidator(validation.Port())
+	model := query.New("Invalid").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{"Порт": "abc"})
+	model.AddTasks([]common.Task{port})
+	assert.Error(t, model.Run())
+
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}}).WithItemsDisabled("prod")
+	model = query.New("Disabled").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{"Среда": "prod"})
+	model.AddTasks([]common.Task{env})
+	err := model.Run()
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "prod"))
+}
+
+// TestHeadlessQueueFuncTaskError проверяет, что ошибка FuncTask останавливает очередь и возвращается из Run
+func TestHeadlessQueueFuncTaskError(t *testing.T) {
+	failing := task.NewFuncTask("Сбой", func() error { return errors.New("boom") })
+	model := query.New("Func").WithOutput(&bytes.Buffer{}).WithHeadless(nil)
+	model.AddTasks([]common.Task{failing})
+
+	err := model.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
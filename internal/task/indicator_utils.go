@@ -9,13 +9,14 @@ import (
 // appendIndicatorWithPlainPipe добавляет указатель к индикатору
 // Если указатель найден, то он будет добавлен в индикатор
 //
+// @param th - тема оформления
 // @param sb - билдер строки
 // @param indicator - индикатор
-func appendIndicatorWithPlainPipe(sb *strings.Builder, indicator string) {
-	pipe := ui.VerticalLineSymbol
+func appendIndicatorWithPlainPipe(th *ui.Theme, sb *strings.Builder, indicator string) {
+	pipe := th.Glyphs.Vertical
 	idx := strings.Index(indicator, pipe)
 	if idx == -1 {
-		sb.WriteString(ui.SubtleStyle.Render(indicator))
+		sb.WriteString(th.Styles.Subtle.Render(indicator))
 		return
 	}
 
@@ -23,12 +24,12 @@ func appendIndicatorWithPlainPipe(sb *strings.Builder, indicator string) {
 	suffix := indicator[idx+len(pipe):]
 
 	if prefix != "" {
-		sb.WriteString(ui.SubtleStyle.Render(prefix))
+		sb.WriteString(th.Styles.Subtle.Render(prefix))
 	}
 
 	sb.WriteString(pipe)
 
 	if suffix != "" {
-		sb.WriteString(ui.SubtleStyle.Render(suffix))
+		sb.WriteString(th.Styles.Subtle.Render(suffix))
 	}
 }
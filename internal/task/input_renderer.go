@@ -17,6 +17,8 @@ import (
 // Это отделяет UI логику от бизнес-логики задачи
 type InputRenderer struct {
 	style       lipgloss.Style
+	customStyle bool      // Стиль задан через WithStyle и не заменяется стилем темы
+	theme       *ui.Theme // Тема оформления (nil — глобальные стили пакета ui)
 	helpEnabled bool
 }
 
@@ -31,9 +33,27 @@ func NewInputRenderer() *InputRenderer {
 // WithStyle устанавливает пользовательский стиль
 func (r *InputRenderer) WithStyle(style lipgloss.Style) *InputRenderer {
 	r.style = style
+	r.customStyle = true
 	return r
 }
 
+// WithTheme устанавливает тему оформления рендерера
+func (r *InputRenderer) WithTheme(theme *ui.Theme) *InputRenderer {
+	r.theme = theme
+	if theme != nil && !r.customStyle {
+		r.style = theme.Styles.Input
+	}
+	return r
+}
+
+// Theme возвращает тему оформления рендерера
+func (r *InputRenderer) Theme() *ui.Theme {
+	if r.theme != nil {
+		return r.theme
+	}
+	return ui.CurrentTheme()
+}
+
 // WithHelp включает или отключает отображение справки
 func (r *InputRenderer) WithHelp(enabled bool) *InputRenderer {
 	r.helpEnabled = enabled
@@ -42,9 +62,10 @@ func (r *InputRenderer) WithHelp(enabled bool) *InputRenderer {
 
 // RenderInput отображает активное состояние задачи ввода с поддержкой таймера
 func (r *InputRenderer) RenderInput(title string, textInput textinput.Model, validator validation.Validator, err error, inputType InputType, prefix string, showSeparator bool, width int, timerStr ...string) string {
+	th := r.Theme()
 	// Используем переданный префикс или значение по умолчанию
 	if strings.TrimSpace(prefix) == "" {
-		prefix = ui.GetCurrentTaskPrefix()
+		prefix = th.CurrentTaskPrefix()
 	}
 
 	// При активной нумерации выравниваем префикс так же, как в задачах выбора
@@ -57,12 +78,12 @@ func (r *InputRenderer) RenderInput(title string, textInput textinput.Model, val
 	}
 
 	// Заголовок с учётом префикса, который уже содержит нужные пробелы
-	titleWithPrefix := fmt.Sprintf("%s%s", prefix, ui.ActiveTaskStyle.Render(title))
+	titleWithPrefix := fmt.Sprintf("%s%s", prefix, th.Styles.ActiveTask.Render(title))
 
 	// Если передан таймер, выравниваем его справа
 	var titleView string
 	if len(timerStr) > 0 && timerStr[0] != "" {
-		timer := ui.SubtleStyle.Render(timerStr[0])
+		timer := th.Styles.Subtle.Render(timerStr[0])
 		titleView = ui.AlignTextToRight(titleWithPrefix, timer, width)
 	} else {
 		titleView = titleWithPrefix
@@ -83,14 +104,14 @@ func (r *InputRenderer) RenderInput(title string, textInput textinput.Model, val
 			errText = ui.CapitalizeFirst(err.Error())
 			errText = fmt.Sprintf("%s%s", errIndent, errText)
 		}
-		errView = ui.GetErrorMessageStyle().Render(errText)
+		errView = th.Styles.ErrorMessage.Render(errText)
 	}
 
 	// Текст справки
 	var helpText string
 	if r.helpEnabled {
 		helpIndent := performance.RepeatEfficient(" ", ui.MainLeftIndent)
-		helpText = ui.SubtleStyle.Render(fmt.Sprintf("%s%s", helpIndent, defaults.InputConfirmHint))
+		helpText = th.Styles.Subtle.Render(fmt.Sprintf("%s%s", helpIndent, defaults.InputConfirmHint))
 	}
 
 	// Подсказка о типе ввода
@@ -99,15 +120,15 @@ func (r *InputRenderer) RenderInput(title string, textInput textinput.Model, val
 		description := validator.Description()
 		if description != "" {
 			hintIndent := performance.RepeatEfficient(" ", ui.MainLeftIndent)
-			typeHint = ui.SubtleStyle.Render(fmt.Sprintf("%s%s %s", hintIndent, defaults.InputFormatLabel, description))
+			typeHint = th.Styles.Subtle.Render(fmt.Sprintf("%s%s %s", hintIndent, defaults.InputFormatLabel, description))
 		}
 	}
 
 	// Подсказка
 	prompt := performance.FastConcat(
 		performance.RepeatEfficient(" ", ui.MainLeftIndent),
-		ui.CornerDownSymbol,
-		ui.HorizontalLineSymbol,
+		th.Glyphs.CornerDown,
+		th.Glyphs.Horizontal,
 	)
 
 	// Собираем все вместе динамически
@@ -116,10 +137,10 @@ func (r *InputRenderer) RenderInput(title string, textInput textinput.Model, val
 	// Основная часть
 	result.WriteString(titleView)
 	result.WriteString("\n")
-	result.WriteString(renderSelectionSeparator(width, showSeparator, prefix))
+	result.WriteString(renderSelectionSeparator(th, width, showSeparator, prefix))
 	result.WriteString(prompt + inputView)
 	result.WriteString("\n\n")
-	result.WriteString(ui.DrawLine(width))
+	result.WriteString(th.DrawLine(width))
 
 	// Один перевод после линии для первой дополнительной секции
 	first := true
@@ -144,6 +165,7 @@ func (r *InputRenderer) RenderInput(title string, textInput textinput.Model, val
 
 // RenderFinal отображает финальное состояние задачи ввода
 func (r *InputRenderer) RenderFinal(title string, value string, hasError bool, err error, prefix string, width int) string {
+	th := r.Theme()
 	var statusStyle lipgloss.Style
 	var valueToShow string
 	commentLines := make([]string, 0, 2)
@@ -152,7 +174,7 @@ func (r *InputRenderer) RenderFinal(title string, value string, hasError bool, e
 	buildCommentLine := func(indent, text string) string {
 		return performance.FastConcat(
 			performance.RepeatEfficient(" ", ui.MainLeftIndent),
-			ui.VerticalLineSymbol,
+			th.Glyphs.Vertical,
 			indent,
 			text,
 		)
@@ -160,7 +182,7 @@ func (r *InputRenderer) RenderFinal(title string, value string, hasError bool, e
 
 	// Если есть ошибка
 	if hasError {
-		statusStyle = ui.GetErrorStatusStyle()
+		statusStyle = th.Styles.ErrorStatus
 		if err != nil {
 			valueToShow = err.Error()
 		} else {
@@ -168,7 +190,7 @@ func (r *InputRenderer) RenderFinal(title string, value string, hasError bool, e
 		}
 
 		if taskErr, ok := err.(*terrors.TaskError); ok && taskErr.Type == terrors.ErrorTypeUserCancel {
-			statusStyle = ui.ErrorMessageStyle
+			statusStyle = th.Styles.ErrorMessage
 			valueToShow = defaults.ErrorTypeUserCancel
 
 			cancelMessage := defaults.ErrorMsgCanceled
@@ -179,10 +201,10 @@ func (r *InputRenderer) RenderFinal(title string, value string, hasError bool, e
 				}
 			}
 			cancelIndent := "   "
-			commentLines = append(commentLines, buildCommentLine(cancelIndent, ui.ErrorMessageStyle.Render(cancelMessage)))
+			commentLines = append(commentLines, buildCommentLine(cancelIndent, th.Styles.ErrorMessage.Render(cancelMessage)))
 		}
 	} else {
-		statusStyle = ui.TaskStatusSuccessStyle
+		statusStyle = th.Styles.TaskStatusSuccess
 		valueToShow = strings.ToUpper(defaults.DefaultSuccessLabel)
 
 		// Для паролей показываем звездочки вместо реального значения
@@ -193,7 +215,7 @@ func (r *InputRenderer) RenderFinal(title string, value string, hasError bool, e
 
 	// Используем префикс, переданный очередью, либо значение по умолчанию
 	if strings.TrimSpace(prefix) == "" {
-		prefix = ui.GetCompletedInputTaskPrefix(!hasError)
+		prefix = th.CompletedInputTaskPrefix(!hasError)
 	}
 	leftPart := fmt.Sprintf("%s  %s", prefix, title)
 	rightPart := statusStyle.Render(valueToShow)
@@ -201,7 +223,7 @@ func (r *InputRenderer) RenderFinal(title string, value string, hasError bool, e
 	if value != "" {
 		prefix := performance.FastConcat(
 			performance.RepeatEfficient(" ", ui.MainLeftIndent),
-			ui.VerticalLineSymbol,
+			th.Glyphs.Vertical,
 			defaultIndent,
 		)
 		availableWidth := width - lipgloss.Width(prefix) - 2
@@ -213,7 +235,7 @@ func (r *InputRenderer) RenderFinal(title string, value string, hasError bool, e
 			wrapped = []string{""}
 		}
 		for _, line := range wrapped {
-			commentLines = append(commentLines, prefix+ui.SubtleStyle.Render(line))
+			commentLines = append(commentLines, prefix+th.Styles.Subtle.Render(line))
 		}
 	}
 
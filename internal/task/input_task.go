@@ -13,6 +13,7 @@ import (
 	"github.com/qzeleza/ziva/internal/ui"
 	"github.com/qzeleza/ziva/internal/validation"
 
+	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -47,6 +48,7 @@ type InputTaskNew struct {
 	value         string    // Введенное значение
 	prompt        string    // Подсказка для ввода
 	placeholder   string    // Текст-заполнитель
+	initial       string    // Начальное значение поля (значение по умолчанию без тайм-аута)
 
 	// Настройки
 	width                   int  // Полная ширина поля ввода
@@ -252,6 +254,15 @@ func (t *InputTaskNew) WithPlaceholder(placeholder string) *InputTaskNew {
 	return t
 }
 
+// WithValue заполняет поле начальным значением, которое пользователь может изменить.
+// В неинтерактивном и построчном режимах оно используется как значение по умолчанию.
+func (t *InputTaskNew) WithValue(value string) *InputTaskNew {
+	t.initial = value
+	t.textInput.SetValue(value)
+	t.textInput.CursorEnd()
+	return t
+}
+
 // WithAllowEmpty разрешает пустые значения
 func (t *InputTaskNew) WithAllowEmpty(allow bool) *InputTaskNew {
 	t.allowEmpty = allow
@@ -261,9 +272,33 @@ func (t *InputTaskNew) WithAllowEmpty(allow bool) *InputTaskNew {
 // WithRenderer устанавливает пользовательский рендерер
 func (t *InputTaskNew) WithRenderer(renderer *InputRenderer) *InputTaskNew {
 	t.renderer = renderer
+	if t.theme != nil {
+		renderer.WithTheme(t.theme)
+	}
 	return t
 }
 
+// SetTheme задаёт тему оформления задачи: стиль курсора и рендерер
+func (t *InputTaskNew) SetTheme(theme *ui.Theme) {
+	t.BaseTask.SetTheme(theme)
+	t.renderer.WithTheme(theme)
+	if theme != nil {
+		t.textInput.Cursor.Style = lipgloss.NewStyle().Background(theme.Palette.Accent).Bold(true)
+		t.textInput.Cursor.TextStyle = lipgloss.NewStyle().Foreground(theme.Palette.Accent).Bold(true)
+	}
+}
+
+// SetClock задаёт источник времени задачи. Мигание курсора отсчитывается
+// по системному времени, поэтому с другими часами курсор не мигает
+func (t *InputTaskNew) SetClock(clock common.Clock) {
+	t.BaseTask.SetClock(clock)
+	mode := cursor.CursorBlink
+	if t.Clock() != common.SystemClock {
+		mode = cursor.CursorStatic
+	}
+	t.textInput.Cursor.SetMode(mode)
+}
+
 // WithStyle устанавливает стиль для рендерера
 func (t *InputTaskNew) WithStyle(style lipgloss.Style) *InputTaskNew {
 	t.renderer.WithStyle(style)
@@ -324,6 +359,10 @@ func (t *InputTaskNew) Update(msg tea.Msg) (Task, tea.Cmd) {
 		case "left", "Left":
 			// Обрабатываем выход по стрелке влево при пустом поле ввода
 			if performance.TrimSpaceEfficient(t.textInput.Value()) == "" {
+				// В очереди с возвратом открываем предыдущую задачу вместо отмены
+				if t.requestBack() {
+					return t, nil
+				}
 				return t.handleCancel()
 			}
 			var cmd tea.Cmd
@@ -404,6 +443,7 @@ func (t *InputTaskNew) validateInput() {
 
 // handleSubmit обрабатывает подтверждение ввода
 func (t *InputTaskNew) handleSubmit() (Task, tea.Cmd) {
+	th := t.Theme()
 	currentValue := t.textInput.Value()
 
 	// Финальная валидация
@@ -433,22 +473,23 @@ func (t *InputTaskNew) handleSubmit() (Task, tea.Cmd) {
 	t.SetError(nil)
 	t.value = currentValue
 	t.done = true
-	t.icon = ui.IconDone
-	t.finalValue = ui.SuccessLabelStyle.Render(t.getDisplayValue())
+	t.icon = th.Icons.Done
+	t.finalValue = th.Styles.SuccessLabel.Render(t.getDisplayValue())
 
 	return t, nil
 }
 
 // handleCancel обрабатывает отмену ввода
 func (t *InputTaskNew) handleCancel() (Task, tea.Cmd) {
+	th := t.Theme()
 	cancelErr := terrors.NewCancelError(t.title).
 		WithContext("input_type", t.inputType).
 		WithContext("partial_value", t.textInput.Value())
 
 	t.SetError(cancelErr)
 	t.done = true
-	t.icon = ui.IconCancelled
-	t.finalValue = ui.ErrorMessageStyle.Render(defaults.CancelShort)
+	t.icon = th.Icons.Cancelled
+	t.finalValue = th.Styles.ErrorMessage.Render(defaults.CancelShort)
 
 	return t, nil
 }
@@ -461,8 +502,15 @@ func (t *InputTaskNew) getDisplayValue() string {
 	return t.value
 }
 
+// isSecret сообщает, нужно ли скрывать значение в отчётах и журнале сеанса:
+// ввод маскируется или поле похоже на пароль (по тем же признакам, что и при отображении)
+func (t *InputTaskNew) isSecret() bool {
+	return t.maskInput || (t.renderer != nil && t.renderer.looksLikePassword(t.title, t.value))
+}
+
 // applyDefaultValue применяет значение по умолчанию при истечении таймера
 func (t *InputTaskNew) applyDefaultValue() {
+	th := t.Theme()
 	// Если есть значение по умолчанию
 	if t.defaultValue != nil {
 		var valueToSet string
@@ -489,8 +537,8 @@ func (t *InputTaskNew) applyDefaultValue() {
 				t.validationErr = validationErr
 				t.SetError(validationErr)
 				t.done = true
-				t.icon = ui.IconError
-				t.finalValue = ui.GetErrorMessageStyle().Render(defaults.ErrDefaultValueInvalid)
+				t.icon = th.Icons.Error
+				t.finalValue = th.Styles.ErrorMessage.Render(defaults.ErrDefaultValueInvalid)
 				return
 			}
 		}
@@ -502,8 +550,8 @@ func (t *InputTaskNew) applyDefaultValue() {
 			t.validationErr = emptyErr
 			t.SetError(emptyErr)
 			t.done = true
-			t.icon = ui.IconError
-			t.finalValue = ui.GetErrorMessageStyle().Render(defaults.ErrDefaultValueEmpty)
+			t.icon = th.Icons.Error
+			t.finalValue = th.Styles.ErrorMessage.Render(defaults.ErrDefaultValueEmpty)
 			return
 		}
 
@@ -511,8 +559,9 @@ func (t *InputTaskNew) applyDefaultValue() {
 		t.textInput.SetValue(valueToSet)
 		t.value = valueToSet
 		t.done = true
-		t.icon = ui.IconDone
-		t.finalValue = ui.SuccessLabelStyle.Render(t.getDisplayValue())
+		t.icon = th.Icons.Done
+		t.timedOut = true
+		t.finalValue = th.Styles.SuccessLabel.Render(t.getDisplayValue())
 		t.validationErr = nil
 		t.SetError(nil)
 	}
@@ -560,7 +609,7 @@ func (t *InputTaskNew) FinalView(width int) string {
 		return t.renderer.RenderFinal(t.title, "", true, t.Error(), completedPrefix, width)
 	}
 
-	return t.renderer.RenderFinal(t.title, t.getDisplayValue(), false, nil, completedPrefix, width)
+	return t.renderer.RenderFinal(t.title, t.getDisplayValue()+t.prefill.note(t.StateAnswer()), false, nil, completedPrefix, width)
 }
 
 // InputTaskBuilder предоставляет fluent API для создания InputTask
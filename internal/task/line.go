@@ -0,0 +1,406 @@
+// task/line.go
+
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// Поддержка построчного режима для последовательных консолей и простых терминалов.
+// Задача выводит вопрос обычными строками без управления курсором,
+// а ответ пользователя приходит строкой и проверяется теми же правилами,
+// что и ответы неинтерактивного режима (ApplyAnswer).
+
+// LineResult возвращает иконку и итоговое значение задачи без ANSI-оформления.
+//
+// @return Иконка и значение задачи
+func (t *BaseTask) LineResult() (string, string) {
+	return ui.StripANSI(t.icon), ui.StripANSI(t.finalValue)
+}
+
+// LinePrompt возвращает вопрос задачи выбора: пронумерованные варианты
+// и строку приглашения вида "Среда [1-3]: ".
+//
+// @return Текст вопроса
+func (t *SingleSelectTask) LinePrompt() string {
+	var b strings.Builder
+	writeLineChoices(&b, t.items, t.isDisabled, nil)
+
+	hint := fmt.Sprintf("[1-%d]", len(t.items))
+	if index := t.lineDefaultIndex(); index >= 0 {
+		hint += " " + fmt.Sprintf(defaults.LineDefaultHint, strconv.Itoa(index+1))
+	}
+	fmt.Fprintf(&b, "%s %s: ", t.title, hint)
+	return b.String()
+}
+
+// ApplyLineAnswer завершает задачу выбора ответом, введённым строкой:
+// номером варианта, ключом или названием. Пустая строка выбирает вариант по умолчанию.
+//
+// @param line Введённая строка
+// @return Ошибка, если вариант не найден, недоступен или ответ не задан
+func (t *SingleSelectTask) ApplyLineAnswer(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		index := t.lineDefaultIndex()
+		if index < 0 {
+			return terrors.NewValidationError(t.title, errors.New(defaults.LineChoiceRequired))
+		}
+		return t.ApplyAnswer(index)
+	}
+
+	answer, err := lineChoice(line, len(t.items))
+	if err != nil {
+		return terrors.NewValidationError(t.title, err)
+	}
+	return t.ApplyAnswer(answer)
+}
+
+// lineDefaultIndex возвращает индекс варианта по умолчанию или -1
+func (t *SingleSelectTask) lineDefaultIndex() int {
+	if t.hasDefaultItem {
+		return t.cursor
+	}
+	switch v := t.defaultValue.(type) {
+	case int:
+		if v >= 0 && v < len(t.items) {
+			return v
+		}
+	case string:
+		return t.choiceIndex(v)
+	}
+	return -1
+}
+
+// LinePrompt возвращает вопрос задачи Да/Нет вида "Продолжить? [Да/Нет]: ".
+//
+// @return Текст вопроса
+func (t *YesNoTask) LinePrompt() string {
+	question := t.question
+	if strings.TrimSpace(question) == "" {
+		question = t.title
+	}
+
+	hint := fmt.Sprintf("[%s/%s]", t.items[0].displayName(), t.items[1].displayName())
+	if index := t.lineDefaultIndex(); index >= 0 {
+		hint += " " + fmt.Sprintf(defaults.LineDefaultHint, t.items[index].displayName())
+	}
+	return fmt.Sprintf("%s %s: ", question, hint)
+}
+
+// ApplyLineAnswer завершает задачу Да/Нет ответом, введённым строкой:
+// "да"/"yes"/"нет"/"no" на всех языках, текстом варианта или его номером.
+//
+// @param line Введённая строка
+// @return Ошибка, если ответ не удалось распознать
+func (t *YesNoTask) ApplyLineAnswer(line string) error {
+	line = strings.TrimSpace(line)
+	switch strings.ToLower(line) {
+	case "y", "д":
+		return t.ApplyAnswer(true)
+	case "n", "н":
+		return t.ApplyAnswer(false)
+	case "":
+		index := t.lineDefaultIndex()
+		if index < 0 {
+			return terrors.NewValidationError(t.title, errors.New(defaults.LineChoiceRequired))
+		}
+		return t.ApplyAnswer(index)
+	}
+
+	answer, err := lineChoice(line, len(t.items))
+	if err != nil {
+		return terrors.NewValidationError(t.title, err)
+	}
+	return t.ApplyAnswer(answer)
+}
+
+// LinePrompt возвращает вопрос задачи множественного выбора: пронумерованные варианты
+// (отмеченные по умолчанию помечены) и строку приглашения.
+//
+// @return Текст вопроса
+func (t *MultiSelectTask) LinePrompt() string {
+	var b strings.Builder
+	writeLineChoices(&b, t.items, t.isDisabled, t.isSelectedRaw)
+
+	hint := fmt.Sprintf("[1-%d, %s]", len(t.items), defaults.LineMultiHint)
+	if indices := t.lineDefaultIndices(); len(indices) > 0 {
+		numbers := make([]string, len(indices))
+		for i, index := range indices {
+			numbers[i] = strconv.Itoa(index + 1)
+		}
+		hint += " " + fmt.Sprintf(defaults.LineDefaultHint, strings.Join(numbers, ","))
+	}
+	fmt.Fprintf(&b, "%s %s: ", t.title, hint)
+	return b.String()
+}
+
+// ApplyLineAnswer завершает задачу множественного выбора ответом, введённым строкой:
+// номерами, ключами или названиями через запятую. Пустая строка подтверждает
+// выбор по умолчанию. При ошибке исходный выбор восстанавливается.
+//
+// @param line Введённая строка
+// @return Ошибка, если вариант не найден, недоступен или выбор обязателен
+func (t *MultiSelectTask) ApplyLineAnswer(line string) error {
+	selected := t.selectedIndices()
+
+	var answers []interface{}
+	if strings.TrimSpace(line) == "" {
+		for _, index := range t.lineDefaultIndices() {
+			answers = append(answers, index)
+		}
+	} else {
+		for _, part := range strings.Split(line, ",") {
+			for _, token := range lineTokens(part) {
+				answer, err := lineChoice(token, len(t.items))
+				if err != nil {
+					return terrors.NewValidationError(t.title, err)
+				}
+				answers = append(answers, answer)
+			}
+		}
+	}
+
+	if err := t.ApplyAnswer(answers); err != nil {
+		t.clearAllSelections()
+		for _, index := range selected {
+			t.setSelectedState(index, true)
+		}
+		return err
+	}
+	return nil
+}
+
+// selectedIndices возвращает индексы выбранных элементов по порядку
+func (t *MultiSelectTask) selectedIndices() []int {
+	var indices []int
+	for i := range t.items {
+		if t.isSelectedRaw(i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// lineDefaultIndices возвращает индексы элементов по умолчанию:
+// отмеченные заранее или заданные значением тайм-аута
+func (t *MultiSelectTask) lineDefaultIndices() []int {
+	if indices := t.selectedIndices(); len(indices) > 0 {
+		return indices
+	}
+
+	var indices []int
+	switch v := t.defaultValue.(type) {
+	case []int:
+		for _, index := range v {
+			if index >= 0 && index < len(t.items) && !t.isDisabled(index) {
+				indices = append(indices, index)
+			}
+		}
+	case []string:
+		for _, value := range v {
+			if index := t.choiceIndex(value); index != -1 && !t.isDisabled(index) {
+				indices = append(indices, index)
+			}
+		}
+	}
+	return indices
+}
+
+// LinePrompt возвращает приглашение задачи ввода: подсказку или заголовок задачи.
+//
+// @return Текст приглашения
+func (t *InputTaskNew) LinePrompt() string {
+	label := strings.TrimSpace(t.prompt)
+	if label == "" {
+		label = t.title
+	}
+	label = strings.TrimSuffix(label, ":")
+	if t.placeholder != "" && t.placeholder != defaults.DefaultPlaceholder {
+		label += " (" + t.placeholder + ")"
+	}
+	if t.initial != "" && !t.maskInput {
+		label += " [" + t.initial + "]"
+	}
+	return label + ": "
+}
+
+// ApplyLineAnswer завершает задачу ввода введённой строкой.
+// Пустая строка выбирает начальное значение поля, если оно задано.
+// Значение проходит те же проверки, что и при вводе в полноэкранном режиме.
+//
+// @param line Введённая строка
+// @return Ошибка валидации
+func (t *InputTaskNew) ApplyLineAnswer(line string) error {
+	if line == "" && t.initial != "" {
+		line = t.initial
+	}
+	return t.ApplyAnswer(line)
+}
+
+// LinePrompt возвращает приглашение задачи многострочного ввода
+// с подсказкой о завершении ввода.
+//
+// @return Текст приглашения
+func (t *TextAreaTask) LinePrompt() string {
+	label := strings.TrimSpace(t.prompt)
+	if label == "" {
+		label = t.title
+	}
+	label = strings.TrimSuffix(label, ":")
+	return label + " " + defaults.LineTextAreaHint + ":\n"
+}
+
+// LineMultiline сообщает, что ответ задачи вводится несколькими строками
+// до строки, содержащей только ".".
+//
+// @return true
+func (t *TextAreaTask) LineMultiline() bool {
+	return true
+}
+
+// ApplyLineAnswer завершает задачу введёнными строками.
+// Пустой ответ выбирает начальный текст поля, если он задан.
+//
+// @param text Введённый текст
+// @return Ошибка валидации
+func (t *TextAreaTask) ApplyLineAnswer(text string) error {
+	if text == "" && t.initial != "" {
+		text = t.initial
+	}
+	return t.ApplyAnswer(text)
+}
+
+// writeLineChoices выводит пронумерованные варианты выбора.
+// Недоступные варианты помечаются, выбранные (если задана функция selected) отмечаются "*".
+func writeLineChoices(b *strings.Builder, items []choice, disabled func(int) bool, selected func(int) bool) {
+	width := len(strconv.Itoa(len(items)))
+	for i, item := range items {
+		mark := " "
+		if selected != nil && selected(i) {
+			mark = "*"
+		}
+		fmt.Fprintf(b, "  %s%*d) %s", mark, width, i+1, item.displayName())
+		if help := item.helpText(); help != "" {
+			fmt.Fprintf(b, " - %s", help)
+		}
+		if disabled(i) {
+			fmt.Fprintf(b, " %s", defaults.LineDisabledMark)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// lineChoice преобразует ответ строкой в ответ ApplyAnswer: номер варианта — в индекс,
+// иначе строка остаётся ключом или названием.
+//
+// @param token Часть введённой строки
+// @param count Число вариантов
+// @return Индекс (int) или строка
+func lineChoice(token string, count int) (interface{}, error) {
+	token = strings.TrimSpace(token)
+	number, err := strconv.Atoi(token)
+	if err != nil {
+		return token, nil
+	}
+	if number < 1 || number > count {
+		return nil, fmt.Errorf(defaults.ErrAnswerUnknownOption, token)
+	}
+	return number - 1, nil
+}
+
+// lineTokens разбивает часть ответа на номера, разделённые пробелами ("1 3"),
+// или возвращает её целиком, если это ключ или название
+func lineTokens(part string) []string {
+	fields := strings.Fields(part)
+	for _, field := range fields {
+		if _, err := strconv.Atoi(field); err != nil {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				return []string{trimmed}
+			}
+			return nil
+		}
+	}
+	return fields
+}
+
+// LinePrompt возвращает приглашение задачи выбора файла
+// с каталогом, от которого отсчитываются относительные пути.
+//
+// @return Текст приглашения
+func (t *FilePickerTask) LinePrompt() string {
+	_, start, err := t.resolveDirs()
+	if err != nil {
+		start = t.startDir
+	}
+	hint := defaults.LinePathHint
+	if t.multiple {
+		hint = defaults.LinePathsHint
+	}
+	return fmt.Sprintf("%s %s: ", t.title, fmt.Sprintf(hint, start))
+}
+
+// ApplyLineAnswer завершает задачу выбора файла путём, введённым строкой
+// (при множественном выборе - путями через запятую).
+//
+// @param line Введённая строка
+// @return Ошибка проверки пути
+func (t *FilePickerTask) ApplyLineAnswer(line string) error {
+	return t.ApplyAnswer(strings.TrimSpace(line))
+}
+
+// LinePrompt возвращает приглашение задачи выбора даты, времени или длительности
+// с ожидаемым форматом и начальным значением.
+//
+// @return Текст приглашения
+func (t *DateTimeTask) LinePrompt() string {
+	label := t.title + " (" + t.formatHint() + ")"
+	if t.initial {
+		label += " [" + t.GetValue() + "]"
+	}
+	return label + ": "
+}
+
+// ApplyLineAnswer завершает задачу значением, введённым строкой.
+// Пустая строка выбирает начальное значение, если оно задано.
+//
+// @param line Введённая строка
+// @return Ошибка разбора или выхода за границы диапазона
+func (t *DateTimeTask) ApplyLineAnswer(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" && t.initial {
+		line = t.GetValue()
+	}
+	return t.ApplyAnswer(line)
+}
+
+// LinePrompt возвращает приглашение задачи выбора числа с диапазоном и начальным значением.
+//
+// @return Текст приглашения
+func (t *NumberTask) LinePrompt() string {
+	label := t.title + " " + fmt.Sprintf(defaults.LineNumberHint, t.formatValue(t.low), t.formatValue(t.high))
+	if t.initial {
+		label += " [" + t.formatNumber(t.value) + "]"
+	}
+	return label + ": "
+}
+
+// ApplyLineAnswer завершает задачу числом, введённым строкой.
+// Пустая строка выбирает начальное значение, если оно задано.
+//
+// @param line Введённая строка
+// @return Ошибка разбора или выхода за границы диапазона
+func (t *NumberTask) ApplyLineAnswer(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" && t.initial {
+		return t.ApplyAnswer(t.value)
+	}
+	return t.ApplyAnswer(line)
+}
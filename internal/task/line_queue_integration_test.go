@@ -0,0 +1,84 @@
+package task_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/qzeleza/ziva/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLineQueueReadsAnswers проверяет построчный режим: номера вариантов, повтор вопроса
+// при некорректном ответе и отсутствие управляющих последовательностей в выводе
+func TestLineQueueReadsAnswers(t *testing.T) {
+	confirm := task.NewYesNoTask("Подтверждение", "Продолжить?")
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev", Name: "Разработка"}, {Key: "test"}, {Key: "prod", Name: "Боевая"}})
+	features := task.NewMultiSelectTask("Компоненты", []task.Item{{Key: "api"}, {Key: "web"}, {Key: "db"}})
+	port := task.NewInputTaskNew("Порт", "Введите порт").WithValidator(validation.Port())
+
+	executed := false
+	deploy := task.NewFuncTask("Развёртывание", func() error {
+		executed = true
+		return nil
+	})
+
+	input := strings.Join([]string{"y", "5", "3", "1, db", "99999", "8080"}, "\n") + "\n"
+	var out bytes.Buffer
+	model := query.New("Установка").WithOutput(&out).WithLineMode(strings.NewReader(input))
+	model.AddTasks([]common.Task{confirm, env, features, port, deploy})
+
+	require.NoError(t, model.Run())
+	assert.True(t, confirm.IsYes())
+	assert.Equal(t, "prod", env.GetSelected())
+	assert.Equal(t, []string{"api", "db"}, features.GetSelected())
+	assert.Equal(t, "8080", port.GetValue())
+	assert.True(t, executed)
+
+	output := out.String()
+	assert.NotContains(t, output, "\x1b", "построчный режим не использует управляющие последовательности")
+	assert.Contains(t, output, "  2) test\n")
+	assert.Contains(t, output, "Среда [1-3]: ")
+	assert.Equal(t, 2, strings.Count(output, "Среда [1-3]: "), "вопрос повторяется после неверного номера")
+	assert.Equal(t, 2, strings.Count(output, "Введите порт: "), "ввод проверяется валидатором")
+	assert.Contains(t, output, "Среда: Боевая")
+}
+
+// TestLineQueueDefaultsAndClosedInput проверяет выбор по умолчанию пустой строкой
+// и ошибку при закрытом вводе
+func TestLineQueueDefaultsAndClosedInput(t *testing.T) {
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}}).WithDefaultItem("prod")
+	name := task.NewInputTaskNew("Имя хоста", "")
+	after := task.NewFuncTask("После", func() error { return nil })
+
+	var out bytes.Buffer
+	model := query.New("").WithOutput(&out).WithLineMode(strings.NewReader("\n"))
+	model.AddTasks([]common.Task{env, name, after})
+
+	err := model.Run()
+	require.Error(t, err)
+	assert.Equal(t, "prod", env.GetSelected())
+	assert.Contains(t, out.String(), "Среда [1-2] ")
+	assert.True(t, name.HasError())
+	assert.False(t, after.IsDone(), "очередь останавливается, если ответ получить невозможно")
+}
+
+// TestLineQueueTextArea проверяет многострочный ответ до строки "." и до конца ввода
+func TestLineQueueTextArea(t *testing.T) {
+	banner := task.NewTextAreaTask("Баннер", "Текст баннера")
+	key := task.NewTextAreaTask("Ключ SSH", "")
+
+	input := "Добро пожаловать\n\nна роутер\n.\nssh-ed25519 AAAA user@host\n"
+	var out bytes.Buffer
+	model := query.New("").WithOutput(&out).WithLineMode(strings.NewReader(input))
+	model.AddTasks([]common.Task{banner, key})
+
+	require.NoError(t, model.Run())
+	assert.Equal(t, "Добро пожаловать\n\nна роутер", banner.GetValue())
+	assert.Equal(t, "ssh-ed25519 AAAA user@host", key.GetValue())
+	assert.Contains(t, out.String(), "Баннер: Добро пожаловать …")
+}
@@ -133,6 +133,7 @@ type MultiSelectTask struct {
 	selectAllEnableText  string                 // Текст опции активации "Выбрать все"
 	selectAllDisableText string                 // Текст опции отключения выбора
 	selectAllStyle       lipgloss.Style         // Стиль отображения опции "Выбрать все"
+	selectAllStyleCustom bool                   // Стиль опции "Выбрать все" задан явно и не заменяется темой
 	showHelpMessage      bool                   // Показывать ли сообщение-подсказку
 	helpMessage          string                 // Текст сообщения-подсказки
 	// Viewport (окно просмотра) для ограничения количества отображаемых элементов
@@ -140,6 +141,9 @@ type MultiSelectTask struct {
 	viewportStart    int  // Начальная позиция viewport в списке элементов
 	showCounters     bool // Показывать ли счетчики для выбранных элементов
 	requireSelection bool // Требовать выбор хотя бы одного элемента перед завершением задачи
+	hasDefaultItems  bool // Элементы по умолчанию были заданы явно
+	// filter сужает список по запросу, введённому после нажатия "/"
+	filter itemFilter
 }
 
 // NewMultiSelectTask создает новую задачу множественного выбора.
@@ -194,7 +198,7 @@ func (t *MultiSelectTask) isDisabled(index int) bool {
 // ensureCursorSelectable пытается разместить курсор на ближайшем доступном элементе
 func (t *MultiSelectTask) ensureCursorSelectable() bool {
 	if len(t.items) == 0 {
-		if t.hasSelectAll {
+		if t.selectAllShown() {
 			t.cursor = -1
 		} else {
 			t.cursor = -1
@@ -202,11 +206,11 @@ func (t *MultiSelectTask) ensureCursorSelectable() bool {
 		return false
 	}
 
-	if t.hasSelectAll && t.cursor == -1 {
+	if t.selectAllShown() && t.cursor == -1 {
 		return true
 	}
 
-	if t.cursor >= 0 && t.cursor < len(t.items) && !t.isDisabled(t.cursor) {
+	if t.cursor >= 0 && t.cursor < len(t.items) && t.isSelectable(t.cursor) {
 		return true
 	}
 
@@ -225,7 +229,7 @@ func (t *MultiSelectTask) ensureCursorSelectable() bool {
 		return true
 	}
 
-	if t.hasSelectAll {
+	if t.selectAllShown() {
 		t.cursor = -1
 		return true
 	}
@@ -240,7 +244,7 @@ func (t *MultiSelectTask) findEnabledForward(from int) (int, bool) {
 		from = 0
 	}
 	for i := from; i < len(t.items); i++ {
-		if !t.isDisabled(i) {
+		if t.isSelectable(i) {
 			return i, true
 		}
 	}
@@ -253,13 +257,35 @@ func (t *MultiSelectTask) findEnabledBackward(from int) (int, bool) {
 		from = len(t.items) - 1
 	}
 	for i := from; i >= 0; i-- {
-		if !t.isDisabled(i) {
+		if t.isSelectable(i) {
 			return i, true
 		}
 	}
 	return -1, false
 }
 
+// isSelectable проверяет, доступен ли элемент для курсора: не отключён и не скрыт фильтром
+func (t *MultiSelectTask) isSelectable(index int) bool {
+	return !t.isDisabled(index) && t.filter.includes(index)
+}
+
+// selectAllShown сообщает, отображается ли опция "Выбрать все".
+// При активном фильтре опция скрывается, чтобы не затрагивать невидимые элементы.
+func (t *MultiSelectTask) selectAllShown() bool {
+	return t.hasSelectAll && !t.filter.applied()
+}
+
+// applyFilter пересчитывает видимые элементы после изменения запроса фильтра
+func (t *MultiSelectTask) applyFilter() {
+	t.filter.refresh(t.items)
+	t.viewportStart = 0
+	if t.cursor == -1 && !t.selectAllShown() {
+		t.cursor = 0
+	}
+	t.ensureCursorSelectable()
+	t.updateViewport()
+}
+
 // moveCursorForward перемещает курсор на следующий доступный элемент
 func (t *MultiSelectTask) moveCursorForward() bool {
 	original := t.cursor
@@ -276,7 +302,7 @@ func (t *MultiSelectTask) moveCursorForward() bool {
 		return true
 	}
 
-	if t.hasSelectAll && original != -1 {
+	if t.selectAllShown() && original != -1 {
 		t.cursor = -1
 		return true
 	}
@@ -305,7 +331,7 @@ func (t *MultiSelectTask) moveCursorBackward() bool {
 		return true
 	}
 
-	if t.hasSelectAll {
+	if t.selectAllShown() {
 		if original != -1 {
 			t.cursor = -1
 			return true
@@ -608,8 +634,8 @@ func (t *MultiSelectTask) updateViewport() {
 	}
 
 	// Получаем эффективную позицию курсора (с учетом опции "Выбрать все")
-	effectiveCursor := t.cursor
-	if t.hasSelectAll {
+	effectiveCursor := t.filter.position(t.cursor)
+	if t.selectAllShown() {
 		effectiveCursor = t.cursor + 1 // +1 потому что опция "Выбрать все" занимает позицию -1
 	}
 
@@ -628,9 +654,10 @@ func (t *MultiSelectTask) updateViewport() {
 		t.viewportStart = 0
 	}
 
-	maxStart := len(t.items) - t.viewportSize
-	if t.hasSelectAll {
-		maxStart = len(t.items) + 1 - t.viewportSize // +1 для опции "Выбрать все"
+	total := t.filter.count(len(t.items))
+	maxStart := total - t.viewportSize
+	if t.selectAllShown() {
+		maxStart = total + 1 - t.viewportSize // +1 для опции "Выбрать все"
 	}
 	if maxStart < 0 {
 		maxStart = 0
@@ -641,19 +668,22 @@ func (t *MultiSelectTask) updateViewport() {
 }
 
 // getVisibleRange возвращает диапазон видимых элементов с учетом viewport
+// При активном фильтре диапазон задаётся позициями в отфильтрованном списке.
 // Возвращает: startIdx, endIdx, showSelectAll
 func (t *MultiSelectTask) getVisibleRange() (int, int, bool) {
+	total := t.filter.count(len(t.items))
+
 	// Если viewport отключен, показываем все элементы
 	if t.viewportSize <= 0 {
-		return 0, len(t.items), t.hasSelectAll
+		return 0, total, t.selectAllShown()
 	}
 
 	// Определяем, показывать ли опцию "Выбрать все"
-	showSelectAll := t.hasSelectAll && t.viewportStart == 0
+	showSelectAll := t.selectAllShown() && t.viewportStart == 0
 
 	// Вычисляем диапазон элементов списка
 	startIdx := t.viewportStart
-	if t.hasSelectAll && startIdx > 0 {
+	if t.selectAllShown() && startIdx > 0 {
 		startIdx-- // Компенсируем опцию "Выбрать все"
 	}
 
@@ -666,13 +696,26 @@ func (t *MultiSelectTask) getVisibleRange() (int, int, bool) {
 		endIdx-- // Уменьшаем на 1, так как одно место занимает опция "Выбрать все"
 	}
 
-	if endIdx > len(t.items) {
-		endIdx = len(t.items)
+	if endIdx > total {
+		endIdx = total
 	}
 
 	return startIdx, endIdx, showSelectAll
 }
 
+// SetTheme задаёт тему оформления задачи и обновляет стили активного элемента
+// и опции "Выбрать все" (если стиль опции не задан явно).
+func (t *MultiSelectTask) SetTheme(theme *ui.Theme) {
+	t.BaseTask.SetTheme(theme)
+	if theme == nil {
+		return
+	}
+	t.activeStyle = theme.Styles.Active
+	if !t.selectAllStyleCustom {
+		t.selectAllStyle = theme.Styles.MenuAction
+	}
+}
+
 // WithSelectAll добавляет опцию "Выбрать все" в начало списка.
 // При выборе этой опции все остальные пункты автоматически помечаются/снимаются.
 //
@@ -684,6 +727,10 @@ func (t *MultiSelectTask) WithSelectAll(options ...interface{}) *MultiSelectTask
 	enableText := defaults.SelectAllDefaultText
 	disableText := defaults.SelectAllDisableDefaultText
 	style := ui.MenuActionDefaultStyle()
+	if t.theme != nil {
+		style = t.theme.Styles.MenuAction
+	}
+	customStyle := false
 	stringIndex := 0
 
 	for _, option := range options {
@@ -701,10 +748,10 @@ func (t *MultiSelectTask) WithSelectAll(options ...interface{}) *MultiSelectTask
 			}
 			stringIndex++
 		case lipgloss.Style:
-			style = v
+			style, customStyle = v, true
 		case *lipgloss.Style:
 			if v != nil {
-				style = *v
+				style, customStyle = *v, true
 			}
 		}
 	}
@@ -712,6 +759,7 @@ func (t *MultiSelectTask) WithSelectAll(options ...interface{}) *MultiSelectTask
 	t.selectAllEnableText = enableText
 	t.selectAllDisableText = disableText
 	t.selectAllStyle = style
+	t.selectAllStyleCustom = customStyle
 	t.ensureCursorSelectable()
 	t.updateViewport()
 	return t
@@ -780,6 +828,8 @@ func (t *MultiSelectTask) WithDefaultItems(defauiltSelection interface{}) *Multi
 		}
 	}
 
+	t.hasDefaultItems = true
+
 	if anyApplied {
 		// Перемещаем курсор на первый выбранный элемент (если он вне диапазона)
 		if t.cursor < 0 || t.cursor >= len(t.items) {
@@ -979,6 +1029,15 @@ func (t *MultiSelectTask) Update(msg tea.Msg) (Task, tea.Cmd) {
 			t.helpMessage = ""
 		}
 
+		// Ввод запроса фильтра ("/" включает режим фильтра)
+		if handled, changed := t.filter.handleKey(msg); handled {
+			t.stopTimeout()
+			if changed {
+				t.applyFilter()
+			}
+			return t, nil
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			t.stopTimeout()
@@ -999,7 +1058,7 @@ func (t *MultiSelectTask) Update(msg tea.Msg) (Task, tea.Cmd) {
 			t.stopTimeout()
 
 			// В любом случае выполняем выбор/переключение
-			if t.hasSelectAll && t.cursor == -1 {
+			if t.selectAllShown() && t.cursor == -1 {
 				// Нажатие пробела на опции "Выбрать все"
 				t.toggleSelectAll()
 			} else if t.cursor >= 0 && !t.isDisabled(t.cursor) {
@@ -1008,6 +1067,10 @@ func (t *MultiSelectTask) Update(msg tea.Msg) (Task, tea.Cmd) {
 			}
 		case "q", "Q", "esc", "Esc", "ctrl+c", "Ctrl+C", "left", "Left":
 			t.stopTimeout()
+			// В очереди с возвратом стрелка влево открывает предыдущую задачу
+			if isBackKey(msg.String()) && t.requestBack() {
+				return t, nil
+			}
 			return t.confirmSelection()
 
 		case "enter":
@@ -1030,6 +1093,7 @@ func (t *MultiSelectTask) Update(msg tea.Msg) (Task, tea.Cmd) {
 
 // confirmSelection завершает задачу, имитируя поведение клавиши Enter.
 func (t *MultiSelectTask) confirmSelection() (Task, tea.Cmd) {
+	th := t.Theme()
 	keys, names := t.collectSelectionSnapshot()
 	if len(keys) == 0 {
 		if t.requireSelection {
@@ -1040,7 +1104,7 @@ func (t *MultiSelectTask) confirmSelection() (Task, tea.Cmd) {
 		}
 		// Пустой выбор разрешен — завершаем без ошибок
 		t.done = true
-		t.icon = ui.IconDone
+		t.icon = th.Icons.Done
 		t.finalValue = defaults.DefaultSuccessLabel
 		t.SetError(nil)
 		t.showHelpMessage = false
@@ -1050,7 +1114,7 @@ func (t *MultiSelectTask) confirmSelection() (Task, tea.Cmd) {
 
 	// Есть выбранные элементы — завершаем с их перечислением
 	t.done = true
-	t.icon = ui.IconDone
+	t.icon = th.Icons.Done
 	t.finalValue = strings.Join(names, defaults.DefaultSeparator)
 	t.SetError(nil)
 	t.showHelpMessage = false
@@ -1069,6 +1133,7 @@ func (t *MultiSelectTask) Run() tea.Cmd {
 
 // applyDefaultValue применяет значение по умолчанию при истечении таймера
 func (t *MultiSelectTask) applyDefaultValue() {
+	th := t.Theme()
 	// Если есть значение по умолчанию
 	if t.defaultValue != nil {
 		switch val := t.defaultValue.(type) {
@@ -1114,7 +1179,8 @@ func (t *MultiSelectTask) applyDefaultValue() {
 			_, names := t.collectSelectionSnapshot()
 			// Завершаем задачу
 			t.done = true
-			t.icon = ui.IconDone
+			t.icon = th.Icons.Done
+			t.timedOut = true
 			t.finalValue = strings.Join(names, defaults.DefaultSeparator)
 			t.SetError(nil)
 		}
@@ -1126,6 +1192,7 @@ func (t *MultiSelectTask) applyDefaultValue() {
 // @param width Ширина макета для отображения
 // @return Строка с отформатированным представлением задачи
 func (t *MultiSelectTask) View(width int) string {
+	th := t.Theme()
 	// Если задача завершена, возвращаем FinalView
 	if t.done {
 		return t.FinalView(width)
@@ -1136,7 +1203,7 @@ func (t *MultiSelectTask) View(width int) string {
 	titlePrefix := t.InProgressPrefix()
 
 	// Формируем заголовок с префиксом
-	title := ui.ActiveTaskStyle.Render(t.title)
+	title := th.Styles.ActiveTask.Render(t.title)
 	titleWithPrefix := fmt.Sprintf("%s%s", titlePrefix, title)
 
 	// Получаем отформатированный таймер (если он активен)
@@ -1150,10 +1217,16 @@ func (t *MultiSelectTask) View(width int) string {
 		sb.WriteString(titleWithPrefix + "\n")
 	}
 
-	sb.WriteString(renderSelectionSeparator(width, t.showSelectionSeparator, titlePrefix))
+	sb.WriteString(renderSelectionSeparator(th, width, t.showSelectionSeparator, titlePrefix))
+
+	// Строка ввода фильтра
+	if t.filter.active() {
+		sb.WriteString(t.filter.renderFilterLine(th, len(t.items)))
+	}
 
 	// Получаем диапазон видимых элементов с учетом viewport
 	startIdx, endIdx, showSelectAll := t.getVisibleRange()
+	total := t.filter.count(len(t.items))
 
 	// Отображаем опцию "Выбрать все" если она включена и видима в viewport
 	if showSelectAll {
@@ -1163,18 +1236,18 @@ func (t *MultiSelectTask) View(width int) string {
 
 		// Проверяем, выбраны ли все элементы
 		if t.isAllSelected() {
-			checked = ui.IconSelected
+			checked = th.Icons.Selected
 			displayText = t.selectAllDisableText
 		}
 
 		// Определяем префикс для опции "Выбрать все"
 		if t.cursor == -1 {
 			// Опция "Выбрать все" активна
-			itemPrefix = ui.GetSelectItemPrefix("active")
+			itemPrefix = th.SelectItemPrefix("active")
 		} else {
 			// Опция "Выбрать все" не активна - всегда показываем префикс "above"
 			// потому что курсор находится ниже неё (на элементах списка)
-			itemPrefix = ui.GetSelectItemPrefix("above")
+			itemPrefix = th.SelectItemPrefix("above")
 		}
 
 		// Применяем стиль отображения
@@ -1187,24 +1260,24 @@ func (t *MultiSelectTask) View(width int) string {
 
 	// Добавляем индикатор прокрутки вверх, если есть скрытые элементы выше
 	// При наличии пункта "Выбрать все" индикатор должен показываться даже если startIdx == 0
-	if t.viewportSize > 0 && (startIdx > 0 || (t.hasSelectAll && t.viewportStart > 0)) {
+	if t.viewportSize > 0 && (startIdx > 0 || (t.selectAllShown() && t.viewportStart > 0)) {
 		// Используем точно такой же префикс как у элементов "above"
-		indentPrefix := ui.GetSelectItemPrefix("above")
+		indentPrefix := th.SelectItemPrefix("above")
 		// Определяем количество элементов выше
 		itemsAbove := startIdx
-		if t.hasSelectAll && t.viewportStart > 0 {
+		if t.selectAllShown() && t.viewportStart > 0 {
 			// Если есть пункт "Выбрать все" и он скрыт, добавляем +1 к счетчику
 			itemsAbove = t.viewportStart
 		}
 		var indicator string
 		if t.showCounters {
-			arrow := ui.UpArrowSymbol + " "
+			arrow := th.Glyphs.UpArrow + " "
 			indicator = fmt.Sprintf(defaults.ScrollAboveFormat, indentPrefix, arrow, itemsAbove)
 		} else {
-			indicator = fmt.Sprintf("%s %s", indentPrefix, ui.UpArrowSymbol)
+			indicator = fmt.Sprintf("%s %s", indentPrefix, th.Glyphs.UpArrow)
 		}
 		// Не добавляем перенос строки в самой строке, чтобы не нарушать форматирование
-		appendIndicatorWithPlainPipe(&sb, indicator)
+		appendIndicatorWithPlainPipe(th, &sb, indicator)
 		// Добавляем перенос строки отдельно
 		sb.WriteString("\n")
 	}
@@ -1212,8 +1285,9 @@ func (t *MultiSelectTask) View(width int) string {
 	activeHelp := ""
 
 	// Отображаем только видимые элементы списка
-	for i := startIdx; i < endIdx; i++ {
-		if i >= len(t.items) {
+	for pos := startIdx; pos < endIdx; pos++ {
+		i := t.filter.indexAt(pos)
+		if i < 0 || i >= len(t.items) {
 			break
 		}
 
@@ -1225,40 +1299,43 @@ func (t *MultiSelectTask) View(width int) string {
 		itemDisabled := t.isDisabled(i)
 		isExit := isExitChoice(item)
 		isBack := !isExit && isBackChoice(item)
+		labelStyle, styled := lipgloss.NewStyle(), false
 
 		if t.isSelected(i) {
-			checked = ui.IconSelected
+			checked = th.Icons.Selected
 		}
 
 		if itemDisabled {
-			label = ui.DisabledStyle.Render(label)
-			checked = ui.DisabledStyle.Render(checked)
+			labelStyle, styled = th.Styles.Disabled, true
+			checked = th.Styles.Disabled.Render(checked)
 		}
 		if !itemDisabled && t.cursor != i {
 			switch {
 			case isExit:
-				label = ui.MenuExitItemStyle.Render(label)
+				labelStyle, styled = th.Styles.MenuExitItem, true
 			case isBack:
-				label = ui.MenuBackItemStyle.Render(label)
+				labelStyle, styled = th.Styles.MenuBackItem, true
 			}
 		}
 
 		if t.cursor == i {
-			itemPrefix = ui.GetSelectItemPrefix("active")
-			label = t.activeStyle.Render(label)
-		} else if t.hasSelectAll && t.cursor == -1 {
-			itemPrefix = ui.GetSelectItemPrefix("below")
+			itemPrefix = th.SelectItemPrefix("active")
+			labelStyle, styled = t.activeStyle, true
+		} else if t.selectAllShown() && t.cursor == -1 {
+			itemPrefix = th.SelectItemPrefix("below")
 		} else if i < t.cursor {
-			itemPrefix = ui.GetSelectItemPrefix("above")
+			itemPrefix = th.SelectItemPrefix("above")
 		} else {
-			itemPrefix = ui.GetSelectItemPrefix("below")
+			itemPrefix = th.SelectItemPrefix("below")
 		}
+		// Применяем стиль и подсвечиваем символы, совпавшие с фильтром
+		label = renderFilteredLabel(th, label, labelStyle, styled, t.filter.highlighted(i))
 
 		openBracket := "["
 		closeBracket := "]"
 		if itemDisabled {
-			openBracket = ui.DisabledStyle.Render(openBracket)
-			closeBracket = ui.DisabledStyle.Render(closeBracket)
+			openBracket = th.Styles.Disabled.Render(openBracket)
+			closeBracket = th.Styles.Disabled.Render(closeBracket)
 		}
 		sb.WriteString(fmt.Sprintf("%s%s%s%s %s\n", itemPrefix, openBracket, checked, closeBracket, label))
 
@@ -1268,19 +1345,19 @@ func (t *MultiSelectTask) View(width int) string {
 	}
 
 	// Добавляем индикатор прокрутки вниз, если есть скрытые элементы ниже
-	if t.viewportSize > 0 && endIdx < len(t.items) {
+	if t.viewportSize > 0 && endIdx < total {
 		// Используем точно такой же префикс как у элементов "below"
-		indentPrefix := ui.GetSelectItemPrefix("below")
+		indentPrefix := th.SelectItemPrefix("below")
 		// Не добавляем перенос строки в конце, чтобы не нарушать форматирование
-		remaining := len(t.items) - endIdx
+		remaining := total - endIdx
 		var indicator string
 		if t.showCounters {
-			arrow := ui.DownArrowSymbol + " "
+			arrow := th.Glyphs.DownArrow + " "
 			indicator = fmt.Sprintf(defaults.ScrollBelowFormat, indentPrefix, arrow, remaining)
 		} else {
-			indicator = fmt.Sprintf("%s %s", indentPrefix, ui.DownArrowSymbol)
+			indicator = fmt.Sprintf("%s %s", indentPrefix, th.Glyphs.DownArrow)
 		}
-		appendIndicatorWithPlainPipe(&sb, indicator)
+		appendIndicatorWithPlainPipe(th, &sb, indicator)
 		// Добавляем перенос строки отдельно
 		sb.WriteString("\n")
 	}
@@ -1299,7 +1376,7 @@ func (t *MultiSelectTask) View(width int) string {
 	if t.showHelpMessage && t.helpMessage != "" {
 		activeHelp = ""
 		helpLine = ""
-		warning = ui.GetErrorMessageStyle().Render(fmt.Sprintf("%s%s", helpIndent, t.helpMessage))
+		warning = th.Styles.ErrorMessage.Render(fmt.Sprintf("%s%s", helpIndent, t.helpMessage))
 	}
 
 	// Если есть опция "Выбрать все", добавляем её в подсказку
@@ -1307,37 +1384,42 @@ func (t *MultiSelectTask) View(width int) string {
 	if t.hasSelectAll {
 		helpText = defaults.MultiSelectHelpSelectAll
 	}
+	if t.filter.editing {
+		helpText = defaults.FilterHelp
+	}
 	// Добавляем разделительную линию
-	sb.WriteString("\n" + ui.DrawLine(width))
+	sb.WriteString("\n" + th.DrawLine(width))
 	// Добавляем сообщение-подсказку если нужно
 	if warning != "" {
 		sb.WriteString(warning + "\n")
 	}
 	// Если есть активный элемент, добавляем его подсказку
 	if activeHelp != "" {
-		sb.WriteString(ui.HelpTextStyle.Render(indentLines(activeHelp, helpIndent)))
+		sb.WriteString(th.Styles.HelpText.Render(indentLines(activeHelp, helpIndent)))
 	}
 	// Добавляем подсказку
 	if helpLine != "" {
 		sb.WriteString(helpLine)
 	}
 	formattedHelp := indentLines(formatNavigationHelpText(helpText, width), helpIndent)
-	sb.WriteString(ui.SubtleStyle.Render(formattedHelp))
+	sb.WriteString(th.Styles.Subtle.Render(formattedHelp))
 
 	return sb.String()
 }
 
 func (t *MultiSelectTask) FinalView(width int) string {
+	th := t.Theme()
 	// Получаем базовое финальное представление
 	result := t.BaseTask.FinalView(width)
 
 	// Если задача завершилась успешно и есть дополнительные строки для вывода
-	if t.icon == ui.IconDone && len(t.items) > 0 {
+	if t.icon == th.Icons.Done && len(t.items) > 0 {
 		_, names := t.collectSelectionSnapshot()
 		if len(names) > 0 {
+			names[len(names)-1] += t.prefill.note(t.StateAnswer())
 			result += "\n"
 			for _, value := range names {
-				result += ui.DrawSummaryLine(value)
+				result += th.DrawSummaryLine(value)
 			}
 		}
 	}
@@ -0,0 +1,107 @@
+// task/navigation.go
+
+package task
+
+// Возврат к предыдущей задаче очереди (режим мастера).
+// Очередь разрешает возврат через SetBackNavigation, задача фиксирует запрос
+// (стрелка влево или выбор пункта "Назад"), а очередь повторно открывает
+// предыдущую задачу методом Reopen с сохранением её прежнего ответа.
+
+// SetBackNavigation разрешает или запрещает задаче запрашивать возврат к предыдущей задаче.
+// Вызывается очередью перед запуском задачи.
+//
+// @param enabled true - возврат разрешён
+func (t *BaseTask) SetBackNavigation(enabled bool) {
+	t.backNavigation = enabled
+	t.backRequested = false
+}
+
+// BackRequested сообщает, запросил ли пользователь возврат к предыдущей задаче.
+func (t *BaseTask) BackRequested() bool { return t.backRequested }
+
+// requestBack фиксирует запрос возврата, если очередь его разрешила.
+//
+// @return true, если запрос принят
+func (t *BaseTask) requestBack() bool {
+	if !t.backNavigation {
+		return false
+	}
+	if t.timeoutManager != nil {
+		t.timeoutManager.StopTimeout()
+	}
+	t.backRequested = true
+	return true
+}
+
+// reopen сбрасывает состояние завершения задачи.
+// Тайм-аут отключается: пользователь уже взаимодействует с очередью.
+func (t *BaseTask) reopen() {
+	t.DisableTimeout()
+	t.showTimeout = false
+	t.done = false
+	t.icon = ""
+	t.err = nil
+	t.finalValue = ""
+	t.timedOut = false
+	t.backRequested = false
+}
+
+// Reopen повторно открывает задачу выбора; курсор остаётся на прежнем ответе.
+func (t *SingleSelectTask) Reopen() {
+	t.reopen()
+	t.filter = itemFilter{}
+	t.ensureCursorSelectable()
+	t.updateViewport()
+}
+
+// Reopen повторно открывает задачу множественного выбора с сохранением отмеченных пунктов.
+func (t *MultiSelectTask) Reopen() {
+	t.reopen()
+	t.filter = itemFilter{}
+	t.showHelpMessage = false
+	t.helpMessage = ""
+}
+
+// Reopen повторно открывает задачу ввода с прежним значением в поле.
+func (t *InputTaskNew) Reopen() {
+	t.reopen()
+	t.validationErr = nil
+	t.textInput.SetValue(t.value)
+	t.textInput.CursorEnd()
+	t.textInput.Focus()
+}
+
+// Reopen повторно открывает задачу многострочного ввода с прежним текстом в поле.
+func (t *TextAreaTask) Reopen() {
+	t.reopen()
+	t.validationErr = nil
+	t.textArea.SetValue(t.value)
+	t.textArea.Focus()
+}
+
+// Reopen повторно открывает задачу выбора файла в последнем открытом каталоге
+// с сохранением отмеченных элементов.
+func (t *FilePickerTask) Reopen() {
+	t.reopen()
+	t.typed = nil
+	t.notice = nil
+}
+
+// Reopen повторно открывает задачу выбора даты и времени с прежним значением.
+func (t *DateTimeTask) Reopen() {
+	t.reopen()
+	t.resetTyped()
+	t.validationErr = nil
+}
+
+// Reopen повторно открывает задачу выбора числа с прежним значением.
+func (t *NumberTask) Reopen() {
+	t.reopen()
+	t.typed = nil
+	t.validationErr = nil
+}
+
+// isBackKey проверяет, является ли клавиша командой возврата
+func isBackKey(key string) bool {
+	return key == "left" || key == "Left"
+}
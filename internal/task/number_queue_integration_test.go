@@ -0,0 +1,72 @@
+package task_test
+
+import (
+	"bytes"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNumberQueueHeadless проверяет ответы неинтерактивного режима и типы значений в результатах
+func TestNumberQueueHeadless(t *testing.T) {
+	cache := task.NewNumberTask("Размер кэша", 64, 1024, 64).WithUnit("MB")
+	cache.SetID("cache")
+	ratio := task.NewNumberTask("Доля", 0, 1, 0.05).WithValue(0.5)
+	ratio.SetID("ratio")
+
+	model := query.New("Настройки").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{
+		"cache": "512MB",
+	})
+	model.AddTasks([]common.Task{cache, ratio})
+
+	require.NoError(t, model.Run())
+	results := model.Results()
+	size, ok := results.ByID("cache")
+	require.True(t, ok)
+	assert.Equal(t, common.KindNumber, size.Kind)
+	assert.Equal(t, 512, size.Value)
+
+	share, ok := results.ByID("ratio")
+	require.True(t, ok)
+	assert.Equal(t, 0.5, share.Value, "без ответа выбирается начальное значение")
+}
+
+// TestNumberQueueOutOfRange проверяет, что ответ вне диапазона останавливает очередь
+func TestNumberQueueOutOfRange(t *testing.T) {
+	cache := task.NewNumberTask("Размер кэша", 64, 1024, 64)
+	after := task.NewFuncTask("После", func() error { return nil })
+
+	model := query.New("Настройки").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{
+		"Размер кэша": 4096,
+	})
+	model.AddTasks([]common.Task{cache, after})
+
+	require.Error(t, model.Run())
+	assert.True(t, cache.HasError())
+	assert.False(t, after.IsDone())
+}
+
+// TestNumberQueueCtrlC проверяет, что после Ctrl+C последний кадр очереди
+// показывает отменённую задачу, а не активную шкалу
+func TestNumberQueueCtrlC(t *testing.T) {
+	cache := task.NewNumberTask("Размер кэша", 64, 1024, 64)
+
+	model := query.New("Настройки")
+	model.AddTasks([]common.Task{cache})
+	active := model.View()
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	require.NotNil(t, cmd, "очередь должна завершиться")
+	require.True(t, cache.IsDone(), "задача должна отметить себя отменённой")
+	assert.True(t, cache.HasError())
+
+	view := model.View()
+	assert.NotEqual(t, active, view)
+	assert.Contains(t, view, defaults.CancelShort, "последний кадр должен показывать отмену")
+}
@@ -0,0 +1,494 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// numberEpsilon — допуск при сравнении чисел с плавающей точкой с сеткой шага и границами
+const numberEpsilon = 1e-9
+
+// maxNumberInput — наибольшая длина числа, набираемого с клавиатуры
+const maxNumberInput = 24
+
+// NumberTask - задача выбора числа из диапазона: значение изменяется стрелками
+// с заданным шагом или вводится цифрами, шкала показывает положение в диапазоне.
+type NumberTask struct {
+	BaseTask
+	low           float64        // Нижняя граница диапазона
+	high          float64        // Верхняя граница диапазона
+	step          float64        // Шаг изменения стрелками
+	value         float64        // Выбранное значение
+	precision     int            // Количество знаков после запятой
+	unit          string         // Единица измерения ("MB", "%", "ms")
+	initial       bool           // Начальное значение задано явно
+	typed         []rune         // Набираемое значение (nil - ввод цифрами не начат)
+	gaugeWidth    int            // Ширина шкалы в символах (0 - шкала не отображается)
+	validationErr error          // Ошибка проверки набранного значения
+	activeStyle   lipgloss.Style // Стиль выбранного значения
+}
+
+// NewNumberTask создает задачу выбора числа из диапазона [low, high] с шагом step.
+// Перепутанные границы меняются местами, неположительный шаг считается равным 1.
+// Количество знаков после запятой по умолчанию определяется по шагу и границам.
+// Начальное значение - нижняя граница диапазона.
+//
+// @param title Заголовок задачи
+// @param low Нижняя граница диапазона
+// @param high Верхняя граница диапазона
+// @param step Шаг изменения значения стрелками
+// @return Указатель на новую задачу выбора числа
+func NewNumberTask(title string, low, high, step float64) *NumberTask {
+	if high < low {
+		low, high = high, low
+	}
+	if step <= 0 || math.IsNaN(step) || math.IsInf(step, 0) {
+		step = 1
+	}
+	return &NumberTask{
+		BaseTask:    NewBaseTask(title),
+		low:         low,
+		high:        high,
+		step:        step,
+		value:       low,
+		precision:   max(decimals(step), decimals(low), decimals(high)),
+		gaugeWidth:  defaults.DefaultNumberGaugeWidth,
+		activeStyle: ui.ActiveStyle,
+	}
+}
+
+// WithValue задаёт начальное значение (приводится к диапазону и точности задачи).
+//
+// @param value Начальное значение
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithValue(value float64) *NumberTask {
+	t.value = t.round(t.clamp(value))
+	t.initial = true
+	return t
+}
+
+// WithPrecision задаёт количество знаков после запятой (от 0 до MaxNumberPrecision).
+//
+// @param precision Количество знаков после запятой
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithPrecision(precision int) *NumberTask {
+	t.precision = max(min(precision, defaults.MaxNumberPrecision), 0)
+	t.value = t.round(t.value)
+	return t
+}
+
+// WithUnit задаёт единицу измерения, выводимую после числа ("MB", "%", "ms").
+// Единица, указанная в ответе или при вводе после числа, игнорируется.
+//
+// @param unit Единица измерения
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithUnit(unit string) *NumberTask {
+	t.unit = strings.TrimSpace(unit)
+	return t
+}
+
+// WithGaugeWidth задаёт ширину шкалы в символах (0 - шкала не отображается).
+//
+// @param width Ширина шкалы
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithGaugeWidth(width int) *NumberTask {
+	t.gaugeWidth = max(width, 0)
+	return t
+}
+
+// WithTimeout устанавливает тайм-аут для задачи выбора числа
+// @param duration Длительность тайм-аута
+// @param defaultValue Значение по умолчанию (число или строка)
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithTimeout(duration time.Duration, defaultValue interface{}) *NumberTask {
+	t.BaseTask.WithTimeout(duration, defaultValue)
+	return t
+}
+
+// SetTheme задаёт тему оформления задачи и обновляет стиль выбранного значения
+func (t *NumberTask) SetTheme(theme *ui.Theme) {
+	t.BaseTask.SetTheme(theme)
+	if theme != nil {
+		t.activeStyle = theme.Styles.Active
+	}
+}
+
+// GetFloat возвращает выбранное значение
+//
+// @return Выбранное значение
+func (t *NumberTask) GetFloat() float64 {
+	return t.value
+}
+
+// GetInt возвращает выбранное значение, округлённое до целого
+//
+// @return Выбранное значение
+func (t *NumberTask) GetInt() int {
+	return int(math.Round(t.value))
+}
+
+// answerValue возвращает значение для результатов и файла состояния:
+// int для целых чисел (точность 0), иначе float64
+func (t *NumberTask) answerValue() interface{} {
+	if t.precision == 0 {
+		return t.GetInt()
+	}
+	return t.value
+}
+
+// round округляет число до точности задачи
+func (t *NumberTask) round(value float64) float64 {
+	scale := math.Pow10(t.precision)
+	return math.Round(value*scale) / scale
+}
+
+// clamp приводит число к границам диапазона
+func (t *NumberTask) clamp(value float64) float64 {
+	return max(min(value, t.high), t.low)
+}
+
+// checkRange проверяет, что число находится внутри диапазона
+//
+// @return Ошибка с допустимой границей или nil
+func (t *NumberTask) checkRange(value float64) error {
+	switch {
+	case value < t.low-numberEpsilon:
+		return fmt.Errorf(defaults.ErrValueBelowMin, t.formatValue(t.low))
+	case value > t.high+numberEpsilon:
+		return fmt.Errorf(defaults.ErrValueAboveMax, t.formatValue(t.high))
+	}
+	return nil
+}
+
+// parse разбирает число, набранное или указанное в ответе. Допускаются запятая
+// вместо точки и единица измерения задачи после числа ("512 MB", "50%").
+//
+// @param text Текст числа
+// @return Число или ошибка разбора
+func (t *NumberTask) parse(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	if t.unit != "" && len(text) >= len(t.unit) && strings.EqualFold(text[len(text)-len(t.unit):], t.unit) {
+		text = strings.TrimSpace(text[:len(text)-len(t.unit)])
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, errors.New(defaults.ValidatorNumberInvalid)
+	}
+	return value, nil
+}
+
+// resolveAnswer разбирает ответ (число или строку), округляет его до точности задачи
+// и проверяет границы диапазона
+//
+// @param answer Ответ
+// @return Число или ошибка разбора или выхода за границы
+func (t *NumberTask) resolveAnswer(answer interface{}) (float64, error) {
+	var value float64
+	switch v := answer.(type) {
+	case int:
+		value = float64(v)
+	case int64:
+		value = float64(v)
+	case float32:
+		value = float64(v)
+	case float64:
+		value = v
+	case string:
+		parsed, err := t.parse(v)
+		if err != nil {
+			return 0, err
+		}
+		value = parsed
+	default:
+		return 0, fmt.Errorf(defaults.ErrAnswerUnsupportedType, answer)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, errors.New(defaults.ValidatorNumberInvalid)
+	}
+	value = t.round(value)
+	if err := t.checkRange(value); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+// stepBy изменяет значение на delta шагов. Значение вне сетки шага сначала
+// выравнивается по ней: с 7 при шаге 5 стрелка вправо даёт 10, влево - 5.
+func (t *NumberTask) stepBy(delta int) {
+	t.commitTyped()
+	position := (t.value - t.low) / t.step
+	if delta > 0 {
+		position = math.Floor(position + numberEpsilon)
+	} else {
+		position = math.Ceil(position - numberEpsilon)
+	}
+	t.value = t.round(t.clamp(t.low + (position+float64(delta))*t.step))
+	t.validationErr = nil
+}
+
+// commitTyped применяет набранное значение (приведённое к диапазону) перед изменением стрелками
+func (t *NumberTask) commitTyped() {
+	if t.typed == nil {
+		return
+	}
+	if value, err := t.parse(string(t.typed)); err == nil {
+		t.value = t.round(t.clamp(value))
+	}
+	t.typed = nil
+}
+
+// typeRune добавляет символ к набираемому числу: цифры, десятичный разделитель
+// и минус в начале (если диапазон допускает отрицательные значения)
+func (t *NumberTask) typeRune(r rune) {
+	switch {
+	case r >= '0' && r <= '9':
+	case r == '.' || r == ',':
+		if t.precision == 0 || strings.ContainsAny(string(t.typed), ".,") {
+			return
+		}
+	case r == '-':
+		if t.low >= 0 || len(t.typed) > 0 {
+			return
+		}
+	default:
+		return
+	}
+	if len(t.typed) >= maxNumberInput {
+		return
+	}
+	t.typed = append(t.typed, r)
+	t.validationErr = nil
+}
+
+// submit завершает задачу выбранным или набранным значением, если оно входит в диапазон
+func (t *NumberTask) submit() {
+	if t.typed != nil {
+		value, err := t.resolveAnswer(string(t.typed))
+		if err != nil {
+			t.validationErr = err
+			return
+		}
+		t.value = value
+	}
+	t.finish()
+}
+
+// finish завершает задачу текущим значением
+func (t *NumberTask) finish() {
+	th := t.Theme()
+	t.done = true
+	t.icon = th.Icons.Done
+	t.typed = nil
+	t.validationErr = nil
+	t.finalValue = t.formatValue(t.value)
+	t.SetError(nil)
+}
+
+// handleCancel обрабатывает отмену выбора
+func (t *NumberTask) handleCancel() (Task, tea.Cmd) {
+	th := t.Theme()
+	t.SetError(terrors.NewCancelError(t.title).WithContext("value", t.value))
+	t.done = true
+	t.icon = th.Icons.Cancelled
+	t.finalValue = th.Styles.ErrorMessage.Render(defaults.CancelShort)
+	return t, nil
+}
+
+// applyDefaultValue применяет значение по умолчанию при истечении таймера
+func (t *NumberTask) applyDefaultValue() {
+	if t.defaultValue == nil {
+		return
+	}
+	th := t.Theme()
+	if err := t.ApplyAnswer(t.defaultValue); err != nil {
+		t.SetError(err)
+		t.done = true
+		t.icon = th.Icons.Error
+		t.finalValue = th.Styles.ErrorMessage.Render(defaults.ErrDefaultValueInvalid)
+		return
+	}
+	t.timedOut = true
+}
+
+// Run запускает задачу и таймер тайм-аута
+func (t *NumberTask) Run() tea.Cmd {
+	if t.timeoutEnabled && t.timeoutManager != nil {
+		return t.timeoutManager.StartTickerAndTimeout()
+	}
+	return nil
+}
+
+// Update обрабатывает нажатия клавиш для изменения и ввода значения.
+func (t *NumberTask) Update(msg tea.Msg) (Task, tea.Cmd) {
+	if t.done {
+		return t, nil
+	}
+
+	switch msg := msg.(type) {
+	case TimeoutMsg:
+		t.applyDefaultValue()
+		return t, nil
+	case TickMsg:
+		if t.timeoutEnabled && t.timeoutManager != nil && t.timeoutManager.IsActive() {
+			return t, t.timeoutManager.StartTicker()
+		}
+		return t, nil
+	case tea.KeyMsg:
+		pressed := msg.String()
+		// Любое изменение значения отключает таймер
+		if t.timeoutEnabled && t.timeoutManager != nil && t.timeoutManager.IsActive() {
+			if pressed != "ctrl+c" && pressed != "esc" && pressed != "enter" {
+				t.DisableTimeout()
+			}
+		}
+
+		if msg.Type == tea.KeyRunes && !msg.Alt {
+			for _, r := range msg.Runes {
+				t.typeRune(r)
+			}
+			return t, nil
+		}
+
+		switch pressed {
+		case "right", "Right", "up", "Up":
+			t.stepBy(1)
+		case "left", "Left", "down", "Down":
+			t.stepBy(-1)
+		case "pgup":
+			t.stepBy(10)
+		case "pgdown":
+			t.stepBy(-10)
+		case "home":
+			t.typed = nil
+			t.value = t.round(t.low)
+		case "end":
+			t.typed = nil
+			t.value = t.round(t.high)
+		case "backspace":
+			if len(t.typed) > 0 {
+				t.typed = t.typed[:len(t.typed)-1]
+			}
+			if len(t.typed) == 0 {
+				t.typed = nil
+			}
+			t.validationErr = nil
+		case "enter":
+			t.submit()
+		case "esc", "Esc":
+			// Esc сначала отменяет ввод цифрами, затем - задачу
+			if t.typed != nil {
+				t.typed = nil
+				t.validationErr = nil
+				return t, nil
+			}
+			return t.handleCancel()
+		case "ctrl+c", "Ctrl+C":
+			return t.handleCancel()
+		}
+	}
+	return t, nil
+}
+
+// View отрисовывает выбранное (или набираемое) значение и шкалу диапазона.
+//
+// @param width Ширина макета для отображения
+// @return Строка с отформатированным представлением задачи
+func (t *NumberTask) View(width int) string {
+	th := t.Theme()
+	if t.done {
+		return t.FinalView(width)
+	}
+
+	var sb strings.Builder
+	titlePrefix := t.InProgressPrefix()
+	titleView := titlePrefix + th.Styles.ActiveTitle.Render(t.title)
+	if timer := t.RenderTimer(); timer != "" {
+		titleView = ui.AlignTextToRight(titleView, timer, width)
+	}
+	sb.WriteString(titleView + "\n")
+	sb.WriteString(renderSelectionSeparator(th, width, t.showSelectionSeparator, titlePrefix))
+
+	linePrefix := performance.FastConcat(performance.RepeatEfficient(" ", ui.MainLeftIndent), th.Glyphs.Vertical, "  ")
+	value := t.activeStyle.Render(t.formatNumber(t.value))
+	if t.typed != nil {
+		value = th.Styles.Input.Render(string(t.typed) + "_")
+	}
+	if t.unit != "" {
+		value += t.unitSeparator() + th.Styles.Subtle.Render(t.unit)
+	}
+	sb.WriteString(linePrefix + value + "\n")
+
+	if t.gaugeWidth > 0 {
+		percent := 100.0
+		if t.high > t.low {
+			percent = (t.value - t.low) / (t.high - t.low) * 100
+		}
+		gauge := performance.FastConcat(
+			th.Styles.Subtle.Render(t.formatNumber(t.low)), " ",
+			th.RenderProgressBar(percent, t.gaugeWidth), " ",
+			th.Styles.Subtle.Render(t.formatNumber(t.high)),
+		)
+		sb.WriteString(linePrefix + gauge + "\n")
+	}
+
+	helpIndent := performance.RepeatEfficient(" ", ui.MainLeftIndent)
+	sb.WriteString("\n" + th.DrawLine(width))
+	if t.validationErr != nil {
+		sb.WriteString(th.Styles.ErrorMessage.Render(helpIndent+t.validationErr.Error()) + "\n")
+	}
+	sb.WriteString(th.Styles.Subtle.Render(indentLines(formatNavigationHelpText(defaults.NumberHelp, width), helpIndent)))
+	return sb.String()
+}
+
+// FinalView отображает итог задачи с выбранным значением
+func (t *NumberTask) FinalView(width int) string {
+	th := t.Theme()
+	result := t.BaseTask.FinalView(width)
+	if t.icon == th.Icons.Done {
+		result += "\n" + th.DrawSummaryLine(t.formatValue(t.value)+t.prefill.note(t.StateAnswer()))
+	}
+	return result
+}
+
+// formatNumber возвращает число с точностью задачи
+func (t *NumberTask) formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', t.precision, 64)
+}
+
+// formatValue возвращает число с единицей измерения
+func (t *NumberTask) formatValue(value float64) string {
+	if t.unit == "" {
+		return t.formatNumber(value)
+	}
+	return t.formatNumber(value) + t.unitSeparator() + t.unit
+}
+
+// unitSeparator возвращает разделитель между числом и единицей измерения:
+// знак процента пишется слитно ("50%"), остальные единицы - через пробел ("512 MB")
+func (t *NumberTask) unitSeparator() string {
+	if strings.HasPrefix(t.unit, "%") {
+		return ""
+	}
+	return " "
+}
+
+// decimals возвращает количество знаков после запятой в записи числа (не больше MaxNumberPrecision)
+func decimals(value float64) int {
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	dot := strings.IndexByte(text, '.')
+	if dot < 0 {
+		return 0
+	}
+	return min(len(text)-dot-1, defaults.MaxNumberPrecision)
+}
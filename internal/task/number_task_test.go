@@ -0,0 +1,138 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// typeNumber набирает текст в задаче выбора числа
+func typeNumber(task *NumberTask, text string) {
+	task.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+}
+
+// TestNumberTaskStepping проверяет изменение значения стрелками с выравниванием по шагу
+func TestNumberTaskStepping(t *testing.T) {
+	cache := NewNumberTask("Размер кэша", 0, 1024, 64).WithValue(100).WithUnit("MB")
+	cache.Run()
+	assert.Equal(t, 100.0, cache.GetFloat())
+
+	cache.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, 128, cache.GetInt(), "значение вне сетки выравнивается по шагу")
+	cache.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	cache.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, 0, cache.GetInt())
+	cache.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, 0, cache.GetInt(), "значение не выходит за нижнюю границу")
+
+	cache.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	assert.Equal(t, 640, cache.GetInt())
+	cache.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	assert.Equal(t, 1024, cache.GetInt(), "значение не выходит за верхнюю границу")
+	cache.Update(tea.KeyMsg{Type: tea.KeyHome})
+	assert.Equal(t, 0, cache.GetInt())
+
+	assert.Contains(t, cache.View(80), "0 MB")
+	cache.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, cache.IsDone())
+	assert.Contains(t, cache.FinalView(80), "0 MB")
+	result := cache.Result()
+	assert.Equal(t, common.KindNumber, result.Kind)
+	assert.Equal(t, 0, result.Value)
+}
+
+// TestNumberTaskFloat проверяет дробный шаг, точность и единицу измерения в процентах
+func TestNumberTaskFloat(t *testing.T) {
+	ratio := NewNumberTask("Доля", 0, 1, 0.1).WithValue(0.2)
+	ratio.Run()
+	ratio.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, 0.3, ratio.GetFloat(), "точность определяется по шагу")
+
+	ratio.WithPrecision(2).WithUnit("%")
+	ratio.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, 0.4, ratio.GetFloat())
+	assert.Contains(t, ratio.View(80), "0.40")
+	ratio.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, 0.4, ratio.Result().Value)
+	assert.Contains(t, ratio.FinalView(80), "0.40%")
+}
+
+// TestNumberTaskTyping проверяет ввод значения цифрами
+func TestNumberTaskTyping(t *testing.T) {
+	timeout := NewNumberTask("Тайм-аут", 10, 5000, 10).WithUnit("ms")
+	timeout.Run()
+
+	typeNumber(timeout, "9000")
+	timeout.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, timeout.IsDone(), "значение вне диапазона не подтверждается")
+	assert.Contains(t, timeout.View(80), "5000 ms")
+
+	timeout.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	timeout.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Contains(t, timeout.View(80), "90")
+	timeout.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, 100, timeout.GetInt(), "стрелка применяет набранное значение и делает шаг")
+
+	typeNumber(timeout, "1.5")
+	assert.Equal(t, []rune("15"), timeout.typed, "дробная часть не набирается при точности 0")
+	timeout.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, timeout.IsDone(), "Esc сначала отменяет набор")
+	assert.Equal(t, 100, timeout.GetInt())
+
+	typeNumber(timeout, "250")
+	timeout.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, timeout.IsDone())
+	assert.Equal(t, 250, timeout.GetInt())
+}
+
+// TestNumberTaskApplyAnswer проверяет разбор ответов, границы и построчный режим
+func TestNumberTaskApplyAnswer(t *testing.T) {
+	cache := NewNumberTask("Размер кэша", 64, 1024, 64).WithUnit("MB")
+	require.NoError(t, cache.ApplyAnswer("512 mb"))
+	assert.Equal(t, 512, cache.GetInt())
+
+	ratio := NewNumberTask("Доля", 0, 1, 0.05)
+	require.NoError(t, ratio.ApplyAnswer("0,25"))
+	assert.Equal(t, 0.25, ratio.GetFloat())
+
+	err := NewNumberTask("Размер кэша", 64, 1024, 64).ApplyAnswer(2048)
+	var taskErr *terrors.TaskError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, terrors.ErrorTypeValidation, taskErr.Type)
+	assert.Error(t, NewNumberTask("Размер кэша", 64, 1024, 64).ApplyAnswer("много"))
+	assert.Error(t, NewNumberTask("Размер кэша", 64, 1024, 64).ApplyAnswer(true))
+
+	line := NewNumberTask("Размер кэша", 64, 1024, 64).WithValue(256).WithUnit("MB")
+	assert.Contains(t, line.LinePrompt(), "[256]")
+	assert.Contains(t, line.LinePrompt(), "1024 MB")
+	require.NoError(t, line.ApplyLineAnswer(""))
+	assert.Equal(t, 256, line.GetInt())
+}
+
+// TestNumberTaskTimeoutAndCancel проверяет значение тайм-аута и отмену выбора
+func TestNumberTaskTimeoutAndCancel(t *testing.T) {
+	timed := NewNumberTask("Порт", 1, 65535, 1).WithTimeout(time.Second, 8080.0)
+	timed.Run()
+	timed.Update(TimeoutMsg{})
+	require.True(t, timed.IsDone())
+	assert.Equal(t, 8080, timed.GetInt())
+	assert.True(t, timed.Result().TimedOut)
+
+	invalid := NewNumberTask("Порт", 1, 65535, 1).WithTimeout(time.Second, 0.0)
+	invalid.Update(TimeoutMsg{})
+	assert.True(t, invalid.HasError())
+	assert.Contains(t, invalid.FinalView(80), defaults.ErrDefaultValueInvalid)
+
+	cancelled := NewNumberTask("Порт", 1, 65535, 1)
+	cancelled.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, cancelled.IsDone())
+	assert.True(t, cancelled.HasError())
+	assert.Nil(t, cancelled.Result().Value)
+}
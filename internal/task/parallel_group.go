@@ -0,0 +1,487 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+/**
+ * @brief Составная задача, выполняющая несколько FuncTask одновременно.
+ * @details Группа занимает в очереди одно место: запускает функции дочерних задач
+ * параллельно (с ограничением числа одновременно выполняемых), выводит состояние
+ * каждой из них вложенными строками и завершается, когда завершены все.
+ * В режиме fail-fast первая ошибка отменяет контекст остальных задач,
+ * иначе группа дожидается всех задач и собирает все ошибки.
+ */
+type ParallelGroup struct {
+	BaseTask
+	spinner  spinner.Model
+	children []*FuncTask
+	// limit ограничивает число одновременно выполняемых задач (0 — без ограничения)
+	limit int
+	// failFast отменяет оставшиеся задачи после первой ошибки
+	failFast bool
+	// cancel отменяет контекст выполняющихся функций
+	cancel context.CancelFunc
+	// remaining количество задач, результат которых ещё не получен
+	remaining int
+	// failed количество задач, завершившихся ошибкой
+	failed int
+	// errs ошибки задач в порядке их получения
+	errs []error
+
+	// running отмечает задачи, функции которых выполняются в данный момент
+	mu      sync.Mutex
+	running []bool
+}
+
+// parallelChildDoneMsg сообщает о завершении функции дочерней задачи группы
+type parallelChildDoneMsg struct {
+	group *ParallelGroup
+	index int
+	err   error
+	// cancelled — функция не запускалась или прервана отменой группы
+	cancelled bool
+}
+
+/**
+ * @brief Создает группу задач, выполняемых параллельно.
+ * @param title Заголовок группы.
+ * @param tasks Дочерние задачи.
+ * @return Указатель на созданную группу.
+ * @details По умолчанию все задачи запускаются одновременно, а группа собирает
+ * ошибки всех задач. Поведение меняется методами WithConcurrency и WithFailFast.
+ *
+ * group := NewParallelGroup("Проверка окружения",
+ *     NewFuncTask("Свободное место", checkDisk),
+ *     NewFuncTask("Доступность зеркала", pingMirror),
+ *     NewFuncTask("Подпись пакета", verifySignature),
+ * ).WithConcurrency(2).WithFailFast(true)
+ */
+func NewParallelGroup(title string, tasks ...*FuncTask) *ParallelGroup {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = ui.SpinnerStyle
+
+	children := make([]*FuncTask, 0, len(tasks))
+	for _, child := range tasks {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+
+	return &ParallelGroup{
+		BaseTask: NewBaseTask(title),
+		spinner:  s,
+		children: children,
+	}
+}
+
+/**
+ * @brief Ограничивает число одновременно выполняемых задач.
+ * @param limit Максимальное число задач (0 или меньше — без ограничения).
+ * @return Указатель на группу для возможности цепочки вызовов.
+ */
+func (g *ParallelGroup) WithConcurrency(limit int) *ParallelGroup {
+	if limit < 0 {
+		limit = 0
+	}
+	g.limit = limit
+	return g
+}
+
+/**
+ * @brief Включает или выключает режим fail-fast.
+ * @param failFast true — отменить оставшиеся задачи после первой ошибки,
+ * false — дождаться всех задач и собрать все ошибки.
+ * @return Указатель на группу для возможности цепочки вызовов.
+ */
+func (g *ParallelGroup) WithFailFast(failFast bool) *ParallelGroup {
+	g.failFast = failFast
+	return g
+}
+
+/**
+ * @brief Задает тему оформления группы и ее дочерних задач.
+ * @param theme Тема оформления (nil — глобальные стили пакета ui).
+ */
+func (g *ParallelGroup) SetTheme(theme *ui.Theme) {
+	g.BaseTask.SetTheme(theme)
+	if theme != nil {
+		g.spinner.Style = theme.Styles.Spinner
+	}
+	for _, child := range g.children {
+		child.SetTheme(theme)
+	}
+}
+
+/**
+ * @brief Задает источник времени группы и ее дочерних задач.
+ * @param clock Источник времени (nil — системное время).
+ */
+func (g *ParallelGroup) SetClock(clock common.Clock) {
+	g.BaseTask.SetClock(clock)
+	for _, child := range g.children {
+		child.SetClock(clock)
+	}
+}
+
+/**
+ * @brief Возвращает дочерние задачи группы.
+ * @return Срез дочерних задач.
+ */
+func (g *ParallelGroup) Tasks() []*FuncTask {
+	return g.children
+}
+
+/**
+ * @brief Отменяет контекст выполняющихся функций группы.
+ */
+func (g *ParallelGroup) Cancel() {
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
+
+/**
+ * @brief Запускает функции дочерних задач.
+ * @return Команда tea.Cmd, объединяющая команды всех дочерних задач.
+ */
+func (g *ParallelGroup) Run() tea.Cmd {
+	runs := g.start()
+	if len(runs) == 0 {
+		g.finish()
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, 0, len(runs)+1)
+	cmds = append(cmds, g.spinner.Tick)
+	for _, run := range runs {
+		run := run
+		cmds = append(cmds, func() tea.Msg { return run() })
+	}
+	return tea.Batch(cmds...)
+}
+
+/**
+ * @brief Синхронно выполняет группу без цикла событий bubbletea.
+ * @return Ошибка группы.
+ * @details Используется в неинтерактивном режиме очереди.
+ */
+func (g *ParallelGroup) Execute() error {
+	runs := g.start()
+	results := make(chan parallelChildDoneMsg, len(runs))
+	for _, run := range runs {
+		go func(run func() parallelChildDoneMsg) { results <- run() }(run)
+	}
+	for range runs {
+		g.apply(<-results)
+	}
+	if len(runs) == 0 {
+		g.finish()
+	}
+	return g.err
+}
+
+/**
+ * @brief Готовит группу к запуску и возвращает функции запуска дочерних задач.
+ * @return Функции, каждая из которых выполняет одну дочернюю задачу.
+ */
+func (g *ParallelGroup) start() []func() parallelChildDoneMsg {
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancel = cancel
+	g.remaining = len(g.children)
+	g.failed = 0
+	g.errs = nil
+
+	g.mu.Lock()
+	g.running = make([]bool, len(g.children))
+	g.mu.Unlock()
+
+	limit := g.limit
+	if limit <= 0 || limit > len(g.children) {
+		limit = len(g.children)
+	}
+	slots := make(chan struct{}, limit)
+
+	// turns[i] закрывается, когда задача i заняла слот (или отменена), — задачи запускаются по порядку
+	turns := make([]chan struct{}, len(g.children))
+	for i := range turns {
+		turns[i] = make(chan struct{})
+	}
+
+	runs := make([]func() parallelChildDoneMsg, len(g.children))
+	for i := range g.children {
+		index := i
+		runs[i] = func() parallelChildDoneMsg {
+			return g.runChild(ctx, slots, turns, index)
+		}
+	}
+	return runs
+}
+
+/**
+ * @brief Выполняет функцию дочерней задачи, дождавшись свободного слота.
+ * @param ctx Контекст группы.
+ * @param slots Семафор, ограничивающий число одновременно выполняемых задач.
+ * @param turns Сигналы очерёдности запуска задач.
+ * @param index Номер дочерней задачи.
+ * @return Сообщение о завершении дочерней задачи.
+ */
+func (g *ParallelGroup) runChild(ctx context.Context, slots chan struct{}, turns []chan struct{}, index int) parallelChildDoneMsg {
+	child := g.children[index]
+
+	if !acquireSlot(ctx, slots, turns, index) {
+		return parallelChildDoneMsg{group: g, index: index, err: terrors.NewCancelError(child.title), cancelled: true}
+	}
+	defer func() { <-slots }()
+
+	// Группа могла быть отменена одновременно с получением слота
+	if ctx.Err() != nil {
+		return parallelChildDoneMsg{group: g, index: index, err: terrors.NewCancelError(child.title), cancelled: true}
+	}
+
+	g.setRunning(index, true)
+	defer g.setRunning(index, false)
+
+	err := child.executeWithRetry(ctx)
+	cancelled := err != nil && ctx.Err() != nil && isCancelError(err)
+	// Отменяем остальные задачи до освобождения слота, чтобы ожидающая задача не успела запуститься
+	if err != nil && !cancelled && g.failFast {
+		g.Cancel()
+	}
+	return parallelChildDoneMsg{group: g, index: index, err: err, cancelled: cancelled}
+}
+
+/**
+ * @brief Занимает слот выполнения после того, как его заняла предыдущая задача.
+ * @param ctx Контекст группы.
+ * @param slots Семафор, ограничивающий число одновременно выполняемых задач.
+ * @param turns Сигналы очерёдности запуска задач.
+ * @param index Номер дочерней задачи.
+ * @return true, если слот занят; false, если группа отменена раньше.
+ */
+func acquireSlot(ctx context.Context, slots chan struct{}, turns []chan struct{}, index int) bool {
+	defer close(turns[index])
+
+	if index > 0 {
+		select {
+		case <-turns[index-1]:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// setRunning отмечает начало или окончание выполнения дочерней задачи
+func (g *ParallelGroup) setRunning(index int, running bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.running[index] = running
+}
+
+// isRunning сообщает, выполняется ли функция дочерней задачи
+func (g *ParallelGroup) isRunning(index int) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return index < len(g.running) && g.running[index]
+}
+
+/**
+ * @brief Обрабатывает сообщения для группы.
+ * @param msg Сообщение для обработки.
+ * @return Обновленная задача и команда tea.Cmd.
+ */
+func (g *ParallelGroup) Update(msg tea.Msg) (Task, tea.Cmd) {
+	th := g.Theme()
+	switch msg := msg.(type) {
+	case parallelChildDoneMsg:
+		// Сообщения другой группы и результаты после прерывания группы не учитываем
+		if msg.group != g || g.done {
+			return g, nil
+		}
+		g.apply(msg)
+		return g, nil
+	case spinner.TickMsg:
+		if g.done {
+			return g, nil
+		}
+		var cmd tea.Cmd
+		g.spinner, cmd = spinnerTick(g.Clock(), g.spinner, msg)
+		return g, cmd
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "Q", "ctrl+c", "Esc", "esc":
+			// Отменяем все функции группы и помечаем группу как отменённую
+			g.Cancel()
+			cancelErr := fmt.Errorf(defaults.ErrorMsgCanceled)
+			for _, child := range g.children {
+				if !child.done {
+					child.markCancelled(cancelErr)
+				}
+			}
+			g.done = true
+			g.icon = th.Icons.Cancelled
+			g.SetError(cancelErr)
+			g.finalValue = th.Styles.ErrorMessage.Render(cancelErr.Error())
+			return g, nil
+		}
+	}
+	return g, nil
+}
+
+/**
+ * @brief Применяет результат дочерней задачи и завершает группу после последнего результата.
+ * @param msg Сообщение о завершении дочерней задачи.
+ */
+func (g *ParallelGroup) apply(msg parallelChildDoneMsg) {
+	child := g.children[msg.index]
+	g.remaining--
+
+	switch {
+	case msg.err == nil:
+		child.markCompleted()
+	case msg.cancelled:
+		// Задача отменена из-за ошибки другой задачи группы
+		child.markCancelled(msg.err)
+	default:
+		child.markFailed(msg.err)
+		g.failed++
+		g.errs = append(g.errs, msg.err)
+	}
+
+	if g.remaining <= 0 {
+		g.finish()
+	}
+}
+
+/**
+ * @brief Завершает группу и формирует её итоговое состояние.
+ */
+func (g *ParallelGroup) finish() {
+	th := g.Theme()
+	g.Cancel()
+	g.done = true
+
+	if g.failed == 0 {
+		g.icon = th.Icons.Done
+		g.finalValue = th.Styles.SuccessLabel.Render(defaults.DefaultSuccessLabel)
+		return
+	}
+
+	// В режиме fail-fast ошибкой группы считается первая ошибка, иначе — все ошибки
+	if g.failFast {
+		g.err = g.errs[0]
+	} else {
+		g.err = errors.Join(g.errs...)
+	}
+	g.icon = th.Icons.Error
+	g.finalValue = th.Styles.ErrorMessage.Render(fmt.Sprintf(defaults.ParallelGroupFailedFormat, g.failed, len(g.children)))
+}
+
+// isCancelError сообщает, вызвана ли ошибка отменой задачи
+func isCancelError(err error) bool {
+	var taskErr *terrors.TaskError
+	return errors.As(err, &taskErr) && taskErr.Type == terrors.ErrorTypeUserCancel
+}
+
+/**
+ * @brief Отображает текущее состояние группы.
+ * @param width Ширина области отображения.
+ * @return Строка с заголовком группы и вложенными строками дочерних задач.
+ */
+func (g *ParallelGroup) View(width int) string {
+	if g.IsDone() {
+		return g.FinalView(width)
+	}
+
+	th := g.Theme()
+
+	prefix := performance.FastConcat(
+		performance.RepeatEfficient(" ", ui.MainLeftIndent),
+		th.Glyphs.InProgress,
+		" ",
+	)
+	result := fmt.Sprintf("%s%s%s\n", prefix, g.spinner.View(), th.Styles.ActiveTask.Render(g.title))
+
+	for i, child := range g.children {
+		var status string
+		switch {
+		case child.done:
+			status = child.icon + " "
+		case g.isRunning(i):
+			status = g.spinner.View()
+		default:
+			status = th.Icons.Undone + " "
+		}
+		result += g.childLine(status, child.title)
+		if g.isRunning(i) {
+			result += child.progressView()
+		}
+	}
+
+	helpIndent := performance.RepeatEfficient(" ", ui.MainLeftIndent)
+	result += "\n" + th.DrawLine(width) + th.Styles.Subtle.Render(fmt.Sprintf("%s%s", helpIndent, defaults.TaskExitHint))
+
+	return result
+}
+
+/**
+ * @brief Отображает финальное состояние группы.
+ * @param width Ширина области отображения.
+ * @return Строка с итогом группы и итогами дочерних задач.
+ */
+func (g *ParallelGroup) FinalView(width int) string {
+	th := g.Theme()
+	result := g.BaseTask.FinalView(width) + "\n"
+
+	for _, child := range g.children {
+		title := child.title
+		if child.icon == th.Icons.Error && child.err != nil {
+			title += th.Styles.Subtle.Render(": ") + th.Styles.ErrorMessage.Render(child.err.Error())
+		}
+		icon := child.icon
+		if icon == "" {
+			icon = th.Icons.Undone
+		}
+		result += g.childLine(icon+" ", title)
+	}
+
+	return result
+}
+
+// childLine формирует вложенную строку дочерней задачи
+func (g *ParallelGroup) childLine(status, title string) string {
+	th := g.Theme()
+	return performance.FastConcat(
+		performance.RepeatEfficient(" ", ui.MainLeftIndent),
+		th.Glyphs.Vertical,
+		ui.GetResultIndentWhenNumberingEnabled(),
+		"  ",
+		status,
+		title,
+		"\n",
+	)
+}
+
+// Result возвращает итог параллельной группы задач.
+func (g *ParallelGroup) Result() common.TaskResult {
+	return g.baseResult(common.KindParallel)
+}
@@ -0,0 +1,37 @@
+package task_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParallelGroupInQueue проверяет выполнение параллельной группы как одной задачи очереди
+func TestParallelGroupInQueue(t *testing.T) {
+	group := task.NewParallelGroup("Проверка окружения",
+		task.NewFuncTask("Свободное место", func() error { return nil }),
+		task.NewFuncTask("Доступность зеркала", func() error { return errors.New("timeout") }),
+	)
+	group.SetStopOnError(false)
+	group.SetID("checks")
+	after := task.NewFuncTask("Установка", func() error { return nil })
+
+	var out bytes.Buffer
+	model := query.New("Deploy").WithOutput(&out).WithHeadless(nil)
+	model.AddTasks([]common.Task{group, after})
+
+	require.NoError(t, model.Run())
+	assert.True(t, after.IsDone(), "очередь должна продолжиться после группы")
+	assert.Contains(t, out.String(), "Доступность зеркала")
+
+	result, ok := model.Results().ByID("checks")
+	require.True(t, ok)
+	assert.Equal(t, common.KindParallel, result.Kind)
+	assert.Equal(t, common.ResultError, result.Status)
+}
@@ -0,0 +1,126 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParallelGroupRunsConcurrently проверяет одновременное выполнение задач и ограничение их числа
+func TestParallelGroupRunsConcurrently(t *testing.T) {
+	var active, peak int32
+	work := func() error {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		return nil
+	}
+
+	group := NewParallelGroup("Проверки",
+		NewFuncTask("Диск", work),
+		NewFuncTask("Зеркало", work),
+		NewFuncTask("Подпись", work),
+		NewFuncTask("Сеть", work),
+	).WithConcurrency(2)
+
+	require.NoError(t, group.Execute())
+	assert.True(t, group.IsDone())
+	assert.False(t, group.HasError())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&peak), "одновременно должно выполняться не более двух задач")
+	for _, child := range group.Tasks() {
+		assert.Equal(t, ui.IconDone, child.icon)
+	}
+}
+
+// TestParallelGroupCollectsAllErrors проверяет сбор ошибок всех задач
+func TestParallelGroupCollectsAllErrors(t *testing.T) {
+	errDisk := errors.New("мало места")
+	errMirror := errors.New("зеркало недоступно")
+	group := NewParallelGroup("Проверки",
+		NewFuncTask("Диск", func() error { return errDisk }),
+		NewFuncTask("Зеркало", func() error { return errMirror }),
+		NewFuncTask("Подпись", func() error { return nil }),
+	)
+
+	err := group.Execute()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errDisk)
+	assert.ErrorIs(t, err, errMirror)
+	assert.Equal(t, ui.IconDone, group.Tasks()[2].icon)
+	assert.Contains(t, group.FinalView(80), "мало места")
+}
+
+// TestParallelGroupFailFast проверяет отмену оставшихся задач после первой ошибки
+func TestParallelGroupFailFast(t *testing.T) {
+	errDisk := errors.New("мало места")
+	started := make(chan struct{})
+	group := NewParallelGroup("Проверки",
+		NewFuncTaskCtx("Зеркало", func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		NewFuncTask("Диск", func() error {
+			<-started
+			return errDisk
+		}),
+		NewFuncTask("Подпись", func() error { return nil }),
+	).WithConcurrency(2).WithFailFast(true)
+
+	err := group.Execute()
+	assert.ErrorIs(t, err, errDisk)
+	children := group.Tasks()
+	assert.Equal(t, ui.IconCancelled, children[0].icon, "выполняющаяся задача должна быть отменена")
+	assert.Equal(t, ui.IconError, children[1].icon)
+	assert.Equal(t, ui.IconCancelled, children[2].icon, "ожидающая задача не должна запускаться")
+}
+
+// TestParallelGroupUpdate проверяет обработку сообщений дочерних задач в цикле событий
+func TestParallelGroupUpdate(t *testing.T) {
+	releaseDisk := make(chan struct{})
+	releaseMirror := make(chan struct{})
+	group := NewParallelGroup("Проверки",
+		NewFuncTask("Диск", func() error { <-releaseDisk; return nil }),
+		NewFuncTask("Зеркало", func() error { <-releaseMirror; return nil }),
+	)
+
+	batch, ok := group.Run()().(tea.BatchMsg)
+	require.True(t, ok)
+	msgs := make(chan tea.Msg, len(batch))
+	for _, cmd := range batch {
+		go func(cmd tea.Cmd) {
+			if msg := cmd(); msg != nil {
+				if _, isTick := msg.(spinner.TickMsg); !isTick {
+					msgs <- msg
+				}
+			}
+		}(cmd)
+	}
+
+	assert.Contains(t, group.View(80), "Диск")
+
+	// Завершённая задача отделяется от заголовка пробелом, пока другие выполняются
+	close(releaseDisk)
+	group.Update(<-msgs)
+	require.False(t, group.IsDone())
+	assert.Contains(t, group.View(80), ui.IconDone+" Диск")
+
+	close(releaseMirror)
+	group.Update(<-msgs)
+	assert.True(t, group.IsDone())
+	assert.False(t, group.HasError())
+}
@@ -0,0 +1,438 @@
+// task/prefill.go
+
+package task
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/qzeleza/ziva/internal/defaults"
+)
+
+// Начальные значения задач из переменных окружения и флагов командной строки.
+// Значение читается, когда очередь доходит до задачи. Корректное значение
+// выбирается по умолчанию (курсор, отметки, текст поля) или, в режиме
+// PrefillAccept, принимается без вопроса. Если ответ задачи совпадает
+// со значением источника, в итоговом виде задачи указывается источник.
+
+// PrefillMode определяет, как используется значение из переменной окружения или флага
+type PrefillMode int
+
+const (
+	// PrefillDefault — значение выбирается по умолчанию, пользователь подтверждает или меняет его
+	PrefillDefault PrefillMode = iota
+	// PrefillAccept — корректное значение принимается без вопроса
+	PrefillAccept
+)
+
+// prefill — источник начального значения задачи
+type prefill struct {
+	source  string                // Источник для итогового вида: $APP_PORT или --env
+	lookup  func() (string, bool) // Чтение значения (false — значение не задано)
+	mode    PrefillMode           // Способ использования значения
+	applied bool                  // Значение уже применено
+	answer  interface{}           // Ответ задачи, соответствующий значению (nil — значение некорректно)
+}
+
+// envPrefill создаёт источник значения из переменной окружения.
+// Пустая переменная считается не заданной.
+func envPrefill(name string, mode []PrefillMode) *prefill {
+	return &prefill{
+		source: "$" + name,
+		mode:   prefillMode(mode),
+		lookup: func() (string, bool) {
+			value, ok := os.LookupEnv(name)
+			return value, ok && value != ""
+		},
+	}
+}
+
+// flagPrefill создаёт источник значения из флага командной строки.
+// Учитывается только флаг, явно указанный при разборе (значение по умолчанию флага не используется).
+func flagPrefill(fs *flag.FlagSet, name string, mode []PrefillMode) *prefill {
+	return &prefill{
+		source: "--" + name,
+		mode:   prefillMode(mode),
+		lookup: func() (string, bool) {
+			var value string
+			set := false
+			fs.Visit(func(f *flag.Flag) {
+				if f.Name == name {
+					value, set = f.Value.String(), true
+				}
+			})
+			return value, set
+		},
+	}
+}
+
+// prefillMode возвращает режим из необязательного аргумента (по умолчанию PrefillDefault)
+func prefillMode(mode []PrefillMode) PrefillMode {
+	if len(mode) > 0 {
+		return mode[0]
+	}
+	return PrefillDefault
+}
+
+// resolve однократно читает значение и передаёт его задаче.
+//
+// @param apply Функция задачи: проверяет значение, выбирает его по умолчанию
+// или принимает (accept) и возвращает соответствующий ответ (nil — значение некорректно)
+// @return true, если задача завершена значением без вопроса
+func (p *prefill) resolve(apply func(value string, accept bool) interface{}) bool {
+	if p == nil || p.applied {
+		return false
+	}
+	p.applied = true
+	value, ok := p.lookup()
+	if !ok {
+		return false
+	}
+	p.answer = apply(value, p.mode == PrefillAccept)
+	return p.answer != nil && p.mode == PrefillAccept
+}
+
+// note возвращает пометку об источнике, если ответ задачи совпадает со значением источника.
+//
+// @param answer Ответ задачи (как в StateAnswer)
+// @param done Признак завершения задачи ответом
+// @return Пометка вида " (из $APP_PORT)" или пустая строка
+func (p *prefill) note(answer interface{}, done bool) string {
+	if p == nil || p.answer == nil || !done || !reflect.DeepEqual(answer, p.answer) {
+		return ""
+	}
+	return " " + fmt.Sprintf(defaults.PrefillSourceFormat, p.source)
+}
+
+// FromEnv берёт начальное значение задачи из переменной окружения:
+// ключ или название варианта.
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *SingleSelectTask) FromEnv(name string, mode ...PrefillMode) *SingleSelectTask {
+	t.prefill = envPrefill(name, mode)
+	return t
+}
+
+// FromFlag берёт начальное значение задачи из явно указанного флага командной строки.
+//
+// @param fs Набор флагов (после разбора)
+// @param name Имя флага
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *SingleSelectTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *SingleSelectTask {
+	t.prefill = flagPrefill(fs, name, mode)
+	return t
+}
+
+// ApplyPrefill применяет значение из переменной окружения или флага.
+//
+// @return true, если задача завершена значением без вопроса
+func (t *SingleSelectTask) ApplyPrefill() bool {
+	return t.prefill.resolve(func(value string, accept bool) interface{} {
+		index := t.choiceIndex(value)
+		if index == -1 || t.isDisabled(index) {
+			return nil
+		}
+		key := t.items[index].valueKey()
+		if accept {
+			if err := t.ApplyAnswer(index); err != nil {
+				return nil
+			}
+		} else {
+			t.WithDefaultItem(index)
+		}
+		return key
+	})
+}
+
+// FromEnv берёт начальный ответ из переменной окружения:
+// да/yes/true/1 или нет/no/false/0.
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *YesNoTask) FromEnv(name string, mode ...PrefillMode) *YesNoTask {
+	t.prefill = envPrefill(name, mode)
+	return t
+}
+
+// FromFlag берёт начальный ответ из явно указанного флага командной строки
+// (для логического флага — его значение).
+//
+// @param fs Набор флагов (после разбора)
+// @param name Имя флага
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *YesNoTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *YesNoTask {
+	t.prefill = flagPrefill(fs, name, mode)
+	return t
+}
+
+// ApplyPrefill применяет ответ из переменной окружения или флага.
+// Ответ "Нет", который считается ошибкой, не принимается без вопроса,
+// а только выбирается по умолчанию.
+//
+// @return true, если задача завершена ответом без вопроса
+func (t *YesNoTask) ApplyPrefill() bool {
+	accepted := t.prefill.resolve(func(value string, accept bool) interface{} {
+		yes, err := strconv.ParseBool(value)
+		if err != nil {
+			index, ok := t.normalizeStringToIndex(value).(int)
+			if !ok || index < 0 || index > 1 {
+				return nil
+			}
+			yes = index == 0
+		}
+		if accept && (yes || !t.noCountsAsError) {
+			if err := t.ApplyAnswer(yes); err != nil {
+				return nil
+			}
+			return yes
+		}
+		if yes {
+			t.WithDefaultYes()
+		} else {
+			t.WithDefaultNo()
+		}
+		return yes
+	})
+	// Ответ "Нет", выбранный только по умолчанию, задачу не завершает
+	return accepted && t.IsDone()
+}
+
+// FromEnv берёт начальный набор вариантов из переменной окружения:
+// ключи или названия через запятую.
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *MultiSelectTask) FromEnv(name string, mode ...PrefillMode) *MultiSelectTask {
+	t.prefill = envPrefill(name, mode)
+	return t
+}
+
+// FromFlag берёт начальный набор вариантов из явно указанного флага командной строки.
+//
+// @param fs Набор флагов (после разбора)
+// @param name Имя флага
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *MultiSelectTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *MultiSelectTask {
+	t.prefill = flagPrefill(fs, name, mode)
+	return t
+}
+
+// ApplyPrefill применяет набор вариантов из переменной окружения или флага.
+//
+// @return true, если задача завершена набором без вопроса
+func (t *MultiSelectTask) ApplyPrefill() bool {
+	return t.prefill.resolve(func(value string, accept bool) interface{} {
+		values, err := answerList(value)
+		if err != nil || (len(values) == 0 && t.requireSelection) {
+			return nil
+		}
+		indices := make([]int, 0, len(values))
+		for _, v := range values {
+			index, err := t.answerIndex(v)
+			if err != nil || t.isDisabled(index) {
+				return nil
+			}
+			indices = append(indices, index)
+		}
+
+		if accept {
+			if err := t.ApplyAnswer(value); err != nil {
+				return nil
+			}
+			return t.GetSelected()
+		}
+		sort.Ints(indices)
+		keys := make([]string, len(indices))
+		for i, index := range indices {
+			keys[i] = t.items[index].valueKey()
+		}
+		t.WithDefaultItems(keys)
+		return keys
+	})
+}
+
+// FromEnv берёт начальное значение поля из переменной окружения.
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *InputTaskNew) FromEnv(name string, mode ...PrefillMode) *InputTaskNew {
+	t.prefill = envPrefill(name, mode)
+	return t
+}
+
+// FromFlag берёт начальное значение поля из явно указанного флага командной строки.
+//
+// @param fs Набор флагов (после разбора)
+// @param name Имя флага
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *InputTaskNew) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *InputTaskNew {
+	t.prefill = flagPrefill(fs, name, mode)
+	return t
+}
+
+// ApplyPrefill применяет значение из переменной окружения или флага.
+// Значение проверяется валидатором задачи; некорректное значение не используется.
+//
+// @return true, если задача завершена значением без вопроса
+func (t *InputTaskNew) ApplyPrefill() bool {
+	return t.prefill.resolve(func(value string, accept bool) interface{} {
+		if t.validator != nil {
+			if err := t.validator.Validate(value); err != nil {
+				return nil
+			}
+		}
+		if accept {
+			if err := t.ApplyAnswer(value); err != nil {
+				return nil
+			}
+			return value
+		}
+		t.WithValue(value)
+		return value
+	})
+}
+
+// FromEnv берёт начальный текст поля из переменной окружения.
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) FromEnv(name string, mode ...PrefillMode) *TextAreaTask {
+	t.prefill = envPrefill(name, mode)
+	return t
+}
+
+// FromFlag берёт начальный текст поля из явно указанного флага командной строки.
+//
+// @param fs Набор флагов (после разбора)
+// @param name Имя флага
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *TextAreaTask {
+	t.prefill = flagPrefill(fs, name, mode)
+	return t
+}
+
+// ApplyPrefill применяет текст из переменной окружения или флага.
+// Текст, не прошедший проверку задачи, не используется.
+//
+// @return true, если задача завершена текстом без вопроса
+func (t *TextAreaTask) ApplyPrefill() bool {
+	return t.prefill.resolve(func(value string, accept bool) interface{} {
+		value = normalizeNewlines(value)
+		if t.validate(value) != nil {
+			return nil
+		}
+		if accept {
+			if err := t.ApplyAnswer(value); err != nil {
+				return nil
+			}
+			return value
+		}
+		t.WithValue(value)
+		return value
+	})
+}
+
+// FromEnv берёт начальное значение задачи выбора даты, времени или длительности
+// из переменной окружения (в формате ответов: "2006-01-02", "15:04", "1h30m").
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTimeTask) FromEnv(name string, mode ...PrefillMode) *DateTimeTask {
+	t.prefill = envPrefill(name, mode)
+	return t
+}
+
+// FromFlag берёт начальное значение задачи выбора даты, времени или длительности
+// из явно указанного флага командной строки.
+//
+// @param fs Набор флагов (после разбора)
+// @param name Имя флага
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *DateTimeTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *DateTimeTask {
+	t.prefill = flagPrefill(fs, name, mode)
+	return t
+}
+
+// ApplyPrefill применяет значение из переменной окружения или флага.
+// Значение в неверном формате или вне диапазона не используется.
+//
+// @return true, если задача завершена значением без вопроса
+func (t *DateTimeTask) ApplyPrefill() bool {
+	return t.prefill.resolve(func(text string, accept bool) interface{} {
+		value, duration, err := t.resolveAnswer(text)
+		if err != nil {
+			return nil
+		}
+		if accept {
+			if err := t.ApplyAnswer(text); err != nil {
+				return nil
+			}
+			return t.GetValue()
+		}
+		if t.mode == modeDuration {
+			t.WithDuration(duration)
+		} else {
+			t.WithValue(value)
+		}
+		return t.GetValue()
+	})
+}
+
+// FromEnv берёт начальное значение задачи выбора числа из переменной окружения.
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) FromEnv(name string, mode ...PrefillMode) *NumberTask {
+	t.prefill = envPrefill(name, mode)
+	return t
+}
+
+// FromFlag берёт начальное значение задачи выбора числа из явно указанного флага командной строки.
+//
+// @param fs Набор флагов (после разбора)
+// @param name Имя флага
+// @param mode Необязательный режим (PrefillDefault или PrefillAccept)
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *NumberTask {
+	t.prefill = flagPrefill(fs, name, mode)
+	return t
+}
+
+// ApplyPrefill применяет число из переменной окружения или флага.
+// Значение, которое не является числом или выходит за границы диапазона, не используется.
+//
+// @return true, если задача завершена значением без вопроса
+func (t *NumberTask) ApplyPrefill() bool {
+	return t.prefill.resolve(func(text string, accept bool) interface{} {
+		value, err := t.resolveAnswer(text)
+		if err != nil {
+			return nil
+		}
+		if accept {
+			if err := t.ApplyAnswer(value); err != nil {
+				return nil
+			}
+		} else {
+			t.WithValue(value)
+		}
+		return t.answerValue()
+	})
+}
@@ -0,0 +1,110 @@
+package task_test
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/qzeleza/ziva/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPrefillAcceptSkipsAnswers проверяет приём значений из окружения и флагов без ответов
+func TestPrefillAcceptSkipsAnswers(t *testing.T) {
+	t.Setenv("APP_PORT", "8080")
+	t.Setenv("APP_DEBUG", "yes")
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.String("env", "dev", "среда")
+	fs.String("features", "", "компоненты")
+	require.NoError(t, fs.Parse([]string{"-env", "prod", "-features", "web,api"}))
+
+	port := task.NewInputTaskNew("Порт", "Введите порт").
+		WithValidator(validation.DefaultFactory.Port()).FromEnv("APP_PORT", task.PrefillAccept)
+	debug := task.NewYesNoTask("Отладка", "Включить отладку?").FromEnv("APP_DEBUG", task.PrefillAccept)
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}}).
+		FromFlag(fs, "env", task.PrefillAccept)
+	features := task.NewMultiSelectTask("Компоненты", []task.Item{{Key: "api"}, {Key: "web"}, {Key: "cli"}}).
+		FromFlag(fs, "features", task.PrefillAccept)
+
+	model := query.New("Настройка").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{})
+	model.AddTasks([]common.Task{port, debug, env, features})
+	require.NoError(t, model.Run())
+
+	results := model.Results()
+	assert.Equal(t, "8080", results[0].Value)
+	assert.Equal(t, true, results[1].Value)
+	assert.Equal(t, "prod", results[2].Value)
+	assert.Equal(t, []string{"api", "web"}, results[3].Values)
+
+	assert.Contains(t, port.FinalView(80), "(из $APP_PORT)")
+	assert.Contains(t, env.FinalView(80), "(из --env)")
+	assert.Contains(t, features.FinalView(80), "(из --features)")
+}
+
+// TestPrefillDefaultIsPreselected проверяет выбор значения по умолчанию без автоматического ответа
+func TestPrefillDefaultIsPreselected(t *testing.T) {
+	t.Setenv("APP_ENV", "prod")
+	t.Setenv("APP_NAME", "router")
+
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}}).FromEnv("APP_ENV")
+	name := task.NewInputTaskNew("Имя", "Введите имя").FromEnv("APP_NAME")
+
+	model := query.New("Настройка").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{"Имя": "gateway"})
+	model.AddTasks([]common.Task{env, name})
+	require.NoError(t, model.Run())
+
+	results := model.Results()
+	assert.Equal(t, "prod", results[0].Value, "без ответа принимается выбранное по умолчанию значение")
+	assert.Equal(t, "gateway", results[1].Value, "ответ заменяет значение по умолчанию")
+	assert.Contains(t, env.FinalView(80), "(из $APP_ENV)")
+	assert.NotContains(t, name.FinalView(80), "$APP_NAME", "источник не указывается для изменённого значения")
+}
+
+// TestPrefillInvalidValueIsIgnored проверяет, что некорректное значение не используется
+func TestPrefillInvalidValueIsIgnored(t *testing.T) {
+	t.Setenv("APP_PORT", "http")
+	t.Setenv("APP_ENV", "staging")
+
+	port := task.NewInputTaskNew("Порт", "Введите порт").
+		WithValidator(validation.DefaultFactory.Port()).FromEnv("APP_PORT", task.PrefillAccept)
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}}).FromEnv("APP_ENV", task.PrefillAccept)
+
+	model := query.New("Настройка").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{"Порт": "443", "Среда": "dev"})
+	model.AddTasks([]common.Task{port, env})
+	require.NoError(t, model.Run())
+
+	results := model.Results()
+	assert.Equal(t, "443", results[0].Value)
+	assert.Equal(t, "dev", results[1].Value)
+	assert.NotContains(t, port.FinalView(80), "$APP_PORT")
+}
+
+// TestPrefillUnsetFlagIsIgnored проверяет, что значение флага по умолчанию не используется
+func TestPrefillUnsetFlagIsIgnored(t *testing.T) {
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.String("env", "prod", "среда")
+	require.NoError(t, fs.Parse(nil))
+
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}}).FromFlag(fs, "env", task.PrefillAccept)
+	assert.False(t, env.ApplyPrefill())
+	assert.False(t, env.IsDone())
+}
+
+// TestPrefillNoAsErrorIsNotAccepted проверяет, что ответ "Нет", считающийся ошибкой, не принимается без вопроса
+func TestPrefillNoAsErrorIsNotAccepted(t *testing.T) {
+	t.Setenv("APP_CONFIRM", "false")
+
+	confirm := task.NewYesNoTask("Подтверждение", "Продолжить?").FromEnv("APP_CONFIRM", task.PrefillAccept)
+	assert.False(t, confirm.ApplyPrefill())
+	assert.False(t, confirm.IsDone())
+
+	optional := task.NewYesNoTask("Отладка", "Включить отладку?").WithNoAsError().FromEnv("APP_CONFIRM", task.PrefillAccept)
+	assert.True(t, optional.ApplyPrefill())
+	answer, ok := optional.StateAnswer()
+	require.True(t, ok)
+	assert.Equal(t, false, answer)
+}
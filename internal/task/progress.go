@@ -0,0 +1,294 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// progressBarWidth ширина полосы прогресса в символах
+const progressBarWidth = 24
+
+/**
+ * @brief Канал передачи прогресса из функции задачи в её представление.
+ * @details Методы безопасны для вызова из любой горутины. Изменения
+ * отображаются при очередной перерисовке задачи.
+ */
+type Progress interface {
+	// SetPercent задаёт процент выполнения (0–100)
+	SetPercent(percent float64)
+	// SetBytes задаёт количество обработанных и общее количество байт.
+	// При известном общем объёме процент вычисляется автоматически.
+	SetBytes(done, total int64)
+	// SetStatus задаёт строку состояния под полосой прогресса
+	SetStatus(status string)
+	// Context возвращает контекст выполнения, отменяемый при прерывании задачи
+	Context() context.Context
+}
+
+/**
+ * @brief Потокобезопасное хранилище прогресса FuncTask.
+ */
+type progressTracker struct {
+	mu         sync.Mutex
+	ctx        context.Context
+	percent    float64
+	hasPercent bool
+	bytesDone  int64
+	bytesTotal int64
+	status     string
+	startedAt  time.Time
+	finishedAt time.Time
+	clock      common.Clock
+}
+
+// progressSnapshot копия состояния прогресса для отрисовки
+type progressSnapshot struct {
+	percent    float64
+	hasPercent bool
+	bytesDone  int64
+	bytesTotal int64
+	status     string
+	elapsed    time.Duration
+}
+
+/**
+ * @brief Сбрасывает прогресс перед очередным запуском функции.
+ * @param ctx Контекст выполнения функции.
+ * @param clock Источник времени задачи.
+ */
+func (p *progressTracker) start(ctx context.Context, clock common.Clock) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ctx = ctx
+	p.clock = common.ClockOrSystem(clock)
+	p.percent, p.hasPercent = 0, false
+	p.bytesDone, p.bytesTotal = 0, 0
+	p.status = ""
+	p.startedAt, p.finishedAt = p.clock.Now(), time.Time{}
+}
+
+/**
+ * @brief Фиксирует момент завершения функции.
+ */
+func (p *progressTracker) stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finishedAt = p.clock.Now()
+}
+
+func (p *progressTracker) SetPercent(percent float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.percent = clampPercent(percent)
+	p.hasPercent = true
+}
+
+func (p *progressTracker) SetBytes(done, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bytesDone = done
+	p.bytesTotal = total
+	if total > 0 {
+		p.percent = clampPercent(float64(done) / float64(total) * 100)
+		p.hasPercent = true
+	}
+}
+
+func (p *progressTracker) SetStatus(status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status = strings.TrimSpace(status)
+}
+
+func (p *progressTracker) Context() context.Context {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
+}
+
+/**
+ * @brief Возвращает согласованную копию текущего состояния.
+ */
+func (p *progressTracker) snapshot() progressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	end := p.finishedAt
+	if end.IsZero() {
+		end = common.ClockOrSystem(p.clock).Now()
+	}
+	var elapsed time.Duration
+	if !p.startedAt.IsZero() {
+		elapsed = end.Sub(p.startedAt)
+	}
+
+	return progressSnapshot{
+		percent:    p.percent,
+		hasPercent: p.hasPercent,
+		bytesDone:  p.bytesDone,
+		bytesTotal: p.bytesTotal,
+		status:     p.status,
+		elapsed:    elapsed,
+	}
+}
+
+// eta оценивает оставшееся время по доле выполненной работы
+func (s progressSnapshot) eta() (time.Duration, bool) {
+	if !s.hasPercent || s.percent <= 0 || s.percent >= 100 {
+		return 0, false
+	}
+	remaining := float64(s.elapsed) * (100 - s.percent) / s.percent
+	return time.Duration(remaining), true
+}
+
+/**
+ * @brief Создает задачу FuncTask, функция которой сообщает о ходе выполнения.
+ * @param title Заголовок задачи.
+ * @param funcAction Функция, получающая канал передачи прогресса.
+ * @param options Функциональные опции для настройки задачи.
+ * @return Указатель на созданную задачу FuncTask.
+ * @details Во время выполнения под заголовком выводится полоса прогресса
+ * с оценкой оставшегося времени и строкой состояния, после завершения —
+ * общее время выполнения. Контекст из p.Context() отменяется так же,
+ * как в NewFuncTaskCtx.
+ *
+ * task := NewFuncTaskWithProgress("Загрузка прошивки",
+ *     func(p Progress) error {
+ *         p.SetStatus("firmware.bin")
+ *         return download(p.Context(), url, func(done, total int64) {
+ *             p.SetBytes(done, total)
+ *         })
+ *     },
+ * )
+ */
+func NewFuncTaskWithProgress(title string, funcAction func(p Progress) error, options ...FuncTaskOption) *FuncTask {
+	task := NewFuncTask(title, nil, options...)
+	task.progressFunction = funcAction
+	task.progress = &progressTracker{}
+	return task
+}
+
+/**
+ * @brief Выполняет функцию с прогрессом, фиксируя время начала и окончания.
+ * @param ctx Контекст выполнения.
+ * @return Ошибка функции или ошибка контекста, если он отменён раньше.
+ * @details Срок выполнения соблюдается, даже если функция не проверяет Progress.Context():
+ * по его истечении задача завершается, а функция продолжает работу в фоне.
+ */
+func (t *FuncTask) runWithProgress(ctx context.Context) error {
+	t.progress.start(ctx, t.Clock())
+	defer t.progress.stop()
+	progress := t.progress
+	return runUntilDone(ctx, func() error { return t.progressFunction(progress) })
+}
+
+/**
+ * @brief Формирует строки прогресса для активного представления задачи.
+ * @return Полоса прогресса и строка состояния (пустая строка, если прогресса нет).
+ */
+func (t *FuncTask) progressView() string {
+	th := t.Theme()
+	if t.progress == nil {
+		return ""
+	}
+	state := t.progress.snapshot()
+
+	parts := make([]string, 0, 4)
+	if state.hasPercent {
+		parts = append(parts,
+			th.RenderProgressBar(state.percent, progressBarWidth),
+			fmt.Sprintf("%3.0f%%", state.percent),
+		)
+	}
+	if state.bytesDone > 0 || state.bytesTotal > 0 {
+		bytes := formatBytes(state.bytesDone)
+		if state.bytesTotal > 0 {
+			bytes += " / " + formatBytes(state.bytesTotal)
+		}
+		parts = append(parts, th.Styles.Subtle.Render(bytes))
+	}
+	if eta, ok := state.eta(); ok {
+		parts = append(parts, th.Styles.Subtle.Render(defaults.ProgressETALabel+" "+formatElapsed(eta)))
+	}
+
+	if len(parts) == 0 && state.status == "" {
+		return ""
+	}
+
+	prefix := performance.FastConcat(
+		performance.RepeatEfficient(" ", ui.MainLeftIndent),
+		th.Glyphs.Vertical,
+		ui.GetResultIndentWhenNumberingEnabled(),
+		"  ",
+	)
+
+	result := ""
+	if len(parts) > 0 {
+		result += prefix + strings.Join(parts, " ") + "\n"
+	}
+	if state.status != "" {
+		result += prefix + th.Styles.Subtle.Render(state.status) + "\n"
+	}
+	return result
+}
+
+/**
+ * @brief Возвращает строку с общим временем выполнения задачи с прогрессом.
+ * @return Строка для вывода под заголовком или пустая строка.
+ */
+func (t *FuncTask) elapsedLine() string {
+	if t.progress == nil {
+		return ""
+	}
+	return fmt.Sprintf(defaults.ProgressElapsedFormat, formatElapsed(t.progress.snapshot().elapsed))
+}
+
+// clampPercent ограничивает процент диапазоном 0–100
+func clampPercent(percent float64) float64 {
+	if percent < 0 {
+		return 0
+	}
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
+
+// formatBytes выводит объём данных в двоичных единицах (B, KiB, MiB, GiB)
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit && exp < 3; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+// formatElapsed выводит длительность в компактном виде: 850ms, 4.2s, 1m05s, 1h02m
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
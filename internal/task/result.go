@@ -0,0 +1,113 @@
+// task/result.go
+
+package task
+
+import (
+	"github.com/qzeleza/ziva/internal/common"
+)
+
+// Формирование итоговых записей задач для Queue.Results.
+// Очередь дополняет запись порядковым номером, состоянием и временем выполнения.
+
+// CompletedByTimeout сообщает, было ли значение выбрано автоматически по истечении тайм-аута.
+func (t *BaseTask) CompletedByTimeout() bool { return t.timedOut }
+
+// baseResult заполняет общие поля результата задачи
+func (t *BaseTask) baseResult(kind string) common.TaskResult {
+	return common.TaskResult{
+		ID:       t.id,
+		Title:    t.title,
+		Kind:     kind,
+		TimedOut: t.timedOut,
+		Err:      t.err,
+	}
+}
+
+// Result возвращает итог задачи Да/Нет; значение имеет тип bool.
+func (t *YesNoTask) Result() common.TaskResult {
+	result := t.baseResult(common.KindYesNo)
+	if t.done && !t.HasError() {
+		result.Value = t.IsYes()
+	}
+	return result
+}
+
+// Result возвращает итог задачи выбора; значение — ключ выбранного элемента.
+func (t *SingleSelectTask) Result() common.TaskResult {
+	result := t.baseResult(common.KindSingleSelect)
+	if t.done && !t.HasError() {
+		result.Value = t.GetSelected()
+	}
+	return result
+}
+
+// Result возвращает итог задачи множественного выбора; значения — ключи выбранных элементов.
+func (t *MultiSelectTask) Result() common.TaskResult {
+	result := t.baseResult(common.KindMultiSelect)
+	if t.done && !t.HasError() {
+		result.Values = t.GetSelected()
+	}
+	return result
+}
+
+// Result возвращает итог задачи ввода; значение пароля помечается как секретное.
+func (t *InputTaskNew) Result() common.TaskResult {
+	result := t.baseResult(common.KindInput)
+	result.Secret = t.isSecret()
+	if t.done && !t.HasError() {
+		result.Value = t.value
+	}
+	return result
+}
+
+// Result возвращает итог задачи многострочного ввода.
+func (t *TextAreaTask) Result() common.TaskResult {
+	result := t.baseResult(common.KindInput)
+	if t.done && !t.HasError() {
+		result.Value = t.value
+	}
+	return result
+}
+
+// Result возвращает итог задачи-функции с числом попыток выполнения.
+func (t *FuncTask) Result() common.TaskResult {
+	result := t.baseResult(common.KindFunc)
+	result.Attempts = t.attempts
+	if t.skipped {
+		result.Status = common.ResultSkipped
+	}
+	return result
+}
+
+// Result возвращает итог задачи выбора файла: путь или, при множественном выборе, список путей.
+func (t *FilePickerTask) Result() common.TaskResult {
+	result := t.baseResult(common.KindPath)
+	if t.done && !t.HasError() {
+		if t.multiple {
+			result.Values = t.GetSelected()
+		} else {
+			result.Value = t.GetPath()
+		}
+	}
+	return result
+}
+
+// Result возвращает итог задачи выбора даты, времени или длительности;
+// значение - строка в формате ответов ("2006-01-02", "15:04", "1h30m0s").
+func (t *DateTimeTask) Result() common.TaskResult {
+	result := t.baseResult(t.kind())
+	if t.done && !t.HasError() {
+		result.Value = t.GetValue()
+	}
+	return result
+}
+
+// Result возвращает итог задачи выбора числа; значение имеет тип int
+// для целых чисел (точность 0) и float64 в остальных случаях.
+func (t *NumberTask) Result() common.TaskResult {
+	result := t.baseResult(common.KindNumber)
+	if t.done && !t.HasError() {
+		result.Value = t.answerValue()
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package task_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueueResultsFromTasks проверяет типизированные результаты реальных задач
+func TestQueueResultsFromTasks(t *testing.T) {
+	confirm := task.NewYesNoTask("Подтверждение", "Продолжить?").WithTimeoutYes(time.Second)
+	confirm.SetID("confirm")
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}})
+	features := task.NewMultiSelectTask("Компоненты", []task.Item{{Key: "api"}, {Key: "web"}})
+	secret := task.NewInputTaskNew("Пароль", "Введите пароль").WithInputType(task.InputTypePassword)
+	deploy := task.NewFuncTask("Развёртывание", func() error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+
+	model := query.New("Результаты").WithOutput(&bytes.Buffer{}).WithHeadless(query.MapAnswers{
+		"Среда":      "prod",
+		"Компоненты": "api,web",
+		"Пароль":     "S3cure!Passw0rd",
+	})
+	model.AddTasks([]common.Task{confirm, env, features, secret, deploy})
+	require.NoError(t, model.Run())
+
+	results := model.Results()
+	require.Len(t, results, 5)
+
+	byID, ok := results.ByID("confirm")
+	require.True(t, ok)
+	assert.Equal(t, common.KindYesNo, byID.Kind)
+	assert.Equal(t, true, byID.Value)
+	assert.True(t, byID.TimedOut, "значение выбрано по тайм-ауту")
+
+	assert.Equal(t, common.KindSingleSelect, results[1].Kind)
+	assert.Equal(t, "prod", results[1].Value)
+	assert.False(t, results[1].TimedOut)
+
+	assert.Equal(t, common.KindMultiSelect, results[2].Kind)
+	assert.Equal(t, []string{"api", "web"}, results[2].Values)
+
+	assert.Equal(t, common.KindInput, results[3].Kind)
+	assert.True(t, results[3].Secret)
+
+	assert.Equal(t, common.KindFunc, results[4].Kind)
+	assert.Equal(t, common.ResultSuccess, results[4].Status)
+	assert.GreaterOrEqual(t, results[4].Duration, 5*time.Millisecond)
+
+	var buf bytes.Buffer
+	require.NoError(t, model.WriteJSON(&buf))
+	assert.NotContains(t, buf.String(), "S3cure!Passw0rd", "пароль не должен попадать в отчёт")
+	assert.True(t, strings.Contains(buf.String(), `"kind": "multi_select"`))
+}
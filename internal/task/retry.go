@@ -0,0 +1,365 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+/**
+ * @brief Политика повторного выполнения функции FuncTask.
+ * @details Задержка перед попыткой n (n ≥ 1) равна InitialDelay·Multiplier^(n-1),
+ * но не больше MaxDelay, и случайно отклоняется на долю Jitter.
+ * Повторяются только ошибки, тип которых входит в RetryOn; если список пуст,
+ * тип определяется классификатором ошибок (повторяются сетевые ошибки, тайм-ауты
+ * и ненулевой код завершения команды CommandTask).
+ */
+type RetryPolicy struct {
+	MaxAttempts  int                 // Максимальное число попыток, включая первую
+	InitialDelay time.Duration       // Задержка перед первым повтором
+	MaxDelay     time.Duration       // Максимальная задержка (0 — без ограничения)
+	Multiplier   float64             // Множитель экспоненциальной задержки (0 — значение 2)
+	Jitter       float64             // Доля случайного отклонения задержки (0–1)
+	RetryOn      []terrors.ErrorType // Типы ошибок, при которых выполняется повтор
+	Prompt       bool                // Предлагать «Повторить / Пропустить / Прервать» после исчерпания попыток
+}
+
+/**
+ * @brief Возвращает политику повтора по умолчанию.
+ * @return Три попытки с задержкой от 1 до 30 секунд, отклонением 20% и выбором действия после исчерпания попыток.
+ */
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  3,
+		InitialDelay: time.Second,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+		Prompt:       true,
+	}
+}
+
+/**
+ * @brief Вычисляет задержку перед повтором после указанной попытки.
+ * @param attempt Номер завершившейся попытки (с единицы).
+ * @return Задержка перед следующей попыткой.
+ */
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	if attempt < 1 || p.InitialDelay <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		delay += delay * jitter * (rand.Float64()*2 - 1)
+	}
+	return time.Duration(delay)
+}
+
+/**
+ * @brief Проверяет, относится ли ошибка к повторяемым по этой политике.
+ * @param title Заголовок задачи (для классификации ошибки).
+ * @param err Ошибка попытки.
+ * @return true, если тип ошибки допускает повтор.
+ */
+func (p RetryPolicy) retryable(title string, err error) bool {
+	if err == nil || isCancelError(err) {
+		return false
+	}
+	classified := terrors.DefaultErrorHandler.Handle(title, err)
+	if len(p.RetryOn) == 0 {
+		return classified.IsRetryable() || isCommandExit(err)
+	}
+	for _, errorType := range p.RetryOn {
+		if classified.Type == errorType {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * @brief Проверяет, нужно ли повторить функцию после неудачной попытки.
+ * @param title Заголовок задачи.
+ * @param err Ошибка попытки.
+ * @param attempt Номер завершившейся попытки (с единицы).
+ * @return true, если попытки не исчерпаны и ошибка повторяемая.
+ */
+func (p RetryPolicy) shouldRetry(title string, err error, attempt int) bool {
+	if len(p.RetryOn) == 0 && !isCommandExit(err) {
+		handler := terrors.ErrorHandler{RetryAttempts: p.MaxAttempts}
+		return !isCancelError(err) && handler.ShouldRetry(terrors.DefaultErrorHandler.Handle(title, err), attempt)
+	}
+	return attempt < p.MaxAttempts && p.retryable(title, err)
+}
+
+// funcTaskRetryMsg сообщает, что задержка перед очередной попыткой истекла
+type funcTaskRetryMsg struct {
+	task *FuncTask
+}
+
+// Варианты действия после исчерпания попыток
+const (
+	retryChoiceRetry = iota
+	retryChoiceSkip
+	retryChoiceAbort
+	retryChoiceCount
+)
+
+/**
+ * @brief Устанавливает политику повтора функции задачи.
+ * @param policy Политика повтора.
+ * @return Функциональная опция для NewFuncTask.
+ */
+func WithRetryOption(policy RetryPolicy) FuncTaskOption {
+	return func(t *FuncTask) {
+		t.WithRetry(policy)
+	}
+}
+
+/**
+ * @brief Включает повтор функции при повторяемых ошибках.
+ * @param policy Политика повтора.
+ * @return Указатель на задачу для возможности цепочки вызовов.
+ * @details Во время ожидания под заголовком выводится «попытка 2/5, повтор через 4s»,
+ * в итоговом представлении — число попыток. Если policy.Prompt установлен,
+ * после исчерпания попыток пользователю предлагается повторить, пропустить
+ * задачу или прервать её с ошибкой.
+ */
+func (t *FuncTask) WithRetry(policy RetryPolicy) *FuncTask {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	t.retry = &policy
+	return t
+}
+
+/**
+ * @brief Возвращает общее число выполненных попыток.
+ * @return Число запусков функции.
+ */
+func (t *FuncTask) Attempts() int {
+	return t.attempts
+}
+
+/**
+ * @brief Возвращает true, если пользователь пропустил задачу после исчерпания попыток.
+ */
+func (t *FuncTask) Skipped() bool {
+	return t.skipped
+}
+
+/**
+ * @brief Создает команду очередной попытки выполнения функции.
+ * @return Команда, возвращающая ошибку функции или сообщение об успешном завершении.
+ */
+func (t *FuncTask) attemptCmd() tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	t.attempt++
+	t.attempts++
+	t.retryAt = time.Time{}
+	clock := t.Clock()
+	attempt := t.attempts
+
+	return func() tea.Msg {
+		defer cancel()
+
+		// Выполняем функцию и проверяем на ошибку.
+		// Ошибка сохраняется в задаче при обработке сообщения в Update
+		if err := t.execute(ctx); err != nil {
+			return funcTaskErrorMsg{task: t, attempt: attempt, err: err}
+		}
+
+		// Делаем задержку перед завершением
+		// для лучшей визуальной анимации (если она включена)
+		if defaults.IsCompletionDelayEnabled() {
+			<-clock.After(defaults.DefaultCompletionDelay)
+		}
+
+		// Возвращаем специальное сообщение об успешном завершении
+		return funcTaskCompleteMsg{task: t, attempt: attempt}
+	}
+}
+
+/**
+ * @brief Проверяет, относится ли сообщение функции к текущей попытке этой задачи.
+ * @param task Задача, отправившая сообщение.
+ * @param attempt Порядковый номер попытки среди всех запусков задачи.
+ * @return true, если сообщение отправлено текущей попыткой задачи.
+ */
+func (t *FuncTask) ownsAttempt(task *FuncTask, attempt int) bool {
+	return task == t && attempt == t.attempts
+}
+
+/**
+ * @brief Обрабатывает ошибку попытки: планирует повтор, предлагает выбор или завершает задачу.
+ * @param err Ошибка попытки.
+ * @return Команда ожидания следующей попытки или nil.
+ */
+func (t *FuncTask) handleAttemptError(err error) tea.Cmd {
+	t.lastErr = err
+	if t.retry == nil {
+		t.markFailed(err)
+		return nil
+	}
+
+	if t.retry.shouldRetry(t.title, err, t.attempt) {
+		delay := t.retry.Delay(t.attempt)
+		t.retryAt = t.Clock().Now().Add(delay)
+		return t.Clock().Tick(delay, func(time.Time) tea.Msg { return funcTaskRetryMsg{task: t} })
+	}
+
+	if t.retry.Prompt && t.retry.retryable(t.title, err) {
+		t.deciding = true
+		t.choice = retryChoiceRetry
+		return nil
+	}
+
+	t.markFailed(err)
+	return nil
+}
+
+/**
+ * @brief Обрабатывает клавиши выбора действия после исчерпания попыток.
+ * @param msg Сообщение клавиатуры.
+ * @return Команда новой серии попыток или nil.
+ */
+func (t *FuncTask) updateRetryChoice(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "left", "shift+tab", "up":
+		t.choice = (t.choice + retryChoiceCount - 1) % retryChoiceCount
+	case "right", "tab", "down":
+		t.choice = (t.choice + 1) % retryChoiceCount
+	case "enter":
+		t.deciding = false
+		switch t.choice {
+		case retryChoiceRetry:
+			// Начинаем новую серию попыток
+			t.attempt = 0
+			return t.attemptCmd()
+		case retryChoiceSkip:
+			t.markSkipped()
+		default:
+			t.markFailed(t.lastErr)
+		}
+	}
+	return nil
+}
+
+/**
+ * @brief Переводит задачу в состояние пропуска после исчерпания попыток.
+ * @details Ошибка не сохраняется, поэтому очередь продолжает выполнение.
+ */
+func (t *FuncTask) markSkipped() {
+	th := t.Theme()
+	t.done = true
+	t.skipped = true
+	t.icon = th.Icons.Cancelled
+	t.finalValue = th.Styles.Subtle.Render(th.TaskStatusSkipped())
+}
+
+/**
+ * @brief Синхронно выполняет функцию с повторами по политике задачи.
+ * @param ctx Контекст выполнения.
+ * @return Ошибка последней попытки.
+ * @details Используется в неинтерактивном режиме и в параллельной группе,
+ * где выбор действия после исчерпания попыток не предлагается.
+ */
+func (t *FuncTask) executeWithRetry(ctx context.Context) error {
+	t.attempt = 0
+	for {
+		t.attempt++
+		t.attempts++
+		err := t.execute(ctx)
+		if err == nil || t.retry == nil || !t.retry.shouldRetry(t.title, err, t.attempt) {
+			return err
+		}
+
+		select {
+		case <-t.Clock().After(t.retry.Delay(t.attempt)):
+		case <-ctx.Done():
+			return terrors.NewCancelError(t.title)
+		}
+	}
+}
+
+/**
+ * @brief Формирует строки состояния повтора для активного представления задачи.
+ * @return Строка попытки, ожидания или выбора действия (пустая строка без повторов).
+ */
+func (t *FuncTask) retryView() string {
+	th := t.Theme()
+	if t.retry == nil {
+		return ""
+	}
+
+	prefix := performance.FastConcat(
+		performance.RepeatEfficient(" ", ui.MainLeftIndent),
+		th.Glyphs.Vertical,
+		ui.GetResultIndentWhenNumberingEnabled(),
+		"  ",
+	)
+
+	switch {
+	case t.deciding:
+		choices := []string{defaults.RetryChoiceRetry, defaults.RetryChoiceSkip, defaults.RetryChoiceAbort}
+		rendered := make([]string, len(choices))
+		for i, choice := range choices {
+			if i == t.choice {
+				rendered[i] = th.Styles.Active.Render("[" + choice + "]")
+			} else {
+				rendered[i] = th.Styles.Subtle.Render(" " + choice + " ")
+			}
+		}
+		result := prefix + th.Styles.ErrorMessage.Render(t.lastErr.Error()) + "\n"
+		result += prefix + defaults.RetryExhaustedPrompt + "\n"
+		result += prefix + strings.Join(rendered, " ") + "\n"
+		result += prefix + th.Styles.Subtle.Render(defaults.RetryPromptHint) + "\n"
+		return result
+	case !t.retryAt.IsZero():
+		wait := t.retryAt.Sub(t.Clock().Now())
+		if wait < 0 {
+			wait = 0
+		}
+		// Округляем оставшееся время вверх до секунды, чтобы не показывать «0s» раньше времени
+		wait = ((wait + time.Second - 1) / time.Second) * time.Second
+		status := fmt.Sprintf(defaults.RetryWaitFormat, t.attempt+1, t.retry.MaxAttempts, wait)
+		return prefix + th.Styles.Subtle.Render(status) + "\n"
+	case t.attempt > 1:
+		status := fmt.Sprintf(defaults.RetryAttemptFormat, t.attempt, t.retry.MaxAttempts)
+		return prefix + th.Styles.Subtle.Render(status) + "\n"
+	}
+	return ""
+}
+
+/**
+ * @brief Возвращает строку с общим числом попыток для итогового представления.
+ * @return Строка для вывода под заголовком или пустая строка, если попытка была одна.
+ */
+func (t *FuncTask) attemptsLine() string {
+	if t.retry == nil || t.attempts < 2 {
+		return ""
+	}
+	return fmt.Sprintf(defaults.RetryAttemptsFormat, t.attempts)
+}
@@ -24,6 +24,10 @@ type SingleSelectTask struct {
 	viewportSize  int // Размер viewport (количество видимых элементов), 0 = показать все
 	viewportStart int // Начальная позиция viewport в списке элементов
 	showCounters  bool
+	// hasDefaultItem фиксирует, что элемент по умолчанию был задан явно
+	hasDefaultItem bool
+	// filter сужает список по запросу, введённому после нажатия "/"
+	filter itemFilter
 }
 
 // NewSingleSelectTask создает новую задачу выбора одного варианта из списка.
@@ -61,6 +65,7 @@ func (t *SingleSelectTask) captureSelection(index int) {
 // finalizeSelection завершает задачу с выбранным элементом.
 // Возвращает true, если удалось завершить задачу без ошибок.
 func (t *SingleSelectTask) finalizeSelection(index int) bool {
+	th := t.Theme()
 	if index < 0 || index >= len(t.items) {
 		return false
 	}
@@ -69,7 +74,7 @@ func (t *SingleSelectTask) finalizeSelection(index int) bool {
 	}
 
 	t.done = true
-	t.icon = ui.IconDone
+	t.icon = th.Icons.Done
 	t.captureSelection(index)
 	t.SetError(nil)
 	return true
@@ -77,6 +82,7 @@ func (t *SingleSelectTask) finalizeSelection(index int) bool {
 
 // handleExitShortcut обрабатывает быстрый выход без ошибки по Ctrl+C, Esc, ← и т.п.
 func (t *SingleSelectTask) handleExitShortcut() (Task, tea.Cmd) {
+	th := t.Theme()
 	// Останавливаем таймер, если он активен
 	t.stopTimeout()
 
@@ -101,7 +107,7 @@ func (t *SingleSelectTask) handleExitShortcut() (Task, tea.Cmd) {
 
 	// Если выбрать нечего (например, пустой список), завершаем без ошибки
 	t.done = true
-	t.icon = ui.IconDone
+	t.icon = th.Icons.Done
 	if strings.TrimSpace(t.finalValue) == "" {
 		t.finalValue = defaults.DefaultSuccessLabel
 	}
@@ -148,7 +154,7 @@ func (t *SingleSelectTask) ensureCursorSelectable() bool {
 		return false
 	}
 
-	if t.cursor >= 0 && t.cursor < len(t.items) && !t.isDisabled(t.cursor) {
+	if t.cursor >= 0 && t.cursor < len(t.items) && t.isSelectable(t.cursor) {
 		return true
 	}
 
@@ -177,7 +183,7 @@ func (t *SingleSelectTask) findEnabledForward(from int) (int, bool) {
 		from = 0
 	}
 	for i := from; i < len(t.items); i++ {
-		if !t.isDisabled(i) {
+		if t.isSelectable(i) {
 			return i, true
 		}
 	}
@@ -190,13 +196,26 @@ func (t *SingleSelectTask) findEnabledBackward(from int) (int, bool) {
 		from = len(t.items) - 1
 	}
 	for i := from; i >= 0; i-- {
-		if !t.isDisabled(i) {
+		if t.isSelectable(i) {
 			return i, true
 		}
 	}
 	return -1, false
 }
 
+// isSelectable проверяет, доступен ли элемент для курсора: не отключён и не скрыт фильтром
+func (t *SingleSelectTask) isSelectable(index int) bool {
+	return !t.isDisabled(index) && t.filter.includes(index)
+}
+
+// applyFilter пересчитывает видимые элементы после изменения запроса фильтра
+func (t *SingleSelectTask) applyFilter() {
+	t.filter.refresh(t.items)
+	t.viewportStart = 0
+	t.ensureCursorSelectable()
+	t.updateViewport()
+}
+
 // moveCursorForward перемещает курсор на следующий доступный элемент
 func (t *SingleSelectTask) moveCursorForward() bool {
 	if len(t.items) == 0 {
@@ -236,6 +255,14 @@ func (t *SingleSelectTask) moveCursorBackward() bool {
 	return false
 }
 
+// SetTheme задаёт тему оформления задачи и обновляет стиль активного элемента
+func (t *SingleSelectTask) SetTheme(theme *ui.Theme) {
+	t.BaseTask.SetTheme(theme)
+	if theme != nil {
+		t.activeStyle = theme.Styles.Active
+	}
+}
+
 // WithItemsDisabled помечает элементы меню как недоступные для выбора.
 // Поддерживаются типы: int, []int, string, []string. Nil очищает список отключённых элементов.
 func (t *SingleSelectTask) WithItemsDisabled(disabled interface{}) *SingleSelectTask {
@@ -339,6 +366,7 @@ func (t *SingleSelectTask) WithDefaultItem(selection interface{}) *SingleSelectT
 		}
 	}
 
+	t.hasDefaultItem = true
 	t.ensureCursorSelectable()
 	// После обновления курсора синхронизируем viewport
 	t.updateViewport()
@@ -369,14 +397,17 @@ func (t *SingleSelectTask) updateViewport() {
 		return
 	}
 
+	// Позиция курсора в списке с учётом фильтра
+	cursor := t.filter.position(t.cursor)
+
 	// Если курсор выше viewport, сдвигаем viewport вверх
-	if t.cursor < t.viewportStart {
-		t.viewportStart = t.cursor
+	if cursor < t.viewportStart {
+		t.viewportStart = cursor
 	}
 
 	// Если курсор ниже viewport, сдвигаем viewport вниз
-	if t.cursor >= t.viewportStart+t.viewportSize {
-		t.viewportStart = t.cursor - t.viewportSize + 1
+	if cursor >= t.viewportStart+t.viewportSize {
+		t.viewportStart = cursor - t.viewportSize + 1
 	}
 
 	// Убеждаемся, что viewport не выходит за границы списка
@@ -384,7 +415,7 @@ func (t *SingleSelectTask) updateViewport() {
 		t.viewportStart = 0
 	}
 
-	maxStart := len(t.items) - t.viewportSize
+	maxStart := t.filter.count(len(t.items)) - t.viewportSize
 	if maxStart < 0 {
 		maxStart = 0
 	}
@@ -393,12 +424,15 @@ func (t *SingleSelectTask) updateViewport() {
 	}
 }
 
-// getVisibleRange возвращает диапазон видимых элементов с учетом viewport
+// getVisibleRange возвращает диапазон видимых элементов с учетом viewport.
+// При активном фильтре диапазон задаётся позициями в отфильтрованном списке.
 // Возвращает: startIdx, endIdx
 func (t *SingleSelectTask) getVisibleRange() (int, int) {
+	total := t.filter.count(len(t.items))
+
 	// Если viewport отключен, показываем все элементы
 	if t.viewportSize <= 0 {
-		return 0, len(t.items)
+		return 0, total
 	}
 
 	startIdx := t.viewportStart
@@ -407,8 +441,8 @@ func (t *SingleSelectTask) getVisibleRange() (int, int) {
 	}
 
 	endIdx := startIdx + t.viewportSize
-	if endIdx > len(t.items) {
-		endIdx = len(t.items)
+	if endIdx > total {
+		endIdx = total
 	}
 
 	return startIdx, endIdx
@@ -443,6 +477,15 @@ func (t *SingleSelectTask) Update(msg tea.Msg) (Task, tea.Cmd) {
 		}
 		return t, nil
 	case tea.KeyMsg:
+		// Ввод запроса фильтра ("/" включает режим фильтра)
+		if handled, changed := t.filter.handleKey(msg); handled {
+			t.stopTimeout()
+			if changed {
+				t.applyFilter()
+			}
+			return t, nil
+		}
+
 		// При нажатии клавиш сбрасываем таймер
 		switch msg.String() {
 		case "up", "k":
@@ -462,10 +505,18 @@ func (t *SingleSelectTask) Update(msg tea.Msg) (Task, tea.Cmd) {
 			}
 			return t, nil
 		case "q", "Q", "esc", "Esc", "ctrl+c", "Ctrl+C", "left", "Left":
+			// В очереди с возвратом стрелка влево открывает предыдущую задачу
+			if isBackKey(msg.String()) && t.requestBack() {
+				return t, nil
+			}
 			return t.handleExitShortcut()
 		case "enter", "right", "Right":
 			// Если таймер активен, останавливаем его
 			t.stopTimeout()
+			// Выбор пункта "Назад" в очереди с возвратом открывает предыдущую задачу
+			if t.cursor >= 0 && t.cursor < len(t.items) && isBackChoice(t.items[t.cursor]) && t.requestBack() {
+				return t, nil
+			}
 			if t.finalizeSelection(t.cursor) {
 				return t, nil
 			}
@@ -497,6 +548,7 @@ func (t *SingleSelectTask) Run() tea.Cmd {
 
 // applyDefaultValue применяет значение по умолчанию при истечении таймера
 func (t *SingleSelectTask) applyDefaultValue() {
+	th := t.Theme()
 	// Если есть значение по умолчанию и это число (индекс)
 	if t.defaultValue != nil {
 		targetIndex := -1
@@ -517,7 +569,8 @@ func (t *SingleSelectTask) applyDefaultValue() {
 			if t.ensureCursorSelectable() && t.cursor >= 0 {
 				// Устанавливаем задачу как завершенную
 				t.done = true
-				t.icon = ui.IconDone
+				t.icon = th.Icons.Done
+				t.timedOut = true
 				t.captureSelection(t.cursor)
 			}
 		}
@@ -529,6 +582,7 @@ func (t *SingleSelectTask) applyDefaultValue() {
 // @param width Ширина макета для отображения
 // @return Строка с отформатированным представлением задачи
 func (t *SingleSelectTask) View(width int) string {
+	th := t.Theme()
 	// Если задача завершена, возвращаем FinalView
 	if t.done {
 		return t.FinalView(width)
@@ -539,7 +593,7 @@ func (t *SingleSelectTask) View(width int) string {
 	titlePrefix := t.InProgressPrefix()
 
 	// Формируем заголовок с префиксом
-	title := ui.ActiveTitleStyle.Render(t.title)
+	title := th.Styles.ActiveTitle.Render(t.title)
 	titleWithPrefix := fmt.Sprintf("%s%s", titlePrefix, title)
 
 	// Получаем отформатированный таймер (если он активен)
@@ -553,70 +607,80 @@ func (t *SingleSelectTask) View(width int) string {
 		sb.WriteString(titleWithPrefix + "\n")
 	}
 
-	sb.WriteString(renderSelectionSeparator(width, t.showSelectionSeparator, titlePrefix))
+	sb.WriteString(renderSelectionSeparator(th, width, t.showSelectionSeparator, titlePrefix))
+
+	// Строка ввода фильтра
+	if t.filter.active() {
+		sb.WriteString(t.filter.renderFilterLine(th, len(t.items)))
+	}
 
 	// Получаем диапазон видимых элементов с учетом viewport
 	startIdx, endIdx := t.getVisibleRange()
+	total := t.filter.count(len(t.items))
 
 	// Добавляем индикатор прокрутки вверх, если есть скрытые элементы выше
 	if t.viewportSize > 0 && startIdx > 0 {
-		indentPrefix := ui.GetSelectItemPrefix("above")
+		indentPrefix := th.SelectItemPrefix("above")
 		var indicator string
 		if t.showCounters {
-			arrow := ui.UpArrowSymbol + " "
+			arrow := th.Glyphs.UpArrow + " "
 			indicator = fmt.Sprintf(defaults.ScrollAboveFormat, indentPrefix, arrow, startIdx)
 		} else {
-			indicator = fmt.Sprintf("%s %s", indentPrefix, ui.UpArrowSymbol)
+			indicator = fmt.Sprintf("%s %s", indentPrefix, th.Glyphs.UpArrow)
 		}
-		appendIndicatorWithPlainPipe(&sb, indicator)
+		appendIndicatorWithPlainPipe(th, &sb, indicator)
 		sb.WriteString("\n")
 	}
 
 	activeHelp := ""
 
 	// Отображаем только видимые элементы списка
-	for i := startIdx; i < endIdx; i++ {
-		if i >= len(t.items) {
+	for pos := startIdx; pos < endIdx; pos++ {
+		i := t.filter.indexAt(pos)
+		if i < 0 || i >= len(t.items) {
 			break
 		}
 
 		item := t.items[i]                      // Получаем элемент списка
 		label := item.displayName()             // Получаем отображаемый текст
 		description := item.helpText()          // Получаем подсказку
-		checked := ui.IconRadioOff              // Получаем иконку
+		checked := th.Icons.RadioOff            // Получаем иконку
 		var itemPrefix string                   // Получаем префикс
 		isDisabled := t.isDisabled(i)           // Проверяем, отключена ли задача
 		isExit := isExitChoice(item)            // Проверяем, является ли задача выходом
 		isBack := !isExit && isBackChoice(item) // Проверяем, является ли задача возвратом
+		labelStyle, styled := lipgloss.NewStyle(), false
 
 		if isDisabled {
 			// Если задача отключена, применяем стиль отключения
-			label = ui.DisabledStyle.Render(label)
-			checked = ui.DisabledStyle.Render(checked)
+			labelStyle, styled = th.Styles.Disabled, true
+			checked = th.Styles.Disabled.Render(checked)
 		}
 		if !isDisabled && t.cursor != i {
 			// Если задача не отключена и не является активной, применяем стиль
 			switch {
 			case isExit:
-				label = ui.MenuExitItemStyle.Render(label)
+				labelStyle, styled = th.Styles.MenuExitItem, true
 			case isBack:
-				label = ui.MenuBackItemStyle.Render(label)
+				labelStyle, styled = th.Styles.MenuBackItem, true
 			}
 		}
 
 		if t.cursor == i {
 			// Если задача является активной, применяем стиль активности
-			itemPrefix = ui.GetSelectItemPrefix("active")
-			checked = ui.IconRadioOn
-			label = t.activeStyle.Render(label)
+			itemPrefix = th.SelectItemPrefix("active")
+			checked = th.Icons.RadioOn
+			labelStyle, styled = t.activeStyle, true
 			checked = t.activeStyle.Render(checked)
 		} else if i < t.cursor {
 			// Если задача находится выше активной, применяем стиль выше
-			itemPrefix = ui.GetSelectItemPrefix("above")
+			itemPrefix = th.SelectItemPrefix("above")
 		} else {
 			// Если задача находится ниже активной, применяем стиль ниже
-			itemPrefix = ui.GetSelectItemPrefix("below")
+			itemPrefix = th.SelectItemPrefix("below")
 		}
+		// Применяем стиль и подсвечиваем символы, совпавшие с фильтром
+		label = renderFilteredLabel(th, label, labelStyle, styled, t.filter.highlighted(i))
 
 		if t.cursor == i {
 			// Если задача является активной, добавляем скобки и иконку
@@ -629,8 +693,8 @@ func (t *SingleSelectTask) View(width int) string {
 			closeBracket := ")"
 			if isDisabled {
 				// Если задача отключена, применяем стиль отключения
-				openBracket = ui.DisabledStyle.Render(openBracket)
-				closeBracket = ui.DisabledStyle.Render(closeBracket)
+				openBracket = th.Styles.Disabled.Render(openBracket)
+				closeBracket = th.Styles.Disabled.Render(closeBracket)
 			}
 			sb.WriteString(fmt.Sprintf("%s%s%s%s %s\n", itemPrefix, openBracket, checked, closeBracket, label))
 		}
@@ -641,41 +705,46 @@ func (t *SingleSelectTask) View(width int) string {
 	}
 
 	// Добавляем индикатор прокрутки вниз, если есть скрытые элементы ниже
-	if t.viewportSize > 0 && endIdx < len(t.items) {
-		indentPrefix := ui.GetSelectItemPrefix("below")
+	if t.viewportSize > 0 && endIdx < total {
+		indentPrefix := th.SelectItemPrefix("below")
 		var indicator string
-		remaining := len(t.items) - endIdx
+		remaining := total - endIdx
 		if t.showCounters {
-			arrow := ui.DownArrowSymbol + " "
+			arrow := th.Glyphs.DownArrow + " "
 			indicator = fmt.Sprintf(defaults.ScrollBelowFormat, indentPrefix, arrow, remaining)
 		} else {
-			indicator = fmt.Sprintf("%s %s", indentPrefix, ui.DownArrowSymbol)
+			indicator = fmt.Sprintf("%s %s", indentPrefix, th.Glyphs.DownArrow)
 		}
-		appendIndicatorWithPlainPipe(&sb, indicator)
+		appendIndicatorWithPlainPipe(th, &sb, indicator)
 		sb.WriteString("\n")
 	}
 
 	// Добавляем подсказку о навигации с новым отступом
 	helpIndent := performance.RepeatEfficient(" ", ui.MainLeftIndent)
 
-	sb.WriteString("\n" + ui.DrawLine(width))
+	sb.WriteString("\n" + th.DrawLine(width))
 	if activeHelp != "" {
-		sb.WriteString(ui.HelpTextStyle.Render(indentLines(activeHelp, helpIndent)))
+		sb.WriteString(th.Styles.HelpText.Render(indentLines(activeHelp, helpIndent)))
 		sb.WriteString("\n")
 	}
-	navigationHelp := indentLines(formatNavigationHelpText(defaults.SingleSelectHelp, width), helpIndent)
-	sb.WriteString(ui.SubtleStyle.Render(navigationHelp))
+	helpText := defaults.SingleSelectHelp
+	if t.filter.editing {
+		helpText = defaults.FilterHelp
+	}
+	navigationHelp := indentLines(formatNavigationHelpText(helpText, width), helpIndent)
+	sb.WriteString(th.Styles.Subtle.Render(navigationHelp))
 
 	return sb.String()
 }
 
 func (t *SingleSelectTask) FinalView(width int) string {
+	th := t.Theme()
 	// Получаем базовое финальное представление
 	result := t.BaseTask.FinalView(width)
 
 	// Если задача завершилась успешно и есть дополнительные строки для вывода
-	if t.icon == ui.IconDone && len(t.items) > 0 && t.cursor >= 0 && t.cursor < len(t.items) {
-		result += "\n" + ui.DrawSummaryLine(t.items[t.cursor].displayName())
+	if t.icon == th.Icons.Done && len(t.items) > 0 && t.cursor >= 0 && t.cursor < len(t.items) {
+		result += "\n" + th.DrawSummaryLine(t.items[t.cursor].displayName()+t.prefill.note(t.StateAnswer()))
 	}
 
 	return result
@@ -0,0 +1,52 @@
+package task_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/sink"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSinkQueue создаёт очередь, записывающую ответы в файл UCI; flashErr — ошибка последнего шага
+func newSinkQueue(s *sink.Sink, answers query.MapAnswers, flashErr error) *query.Model {
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}})
+	env.SetID("env")
+	wifi := task.NewYesNoTask("Wi-Fi", "Включить Wi-Fi?")
+	wifi.SetID("wifi")
+	flash := task.NewFuncTask("Прошивка", func() error { return flashErr })
+
+	model := query.New("Установка").WithOutput(&bytes.Buffer{}).WithHeadless(answers).WithSink(s)
+	model.AddTasks([]common.Task{env, wifi, flash})
+	return model
+}
+
+// TestSinkWritesAnswersAfterQueue проверяет запись ответов в файл после выполнения очереди
+func TestSinkWritesAnswersAfterQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app")
+	s := sink.New(path, sink.UCI{Type: "app", Name: "main"}).Map("env", "environment").Map("wifi", "wifi")
+
+	require.Error(t, newSinkQueue(s, query.MapAnswers{"env": "prod", "wifi": true}, errors.New("сбой")).Run())
+	_, err := os.Stat(path)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "очередь, остановленная ошибкой, файл не записывает")
+
+	require.NoError(t, newSinkQueue(s, query.MapAnswers{"env": "prod", "wifi": true}, nil).Run())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "config app 'main'\n\toption environment 'prod'\n\toption wifi '1'\n", string(data))
+}
+
+// TestSinkWriteErrorIsReturned проверяет ошибку записи файла
+func TestSinkWriteErrorIsReturned(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "app.json")
+	err := newSinkQueue(sink.New(path, sink.JSON{}), query.MapAnswers{"env": "dev", "wifi": false}, nil).Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app.json")
+}
@@ -0,0 +1,264 @@
+// task/state.go
+
+package task
+
+// Сохранение и восстановление ответов для возобновления прерванной очереди.
+// Очередь после каждого шага запрашивает у задач ответ методом StateAnswer
+// и записывает его в файл состояния, а при возобновлении возвращает ответ
+// задаче методом RestoreAnswer. Задача, которую нужно выполнить заново,
+// остаётся незавершённой и будет запущена очередью как обычно.
+
+// ResumeMode определяет, как FuncTask, успешно выполненная в прерванном запуске,
+// обрабатывается при возобновлении очереди
+type ResumeMode int
+
+const (
+	// ResumeIdempotent — результат функции сохраняется между запусками:
+	// при возобновлении задача отмечается выполненной без повторного вызова (по умолчанию)
+	ResumeIdempotent ResumeMode = iota
+	// ResumeRerun — функция обязательно выполняется заново
+	// (например, подключение к устройству или запуск службы)
+	ResumeRerun
+)
+
+/**
+ * @brief Задаёт поведение задачи при возобновлении очереди.
+ * @param mode Режим возобновления.
+ * @return Функциональная опция для NewFuncTask.
+ */
+func WithResumeModeOption(mode ResumeMode) FuncTaskOption {
+	return func(t *FuncTask) {
+		t.WithResumeMode(mode)
+	}
+}
+
+/**
+ * @brief Задаёт поведение задачи при возобновлении очереди.
+ * @param mode Режим возобновления (ResumeIdempotent или ResumeRerun).
+ * @return Указатель на задачу для возможности цепочки вызовов.
+ */
+func (t *FuncTask) WithResumeMode(mode ResumeMode) *FuncTask {
+	t.resume = mode
+	return t
+}
+
+/**
+ * @brief Возвращает режим возобновления задачи.
+ */
+func (t *FuncTask) ResumeMode() ResumeMode {
+	return t.resume
+}
+
+/**
+ * @brief Сообщает, выполнена ли функция успешно в этом запуске.
+ * @return Пустой ответ и true, если задачу можно сохранить как выполненную.
+ */
+func (t *FuncTask) StateAnswer() (interface{}, bool) {
+	return nil, t.done && !t.HasError() && !t.skipped
+}
+
+/**
+ * @brief Отмечает задачу выполненной в прерванном запуске.
+ * @return Всегда nil; задача с режимом ResumeRerun остаётся незавершённой.
+ * @details Функция и функция сводки не вызываются.
+ */
+func (t *FuncTask) RestoreAnswer(interface{}) error {
+	if t.resume == ResumeRerun {
+		return nil
+	}
+	t.restoreCompleted()
+	return nil
+}
+
+/**
+ * @brief Переводит задачу в состояние успешного завершения без вызова функции сводки.
+ */
+func (t *FuncTask) restoreCompleted() {
+	th := t.Theme()
+	t.done = true
+	t.icon = th.Icons.Done
+	t.finalValue = th.Styles.SuccessLabel.Render(t.successLabel)
+}
+
+/**
+ * @brief Сообщает, выполнены ли успешно все задачи группы.
+ * @return Пустой ответ и true, если группу можно сохранить как выполненную.
+ */
+func (g *ParallelGroup) StateAnswer() (interface{}, bool) {
+	return nil, g.done && !g.HasError()
+}
+
+/**
+ * @brief Отмечает группу и её задачи выполненными в прерванном запуске.
+ * @return Всегда nil; группа, в которой есть задача с режимом ResumeRerun,
+ * остаётся незавершённой и выполняется заново целиком.
+ */
+func (g *ParallelGroup) RestoreAnswer(interface{}) error {
+	for _, child := range g.children {
+		if child.resume == ResumeRerun {
+			return nil
+		}
+	}
+	for _, child := range g.children {
+		child.restoreCompleted()
+	}
+	g.finish()
+	return nil
+}
+
+// StateAnswer возвращает ключ выбранного элемента для файла состояния.
+//
+// @return Ответ и признак того, что задача завершена успешно
+func (t *SingleSelectTask) StateAnswer() (interface{}, bool) {
+	if !t.done || t.HasError() {
+		return nil, false
+	}
+	return t.GetSelected(), true
+}
+
+// RestoreAnswer завершает задачу ответом из файла состояния.
+//
+// @param answer Сохранённый ответ
+// @return Ошибка, если вариант больше не существует или недоступен
+func (t *SingleSelectTask) RestoreAnswer(answer interface{}) error {
+	return t.ApplyAnswer(answer)
+}
+
+// StateAnswer возвращает ответ Да/Нет для файла состояния.
+// Ответ "Нет" сохраняется и тогда, когда он считается ошибкой.
+//
+// @return Ответ (bool) и признак того, что задача завершена ответом пользователя
+func (t *YesNoTask) StateAnswer() (interface{}, bool) {
+	if !t.done || isCancelError(t.Error()) {
+		return nil, false
+	}
+	if t.HasError() && !(t.IsNo() && t.noCountsAsError) {
+		return nil, false
+	}
+	return t.IsYes(), true
+}
+
+// RestoreAnswer завершает задачу ответом из файла состояния.
+//
+// @param answer Сохранённый ответ
+// @return Ошибка, если ответ не удалось распознать
+func (t *YesNoTask) RestoreAnswer(answer interface{}) error {
+	return t.ApplyAnswer(answer)
+}
+
+// StateAnswer возвращает ключи отмеченных элементов для файла состояния.
+//
+// @return Ответ и признак того, что задача завершена успешно
+func (t *MultiSelectTask) StateAnswer() (interface{}, bool) {
+	if !t.done || t.HasError() {
+		return nil, false
+	}
+	return t.GetSelected(), true
+}
+
+// RestoreAnswer завершает задачу набором вариантов из файла состояния.
+//
+// @param answer Сохранённый ответ
+// @return Ошибка, если хотя бы один вариант больше не существует или недоступен
+func (t *MultiSelectTask) RestoreAnswer(answer interface{}) error {
+	return t.ApplyAnswer(answer)
+}
+
+// StateAnswer возвращает введённое значение для файла состояния.
+// Очередь не сохраняет значение пароля в открытом виде (см. Result().Secret).
+//
+// @return Ответ и признак того, что задача завершена успешно
+func (t *InputTaskNew) StateAnswer() (interface{}, bool) {
+	if !t.done || t.HasError() {
+		return nil, false
+	}
+	return t.value, true
+}
+
+// RestoreAnswer завершает задачу значением из файла состояния.
+// Значение проверяется тем же валидатором, что и при вводе.
+//
+// @param answer Сохранённый ответ
+// @return Ошибка проверки значения
+func (t *InputTaskNew) RestoreAnswer(answer interface{}) error {
+	return t.ApplyAnswer(answer)
+}
+
+// StateAnswer возвращает введённый текст для файла состояния.
+//
+// @return Ответ и признак того, что задача завершена успешно
+func (t *TextAreaTask) StateAnswer() (interface{}, bool) {
+	if !t.done || t.HasError() {
+		return nil, false
+	}
+	return t.value, true
+}
+
+// RestoreAnswer завершает задачу текстом из файла состояния.
+// Текст проверяется так же, как при вводе.
+//
+// @param answer Сохранённый ответ
+// @return Ошибка проверки текста
+func (t *TextAreaTask) RestoreAnswer(answer interface{}) error {
+	return t.ApplyAnswer(answer)
+}
+
+// StateAnswer возвращает выбранный путь (или список путей) для файла состояния.
+//
+// @return Ответ и признак того, что задача завершена успешно
+func (t *FilePickerTask) StateAnswer() (interface{}, bool) {
+	if !t.done || t.HasError() {
+		return nil, false
+	}
+	if t.multiple {
+		return t.GetSelected(), true
+	}
+	return t.GetPath(), true
+}
+
+// RestoreAnswer завершает задачу путями из файла состояния.
+// Пути проверяются заново: файл мог быть удалён после прерванного запуска.
+//
+// @param answer Сохранённый ответ
+// @return Ошибка проверки пути
+func (t *FilePickerTask) RestoreAnswer(answer interface{}) error {
+	return t.ApplyAnswer(answer)
+}
+
+// StateAnswer возвращает выбранное значение строкой для файла состояния.
+//
+// @return Ответ и признак того, что задача завершена успешно
+func (t *DateTimeTask) StateAnswer() (interface{}, bool) {
+	if !t.done || t.HasError() {
+		return nil, false
+	}
+	return t.GetValue(), true
+}
+
+// RestoreAnswer завершает задачу значением из файла состояния.
+// Значение проверяется по границам диапазона задачи.
+//
+// @param answer Сохранённый ответ
+// @return Ошибка разбора или выхода за границы диапазона
+func (t *DateTimeTask) RestoreAnswer(answer interface{}) error {
+	return t.ApplyAnswer(answer)
+}
+
+// StateAnswer возвращает выбранное число для файла состояния.
+//
+// @return Ответ и признак того, что задача завершена успешно
+func (t *NumberTask) StateAnswer() (interface{}, bool) {
+	if !t.done || t.HasError() {
+		return nil, false
+	}
+	return t.answerValue(), true
+}
+
+// RestoreAnswer завершает задачу числом из файла состояния.
+// Значение проверяется по границам диапазона задачи.
+//
+// @param answer Сохранённый ответ
+// @return Ошибка разбора или выхода за границы диапазона
+func (t *NumberTask) RestoreAnswer(answer interface{}) error {
+	return t.ApplyAnswer(answer)
+}
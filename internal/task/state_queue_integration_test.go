@@ -0,0 +1,188 @@
+package task_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resumeQuestion — вопрос о возобновлении, запоминающий номер шага
+func resumeQuestion(step *int) query.ResumePrompt {
+	return func(s int) common.Task {
+		*step = s
+		question := fmt.Sprintf("Продолжить с шага %d?", s)
+		prompt := task.NewYesNoTask(question, question).WithDefaultYes().WithNoAsError()
+		prompt.SetID("resume")
+		return prompt
+	}
+}
+
+// installRun — задачи одного запуска очереди установки и счётчики вызовов функций
+type installRun struct {
+	env      *task.SingleSelectTask
+	password *task.InputTaskNew
+	unpack   *task.FuncTask
+	connect  *task.FuncTask
+	flash    *task.FuncTask
+	calls    map[string]int
+}
+
+// newInstallRun создаёт очередь установки; flashErr — ошибка шага прошивки
+func newInstallRun(path string, key []byte, answers query.MapAnswers, step *int, flashErr error) (*query.Model, *installRun) {
+	run := &installRun{calls: map[string]int{}}
+	count := func(name string, err error) func() error {
+		return func() error {
+			run.calls[name]++
+			return err
+		}
+	}
+	run.env = task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev", Name: "Разработка"}, {Key: "prod", Name: "Боевая"}})
+	run.password = task.NewInputTaskNew("Пароль", "Введите пароль").WithInputType(task.InputTypePassword)
+	run.unpack = task.NewFuncTask("Распаковка", count("unpack", nil))
+	run.connect = task.NewFuncTask("Подключение", count("connect", nil)).WithResumeMode(task.ResumeRerun)
+	run.flash = task.NewFuncTask("Прошивка", count("flash", flashErr))
+
+	model := query.New("Установка").WithOutput(&bytes.Buffer{}).
+		WithHeadless(answers).
+		WithStateFile(path, resumeQuestion(step)).
+		WithStateKey(key)
+	model.AddTasks([]common.Task{run.env, run.password, run.unpack, run.connect, run.flash})
+	return model, run
+}
+
+// TestStateFileResumesInterruptedQueue проверяет возобновление очереди с сохранёнными ответами
+func TestStateFileResumesInterruptedQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "install.state")
+	answers := query.MapAnswers{"Среда": "prod", "Пароль": "S3cr3t-Pass"}
+	var step int
+
+	first, _ := newInstallRun(path, nil, answers, &step, errors.New("устройство не отвечает"))
+	require.Error(t, first.Run(), "первый запуск прерывается ошибкой прошивки")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err, "файл состояния должен сохраниться")
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "файл состояния доступен только владельцу")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "S3cr3t-Pass", "пароль без ключа не сохраняется")
+
+	second, run := newInstallRun(path, nil, query.MapAnswers{"Пароль": "N3w-Pass-1"}, &step, nil)
+	require.NoError(t, second.Run())
+
+	assert.Equal(t, 2, step, "очередь продолжается с первой невыполненной задачи — пароля")
+	assert.Equal(t, "prod", run.env.GetSelected(), "выбор восстанавливается из файла состояния")
+	assert.Equal(t, "N3w-Pass-1", run.password.GetValue(), "пароль без ключа запрашивается заново")
+	assert.Equal(t, map[string]int{"connect": 1, "flash": 1}, run.calls,
+		"выполненная функция повторно не вызывается, функция с ResumeRerun выполняется заново")
+
+	_, err = os.Stat(path)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "после успешного завершения файл состояния удаляется")
+}
+
+// TestStateFileEncryptsPasswordsWithKey проверяет сохранение пароля, зашифрованного ключом
+func TestStateFileEncryptsPasswordsWithKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "install.state")
+	key := []byte("ключ оператора")
+	var step int
+
+	first, _ := newInstallRun(path, key, query.MapAnswers{"Среда": "dev", "Пароль": "S3cr3t-Pass"}, &step, errors.New("сбой"))
+	require.Error(t, first.Run())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "S3cr3t-Pass", "пароль хранится только в зашифрованном виде")
+
+	second, run := newInstallRun(path, key, nil, &step, nil)
+	require.NoError(t, second.Run(), "все ответы восстанавливаются без источника ответов")
+	assert.Equal(t, 5, step)
+	assert.Equal(t, "S3cr3t-Pass", run.password.GetValue())
+
+	// С другим ключом пароль не расшифровывается и запрашивается заново
+	third, _ := newInstallRun(path, key, query.MapAnswers{"Среда": "dev", "Пароль": "S3cr3t-Pass"}, &step, errors.New("сбой"))
+	require.Error(t, third.Run())
+	fourth, run := newInstallRun(path, []byte("чужой ключ"), query.MapAnswers{"Пароль": "Oth3r-Pass"}, &step, nil)
+	require.NoError(t, fourth.Run())
+	assert.Equal(t, "Oth3r-Pass", run.password.GetValue())
+}
+
+// TestStateFileDeclinedResumeStartsOver проверяет отказ от возобновления
+func TestStateFileDeclinedResumeStartsOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "install.state")
+	var step int
+
+	first, _ := newInstallRun(path, nil, query.MapAnswers{"Среда": "prod", "Пароль": "F1rst-Pass"}, &step, errors.New("сбой"))
+	require.Error(t, first.Run())
+
+	second, run := newInstallRun(path, nil, query.MapAnswers{"resume": false, "Среда": "dev", "Пароль": "S3cond-Pass"}, &step, nil)
+	require.NoError(t, second.Run())
+	assert.Equal(t, "dev", run.env.GetSelected(), "после отказа задачи выполняются заново")
+	assert.Equal(t, map[string]int{"unpack": 1, "connect": 1, "flash": 1}, run.calls)
+}
+
+// TestStateFileIgnoresChangedQueue проверяет, что восстановление прекращается на изменённой задаче
+func TestStateFileIgnoresChangedQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "install.state")
+	var step int
+
+	first, _ := newInstallRun(path, nil, query.MapAnswers{"Среда": "prod", "Пароль": "F1rst-Pass"}, &step, errors.New("сбой"))
+	require.Error(t, first.Run())
+
+	calls := 0
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev"}, {Key: "prod"}})
+	check := task.NewFuncTask("Проверка", func() error { calls++; return nil })
+	unpack := task.NewFuncTask("Распаковка", func() error { calls++; return nil })
+	model := query.New("Установка").WithOutput(&bytes.Buffer{}).
+		WithHeadless(nil).
+		WithStateFile(path, resumeQuestion(&step))
+	model.AddTasks([]common.Task{env, check, unpack})
+	require.NoError(t, model.Run())
+
+	assert.Equal(t, "prod", env.GetSelected(), "совпадающая задача восстанавливается")
+	assert.Equal(t, 2, calls, "после несовпадения задачи выполняются заново")
+}
+
+// TestStateFileCorruptReturnsError проверяет ошибку повреждённого файла состояния
+func TestStateFileCorruptReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "install.state")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	var step int
+	model, run := newInstallRun(path, nil, nil, &step, nil)
+	err := model.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "повреждён")
+	assert.Zero(t, run.calls["unpack"], "очередь не запускается с повреждённым состоянием")
+}
+
+// TestStateFileResumePromptInTerminal проверяет вопрос о возобновлении в интерактивном режиме
+func TestStateFileResumePromptInTerminal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "install.state")
+	var step int
+
+	first, _ := newInstallRun(path, []byte("k"), query.MapAnswers{"Среда": "prod", "Пароль": "F1rst-Pass"}, &step, errors.New("сбой"))
+	require.Error(t, first.Run())
+
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev", Name: "Разработка"}, {Key: "prod", Name: "Боевая"}})
+	password := task.NewInputTaskNew("Пароль", "Введите пароль").WithInputType(task.InputTypePassword)
+	model := query.New("Установка").WithStateFile(path, resumeQuestion(&step)).WithStateKey([]byte("k"))
+	model.AddTasks([]common.Task{env, password})
+
+	model.Init()
+	assert.Contains(t, model.View(), "Продолжить с шага 5?")
+	assert.False(t, env.IsDone(), "до ответа задачи не восстанавливаются")
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, "prod", env.GetSelected())
+	assert.Equal(t, "F1rst-Pass", password.GetValue())
+	assert.Contains(t, model.View(), "Боевая", "восстановленные задачи отображаются завершёнными")
+}
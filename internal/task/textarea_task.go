@@ -0,0 +1,445 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/performance"
+	"github.com/qzeleza/ziva/internal/ui"
+	"github.com/qzeleza/ziva/internal/validation"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TextAreaTask представляет задачу многострочного ввода: публичные ключи SSH,
+// сертификаты, тексты баннеров, фрагменты JSON.
+// Enter переносит строку, ввод подтверждается сочетанием Ctrl+D или Alt+Enter.
+type TextAreaTask struct {
+	BaseTask
+
+	textArea  textarea.Model       // UI компонент многострочного ввода
+	validator validation.Validator // Валидатор данных
+	renderer  *InputRenderer       // Рендерер итогового вида с ошибкой
+
+	// Состояние задачи
+	validationErr error  // Ошибка валидации
+	value         string // Введённый текст
+	prompt        string // Подсказка для ввода
+	placeholder   string // Текст-заполнитель
+	initial       string // Начальный текст поля (значение по умолчанию без тайм-аута)
+
+	// Настройки
+	maxLength    int  // Максимальная длина текста в символах
+	allowEmpty   bool // Разрешить пустой текст
+	previewLines int  // Количество строк текста в итоговом виде
+}
+
+// NewTextAreaTask создает задачу многострочного ввода
+//
+// @param title Заголовок задачи
+// @param prompt Подсказка для ввода
+// @return Указатель на задачу
+func NewTextAreaTask(title, prompt string) *TextAreaTask {
+	baseTask := NewBaseTask(title)
+	baseTask.SetStopOnError(true)
+
+	ta := textarea.New()
+	ta.Prompt = ""
+	ta.ShowLineNumbers = false
+	ta.Placeholder = defaults.DefaultPlaceholder
+	ta.CharLimit = defaults.MaxTextAreaLength
+	ta.SetHeight(defaults.DefaultTextAreaHeight)
+	// Ctrl+D подтверждает ввод, поэтому удаление символа оставляем только на Delete
+	ta.KeyMap.DeleteCharacterForward = key.NewBinding(key.WithKeys("delete"))
+	ta.Focus()
+
+	t := &TextAreaTask{
+		BaseTask:     baseTask,
+		textArea:     ta,
+		renderer:     NewInputRenderer(),
+		prompt:       prompt,
+		placeholder:  defaults.DefaultPlaceholder,
+		maxLength:    defaults.MaxTextAreaLength,
+		previewLines: defaults.TextAreaPreviewLines,
+	}
+	t.applyStyles(ui.CurrentTheme())
+	return t
+}
+
+// WithValidator устанавливает валидатор для текста
+func (t *TextAreaTask) WithValidator(validator validation.Validator) *TextAreaTask {
+	t.validator = validator
+	return t
+}
+
+// WithHeight устанавливает высоту поля в строках; более длинный текст прокручивается
+func (t *TextAreaTask) WithHeight(height int) *TextAreaTask {
+	if height > 0 {
+		t.textArea.SetHeight(height)
+	}
+	return t
+}
+
+// WithLineNumbers включает или отключает нумерацию строк
+func (t *TextAreaTask) WithLineNumbers(show bool) *TextAreaTask {
+	t.textArea.ShowLineNumbers = show
+	return t
+}
+
+// WithMaxLength устанавливает максимальную длину текста в символах
+func (t *TextAreaTask) WithMaxLength(length int) *TextAreaTask {
+	if length > 0 {
+		t.maxLength = length
+		t.textArea.CharLimit = length
+	}
+	return t
+}
+
+// WithPlaceholder устанавливает текст-заполнитель
+func (t *TextAreaTask) WithPlaceholder(placeholder string) *TextAreaTask {
+	t.placeholder = placeholder
+	t.textArea.Placeholder = placeholder
+	return t
+}
+
+// WithValue заполняет поле начальным текстом, который пользователь может изменить.
+// В неинтерактивном и построчном режимах он используется как значение по умолчанию.
+func (t *TextAreaTask) WithValue(value string) *TextAreaTask {
+	t.initial = normalizeNewlines(value)
+	t.textArea.SetValue(t.initial)
+	return t
+}
+
+// WithAllowEmpty разрешает пустой текст
+func (t *TextAreaTask) WithAllowEmpty(allow bool) *TextAreaTask {
+	t.allowEmpty = allow
+	return t
+}
+
+// WithPreviewLines задаёт количество строк текста в итоговом виде задачи
+// (0 — текст в итоговом виде не выводится)
+func (t *TextAreaTask) WithPreviewLines(lines int) *TextAreaTask {
+	if lines >= 0 {
+		t.previewLines = lines
+	}
+	return t
+}
+
+// WithTimeout устанавливает тайм-аут для задачи многострочного ввода
+// @param duration Длительность тайм-аута
+// @param defaultValue Значение по умолчанию (строка)
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithTimeout(duration time.Duration, defaultValue interface{}) *TextAreaTask {
+	t.BaseTask.WithTimeout(duration, defaultValue)
+	return t
+}
+
+// SetTheme задаёт тему оформления задачи: стили поля и рендерер
+func (t *TextAreaTask) SetTheme(theme *ui.Theme) {
+	t.BaseTask.SetTheme(theme)
+	t.renderer.WithTheme(theme)
+	t.applyStyles(t.Theme())
+}
+
+// SetClock задаёт источник времени задачи. Мигание курсора отсчитывается
+// по системному времени, поэтому с другими часами курсор не мигает
+func (t *TextAreaTask) SetClock(clock common.Clock) {
+	t.BaseTask.SetClock(clock)
+	mode := cursor.CursorBlink
+	if t.Clock() != common.SystemClock {
+		mode = cursor.CursorStatic
+	}
+	t.textArea.Cursor.SetMode(mode)
+}
+
+// applyStyles применяет стили темы к полю ввода
+func (t *TextAreaTask) applyStyles(th *ui.Theme) {
+	style := textarea.Style{
+		Placeholder:      th.Styles.Subtle,
+		LineNumber:       th.Styles.Subtle,
+		CursorLineNumber: lipgloss.NewStyle().Foreground(th.Palette.Accent),
+		EndOfBuffer:      th.Styles.Subtle,
+	}
+	t.textArea.FocusedStyle = style
+	t.textArea.BlurredStyle = style
+	t.textArea.Cursor.Style = lipgloss.NewStyle().Background(th.Palette.Accent).Bold(true)
+	t.textArea.Cursor.TextStyle = lipgloss.NewStyle().Foreground(th.Palette.Accent).Bold(true)
+}
+
+// GetValue возвращает введённый текст
+func (t *TextAreaTask) GetValue() string {
+	return t.value
+}
+
+// Run запускает задачу многострочного ввода
+func (t *TextAreaTask) Run() tea.Cmd {
+	cmds := []tea.Cmd{textarea.Blink}
+	if t.timeoutEnabled && t.timeoutManager != nil {
+		cmds = append(cmds, t.timeoutManager.StartTickerAndTimeout())
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update обрабатывает сообщения и обновляет состояние задачи
+func (t *TextAreaTask) Update(msg tea.Msg) (Task, tea.Cmd) {
+	if t.done {
+		return t, nil
+	}
+
+	switch msg := msg.(type) {
+	case TimeoutMsg:
+		// Применяем значение по умолчанию при истечении таймера
+		t.applyDefaultValue()
+		return t, nil
+	case TickMsg:
+		if t.timeoutEnabled && t.timeoutManager != nil && t.timeoutManager.IsActive() {
+			return t, t.timeoutManager.StartTicker()
+		}
+		return t, nil
+	case tea.KeyMsg:
+		pressed := msg.String()
+		// Любой ввод, кроме управляющих клавиш, отключает таймер
+		if t.timeoutEnabled && t.timeoutManager != nil && t.timeoutManager.IsActive() {
+			if pressed != "ctrl+c" && pressed != "esc" && pressed != "ctrl+d" && pressed != "alt+enter" {
+				t.DisableTimeout()
+			}
+		}
+
+		switch pressed {
+		case "ctrl+c", "esc", "Ctrl+C", "Esc":
+			return t.handleCancel()
+		case "ctrl+d", "alt+enter":
+			t.submit(t.textArea.Value())
+			return t, nil
+		case "left", "Left":
+			// В пустом поле стрелка влево открывает предыдущую задачу или отменяет ввод
+			if performance.TrimSpaceEfficient(t.textArea.Value()) == "" {
+				if t.requestBack() {
+					return t, nil
+				}
+				return t.handleCancel()
+			}
+		}
+
+		var cmd tea.Cmd
+		t.textArea, cmd = t.textArea.Update(msg)
+		t.validationErr = nil
+		return t, cmd
+	}
+
+	var cmd tea.Cmd
+	t.textArea, cmd = t.textArea.Update(msg)
+	return t, cmd
+}
+
+// validate проверяет текст: обязательность, длину и валидатор задачи
+//
+// @param value Текст
+// @return Ошибка валидации или nil
+func (t *TextAreaTask) validate(value string) error {
+	if !t.allowEmpty && performance.TrimSpaceEfficient(value) == "" {
+		return terrors.NewValidationError(t.title, errors.New(defaults.ErrFieldRequired)).
+			WithContext("required", true)
+	}
+	if length := utf8.RuneCountInString(value); length > t.maxLength {
+		return terrors.NewValidationError(t.title, fmt.Errorf(defaults.ErrTextTooLong, t.maxLength)).
+			WithContext("value_length", length)
+	}
+	if t.validator != nil {
+		if err := t.validator.Validate(value); err != nil {
+			return terrors.NewValidationError(t.title, err).
+				WithContext("value_length", utf8.RuneCountInString(value))
+		}
+	}
+	return nil
+}
+
+// submit завершает задачу текстом, если он прошёл проверку
+//
+// @param value Текст
+func (t *TextAreaTask) submit(value string) {
+	th := t.Theme()
+	if err := t.validate(value); err != nil {
+		t.validationErr = err
+		t.SetError(err)
+		t.textArea.Focus()
+		return
+	}
+
+	t.validationErr = nil
+	t.SetError(nil)
+	t.value = value
+	t.done = true
+	t.icon = th.Icons.Done
+	t.finalValue = th.Styles.SuccessLabel.Render(t.firstLine())
+	t.textArea.Blur()
+}
+
+// handleCancel обрабатывает отмену ввода
+func (t *TextAreaTask) handleCancel() (Task, tea.Cmd) {
+	th := t.Theme()
+	cancelErr := terrors.NewCancelError(t.title).
+		WithContext("partial_length", utf8.RuneCountInString(t.textArea.Value()))
+
+	t.SetError(cancelErr)
+	t.done = true
+	t.icon = th.Icons.Cancelled
+	t.finalValue = th.Styles.ErrorMessage.Render(defaults.CancelShort)
+	t.textArea.Blur()
+	return t, nil
+}
+
+// applyDefaultValue применяет значение по умолчанию при истечении таймера
+func (t *TextAreaTask) applyDefaultValue() {
+	if t.defaultValue == nil {
+		return
+	}
+	th := t.Theme()
+	value := normalizeNewlines(fmt.Sprint(t.defaultValue))
+	if err := t.validate(value); err != nil {
+		t.validationErr = err
+		t.SetError(err)
+		t.done = true
+		t.icon = th.Icons.Error
+		t.finalValue = th.Styles.ErrorMessage.Render(defaults.ErrDefaultValueInvalid)
+		return
+	}
+
+	t.textArea.SetValue(value)
+	t.submit(value)
+	t.timedOut = true
+}
+
+// View отображает текущее состояние задачи
+func (t *TextAreaTask) View(width int) string {
+	if t.IsDone() {
+		return t.FinalView(width)
+	}
+	th := t.Theme()
+
+	prefix := t.InProgressPrefix()
+	if strings.TrimSpace(prefix) == "" {
+		prefix = th.CurrentTaskPrefix()
+	}
+	titleView := prefix + th.Styles.ActiveTask.Render(t.title)
+	if timer := t.RenderTimer(); timer != "" {
+		titleView = ui.AlignTextToRight(titleView, th.Styles.Subtle.Render(timer), width)
+	}
+
+	lineIndent := performance.FastConcat(
+		performance.RepeatEfficient(" ", ui.MainLeftIndent),
+		th.Glyphs.Vertical,
+		" ",
+	)
+	fieldWidth := common.CalculateLayoutWidth(width) - lipgloss.Width(lineIndent)
+	if fieldWidth < defaults.MinInputWidth {
+		fieldWidth = defaults.MinInputWidth
+	}
+	t.textArea.SetWidth(fieldWidth)
+	if !t.textArea.Focused() {
+		t.textArea.Focus()
+	}
+
+	var result strings.Builder
+	result.WriteString(titleView + "\n")
+	result.WriteString(renderSelectionSeparator(th, width, t.SelectionSeparatorEnabled(), prefix))
+	if prompt := strings.TrimSpace(t.prompt); prompt != "" {
+		result.WriteString(lineIndent + th.Styles.Subtle.Render(prompt) + "\n")
+	}
+	for _, line := range strings.Split(t.textArea.View(), "\n") {
+		result.WriteString(lineIndent + line + "\n")
+	}
+	length := fmt.Sprintf(defaults.TextAreaLengthFormat, utf8.RuneCountInString(t.textArea.Value()), t.maxLength)
+	result.WriteString(lineIndent + th.Styles.Subtle.Render(length) + "\n\n")
+	result.WriteString(th.DrawLine(width))
+
+	indent := performance.RepeatEfficient(" ", ui.MainLeftIndent)
+	sections := make([]string, 0, 3)
+	if t.validationErr != nil {
+		message := t.validationErr.Error()
+		var taskErr *terrors.TaskError
+		if errors.As(t.validationErr, &taskErr) && taskErr.Err != nil {
+			message = taskErr.Err.Error()
+		}
+		sections = append(sections, th.Styles.ErrorMessage.Render(indent+ui.CapitalizeFirst(message)))
+	}
+	if t.validator != nil && t.validator.Description() != "" {
+		sections = append(sections, th.Styles.Subtle.Render(fmt.Sprintf("%s%s %s", indent, defaults.InputFormatLabel, t.validator.Description())))
+	}
+	sections = append(sections, th.Styles.Subtle.Render(indent+defaults.TextAreaConfirmHint))
+	result.WriteString(strings.Join(sections, "\n"))
+
+	return result.String()
+}
+
+// FinalView отображает финальное состояние задачи с сокращённым текстом
+func (t *TextAreaTask) FinalView(width int) string {
+	completedPrefix := t.CompletedPrefix()
+	if t.HasError() {
+		return t.renderer.RenderFinal(t.title, "", true, t.Error(), completedPrefix, width)
+	}
+
+	th := t.Theme()
+	if strings.TrimSpace(completedPrefix) == "" {
+		completedPrefix = th.CompletedInputTaskPrefix(true)
+	}
+	leftPart := fmt.Sprintf("%s  %s", completedPrefix, t.title)
+	rightPart := th.Styles.TaskStatusSuccess.Render(strings.ToUpper(defaults.DefaultSuccessLabel))
+
+	var result strings.Builder
+	result.WriteString(ui.AlignTextToRight(leftPart, rightPart, width))
+	result.WriteString("\n")
+
+	linePrefix := performance.FastConcat(
+		performance.RepeatEfficient(" ", ui.MainLeftIndent),
+		th.Glyphs.Vertical,
+		ui.GetResultIndentWhenNumberingEnabled(),
+	)
+	available := width - lipgloss.Width(linePrefix) - 2
+	preview := t.preview()
+	if len(preview) > 0 {
+		preview[len(preview)-1] += t.prefill.note(t.StateAnswer())
+	}
+	for _, line := range preview {
+		result.WriteString(linePrefix + th.Styles.Subtle.Render(truncateRunes(line, available)) + "\n")
+	}
+	return result.String()
+}
+
+// preview возвращает первые строки текста для итогового вида
+// и строку с числом оставшихся строк, если текст длиннее
+func (t *TextAreaTask) preview() []string {
+	if t.previewLines == 0 || t.value == "" {
+		return nil
+	}
+	lines := strings.Split(t.value, "\n")
+	if len(lines) <= t.previewLines {
+		return lines
+	}
+	preview := append([]string{}, lines[:t.previewLines]...)
+	return append(preview, fmt.Sprintf(defaults.TextAreaMoreLinesFormat, len(lines)-t.previewLines))
+}
+
+// firstLine возвращает первую строку текста; если строк больше, добавляется многоточие
+func (t *TextAreaTask) firstLine() string {
+	first, rest, more := strings.Cut(t.value, "\n")
+	if more && rest != "" {
+		return first + " …"
+	}
+	return first
+}
+
+// normalizeNewlines приводит переводы строк к виду "\n"
+func normalizeNewlines(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
@@ -0,0 +1,124 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// typeText вводит текст в задачу посимвольно, перерисовывая её после каждой клавиши,
+// как цикл событий; перевод строки вводится клавишей Enter
+func typeText(task *TextAreaTask, text string) {
+	for _, r := range text {
+		if r == '\n' {
+			task.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		} else {
+			task.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		task.View(80)
+	}
+}
+
+// TestTextAreaSubmitKeys проверяет, что Enter переносит строку, а Ctrl+D и Alt+Enter подтверждают ввод
+func TestTextAreaSubmitKeys(t *testing.T) {
+	area := NewTextAreaTask("Баннер", "Введите текст баннера")
+	typeText(area, "Добро пожаловать\nна роутер")
+	assert.False(t, area.IsDone(), "Enter не должен завершать ввод")
+
+	area.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	require.True(t, area.IsDone())
+	assert.False(t, area.HasError())
+	assert.Equal(t, "Добро пожаловать\nна роутер", area.GetValue())
+
+	alt := NewTextAreaTask("Баннер", "")
+	typeText(alt, "строка")
+	alt.Update(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	assert.True(t, alt.IsDone())
+	assert.Equal(t, "строка", alt.GetValue())
+}
+
+// TestTextAreaValidation проверяет обязательность, максимальную длину и валидатор
+func TestTextAreaValidation(t *testing.T) {
+	area := NewTextAreaTask("JSON", "").WithValidator(validation.ValidatorFunc(func(s string) error {
+		if !strings.HasPrefix(s, "{") {
+			return errors.New("ожидается объект JSON")
+		}
+		return nil
+	}))
+
+	area.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	assert.False(t, area.IsDone(), "пустой текст не принимается")
+
+	typeText(area, "[1]")
+	area.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	assert.False(t, area.IsDone())
+	assert.Contains(t, area.View(80), "Ожидается объект JSON")
+
+	limited := NewTextAreaTask("Баннер", "").WithMaxLength(5)
+	err := limited.ApplyAnswer("слишком длинный")
+	var taskErr *terrors.TaskError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, terrors.ErrorTypeValidation, taskErr.Type)
+
+	typeText(limited, "123456789")
+	assert.Contains(t, limited.View(80), "5/5", "ввод ограничен максимальной длиной")
+}
+
+// TestTextAreaLineNumbersAndHeight проверяет нумерацию строк и высоту поля
+func TestTextAreaLineNumbersAndHeight(t *testing.T) {
+	area := NewTextAreaTask("Сертификат", "").WithLineNumbers(true).WithHeight(2)
+	typeText(area, "a\nb\nc")
+
+	view := area.View(80)
+	assert.Contains(t, view, "2 ")
+	assert.Contains(t, view, "3 ")
+	assert.NotContains(t, view, " 1 ", "поле высотой 2 строки показывает только последние строки")
+}
+
+// TestTextAreaFinalPreview проверяет сокращённый текст в итоговом виде
+func TestTextAreaFinalPreview(t *testing.T) {
+	area := NewTextAreaTask("Сертификат", "").WithPreviewLines(2)
+	require.NoError(t, area.ApplyAnswer("-----BEGIN CERTIFICATE-----\r\nMIIB\r\nMIIC\r\n-----END CERTIFICATE-----"))
+
+	view := area.FinalView(80)
+	assert.Contains(t, view, "-----BEGIN CERTIFICATE-----")
+	assert.Contains(t, view, "MIIB")
+	assert.NotContains(t, view, "MIIC")
+	assert.Contains(t, view, "2", "итоговый вид сообщает число скрытых строк")
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----\nMIIB\nMIIC\n-----END CERTIFICATE-----", area.GetValue())
+
+	long := NewTextAreaTask("Ключ SSH", "")
+	require.NoError(t, long.ApplyAnswer("ssh-ed25519 "+strings.Repeat("A", 200)+" user@host"))
+	for _, line := range strings.Split(long.FinalView(60), "\n") {
+		assert.LessOrEqual(t, len([]rune(line)), 80, "строки итогового вида обрезаются по ширине")
+	}
+}
+
+// TestTextAreaTimeoutDefault проверяет применение текста по умолчанию по тайм-ауту
+func TestTextAreaTimeoutDefault(t *testing.T) {
+	area := NewTextAreaTask("Баннер", "").WithTimeout(time.Second, "Добро пожаловать")
+	area.Update(TimeoutMsg{})
+	assert.True(t, area.IsDone())
+	assert.Equal(t, "Добро пожаловать", area.GetValue())
+
+	initial := NewTextAreaTask("Баннер", "").WithValue("по умолчанию")
+	assert.True(t, initial.ApplyDefaultAnswer())
+	assert.Equal(t, "по умолчанию", initial.GetValue())
+}
+
+// TestTextAreaCancel проверяет отмену ввода
+func TestTextAreaCancel(t *testing.T) {
+	area := NewTextAreaTask("Баннер", "")
+	typeText(area, "текст")
+	area.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, area.IsDone())
+	assert.True(t, area.HasError())
+	assert.Empty(t, area.GetValue())
+}
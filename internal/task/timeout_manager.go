@@ -4,6 +4,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva/internal/common"
 )
 
 // TimeoutMsg - специальное сообщение, которое отправляется при истечении времени ожидания
@@ -28,6 +29,8 @@ type TimeoutManager struct {
 	active bool
 	// Канал для отмены текущего таймера
 	cancelCh chan struct{}
+	// Источник времени (по умолчанию системное время)
+	clock common.Clock
 }
 
 // NewTimeoutManager создает новый менеджер тайм-аутов
@@ -36,9 +39,15 @@ func NewTimeoutManager(duration time.Duration) *TimeoutManager {
 		duration: duration,
 		active:   false,
 		cancelCh: make(chan struct{}, 1),
+		clock:    common.SystemClock,
 	}
 }
 
+// SetClock задаёт источник времени таймера
+func (tm *TimeoutManager) SetClock(clock common.Clock) {
+	tm.clock = common.ClockOrSystem(clock)
+}
+
 // StartTimeout запускает таймер тайм-аута
 func (tm *TimeoutManager) StartTimeout() tea.Cmd {
 	// Если таймер уже активен, сначала остановим его
@@ -46,31 +55,48 @@ func (tm *TimeoutManager) StartTimeout() tea.Cmd {
 		tm.StopTimeout()
 	}
 
-	tm.startTime = time.Now()
+	tm.startTime = tm.clock.Now()
 	tm.active = true
 
 	// Создаем новый канал для отмены
 	tm.cancelCh = make(chan struct{}, 1)
 	cancelCh := tm.cancelCh
 
-	// Возвращаем команду для таймера
+	// Поддельные часы тестов управляют временем через Tick
+	if tm.clock != common.SystemClock {
+		return tm.clock.Tick(tm.duration, func(time.Time) tea.Msg {
+			select {
+			case <-cancelCh:
+				// Таймер был отменен
+				return nil
+			default:
+				// Таймер сработал - возвращаем сообщение о тайм-ауте
+				return DefaultTimeout
+			}
+		})
+	}
+
+	// Отмена завершает ожидание сразу, не дожидаясь истечения тайм-аута
+	clock, duration := tm.clock, tm.duration
 	return func() tea.Msg {
-		timer := time.NewTimer(tm.duration)
 		select {
-		case <-timer.C:
-			// Таймер сработал - возвращаем сообщение о тайм-ауте
-			return DefaultTimeout
 		case <-cancelCh:
-			// Таймер был отменен
-			timer.Stop()
 			return nil
+		case <-clock.After(duration):
+		}
+		select {
+		case <-cancelCh:
+			// Таймер отменён одновременно со срабатыванием
+			return nil
+		default:
+			return DefaultTimeout
 		}
 	}
 }
 
 // StartTicker запускает периодическое обновление счетчика каждую секунду
 func (tm *TimeoutManager) StartTicker() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+	return tm.clock.Tick(time.Second, func(t time.Time) tea.Msg {
 		return DefaultTick
 	})
 }
@@ -106,7 +132,7 @@ func (tm *TimeoutManager) RemainingTime() int {
 		return 0
 	}
 
-	elapsed := time.Since(tm.startTime)
+	elapsed := tm.clock.Now().Sub(tm.startTime)
 	remaining := tm.duration - elapsed
 	if remaining < 0 {
 		return 0
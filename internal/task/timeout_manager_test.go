@@ -0,0 +1,32 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTimeoutManagerStopEndsWait проверяет, что остановка таймера сразу завершает ожидание
+func TestTimeoutManagerStopEndsWait(t *testing.T) {
+	tm := NewTimeoutManager(time.Hour)
+	cmd := tm.StartTimeout()
+	tm.StopTimeout()
+
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- cmd() }()
+	select {
+	case msg := <-msgs:
+		assert.Nil(t, msg, "отменённый таймер не отправляет сообщение о тайм-ауте")
+	case <-time.After(time.Second):
+		t.Fatal("отменённый таймер продолжает ожидание")
+	}
+}
+
+// TestTimeoutManagerFires проверяет сообщение о тайм-ауте по истечении времени
+func TestTimeoutManagerFires(t *testing.T) {
+	tm := NewTimeoutManager(10 * time.Millisecond)
+	assert.Equal(t, DefaultTimeout, tm.StartTimeout()())
+	assert.True(t, tm.IsActive())
+}
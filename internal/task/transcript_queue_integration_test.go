@@ -0,0 +1,83 @@
+package task_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/common"
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/query"
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedClock — часы с неизменным временем для предсказуемых записей журнала
+type fixedClock struct {
+	common.Clock
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+// transcriptQueue создаёт неинтерактивную очередь с журналом сеанса
+func transcriptQueue(w *bytes.Buffer, format query.TranscriptFormat) (*query.Model, []common.Task) {
+	env := task.NewSingleSelectTask("Среда", []task.Item{{Key: "dev", Name: "Разработка"}, {Key: "prod", Name: "Боевая"}})
+	confirm := task.NewYesNoTask("Подтверждение", "Продолжить?").WithTimeoutYes(time.Second)
+	secret := task.NewInputTaskNew("Пароль администратора", "Введите пароль")
+	upload := task.NewFuncTask("Загрузка", func() error {
+		return terrors.NewNetworkError("Загрузка", errors.New("нет связи"))
+	})
+
+	clock := fixedClock{Clock: common.SystemClock, now: time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)}
+	model := query.New("Журнал").WithOutput(&bytes.Buffer{}).
+		WithHeadless(query.MapAnswers{"Среда": "prod", "Пароль администратора": "s3cr3t"}).
+		WithClock(clock).
+		WithTranscript(w, format)
+	tasks := []common.Task{env, confirm, secret, upload}
+	model.AddTasks(tasks)
+	return model, tasks
+}
+
+// TestTranscriptTextRecordsEachTask проверяет текстовый журнал сеанса
+func TestTranscriptTextRecordsEachTask(t *testing.T) {
+	var log bytes.Buffer
+	model, _ := transcriptQueue(&log, query.TranscriptText)
+	require.Error(t, model.Run(), "ошибка задачи-функции возвращается из Run")
+
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	require.Len(t, lines, 4, "журнал должен содержать запись о каждой задаче")
+
+	assert.Equal(t, `2024-05-01T10:00:00Z task="Среда" kind=single_select status=success value="Боевая" key="prod" timed_out=false`, lines[0])
+	assert.Contains(t, lines[1], `value="Да" key=true timed_out=true`, "выбор по тайм-ауту должен быть отмечен")
+	assert.Contains(t, lines[2], `value="********" key="********"`)
+	assert.NotContains(t, log.String(), "s3cr3t", "пароль не должен попадать в журнал")
+	assert.Contains(t, lines[3], `status=error timed_out=false error_type=network`)
+}
+
+// TestTranscriptJSONLines проверяет журнал сеанса в формате JSON Lines
+func TestTranscriptJSONLines(t *testing.T) {
+	var log bytes.Buffer
+	model, _ := transcriptQueue(&log, query.TranscriptJSONLines)
+	require.Error(t, model.Run(), "ошибка задачи-функции возвращается из Run")
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(log.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, "2024-05-01T10:00:00Z", entries[0]["time"])
+	assert.Equal(t, "Боевая", entries[0]["value"])
+	assert.Equal(t, "prod", entries[0]["key"])
+	assert.Equal(t, true, entries[1]["timed_out"])
+	assert.Equal(t, "********", entries[2]["key"])
+	assert.Equal(t, "network", entries[3]["error_type"])
+	assert.Equal(t, "error", entries[3]["status"])
+}
@@ -11,17 +11,17 @@ import (
 )
 
 // renderSelectionSeparator формирует разделитель между заголовком и списком пунктов
-func renderSelectionSeparator(width int, enabled bool, inProgressPrefix string) string {
+func renderSelectionSeparator(th *ui.Theme, width int, enabled bool, inProgressPrefix string) string {
 	if !enabled {
 		return ""
 	}
 	if strings.TrimSpace(inProgressPrefix) == "" {
-		inProgressPrefix = ui.GetCurrentTaskPrefix()
+		inProgressPrefix = th.CurrentTaskPrefix()
 	}
 
 	basePrefix := performance.FastConcat(
 		performance.RepeatEfficient(" ", ui.MainLeftIndent),
-		ui.VerticalLineSymbol,
+		th.Glyphs.Vertical,
 		"  ",
 	)
 
@@ -51,7 +51,7 @@ func renderSelectionSeparator(width int, enabled bool, inProgressPrefix string)
 	}
 
 	// Формируем горизонтальную линию с бледным серым оттенком
-	horizontal := ui.VerySubtleStyle.Render(performance.RepeatEfficient(ui.HorizontalLineSymbol, available))
+	horizontal := th.Styles.VerySubtle.Render(performance.RepeatEfficient(th.Glyphs.Horizontal, available))
 
 	// Формируем разделитель
 	return performance.FastConcat(
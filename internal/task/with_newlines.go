@@ -29,8 +29,32 @@ func (t *InputTaskNew) WithNewLinesInErrors(preserve bool) common.Task {
 	return t
 }
 
+// WithNewLinesInErrors реализация для TextAreaTask
+func (t *TextAreaTask) WithNewLinesInErrors(preserve bool) common.Task {
+	t.preserveErrorNewLines = preserve
+	return t
+}
+
 // WithNewLinesInErrors реализация для FuncTask
 func (t *FuncTask) WithNewLinesInErrors(preserve bool) common.Task {
 	t.preserveErrorNewLines = preserve
 	return t
 }
+
+// WithNewLinesInErrors реализация для FilePickerTask
+func (t *FilePickerTask) WithNewLinesInErrors(preserve bool) common.Task {
+	t.preserveErrorNewLines = preserve
+	return t
+}
+
+// WithNewLinesInErrors реализация для DateTimeTask
+func (t *DateTimeTask) WithNewLinesInErrors(preserve bool) common.Task {
+	t.preserveErrorNewLines = preserve
+	return t
+}
+
+// WithNewLinesInErrors реализация для NumberTask
+func (t *NumberTask) WithNewLinesInErrors(preserve bool) common.Task {
+	t.preserveErrorNewLines = preserve
+	return t
+}
@@ -153,12 +153,13 @@ func (t *YesNoTask) View(width int) string {
 // ярко-жёлтым цветом. Для ответа "Да" используется стандартное зелёное
 // оформление выбранной опции.
 func (t *YesNoTask) FinalView(width int) string {
-	if t.icon == ui.IconError {
+	th := t.Theme()
+	if t.icon == th.Icons.Error {
 		return t.SingleSelectTask.FinalView(width)
 	}
 
 	// Для отмененных задач используем базовое представление, чтобы показать статус "ОТМЕНА"
-	if t.icon == ui.IconCancelled {
+	if t.icon == th.Icons.Cancelled {
 		return t.BaseTask.FinalView(width)
 	}
 
@@ -167,7 +168,7 @@ func (t *YesNoTask) FinalView(width int) string {
 	success := !t.HasError() && (t.selectedOption == YesOption || !t.noCountsAsError)
 	prefix := t.CompletedPrefix()
 	if prefix == "" {
-		prefix = ui.GetCompletedTaskPrefix(success)
+		prefix = th.CompletedTaskPrefix(success)
 	}
 
 	// Определяем стиль заголовка в зависимости от результата
@@ -175,7 +176,7 @@ func (t *YesNoTask) FinalView(width int) string {
 	if success {
 		styledTitle = t.title
 	} else {
-		styledTitle = ui.GetErrorStatusStyle().Render(t.title)
+		styledTitle = th.Styles.ErrorStatus.Render(t.title)
 	}
 
 	// Сформируем левую часть строки
@@ -185,21 +186,21 @@ func (t *YesNoTask) FinalView(width int) string {
 	var right string
 	switch t.selectedOption {
 	case YesOption:
-		right = ui.TaskStatusSuccessStyle.Render(defaults.DefaultYesLabel)
+		right = th.Styles.TaskStatusSuccess.Render(defaults.DefaultYesLabel)
 	case NoOption:
 		if t.noCountsAsError {
 			// Для "Нет" выводим слово ОТКАЗ стилем ошибки
-			right = ui.GetErrorStatusStyle().Render(defaults.DefaultNoLabel)
+			right = th.Styles.ErrorStatus.Render(defaults.DefaultNoLabel)
 		} else {
-			right = ui.TaskStatusSuccessStyle.Render(defaults.DefaultNoLabel)
+			right = th.Styles.TaskStatusSuccess.Render(defaults.DefaultNoLabel)
 		}
 	}
 
 	// Сформируем отрисовки линии результата
 	result := "\n"
 	// Если задача завершилась успешно и есть дополнительные строки для вывода
-	if t.showResultLine && t.icon == ui.IconDone { // && len(t.items) > 0 && t.cursor >= 0 && t.cursor < len(t.items) {
-		result = "\n" + ui.DrawSummaryLine(t.items[t.cursor].displayName())
+	if t.showResultLine && t.icon == th.Icons.Done { // && len(t.items) > 0 && t.cursor >= 0 && t.cursor < len(t.items) {
+		result = "\n" + th.DrawSummaryLine(t.items[t.cursor].displayName()+t.prefill.note(t.StateAnswer()))
 	}
 
 	// Выравниваем по ширине макета
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ansiSequences — управляющие последовательности терминала: CSI (цвет, начертание,
+// курсор) и OSC (заголовок окна, ссылки)
+var ansiSequences = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\a\x1b]*(\a|\x1b\\)`)
+
+// ColorMode определяет, выводится ли интерфейс в цвете.
+type ColorMode int
+
+const (
+	// ColorAuto — цвет определяется возможностями терминала
+	ColorAuto ColorMode = iota
+	// ColorNever — цвет отключён (NO_COLOR, CLICOLOR=0)
+	ColorNever
+	// ColorAlways — цвет включён даже без терминала (CLICOLOR_FORCE)
+	ColorAlways
+)
+
+// ColorModeFromEnv определяет режим цвета по соглашениям NO_COLOR (https://no-color.org)
+// и CLICOLOR/CLICOLOR_FORCE (https://bixense.com/clicolors).
+// NO_COLOR имеет приоритет над CLICOLOR_FORCE, а CLICOLOR_FORCE — над CLICOLOR=0.
+//
+// @return Режим цвета
+func ColorModeFromEnv() ColorMode {
+	if os.Getenv("NO_COLOR") != "" {
+		return ColorNever
+	}
+	if force := strings.TrimSpace(os.Getenv("CLICOLOR_FORCE")); force != "" && force != "0" {
+		return ColorAlways
+	}
+	if strings.TrimSpace(os.Getenv("CLICOLOR")) == "0" {
+		return ColorNever
+	}
+	return ColorAuto
+}
+
+// StripANSI удаляет из строки ANSI-последовательности цвета и начертания.
+//
+// @param s Строка с оформлением
+// @return Строка без оформления
+func StripANSI(s string) string {
+	return ansiSequences.ReplaceAllString(s, "")
+}
@@ -76,6 +76,7 @@ func EnableEmbeddedMode() {
 
 // refreshIconsForEmbedded обновляет иконки для embedded режима
 func refreshIconsForEmbedded() {
+	defer invalidateCurrentTheme()
 	IconDone = lipgloss.NewStyle().SetString("✔").Foreground(ColorBrightGreen).String()
 	IconError = lipgloss.NewStyle().SetString("✕").Foreground(ColorBrightRed).String()
 	IconCancelled = lipgloss.NewStyle().SetString("⊗").Foreground(ColorBrightYellow).String()
@@ -92,6 +93,7 @@ func refreshIconsForEmbedded() {
 
 // EnableASCIIMode включает максимально совместимый ASCII-набор иконок
 func EnableASCIIMode() {
+	defer invalidateCurrentTheme()
 	// Устанавливаем простые ASCII-иконки без цвета
 	IconDone = "*"
 	IconError = "x"
@@ -101,6 +103,10 @@ func EnableASCIIMode() {
 	IconRadioOn = "●"
 	IconCursor = ">"
 	IconUndone = "."
+
+	// Полоса прогресса из простых символов
+	ProgressFilledSymbol = "#"
+	ProgressEmptySymbol = "-"
 }
 
 // IsEmbeddedColorMode возвращает true если включен embedded режим
@@ -58,6 +58,7 @@ func TestEnableEmbeddedMode(t *testing.T) {
 	ColorBrightRed = originalBrightRed
 	ErrorMessageStyle = originalErrorMessageStyle
 	ErrorStatusStyle = originalErrorStatusStyle
+	invalidateCurrentTheme()
 }
 
 func TestRefreshIconsForEmbedded(t *testing.T) {
@@ -81,6 +82,7 @@ func TestRefreshIconsForEmbedded(t *testing.T) {
 	// Восстанавливаем исходные иконки
 	IconDone = originalIconDone
 	IconError = originalIconError
+	invalidateCurrentTheme()
 }
 
 func TestIsEmbeddedColorMode(t *testing.T) {
@@ -97,6 +99,7 @@ func TestIsEmbeddedColorMode(t *testing.T) {
 
 	// Восстанавливаем исходное состояние
 	ColorBrightGreen = originalColorBrightGreen
+	invalidateCurrentTheme()
 }
 
 func TestGetEmbeddedMemoryFootprint(t *testing.T) {
@@ -126,6 +129,7 @@ func TestEmbeddedColorFallbacks(t *testing.T) {
 	ColorBrightOrange = originalBrightOrange
 	ColorDarkOrange = originalDarkOrange
 	ColorLightBlue = originalLightBlue
+	invalidateCurrentTheme()
 }
 
 func TestEmbeddedModeConsistency(t *testing.T) {
@@ -172,6 +176,7 @@ func TestEmbeddedModeIdempotency(t *testing.T) {
 
 	// Восстанавливаем исходное состояние
 	ColorBrightGreen = originalBrightGreen
+	invalidateCurrentTheme()
 }
 
 func TestEmbeddedColorValues(t *testing.T) {
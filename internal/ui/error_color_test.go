@@ -31,6 +31,7 @@ func TestSetErrorColor(t *testing.T) {
 	// Восстанавливаем исходные стили
 	ErrorMessageStyle = originalMessageStyle
 	ErrorStatusStyle = originalStatusStyle
+	invalidateCurrentTheme()
 }
 
 // TestResetErrorColors проверяет функцию ResetErrorColors
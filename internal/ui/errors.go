@@ -15,6 +15,11 @@ import (
 // layoutWidth - полная ширина макета для финальной линии
 // preserveNewLines - если true, то сохраняет оригинальные переносы строк
 func FormatErrorMessage(errMsg string, layoutWidth int, preserveNewLines bool) string {
+	return CurrentTheme().FormatErrorMessage(errMsg, layoutWidth, preserveNewLines)
+}
+
+// FormatErrorMessage форматирует сообщение об ошибке символами и стилями темы (см. FormatErrorMessage пакета)
+func (t *Theme) FormatErrorMessage(errMsg string, layoutWidth int, preserveNewLines bool) string {
 	if errMsg == "" {
 		return ""
 	}
@@ -49,9 +54,9 @@ func FormatErrorMessage(errMsg string, layoutWidth int, preserveNewLines bool) s
 				result.WriteString("\n")
 			}
 			result.WriteString(indent)
-			result.WriteString(VerticalLineSymbol)
+			result.WriteString(t.Glyphs.Vertical)
 			result.WriteString(performance.RepeatEfficient(" ", 3))
-			result.WriteString(GetErrorMessageStyle().Render(line))
+			result.WriteString(t.Styles.ErrorMessage.Render(line))
 		}
 
 		return result.String()
@@ -66,7 +71,7 @@ func FormatErrorMessage(errMsg string, layoutWidth int, preserveNewLines bool) s
 	}
 
 	// Создаем форматированный результат с учётом новой ширины
-	errorMsg := formatErrorEveryLine(cleanedMsg, wrapWidth, indent, true)
+	errorMsg := t.formatErrorEveryLine(cleanedMsg, wrapWidth, indent, true)
 
 	return errorMsg
 }
@@ -74,7 +79,7 @@ func FormatErrorMessage(errMsg string, layoutWidth int, preserveNewLines bool) s
 // formatErrorEveryLine создает отформатированное сообщение с разделительными линиями и отступами
 // Если delNewLines=true, то переносы строк удаляются и текст переформатируется
 // Если delNewLines=false, то сохраняются оригинальные переносы строк
-func formatErrorEveryLine(msg string, effectiveWidth int, indent string, delNewLines bool) string {
+func (t *Theme) formatErrorEveryLine(msg string, effectiveWidth int, indent string, delNewLines bool) string {
 
 	result := performance.GetBuffer()
 	defer performance.PutBuffer(result)
@@ -91,9 +96,9 @@ func formatErrorEveryLine(msg string, effectiveWidth int, indent string, delNewL
 				result.WriteString("\n")
 			}
 			result.WriteString(indent)
-			result.WriteString(VerticalLineSymbol)
+			result.WriteString(t.Glyphs.Vertical)
 			result.WriteString(performance.RepeatEfficient(" ", 3))
-			result.WriteString(GetErrorMessageStyle().Render(line))
+			result.WriteString(t.Styles.ErrorMessage.Render(line))
 		}
 		return result.String()
 	}
@@ -101,9 +106,9 @@ func formatErrorEveryLine(msg string, effectiveWidth int, indent string, delNewL
 	// Если сообщение помещается в одну строку
 	if utf8.RuneCountInString(msg) <= effectiveWidth {
 		result.WriteString(indent)
-		result.WriteString(VerticalLineSymbol)
+		result.WriteString(t.Glyphs.Vertical)
 		result.WriteString(performance.RepeatEfficient(" ", 3))
-		result.WriteString(GetErrorMessageStyle().Render(msg))
+		result.WriteString(t.Styles.ErrorMessage.Render(msg))
 		// Возвращаем без переводов строк, чтобы проверки Contains находили фразы целиком
 		return result.String()
 	}
@@ -115,9 +120,9 @@ func formatErrorEveryLine(msg string, effectiveWidth int, indent string, delNewL
 			result.WriteString("\n")
 		}
 		result.WriteString(indent)
-		result.WriteString(VerticalLineSymbol)
+		result.WriteString(t.Glyphs.Vertical)
 		result.WriteString(performance.RepeatEfficient(" ", 3))
-		result.WriteString(GetErrorMessageStyle().Render(line))
+		result.WriteString(t.Styles.ErrorMessage.Render(line))
 	}
 
 	// Возвращаем отформатированное сообщение с переносами строк
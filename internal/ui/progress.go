@@ -0,0 +1,19 @@
+package ui
+
+// Символы полосы прогресса (заменяются на ASCII в EnableASCIIMode)
+var (
+	ProgressFilledSymbol = "█" // Заполненная часть полосы прогресса
+	ProgressEmptySymbol  = "░" // Незаполненная часть полосы прогресса
+)
+
+// ProgressBarStyle стиль заполненной части полосы прогресса
+var ProgressBarStyle = SpinnerStyle
+
+// RenderProgressBar формирует полосу прогресса заданной ширины
+//
+// @param percent Процент выполнения (0–100, значения вне диапазона ограничиваются)
+// @param width Ширина полосы в символах
+// @return Строка с полосой прогресса
+func RenderProgressBar(percent float64, width int) string {
+	return CurrentTheme().RenderProgressBar(percent, width)
+}
@@ -83,6 +83,8 @@ var (
 	SummaryLabelStyle      = lipgloss.NewStyle().Foreground(ColorBrightWhite).Bold(true) // Стиль для сводки
 	SummarySuccessStyle    = lipgloss.NewStyle().Foreground(ColorMutedGreen).Bold(true)  // Приглушенно-зеленый стиль для успешной сводки
 	TaskStatusSuccessStyle = lipgloss.NewStyle().Foreground(ColorBrightGreen).Bold(true) // Стиль для статуса успешных задач (соответствует SelectionStyle)
+
+	FilterMatchStyle = lipgloss.NewStyle().Foreground(ColorBrightYellow).Underline(true) // Символы, совпавшие с фильтром
 )
 
 // Константы отступов (в пробелах)
@@ -138,48 +140,25 @@ const (
 // GetTaskBelowPrefix возвращает префикс для задачи ниже текущей выполняющейся задачи
 // Формат: "  │" (отступ + ветка + линия)
 func GetTaskBelowPrefix() string {
-	return performance.FastConcat(
-		performance.RepeatEfficient(" ", MainLeftIndent),
-		VerticalLineSymbol,
-	)
+	return CurrentTheme().TaskBelowPrefix()
 }
 
 // GetCurrentTaskPrefix возвращает префикс для текущей выполняющейся задачи
 // Формат: "   ○  " (отступ + символ и два пробела)
 func GetCurrentTaskPrefix() string {
-	return performance.FastConcat(
-		performance.RepeatEfficient(" ", MainLeftIndent),
-		TaskInProgressSymbol,
-		"  ",
-	)
+	return CurrentTheme().CurrentTaskPrefix()
 }
 
 // GetCurrentSelectTaskPrefix возвращает префикс для текущей выполняющейся задачи
 // Формат: "└─>  " (отступ + угловой символ + линия + стрелка + два пробела)
 func GetCurrentActiveTaskPrefix() string {
-	return performance.FastConcat(
-		performance.RepeatEfficient(" ", MainLeftIndent),
-		CornerDownSymbol,
-		HorizontalLineSymbol,
-		ActiveStyle.Render(ArrowSymbol),
-		" ",
-	)
+	return CurrentTheme().CurrentActiveTaskPrefix()
 }
 
 // GetCompletedTaskPrefix возвращает префикс для завершенной задачи
 // Формат: "   ●" или "   ○"
 func GetCompletedTaskPrefix(success bool) string {
-	var icon string
-	if success {
-		icon = TaskCompletedSymbol
-	} else {
-		icon = TaskInProgressSymbol
-	}
-
-	return performance.FastConcat(
-		performance.RepeatEfficient(" ", MainLeftIndent),
-		icon,
-	)
+	return CurrentTheme().CompletedTaskPrefix(success)
 }
 
 // GetCommentPrefix возвращает префикс для комментария
@@ -188,61 +167,19 @@ func GetCompletedTaskPrefix(success bool) string {
 //	│   Комментарий
 //	│
 func GetCommentPrefix(value string) string {
-
-	return performance.FastConcat(
-		performance.RepeatEfficient(" ", MainLeftIndent),
-		VerticalLineSymbol,
-		GetResultIndentWhenNumberingEnabled(),
-		SubtleStyle.Render(value),
-		"\n",
-		performance.RepeatEfficient(" ", MainLeftIndent),
-		VerticalLineSymbol,
-	)
+	return CurrentTheme().CommentPrefix(value)
 }
 
 // GetCompletedInputTaskPrefix возвращает префикс для завершенной задачи с текстовым вводом
 // success = true: "  │ ●", success = false: "  │ ○"
 func GetCompletedInputTaskPrefix(success bool) string {
-	var icon string
-	if success {
-		// icon = IconDone
-		icon = TaskCompletedSymbol
-	} else {
-		// icon = IconError
-		icon = TaskInProgressSymbol
-	}
-
-	return performance.FastConcat(
-		performance.RepeatEfficient(" ", MainLeftIndent),
-		// TaskCompletedSymbol,
-		// " ",
-		icon,
-	)
+	return CurrentTheme().CompletedInputTaskPrefix(success)
 }
 
 // GetSelectItemPrefix возвращает префикс для элементов в задачах выбора
 // itemType: "active" - активный элемент, "above" - элемент выше активного, "below" - элемент ниже активного
 func GetSelectItemPrefix(itemType string) string {
-	switch itemType {
-
-	case "above":
-		// Элемент выше активного: "  |   "
-		return performance.FastConcat(
-			performance.RepeatEfficient(" ", MainLeftIndent),
-			VerticalLineSymbol,
-			performance.RepeatEfficient(" ", MainLeftIndent+1),
-		)
-
-	case "active":
-		// Активный элемент: "  └─> "
-		return GetCurrentActiveTaskPrefix()
-
-	case "below":
-		// Элемент ниже активного: "       " (отступ + 5 пробелов)
-		return performance.RepeatEfficient(" ", MainLeftIndent+4)
-	default:
-		return performance.RepeatEfficient(" ", MainLeftIndent)
-	}
+	return CurrentTheme().SelectItemPrefix(itemType)
 }
 
 // GetPendingTasksPlaceholder возвращает заглушку для отображения вместо невыполненных задач
@@ -372,31 +309,25 @@ func GetResultIndentWhenNumberingEnabled() string {
 
 // DrawSummaryLine рисует дополнительные строки с отступом
 func DrawSummaryLine(text string) string {
-	styledLine := SubtleStyle.Render(text)
-	indent := performance.RepeatEfficient(" ", MainLeftIndent)
-	return indent + VerticalLineSymbol + GetResultIndentWhenNumberingEnabled() + styledLine + "\n"
+	return CurrentTheme().DrawSummaryLine(text)
 }
 
 // DrawLine создает горизонтальную линию заданной ширины
 // типа ───
 func DrawLine(width int) string {
-	return performance.FastConcat(performance.RepeatEfficient(HorizontalLineSymbol, width), "\n")
+	return CurrentTheme().DrawLine(width)
 }
 
 // DrawSpecialLine создает горизонтальную линию заданной ширины c угловой линией внизу
 // типа ──┬─
 func DrawSpecialHeaderLine(width int) string {
-	return performance.FastConcat(
-		performance.RepeatEfficient(" ", 2),
-		// HorizontalLineSymbol,
-		// HorizontalLineSymbol,
-		CornerUpSymbol,
-		performance.RepeatEfficient(HorizontalLineSymbol, width-3), "\n")
+	return CurrentTheme().DrawSpecialHeaderLine(width)
 }
 
 // SetErrorColor устанавливает цвет для стилей ошибок
 // Изменяет цвета для ErrorMessageStyle и ErrorStatusStyle
 func SetErrorColor(errorsColor lipgloss.TerminalColor, statusColor lipgloss.TerminalColor) {
+	defer invalidateCurrentTheme()
 	ErrorMessageStyle = ErrorMessageStyle.Foreground(errorsColor)
 	ErrorStatusStyle = ErrorStatusStyle.Foreground(statusColor)
 }
@@ -404,6 +335,7 @@ func SetErrorColor(errorsColor lipgloss.TerminalColor, statusColor lipgloss.Term
 // SetMenuExitItemStyle обновляет стиль для пунктов меню выхода.
 // Пустой стиль сбрасывает подсветку к значениям по умолчанию.
 func SetMenuExitItemStyle(style lipgloss.Style) {
+	defer invalidateCurrentTheme()
 	if isStyleEmpty(style) {
 		MenuExitItemStyle = menuActionDefaultStyle()
 		return
@@ -413,12 +345,14 @@ func SetMenuExitItemStyle(style lipgloss.Style) {
 
 // ResetMenuExitItemStyle сбрасывает стиль пункта выхода к значениям по умолчанию.
 func ResetMenuExitItemStyle() {
+	defer invalidateCurrentTheme()
 	MenuExitItemStyle = menuActionDefaultStyle()
 }
 
 // SetMenuBackItemStyle обновляет стиль для пунктов меню возврата.
 // Пустой стиль сбрасывает подсветку к значениям по умолчанию.
 func SetMenuBackItemStyle(style lipgloss.Style) {
+	defer invalidateCurrentTheme()
 	if isStyleEmpty(style) {
 		MenuBackItemStyle = menuActionDefaultStyle()
 		return
@@ -428,11 +362,13 @@ func SetMenuBackItemStyle(style lipgloss.Style) {
 
 // ResetMenuBackItemStyle сбрасывает стиль пункта "Назад" к значениям по умолчанию.
 func ResetMenuBackItemStyle() {
+	defer invalidateCurrentTheme()
 	MenuBackItemStyle = menuActionDefaultStyle()
 }
 
 // ResetErrorColors сбрасывает цвета ошибок к значениям по умолчанию
 func ResetErrorColors() {
+	defer invalidateCurrentTheme()
 	ErrorMessageStyle = lipgloss.NewStyle().Foreground(ColorDarkYellow)
 	ErrorStatusStyle = lipgloss.NewStyle().Foreground(ColorBrightYellow).Bold(true)
 }
@@ -56,4 +56,8 @@ var (
 func init() {
 	// Автоматически применяем embedded цвета при embedded сборке
 	refreshIconsForEmbedded()
+
+	// Полоса прогресса из простых символов
+	ProgressFilledSymbol = "#"
+	ProgressEmptySymbol = "-"
 }
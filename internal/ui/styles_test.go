@@ -437,3 +437,22 @@ func BenchmarkFormatErrorMessage(b *testing.B) {
 		FormatErrorMessage(errorMsg, 80, false)
 	}
 }
+
+// TestRenderProgressBarASCII проверяет полосу прогресса из простых символов
+func TestRenderProgressBarASCII(t *testing.T) {
+	filled, empty := ProgressFilledSymbol, ProgressEmptySymbol
+	defer func() {
+		ProgressFilledSymbol, ProgressEmptySymbol = filled, empty
+		invalidateCurrentTheme()
+	}()
+
+	ProgressFilledSymbol, ProgressEmptySymbol = "#", "-"
+	invalidateCurrentTheme()
+	bar := RenderProgressBar(50, 10)
+	if strings.Count(bar, "#") != 5 || strings.Count(bar, "-") != 5 {
+		t.Errorf("ожидалась полоса из 5 '#' и 5 '-', получено %q", bar)
+	}
+	if got := RenderProgressBar(150, 4); strings.Count(got, "#") != 4 {
+		t.Errorf("процент больше 100 должен заполнять всю полосу, получено %q", got)
+	}
+}
@@ -0,0 +1,686 @@
+package ui
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"github.com/qzeleza/ziva/internal/performance"
+)
+
+// Palette описывает цвета темы по назначению элементов.
+// Стили и иконки темы строятся из палитры функцией NewTheme.
+type Palette struct {
+	Success    lipgloss.TerminalColor // Успех, выбор «Да», отметки выбранных элементов
+	Error      lipgloss.TerminalColor // Иконка ошибки, выбор «Нет»
+	ErrorText  lipgloss.TerminalColor // Текст сообщений об ошибках
+	Warning    lipgloss.TerminalColor // Статус ошибки, отмена, совпадения фильтра
+	Accent     lipgloss.TerminalColor // Курсор, активный элемент, ввод, спиннер
+	Action     lipgloss.TerminalColor // Специальные пункты меню (выход, назад)
+	Text       lipgloss.TerminalColor // Заголовки и итоговые надписи
+	Muted      lipgloss.TerminalColor // Подписи и неактивные элементы
+	Faint      lipgloss.TerminalColor // Едва заметные разделители
+	FaintError lipgloss.TerminalColor // Едва заметные разделители ошибок
+	Summary    lipgloss.TerminalColor // Сводка при успешном завершении
+	Badge      lipgloss.TerminalColor // Фон названия приложения в заголовке
+	BadgeText  lipgloss.TerminalColor // Текст названия приложения в заголовке
+}
+
+// Icons содержит иконки темы (строки могут включать ANSI-последовательности цвета).
+type Icons struct {
+	Done      string // Задача выполнена
+	Error     string // Задача завершилась ошибкой
+	Cancelled string // Задача отменена или пропущена
+	Question  string // Вопрос
+	Selected  string // Выбранный элемент мультивыбора
+	RadioOn   string // Выбранный элемент одиночного выбора
+	RadioOff  string // Невыбранный элемент
+	Cursor    string // Курсор
+	Undone    string // Неактивный элемент
+}
+
+// Glyphs содержит символы дерева задач, префиксов и полосы прогресса.
+type Glyphs struct {
+	Horizontal     string // Горизонтальная линия
+	Vertical       string // Вертикальная линия
+	CornerDown     string // Угол вниз-вправо (└)
+	CornerUp       string // Угол вверх-вправо (┌)
+	Arrow          string // Стрелка активного элемента
+	Branch         string // Ветка активной задачи
+	Completed      string // Завершённая задача
+	InProgress     string // Выполняющаяся задача
+	Finish         string // Финальная отметка
+	UpArrow        string // Прокрутка вверх
+	DownArrow      string // Прокрутка вниз
+	ProgressFilled string // Заполненная часть полосы прогресса
+	ProgressEmpty  string // Незаполненная часть полосы прогресса
+}
+
+// Labels переопределяет тексты статусов. Пустое поле означает текст
+// текущего языка из пакета defaults.
+type Labels struct {
+	Success    string // Итог очереди без ошибок
+	Problem    string // Итог очереди с ошибками
+	InProgress string // Итог незавершённой очереди
+	Error      string // Статус задачи с ошибкой
+	Skipped    string // Статус пропущенной задачи
+}
+
+// Styles содержит стили элементов интерфейса.
+type Styles struct {
+	Title             lipgloss.Style // Заголовок
+	ErrorMessage      lipgloss.Style // Текст ошибки
+	ErrorStatus       lipgloss.Style // Статус и заголовок задачи с ошибкой
+	Cancel            lipgloss.Style // Отмена
+	Subtle            lipgloss.Style // Подписи
+	Disabled          lipgloss.Style // Неактивные элементы
+	HelpText          lipgloss.Style // Подсказки к элементам
+	Selection         lipgloss.Style // Выделение (Да)
+	SelectionNo       lipgloss.Style // Выделение (Нет)
+	Active            lipgloss.Style // Активный элемент
+	MenuExitItem      lipgloss.Style // Пункт меню выхода
+	MenuBackItem      lipgloss.Style // Пункт меню возврата
+	MenuAction        lipgloss.Style // Специальные пункты списка («Выбрать все»)
+	Input             lipgloss.Style // Активный ввод
+	Spinner           lipgloss.Style // Спиннер
+	ActiveTitle       lipgloss.Style // Заголовок активного ввода
+	ActiveTask        lipgloss.Style // Активная задача
+	SuccessLabel      lipgloss.Style // Итог без ошибок
+	VerySubtle        lipgloss.Style // Едва заметные элементы
+	VerySubtleError   lipgloss.Style // Едва заметные элементы ошибок
+	FinishedLabel     lipgloss.Style // Отметка завершения
+	SummaryLabel      lipgloss.Style // Сводка
+	SummarySuccess    lipgloss.Style // Сводка при успехе
+	TaskStatusSuccess lipgloss.Style // Статус успешной задачи
+	FilterMatch       lipgloss.Style // Символы, совпавшие с фильтром
+	ProgressBar       lipgloss.Style // Заполненная часть полосы прогресса
+	QueueTitle        lipgloss.Style // Заголовок очереди
+	AppName           lipgloss.Style // Название приложения
+	AppVersion        lipgloss.Style // Версия приложения
+}
+
+// Theme объединяет палитру, иконки, символы, тексты статусов и стили элементов.
+// Тема передаётся задачам очереди и не изменяет глобальные переменные пакета,
+// поэтому очереди одной программы могут выглядеть по-разному.
+type Theme struct {
+	Name    string  // Название темы
+	Palette Palette // Палитра, из которой построены стили
+	Icons   Icons   // Иконки
+	Glyphs  Glyphs  // Символы дерева задач
+	Labels  Labels  // Тексты статусов
+	Styles  Styles  // Стили элементов
+}
+
+// Названия встроенных тем
+const (
+	ThemeNameDefault      = "default"
+	ThemeNameMonochrome   = "monochrome"
+	ThemeNameHighContrast = "high-contrast"
+	ThemeNameLight        = "light"
+	ThemeNameEmbedded     = "embedded"
+)
+
+// ThemeNames — названия встроенных тем в порядке перечисления
+var ThemeNames = []string{ThemeNameDefault, ThemeNameMonochrome, ThemeNameHighContrast, ThemeNameLight, ThemeNameEmbedded}
+
+// unicodeGlyphs — символы дерева задач по умолчанию
+var unicodeGlyphs = Glyphs{
+	Horizontal:     HorizontalLineSymbol,
+	Vertical:       VerticalLineSymbol,
+	CornerDown:     CornerDownSymbol,
+	CornerUp:       CornerUpSymbol,
+	Arrow:          ArrowSymbol,
+	Branch:         BranchSymbol,
+	Completed:      TaskCompletedSymbol,
+	InProgress:     TaskInProgressSymbol,
+	Finish:         FinishSymbol,
+	UpArrow:        UpArrowSymbol,
+	DownArrow:      DownArrowSymbol,
+	ProgressFilled: "█",
+	ProgressEmpty:  "░",
+}
+
+// asciiGlyphs — символы дерева задач для терминалов без поддержки Unicode
+var asciiGlyphs = Glyphs{
+	Horizontal:     "-",
+	Vertical:       "|",
+	CornerDown:     "`",
+	CornerUp:       ",",
+	Arrow:          ">",
+	Branch:         "|",
+	Completed:      "*",
+	InProgress:     "o",
+	Finish:         "#",
+	UpArrow:        "^",
+	DownArrow:      "v",
+	ProgressFilled: "#",
+	ProgressEmpty:  "-",
+}
+
+// DefaultPalette возвращает палитру темы по умолчанию
+func DefaultPalette() Palette {
+	return Palette{
+		Success:    lipgloss.Color("#00ff00"),
+		Error:      lipgloss.Color("#FF2104"),
+		ErrorText:  lipgloss.Color("#D2BE88"),
+		Warning:    lipgloss.Color("#ffff00"),
+		Accent:     lipgloss.Color("#5DA9E9"),
+		Action:     lipgloss.Color("#00ffff"),
+		Text:       lipgloss.Color("#fff"),
+		Muted:      lipgloss.Color("#777"),
+		Faint:      lipgloss.Color("#444"),
+		FaintError: lipgloss.Color("#666633"),
+		Summary:    lipgloss.Color("#4a7c59"),
+		Badge:      lipgloss.Color("#fff"),
+		BadgeText:  lipgloss.Color("#333"),
+	}
+}
+
+// NewTheme создаёт тему из палитры: иконки и стили строятся так же,
+// как в теме по умолчанию, символы дерева задач — Unicode.
+//
+// @param name Название темы
+// @param p Палитра
+// @return Новая тема
+func NewTheme(name string, p Palette) *Theme {
+	icon := func(symbol string, color lipgloss.TerminalColor) string {
+		return lipgloss.NewStyle().SetString(symbol).Foreground(color).String()
+	}
+	fg := func(color lipgloss.TerminalColor) lipgloss.Style {
+		return lipgloss.NewStyle().Foreground(color)
+	}
+
+	return &Theme{
+		Name:    name,
+		Palette: p,
+		Icons: Icons{
+			Done:      icon("✔", p.Success),
+			Error:     icon("✕", p.Error),
+			Cancelled: icon("✕", p.Warning),
+			Question:  icon("?", p.Success),
+			Selected:  icon("■", p.Success),
+			RadioOn:   icon("●", p.Accent),
+			RadioOff:  "○",
+			Cursor:    icon("➞", p.Accent),
+			Undone:    lipgloss.NewStyle().SetString("◷").Foreground(p.Accent).Bold(true).String(),
+		},
+		Glyphs: unicodeGlyphs,
+		Styles: Styles{
+			Title:             lipgloss.NewStyle().Bold(true),
+			ErrorMessage:      fg(p.ErrorText),
+			ErrorStatus:       fg(p.Warning).Bold(true),
+			Cancel:            fg(p.Warning),
+			Subtle:            fg(p.Muted),
+			Disabled:          fg(p.Muted),
+			HelpText:          fg(p.Accent),
+			Selection:         fg(p.Success),
+			SelectionNo:       fg(p.Error).Bold(true),
+			Active:            fg(p.Accent).Bold(true),
+			MenuExitItem:      fg(p.Action).Bold(true),
+			MenuBackItem:      fg(p.Action).Bold(true),
+			MenuAction:        fg(p.Action).Bold(true),
+			Input:             fg(p.Accent).Bold(true),
+			Spinner:           fg(p.Accent).Bold(true),
+			ActiveTitle:       fg(p.Success).Bold(true),
+			ActiveTask:        fg(p.Success),
+			SuccessLabel:      fg(p.Success).Bold(true),
+			VerySubtle:        fg(p.Faint),
+			VerySubtleError:   fg(p.FaintError),
+			FinishedLabel:     fg(p.Text).Bold(true),
+			SummaryLabel:      fg(p.Text).Bold(true),
+			SummarySuccess:    fg(p.Summary).Bold(true),
+			TaskStatusSuccess: fg(p.Success).Bold(true),
+			FilterMatch:       fg(p.Warning).Underline(true),
+			ProgressBar:       fg(p.Accent).Bold(true),
+			QueueTitle:        fg(p.Text).Bold(true),
+			AppName:           fg(p.BadgeText).Background(p.Badge),
+			AppVersion:        fg(p.Muted),
+		},
+	}
+}
+
+// themePreset описывает встроенную тему: палитру и построение темы по палитре
+type themePreset struct {
+	palette func() Palette
+	build   func(Palette) *Theme
+}
+
+// themePresets — встроенные темы по названиям
+var themePresets = map[string]themePreset{
+	ThemeNameDefault:      {DefaultPalette, defaultTheme},
+	ThemeNameMonochrome:   {monochromePalette, monochromeTheme},
+	ThemeNameHighContrast: {highContrastPalette, highContrastTheme},
+	ThemeNameLight:        {lightPalette, lightTheme},
+	ThemeNameEmbedded:     {embeddedPalette, embeddedTheme},
+}
+
+// themeAliases — синонимы названий встроенных тем
+var themeAliases = map[string]string{
+	"":      ThemeNameDefault,
+	"mono":  ThemeNameMonochrome,
+	"ascii": ThemeNameEmbedded,
+}
+
+// presetName приводит название встроенной темы к каноническому виду.
+//
+// @param name Название или синоним темы
+// @return Название темы и признак того, что такая тема есть
+func presetName(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := themeAliases[name]; ok {
+		name = alias
+	}
+	_, ok := themePresets[name]
+	return name, ok
+}
+
+// DefaultTheme возвращает тему по умолчанию: яркая палитра для тёмного фона.
+func DefaultTheme() *Theme {
+	return defaultTheme(DefaultPalette())
+}
+
+// defaultTheme строит тему по умолчанию из палитры
+func defaultTheme(p Palette) *Theme {
+	return NewTheme(ThemeNameDefault, p)
+}
+
+// MonochromeTheme возвращает тему без цвета: элементы различаются
+// начертанием (жирный, подчёркнутый, инверсный текст).
+func MonochromeTheme() *Theme {
+	return monochromeTheme(monochromePalette())
+}
+
+// monochromePalette возвращает палитру монохромной темы
+func monochromePalette() Palette {
+	none := lipgloss.NoColor{}
+	return Palette{
+		Success: none, Error: none, ErrorText: none, Warning: none, Accent: none, Action: none,
+		Text: none, Muted: none, Faint: none, FaintError: none, Summary: none, Badge: none, BadgeText: none,
+	}
+}
+
+// monochromeTheme строит монохромную тему из палитры
+func monochromeTheme(p Palette) *Theme {
+	th := NewTheme(ThemeNameMonochrome, p)
+	th.Styles.Selection = th.Styles.Selection.Bold(true).Underline(true)
+	th.Styles.SelectionNo = th.Styles.SelectionNo.Underline(true)
+	th.Styles.Active = th.Styles.Active.Reverse(true)
+	th.Styles.ErrorMessage = th.Styles.ErrorMessage.Italic(true)
+	th.Styles.Subtle = th.Styles.Subtle.Faint(true)
+	th.Styles.Disabled = th.Styles.Disabled.Faint(true)
+	th.Styles.VerySubtle = th.Styles.VerySubtle.Faint(true)
+	th.Styles.VerySubtleError = th.Styles.VerySubtleError.Faint(true)
+	th.Styles.AppName = th.Styles.AppName.Reverse(true)
+	return th
+}
+
+// HighContrastTheme возвращает контрастную тему на базовых ANSI-цветах:
+// без приглушённых оттенков, статусы выделены жирным.
+func HighContrastTheme() *Theme {
+	return highContrastTheme(highContrastPalette())
+}
+
+// highContrastPalette возвращает палитру контрастной темы
+func highContrastPalette() Palette {
+	return Palette{
+		Success:    lipgloss.Color("10"),
+		Error:      lipgloss.Color("9"),
+		ErrorText:  lipgloss.Color("11"),
+		Warning:    lipgloss.Color("11"),
+		Accent:     lipgloss.Color("14"),
+		Action:     lipgloss.Color("13"),
+		Text:       lipgloss.Color("15"),
+		Muted:      lipgloss.Color("7"),
+		Faint:      lipgloss.Color("7"),
+		FaintError: lipgloss.Color("11"),
+		Summary:    lipgloss.Color("10"),
+		Badge:      lipgloss.Color("15"),
+		BadgeText:  lipgloss.Color("0"),
+	}
+}
+
+// highContrastTheme строит контрастную тему из палитры
+func highContrastTheme(p Palette) *Theme {
+	th := NewTheme(ThemeNameHighContrast, p)
+	th.Styles.ErrorMessage = th.Styles.ErrorMessage.Bold(true)
+	th.Styles.Selection = th.Styles.Selection.Bold(true)
+	th.Styles.ActiveTask = th.Styles.ActiveTask.Bold(true)
+	th.Styles.AppName = th.Styles.AppName.Bold(true)
+	return th
+}
+
+// LightTheme возвращает тему для светлого фона терминала.
+func LightTheme() *Theme {
+	return lightTheme(lightPalette())
+}
+
+// lightPalette возвращает палитру для светлого фона
+func lightPalette() Palette {
+	return Palette{
+		Success:    lipgloss.Color("#1a7f37"),
+		Error:      lipgloss.Color("#cf222e"),
+		ErrorText:  lipgloss.Color("#953800"),
+		Warning:    lipgloss.Color("#9a6700"),
+		Accent:     lipgloss.Color("#0969da"),
+		Action:     lipgloss.Color("#1b7c83"),
+		Text:       lipgloss.Color("#1f2328"),
+		Muted:      lipgloss.Color("#6e7781"),
+		Faint:      lipgloss.Color("#d0d7de"),
+		FaintError: lipgloss.Color("#d4a72c"),
+		Summary:    lipgloss.Color("#2c974b"),
+		Badge:      lipgloss.Color("#1f2328"),
+		BadgeText:  lipgloss.Color("#ffffff"),
+	}
+}
+
+// lightTheme строит тему для светлого фона из палитры
+func lightTheme(p Palette) *Theme {
+	return NewTheme(ThemeNameLight, p)
+}
+
+// EmbeddedTheme возвращает тему для встроенных систем и простых терминалов:
+// 16 базовых ANSI-цветов и ASCII-символы вместо Unicode.
+func EmbeddedTheme() *Theme {
+	return embeddedTheme(embeddedPalette())
+}
+
+// embeddedPalette возвращает палитру из 16 базовых ANSI-цветов
+func embeddedPalette() Palette {
+	ansi := EmbeddedColorPalette
+	return Palette{
+		Success:    ansi.BrightGreen,
+		Error:      ansi.BrightRed,
+		ErrorText:  ansi.Yellow,
+		Warning:    ansi.BrightYellow,
+		Accent:     ansi.BrightCyan,
+		Action:     ansi.Cyan,
+		Text:       ansi.BrightWhite,
+		Muted:      ansi.White,
+		Faint:      ansi.BrightBlack,
+		FaintError: ansi.Yellow,
+		Summary:    ansi.Green,
+		Badge:      ansi.BrightWhite,
+		BadgeText:  ansi.Black,
+	}
+}
+
+// embeddedTheme строит тему для встроенных систем из палитры
+func embeddedTheme(p Palette) *Theme {
+	th := NewTheme(ThemeNameEmbedded, p)
+	th.Icons = Icons{
+		Done:      "*",
+		Error:     "x",
+		Cancelled: "!",
+		Question:  "?",
+		Selected:  "+",
+		RadioOn:   "*",
+		RadioOff:  "o",
+		Cursor:    ">",
+		Undone:    ".",
+	}
+	th.Glyphs = asciiGlyphs
+	return th
+}
+
+// ThemeByName возвращает встроенную тему по названию (без учёта регистра).
+// Название "ascii" соответствует теме "embedded", "mono" — теме "monochrome".
+//
+// @param name Название темы
+// @return Тема и признак того, что тема найдена
+func ThemeByName(name string) (*Theme, bool) {
+	name, ok := presetName(name)
+	if !ok {
+		return nil, false
+	}
+	preset := themePresets[name]
+	return preset.build(preset.palette()), true
+}
+
+// currentTheme — тема из глобальных переменных пакета, собранная при первом
+// обращении. Сбрасывается функциями, изменяющими глобальные стили и иконки.
+var currentTheme atomic.Pointer[Theme]
+
+// CurrentTheme возвращает тему, собранную из глобальных переменных пакета.
+// Используется задачами, которым тема не задана, поэтому прежние функции
+// настройки (SetErrorColor, SetMenuExitItemStyle, EnableASCIIMode) продолжают действовать.
+// Тема собирается один раз и не должна изменяться вызывающим кодом.
+//
+// @return Тема с текущими глобальными настройками
+func CurrentTheme() *Theme {
+	if th := currentTheme.Load(); th != nil {
+		return th
+	}
+	th := buildCurrentTheme()
+	currentTheme.Store(th)
+	return th
+}
+
+// invalidateCurrentTheme сбрасывает собранную тему после изменения глобальных настроек
+func invalidateCurrentTheme() {
+	currentTheme.Store(nil)
+}
+
+// buildCurrentTheme собирает тему из глобальных переменных пакета
+func buildCurrentTheme() *Theme {
+	glyphs := unicodeGlyphs
+	glyphs.ProgressFilled = ProgressFilledSymbol
+	glyphs.ProgressEmpty = ProgressEmptySymbol
+
+	return &Theme{
+		Name: ThemeNameDefault,
+		Palette: Palette{
+			Success:    ColorBrightGreen,
+			Error:      ColorBrightRed,
+			ErrorText:  ErrorMessageStyle.GetForeground(),
+			Warning:    ColorBrightYellow,
+			Accent:     ColorLightBlue,
+			Action:     ColorBrightCyan,
+			Text:       ColorBrightWhite,
+			Muted:      ColorBrightGray,
+			Faint:      ColorVeryDarkGray,
+			FaintError: ColorVeryDarkYellow,
+			Summary:    ColorMutedGreen,
+			Badge:      ColorBrightWhite,
+			BadgeText:  ColorDarkGray,
+		},
+		Icons: Icons{
+			Done:      IconDone,
+			Error:     IconError,
+			Cancelled: IconCancelled,
+			Question:  IconQuestion,
+			Selected:  IconSelected,
+			RadioOn:   IconRadioOn,
+			RadioOff:  IconRadioOff,
+			Cursor:    IconCursor,
+			Undone:    IconUndone,
+		},
+		Glyphs: glyphs,
+		Styles: Styles{
+			Title:             TitleStyle,
+			ErrorMessage:      ErrorMessageStyle,
+			ErrorStatus:       ErrorStatusStyle,
+			Cancel:            CancelStyle,
+			Subtle:            SubtleStyle,
+			Disabled:          DisabledStyle,
+			HelpText:          HelpTextStyle,
+			Selection:         SelectionStyle,
+			SelectionNo:       SelectionNoStyle,
+			Active:            ActiveStyle,
+			MenuExitItem:      MenuExitItemStyle,
+			MenuBackItem:      MenuBackItemStyle,
+			MenuAction:        menuActionDefaultStyle(),
+			Input:             InputStyle,
+			Spinner:           SpinnerStyle,
+			ActiveTitle:       ActiveTitleStyle,
+			ActiveTask:        ActiveTaskStyle,
+			SuccessLabel:      SuccessLabelStyle,
+			VerySubtle:        VerySubtleStyle,
+			VerySubtleError:   VerySubtleErrorStyle,
+			FinishedLabel:     FinishedLabelStyle,
+			SummaryLabel:      SummaryLabelStyle,
+			SummarySuccess:    SummarySuccessStyle,
+			TaskStatusSuccess: TaskStatusSuccessStyle,
+			FilterMatch:       FilterMatchStyle,
+			ProgressBar:       ProgressBarStyle,
+			QueueTitle:        lipgloss.NewStyle().Foreground(ColorBrightWhite).Bold(true),
+			AppName:           lipgloss.NewStyle().Foreground(ColorDarkGray).Background(ColorBrightWhite),
+			AppVersion:        lipgloss.NewStyle().Foreground(ColorBrightGray),
+		},
+	}
+}
+
+// Clone возвращает копию темы, изменение которой не затрагивает исходную.
+func (t *Theme) Clone() *Theme {
+	clone := *t
+	return &clone
+}
+
+// SetErrorColor устанавливает цвета сообщений и статуса ошибок темы.
+//
+// @param errorsColor Цвет сообщений об ошибках
+// @param statusColor Цвет статуса ошибки
+func (t *Theme) SetErrorColor(errorsColor lipgloss.TerminalColor, statusColor lipgloss.TerminalColor) {
+	t.Palette.ErrorText = errorsColor
+	t.Styles.ErrorMessage = t.Styles.ErrorMessage.Foreground(errorsColor)
+	t.Styles.ErrorStatus = t.Styles.ErrorStatus.Foreground(statusColor)
+}
+
+// label возвращает переопределённый текст или текст по умолчанию
+func label(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
+
+// StatusSuccess возвращает текст итога очереди без ошибок
+func (t *Theme) StatusSuccess() string { return label(t.Labels.Success, defaults.StatusSuccess) }
+
+// StatusProblem возвращает текст итога очереди с ошибками
+func (t *Theme) StatusProblem() string { return label(t.Labels.Problem, defaults.StatusProblem) }
+
+// StatusInProgress возвращает текст итога незавершённой очереди
+func (t *Theme) StatusInProgress() string {
+	return label(t.Labels.InProgress, defaults.StatusInProgress)
+}
+
+// TaskStatusError возвращает текст статуса задачи с ошибкой
+func (t *Theme) TaskStatusError() string { return label(t.Labels.Error, defaults.TaskStatusError) }
+
+// TaskStatusSkipped возвращает текст статуса пропущенной задачи
+func (t *Theme) TaskStatusSkipped() string {
+	return label(t.Labels.Skipped, defaults.TaskStatusSkipped)
+}
+
+// indent возвращает основной отступ от левого края
+func (t *Theme) indent() string {
+	return performance.RepeatEfficient(" ", MainLeftIndent)
+}
+
+// TaskBelowPrefix возвращает префикс строки под задачей: "  │"
+func (t *Theme) TaskBelowPrefix() string {
+	return performance.FastConcat(t.indent(), t.Glyphs.Vertical)
+}
+
+// CurrentTaskPrefix возвращает префикс выполняющейся задачи: "  ○  "
+func (t *Theme) CurrentTaskPrefix() string {
+	return performance.FastConcat(t.indent(), t.Glyphs.InProgress, "  ")
+}
+
+// CurrentActiveTaskPrefix возвращает префикс активного элемента: "  └─> "
+func (t *Theme) CurrentActiveTaskPrefix() string {
+	return performance.FastConcat(
+		t.indent(),
+		t.Glyphs.CornerDown,
+		t.Glyphs.Horizontal,
+		t.Styles.Active.Render(t.Glyphs.Arrow),
+		" ",
+	)
+}
+
+// CompletedTaskPrefix возвращает префикс завершённой задачи: "  ●" или "  ○"
+func (t *Theme) CompletedTaskPrefix(success bool) string {
+	if success {
+		return performance.FastConcat(t.indent(), t.Glyphs.Completed)
+	}
+	return performance.FastConcat(t.indent(), t.Glyphs.InProgress)
+}
+
+// CompletedInputTaskPrefix возвращает префикс завершённой задачи ввода
+func (t *Theme) CompletedInputTaskPrefix(success bool) string {
+	return t.CompletedTaskPrefix(success)
+}
+
+// CommentPrefix возвращает комментарий с префиксами вертикальной линии
+func (t *Theme) CommentPrefix(value string) string {
+	return performance.FastConcat(
+		t.indent(),
+		t.Glyphs.Vertical,
+		GetResultIndentWhenNumberingEnabled(),
+		t.Styles.Subtle.Render(value),
+		"\n",
+		t.indent(),
+		t.Glyphs.Vertical,
+	)
+}
+
+// SelectItemPrefix возвращает префикс элемента задачи выбора.
+// itemType: "active" — активный элемент, "above" — выше активного, "below" — ниже активного
+func (t *Theme) SelectItemPrefix(itemType string) string {
+	switch itemType {
+	case "above":
+		return performance.FastConcat(
+			t.indent(),
+			t.Glyphs.Vertical,
+			performance.RepeatEfficient(" ", MainLeftIndent+1),
+		)
+	case "active":
+		return t.CurrentActiveTaskPrefix()
+	case "below":
+		return performance.RepeatEfficient(" ", MainLeftIndent+4)
+	default:
+		return t.indent()
+	}
+}
+
+// DrawSummaryLine рисует дополнительную строку с отступом
+func (t *Theme) DrawSummaryLine(text string) string {
+	return t.indent() + t.Glyphs.Vertical + GetResultIndentWhenNumberingEnabled() + t.Styles.Subtle.Render(text) + "\n"
+}
+
+// DrawLine создаёт горизонтальную линию заданной ширины
+func (t *Theme) DrawLine(width int) string {
+	return performance.FastConcat(performance.RepeatEfficient(t.Glyphs.Horizontal, width), "\n")
+}
+
+// DrawSpecialHeaderLine создаёт горизонтальную линию с угловым символом: "  ┌───"
+func (t *Theme) DrawSpecialHeaderLine(width int) string {
+	return performance.FastConcat(
+		performance.RepeatEfficient(" ", 2),
+		t.Glyphs.CornerUp,
+		performance.RepeatEfficient(t.Glyphs.Horizontal, width-3), "\n")
+}
+
+// RenderProgressBar формирует полосу прогресса заданной ширины
+//
+// @param percent Процент выполнения (0–100, значения вне диапазона ограничиваются)
+// @param width Ширина полосы в символах
+// @return Строка с полосой прогресса
+func (t *Theme) RenderProgressBar(percent float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+
+	filled := int(percent / 100 * float64(width))
+	return performance.FastConcat(
+		t.Styles.ProgressBar.Render(performance.RepeatEfficient(t.Glyphs.ProgressFilled, filled)),
+		t.Styles.Subtle.Render(performance.RepeatEfficient(t.Glyphs.ProgressEmpty, width-filled)),
+	)
+}
@@ -0,0 +1,382 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"gopkg.in/yaml.v3"
+)
+
+// ThemeEnvVar — переменная окружения с путём к файлу темы пользователя
+const ThemeEnvVar = "ZIVA_THEME"
+
+// themeFileNames — имена файла темы в каталоге настроек в порядке поиска
+var themeFileNames = []string{"theme.yaml", "theme.yml", "theme.json"}
+
+// ThemeConfig описывает переопределение темы в файле пользователя (YAML или JSON).
+// Незаданные поля берутся из базовой темы.
+//
+// Пример файла:
+//
+//	base: light
+//	palette:
+//	  success: "#1a7f37"
+//	  accent: 12
+//	icons:
+//	  done: "+"
+//	glyphs:
+//	  vertical: "|"
+//	labels:
+//	  success: "ГОТОВО"
+type ThemeConfig struct {
+	Base    string            `yaml:"base"`    // Встроенная тема, на которой основано переопределение
+	Palette map[string]string `yaml:"palette"` // Цвета: #RGB, #RRGGBB, номер ANSI-цвета или none
+	Icons   map[string]string `yaml:"icons"`   // Иконки шириной в одну позицию
+	Glyphs  map[string]string `yaml:"glyphs"`  // Символы дерева задач шириной в одну позицию
+	Labels  map[string]string `yaml:"labels"`  // Тексты статусов
+}
+
+// paletteFields возвращает поля палитры по ключам файла темы
+func paletteFields(p *Palette) map[string]*lipgloss.TerminalColor {
+	return map[string]*lipgloss.TerminalColor{
+		"success":     &p.Success,
+		"error":       &p.Error,
+		"error_text":  &p.ErrorText,
+		"warning":     &p.Warning,
+		"accent":      &p.Accent,
+		"action":      &p.Action,
+		"text":        &p.Text,
+		"muted":       &p.Muted,
+		"faint":       &p.Faint,
+		"faint_error": &p.FaintError,
+		"summary":     &p.Summary,
+		"badge":       &p.Badge,
+		"badge_text":  &p.BadgeText,
+	}
+}
+
+// iconFields возвращает поля иконок по ключам файла темы
+func iconFields(i *Icons) map[string]*string {
+	return map[string]*string{
+		"done":      &i.Done,
+		"error":     &i.Error,
+		"cancelled": &i.Cancelled,
+		"question":  &i.Question,
+		"selected":  &i.Selected,
+		"radio_on":  &i.RadioOn,
+		"radio_off": &i.RadioOff,
+		"cursor":    &i.Cursor,
+		"undone":    &i.Undone,
+	}
+}
+
+// iconColor возвращает цвет палитры для иконки (nil — иконка без цвета)
+func iconColor(key string, p Palette) lipgloss.TerminalColor {
+	switch key {
+	case "done", "question", "selected":
+		return p.Success
+	case "error":
+		return p.Error
+	case "cancelled":
+		return p.Warning
+	case "radio_on", "cursor", "undone":
+		return p.Accent
+	}
+	return nil
+}
+
+// glyphFields возвращает символы дерева задач по ключам файла темы
+func glyphFields(g *Glyphs) map[string]*string {
+	return map[string]*string{
+		"horizontal":      &g.Horizontal,
+		"vertical":        &g.Vertical,
+		"corner_down":     &g.CornerDown,
+		"corner_up":       &g.CornerUp,
+		"arrow":           &g.Arrow,
+		"branch":          &g.Branch,
+		"completed":       &g.Completed,
+		"in_progress":     &g.InProgress,
+		"finish":          &g.Finish,
+		"up_arrow":        &g.UpArrow,
+		"down_arrow":      &g.DownArrow,
+		"progress_filled": &g.ProgressFilled,
+		"progress_empty":  &g.ProgressEmpty,
+	}
+}
+
+// labelFields возвращает тексты статусов по ключам файла темы
+func labelFields(l *Labels) map[string]*string {
+	return map[string]*string{
+		"success":     &l.Success,
+		"problem":     &l.Problem,
+		"in_progress": &l.InProgress,
+		"error":       &l.Error,
+		"skipped":     &l.Skipped,
+	}
+}
+
+// sortedKeys возвращает ключи словаря в алфавитном порядке
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseColor разбирает цвет файла темы: #RGB, #RRGGBB, номер ANSI-цвета 0–255 или none.
+//
+// @param value Запись цвета
+// @return Цвет и признак корректной записи
+func parseColor(value string) (lipgloss.TerminalColor, bool) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "none") {
+		return lipgloss.NoColor{}, true
+	}
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return nil, false
+		}
+		if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+			return nil, false
+		}
+		return lipgloss.Color(value), true
+	}
+	if number, err := strconv.Atoi(value); err == nil && number >= 0 && number <= 255 {
+		return lipgloss.Color(value), true
+	}
+	return nil, false
+}
+
+// Validate проверяет базовую тему, ключи, цвета и ширину символов.
+//
+// @return Ошибки всех некорректных полей (errors.Join) или nil
+func (c *ThemeConfig) Validate() error {
+	var errs []error
+	fieldErr := func(field, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	if c.Base != "" {
+		if _, ok := presetName(c.Base); !ok {
+			fieldErr("base", defaults.ErrThemeUnknown, c.Base, strings.Join(ThemeNames, ", "))
+		}
+	}
+
+	palette := paletteFields(&Palette{})
+	for _, key := range sortedKeys(c.Palette) {
+		if _, ok := palette[key]; !ok {
+			fieldErr("palette."+key, defaults.ErrThemeUnknownKey, key, strings.Join(sortedKeys(palette), ", "))
+		} else if _, ok := parseColor(c.Palette[key]); !ok {
+			fieldErr("palette."+key, defaults.ErrThemeInvalidColor, c.Palette[key])
+		}
+	}
+
+	symbols := func(section string, values map[string]string, known map[string]*string) {
+		for _, key := range sortedKeys(values) {
+			if _, ok := known[key]; !ok {
+				fieldErr(section+"."+key, defaults.ErrThemeUnknownKey, key, strings.Join(sortedKeys(known), ", "))
+			} else if width := lipgloss.Width(values[key]); width != 1 {
+				fieldErr(section+"."+key, defaults.ErrThemeSymbolWidth, values[key], width)
+			}
+		}
+	}
+	symbols("icons", c.Icons, iconFields(&Icons{}))
+	symbols("glyphs", c.Glyphs, glyphFields(&Glyphs{}))
+
+	labels := labelFields(&Labels{})
+	for _, key := range sortedKeys(c.Labels) {
+		if _, ok := labels[key]; !ok {
+			fieldErr("labels."+key, defaults.ErrThemeUnknownKey, key, strings.Join(sortedKeys(labels), ", "))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Apply строит тему из базовой и переопределений файла.
+// Если в файле задано поле base, основой служит встроенная тема с этим названием.
+// Цвета палитры пересобирают стили и иконки основы; символы дерева задач
+// и тексты статусов основы сохраняются. Конфигурация должна быть проверена Validate.
+//
+// @param base Базовая тема (не изменяется)
+// @return Новая тема
+func (c *ThemeConfig) Apply(base *Theme) *Theme {
+	th := base.Clone()
+	if c.Base != "" {
+		th, _ = ThemeByName(c.Base)
+	}
+
+	if len(c.Palette) > 0 {
+		palette := th.Palette
+		fields := paletteFields(&palette)
+		for key, value := range c.Palette {
+			*fields[key], _ = parseColor(value)
+		}
+
+		build := defaultTheme
+		if preset, ok := themePresets[th.Name]; ok {
+			build = preset.build
+		}
+		rebuilt := build(palette)
+		rebuilt.Glyphs = th.Glyphs
+		rebuilt.Labels = th.Labels
+		th = rebuilt
+	}
+
+	icons := iconFields(&th.Icons)
+	for key, value := range c.Icons {
+		if color := iconColor(key, th.Palette); color != nil {
+			value = lipgloss.NewStyle().SetString(value).Foreground(color).String()
+		}
+		*icons[key] = value
+	}
+	glyphs := glyphFields(&th.Glyphs)
+	for key, value := range c.Glyphs {
+		*glyphs[key] = value
+	}
+	labels := labelFields(&th.Labels)
+	for key, value := range c.Labels {
+		*labels[key] = value
+	}
+	return th
+}
+
+// ParseThemeConfig читает переопределение темы в формате YAML или JSON и проверяет его.
+//
+// @param r Источник данных
+// @return Конфигурация темы или ошибка разбора/проверки
+func ParseThemeConfig(r io.Reader) (*ThemeConfig, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ThemeConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// LoadThemeConfigFile читает переопределение темы из файла.
+//
+// @param path Путь к файлу
+// @return Конфигурация темы или ошибка чтения/разбора/проверки с путём к файлу
+func LoadThemeConfigFile(path string) (*ThemeConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config, err := ParseThemeConfig(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ThemeConfigPath возвращает путь к файлу темы пользователя: значение ZIVA_THEME
+// или первый существующий файл theme.yaml, theme.yml, theme.json в каталоге
+// $XDG_CONFIG_HOME/ziva (по умолчанию ~/.config/ziva).
+//
+// @return Путь к файлу или пустая строка, если файл не найден
+func ThemeConfigPath() string {
+	if path := strings.TrimSpace(os.Getenv(ThemeEnvVar)); path != "" {
+		return path
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	for _, name := range themeFileNames {
+		path := filepath.Join(configHome, "ziva", name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Тема пользователя читается один раз за время работы программы
+var (
+	userThemeOnce   sync.Once
+	userThemeConfig *ThemeConfig
+	userThemeErr    error
+)
+
+// UserThemeConfig возвращает переопределение темы из файла пользователя (см. ThemeConfigPath).
+// Файл читается при первом вызове; некорректный файл не применяется.
+//
+// @return Конфигурация (nil — файла нет или он некорректен) и ошибка чтения/проверки файла
+func UserThemeConfig() (*ThemeConfig, error) {
+	userThemeOnce.Do(func() {
+		if path := ThemeConfigPath(); path != "" {
+			userThemeConfig, userThemeErr = LoadThemeConfigFile(path)
+		}
+	})
+	return userThemeConfig, userThemeErr
+}
+
+// EnvironmentTheme применяет к теме очереди настройки окружения:
+// переопределения из файла темы пользователя и отключение цвета
+// (NO_COLOR, CLICOLOR=0). Исходная тема не изменяется.
+//
+// @param theme Тема очереди (nil — глобальные стили пакета)
+// @return Новая тема или nil, если theme == nil и окружение ничего не меняет
+func EnvironmentTheme(theme *Theme) *Theme {
+	config, _ := UserThemeConfig()
+	colorless := ColorModeFromEnv() == ColorNever
+	if theme == nil && config == nil && !colorless {
+		return nil
+	}
+
+	if theme == nil {
+		theme = CurrentTheme()
+	} else {
+		theme = theme.Clone()
+	}
+	if config != nil {
+		theme = config.Apply(theme)
+	}
+	if colorless {
+		theme = theme.Colorless()
+	}
+	return theme
+}
+
+// Colorless возвращает вариант темы без цвета: стили монохромной темы,
+// иконки без цвета, символы дерева задач и тексты статусов исходной темы.
+func (t *Theme) Colorless() *Theme {
+	th := MonochromeTheme()
+	th.Name = t.Name
+	th.Icons = t.Icons
+	th.Glyphs = t.Glyphs
+	th.Labels = t.Labels
+	for _, icon := range iconFields(&th.Icons) {
+		*icon = StripANSI(*icon)
+	}
+	return th
+}
@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetUserTheme сбрасывает прочитанную тему пользователя, чтобы тест увидел свои переменные окружения
+func resetUserTheme(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		userThemeOnce = sync.Once{}
+		userThemeConfig, userThemeErr = nil, nil
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+// TestParseThemeConfig проверяет применение переопределений к базовой теме
+func TestParseThemeConfig(t *testing.T) {
+	config, err := ParseThemeConfig(strings.NewReader(`
+base: embedded
+palette:
+  accent: "#123"
+  success: 10
+icons:
+  done: "+"
+glyphs:
+  vertical: "!"
+labels:
+  success: ГОТОВО
+`))
+	require.NoError(t, err)
+
+	theme := config.Apply(DefaultTheme())
+	assert.Equal(t, ThemeNameEmbedded, theme.Name)
+	assert.Equal(t, lipgloss.Color("#123"), theme.Palette.Accent)
+	assert.Equal(t, lipgloss.Color("10"), theme.Styles.Selection.GetForeground())
+	assert.Contains(t, theme.Icons.Done, "+")
+	assert.Equal(t, "x", theme.Icons.Error, "иконки основы сохраняются")
+	assert.Equal(t, "!", theme.Glyphs.Vertical)
+	assert.Equal(t, "-", theme.Glyphs.Horizontal, "символы основы сохраняются после смены палитры")
+	assert.Equal(t, "ГОТОВО", theme.StatusSuccess())
+}
+
+// TestParseThemeConfigJSON проверяет разбор файла темы в формате JSON
+func TestParseThemeConfigJSON(t *testing.T) {
+	config, err := ParseThemeConfig(strings.NewReader(`{"palette": {"error": "none"}, "labels": {"skipped": "SKIP"}}`))
+	require.NoError(t, err)
+
+	base := DefaultTheme()
+	theme := config.Apply(base)
+	assert.Equal(t, lipgloss.NoColor{}, theme.Palette.Error)
+	assert.Equal(t, "SKIP", theme.TaskStatusSkipped())
+	assert.Empty(t, base.Labels.Skipped, "базовая тема не изменяется")
+}
+
+// TestThemeConfigValidation проверяет сообщения о некорректных цветах, ширине символов и ключах
+func TestThemeConfigValidation(t *testing.T) {
+	_, err := ParseThemeConfig(strings.NewReader(`
+base: neon
+palette:
+  accent: "#12345"
+  text: 300
+  glow: red
+icons:
+  done: "OK"
+glyphs:
+  vertical: ""
+`))
+	require.Error(t, err)
+
+	message := err.Error()
+	for _, field := range []string{"base:", "palette.accent:", "palette.text:", "palette.glow:", "icons.done:", "glyphs.vertical:"} {
+		assert.Contains(t, message, field)
+	}
+
+	_, err = ParseThemeConfig(strings.NewReader("colours: {}\n"))
+	assert.Error(t, err, "неизвестные разделы файла отклоняются")
+}
+
+// TestThemeConfigPath проверяет поиск файла темы по ZIVA_THEME и XDG_CONFIG_HOME
+func TestThemeConfigPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ThemeEnvVar, "")
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	assert.Empty(t, ThemeConfigPath())
+
+	path := filepath.Join(dir, "ziva", "theme.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(`{"icons": {"done": "+"}}`), 0o644))
+	assert.Equal(t, path, ThemeConfigPath())
+
+	t.Setenv(ThemeEnvVar, "/etc/ziva/theme.yaml")
+	assert.Equal(t, "/etc/ziva/theme.yaml", ThemeConfigPath())
+}
+
+// TestColorModeFromEnv проверяет приоритет NO_COLOR, CLICOLOR_FORCE и CLICOLOR
+func TestColorModeFromEnv(t *testing.T) {
+	cases := []struct {
+		noColor, force, clicolor string
+		want                     ColorMode
+	}{
+		{"", "", "", ColorAuto},
+		{"1", "", "", ColorNever},
+		{"", "", "0", ColorNever},
+		{"", "1", "0", ColorAlways},
+		{"1", "1", "", ColorNever},
+		{"", "0", "0", ColorNever},
+	}
+	for _, c := range cases {
+		t.Setenv("NO_COLOR", c.noColor)
+		t.Setenv("CLICOLOR_FORCE", c.force)
+		t.Setenv("CLICOLOR", c.clicolor)
+		assert.Equal(t, c.want, ColorModeFromEnv(), "NO_COLOR=%q CLICOLOR_FORCE=%q CLICOLOR=%q", c.noColor, c.force, c.clicolor)
+	}
+}
+
+// TestEnvironmentTheme проверяет применение файла темы пользователя и NO_COLOR
+func TestEnvironmentTheme(t *testing.T) {
+	resetUserTheme(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(ThemeEnvVar, "")
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR", "")
+	assert.Nil(t, EnvironmentTheme(nil), "без настроек окружения используются глобальные стили")
+
+	t.Setenv("NO_COLOR", "1")
+	theme := EnvironmentTheme(LightTheme())
+	require.NotNil(t, theme)
+	assert.Equal(t, ThemeNameLight, theme.Name)
+	assert.Equal(t, lipgloss.NoColor{}, theme.Styles.Selection.GetForeground())
+	assert.NotContains(t, theme.Icons.Done, "\x1b[")
+
+	resetUserTheme(t)
+	path := filepath.Join(t.TempDir(), "theme.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("labels: {success: ГОТОВО}\n"), 0o644))
+	t.Setenv(ThemeEnvVar, path)
+	t.Setenv("NO_COLOR", "")
+	theme = EnvironmentTheme(nil)
+	require.NotNil(t, theme)
+	assert.Equal(t, "ГОТОВО", theme.StatusSuccess())
+
+	resetUserTheme(t)
+	require.NoError(t, os.WriteFile(path, []byte("icons: {done: DONE}\n"), 0o644))
+	assert.Nil(t, EnvironmentTheme(nil), "некорректный файл не применяется")
+	_, err := UserThemeConfig()
+	assert.ErrorContains(t, err, path)
+}
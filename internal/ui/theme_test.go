@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestThemeByName проверяет поиск встроенных тем по названию и синонимам
+func TestThemeByName(t *testing.T) {
+	for _, name := range ThemeNames {
+		theme, ok := ThemeByName(name)
+		require.True(t, ok, name)
+		assert.Equal(t, name, theme.Name)
+	}
+
+	theme, ok := ThemeByName(" ASCII ")
+	require.True(t, ok)
+	assert.Equal(t, ThemeNameEmbedded, theme.Name)
+
+	_, ok = ThemeByName("neon")
+	assert.False(t, ok)
+}
+
+// TestDefaultThemeMatchesGlobals проверяет, что тема по умолчанию совпадает с глобальными стилями
+func TestDefaultThemeMatchesGlobals(t *testing.T) {
+	ResetErrorColors()
+	current := CurrentTheme()
+	theme := DefaultTheme()
+
+	assert.Equal(t, current.Glyphs, theme.Glyphs)
+	assert.Equal(t, current.Icons.Done, theme.Icons.Done)
+	assert.Equal(t, GetTaskBelowPrefix(), theme.TaskBelowPrefix())
+	assert.Equal(t, DrawLine(10), theme.DrawLine(10))
+}
+
+// TestCurrentThemeIsCached проверяет, что тема собирается один раз и пересобирается после изменения стилей
+func TestCurrentThemeIsCached(t *testing.T) {
+	ResetErrorColors()
+	defer ResetErrorColors()
+
+	current := CurrentTheme()
+	assert.Same(t, current, CurrentTheme(), "тема не собирается заново при каждом вызове")
+
+	red := lipgloss.Color("#ff0000")
+	SetErrorColor(red, red)
+	updated := CurrentTheme()
+	assert.NotSame(t, current, updated, "изменение глобальных стилей сбрасывает тему")
+	assert.Equal(t, red, updated.Styles.ErrorMessage.GetForeground())
+}
+
+// TestEmbeddedThemeUsesASCII проверяет, что тема для встроенных систем не содержит Unicode-символов
+func TestEmbeddedThemeUsesASCII(t *testing.T) {
+	theme := EmbeddedTheme()
+	for _, s := range []string{
+		theme.TaskBelowPrefix(),
+		theme.CompletedTaskPrefix(false),
+		theme.SelectItemPrefix("selected"),
+		theme.DrawLine(5),
+		theme.Icons.Done,
+		theme.Icons.Cursor,
+	} {
+		for _, r := range s {
+			assert.Less(t, r, rune(128), "символ %q в %q", r, s)
+		}
+	}
+}
+
+// TestThemeCloneAndErrorColor проверяет, что изменение копии темы не затрагивает оригинал и глобальные стили
+func TestThemeCloneAndErrorColor(t *testing.T) {
+	ResetErrorColors()
+	globalStatus := GetErrorStatusStyle().GetForeground()
+
+	original := DefaultTheme()
+	clone := original.Clone()
+	clone.SetErrorColor(lipgloss.Color("#123456"), lipgloss.Color("#654321"))
+	clone.Icons.Done = "+"
+
+	assert.Equal(t, lipgloss.Color("#654321"), clone.Styles.ErrorStatus.GetForeground())
+	assert.NotEqual(t, clone.Styles.ErrorStatus.GetForeground(), original.Styles.ErrorStatus.GetForeground())
+	assert.NotEqual(t, "+", original.Icons.Done)
+	assert.Equal(t, globalStatus, GetErrorStatusStyle().GetForeground())
+}
+
+// TestThemeLabels проверяет подстановку текстов статусов
+func TestThemeLabels(t *testing.T) {
+	theme := DefaultTheme()
+	assert.NotEmpty(t, theme.StatusSuccess())
+
+	theme.Labels.Success = "ГОТОВО"
+	assert.Equal(t, "ГОТОВО", theme.StatusSuccess())
+}
@@ -162,6 +162,93 @@ func (f *ValidatorFactory) AlphaNumeric() Validator {
 	})
 }
 
+// validatorArity — число целочисленных аргументов валидаторов фабрики по нормализованному имени
+var validatorArity = map[string]int{
+	"strongpassword":   0,
+	"standardpassword": 0,
+	"email":            0,
+	"port":             0,
+	"httpport":         0,
+	"ipv4":             0,
+	"ipv6":             0,
+	"ip":               0,
+	"domain":           0,
+	"username":         0,
+	"required":         0,
+	"optionalemail":    0,
+	"path":             0,
+	"url":              0,
+	"range":            2,
+	"minlength":        1,
+	"maxlength":        1,
+	"length":           1,
+	"alphanumeric":     0,
+}
+
+// normalizeValidatorName приводит имя валидатора к виду без регистра, "_" и "-"
+func normalizeValidatorName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer("_", "", "-", "").Replace(name)
+}
+
+// ByName создает валидатор по имени метода фабрики (например, "Port" или "min_length")
+// Имя не зависит от регистра, символы "_" и "-" игнорируются.
+//
+// @param name Имя метода фабрики
+// @param args Целочисленные аргументы метода (для Range, MinLength, MaxLength, Length)
+// @return Валидатор или ошибка, если имя неизвестно или число аргументов не совпадает
+func (f *ValidatorFactory) ByName(name string, args ...int) (Validator, error) {
+	key := normalizeValidatorName(name)
+	arity, ok := validatorArity[key]
+	if !ok {
+		return nil, fmt.Errorf(defaults.ErrValidatorUnknown, name)
+	}
+	if len(args) != arity {
+		return nil, fmt.Errorf(defaults.ErrValidatorArgs, name, arity, len(args))
+	}
+
+	switch key {
+	case "strongpassword":
+		return f.StrongPassword(), nil
+	case "standardpassword":
+		return f.StandardPassword(), nil
+	case "email":
+		return f.Email(), nil
+	case "port":
+		return f.Port(), nil
+	case "httpport":
+		return f.HTTPPort(), nil
+	case "ipv4":
+		return f.IPv4(), nil
+	case "ipv6":
+		return f.IPv6(), nil
+	case "ip":
+		return f.IP(), nil
+	case "domain":
+		return f.Domain(), nil
+	case "username":
+		return f.Username(), nil
+	case "required":
+		return f.Required(), nil
+	case "optionalemail":
+		return f.OptionalEmail(), nil
+	case "path":
+		return f.Path(), nil
+	case "url":
+		return f.URL(), nil
+	case "range":
+		return f.Range(args[0], args[1]), nil
+	case "minlength":
+		return f.MinLength(args[0]), nil
+	case "maxlength":
+		return f.MaxLength(args[0]), nil
+	case "length":
+		return f.Length(args[0]), nil
+	default:
+		return f.AlphaNumeric(), nil
+	}
+}
+
 // Предустановленные валидаторы
 var (
 	// DefaultFactory глобальный экземпляр фабрики
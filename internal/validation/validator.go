@@ -166,6 +166,53 @@ func (nv *NumberValidator) Description() string {
 	return fmt.Sprintf(defaults.ValidatorNumberDescription, nv.Min, nv.Max)
 }
 
+// IntegerValidator проверяет, что строка — целое число, помещающееся
+// в целочисленный тип Go указанной разрядности (int8…int64, uint8…uint64)
+type IntegerValidator struct {
+	Bits     int  // Разрядность типа: 8, 16, 32 или 64
+	Unsigned bool // Беззнаковый тип
+}
+
+// NewIntegerValidator создает валидатор целых чисел для типа указанной разрядности
+func NewIntegerValidator(bits int, unsigned bool) *IntegerValidator {
+	if bits <= 0 || bits > 64 {
+		bits = 64
+	}
+	return &IntegerValidator{Bits: bits, Unsigned: unsigned}
+}
+
+// Validate проверяет, что строка разбирается как целое число без переполнения
+func (iv *IntegerValidator) Validate(s string) error {
+	var err error
+	if iv.Unsigned {
+		_, err = strconv.ParseUint(strings.TrimSpace(s), 10, iv.Bits)
+	} else {
+		_, err = strconv.ParseInt(strings.TrimSpace(s), 10, iv.Bits)
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		min, max := iv.bounds()
+		return fmt.Errorf(defaults.ValidatorNumberRange, min, max)
+	}
+	if err != nil {
+		return errors.New(defaults.ValidatorNumberInvalid)
+	}
+	return nil
+}
+
+// Description возвращает описание допустимого диапазона
+func (iv *IntegerValidator) Description() string {
+	min, max := iv.bounds()
+	return fmt.Sprintf(defaults.ValidatorNumberDescription, min, max)
+}
+
+// bounds возвращает границы диапазона типа
+func (iv *IntegerValidator) bounds() (interface{}, interface{}) {
+	if iv.Unsigned {
+		return uint64(0), uint64(1)<<iv.Bits - 1
+	}
+	return -(int64(1) << (iv.Bits - 1)), int64(1)<<(iv.Bits-1) - 1
+}
+
 // IPValidator валидатор для IP адресов
 type IPValidator struct {
 	allowIPv4 bool
@@ -313,6 +360,9 @@ const (
 	AllMustPass CompositeMode = iota
 	// AnyCanPass - достаточно одного прошедшего валидатора
 	AnyCanPass
+	// FirstError - валидаторы проверяются по порядку, возвращается первая ошибка
+	// (например, сначала разбор числа, затем проверка диапазона)
+	FirstError
 )
 
 // NewCompositeValidator создает новый композитный валидатор
@@ -353,6 +403,14 @@ func (cv *CompositeValidator) Validate(input string) error {
 		}
 		return fmt.Errorf(defaults.ValidatorCompositeNonePassed, performance.JoinEfficient(errors, defaults.ValidatorCompositeAllSeparator))
 
+	case FirstError:
+		for _, validator := range cv.validators {
+			if err := validator.Validate(input); err != nil {
+				return err
+			}
+		}
+		return nil
+
 	default:
 		return fmt.Errorf(defaults.ValidatorCompositeUnknownMode)
 	}
@@ -370,7 +428,7 @@ func (cv *CompositeValidator) Description() string {
 	}
 
 	switch cv.mode {
-	case AllMustPass:
+	case AllMustPass, FirstError:
 		return fmt.Sprintf(defaults.ValidatorCompositeAllDescription, performance.JoinEfficient(descriptions, defaults.ValidatorCompositeAllSeparator))
 	case AnyCanPass:
 		return fmt.Sprintf(defaults.ValidatorCompositeAnyDescription, performance.JoinEfficient(descriptions, defaults.ValidatorCompositeAnySeparator))
@@ -252,6 +252,34 @@ func TestCompositeValidator(t *testing.T) {
 	assert.NoError(t, err, "Должно проходить хотя бы одну валидацию")
 }
 
+func TestIntegerValidator(t *testing.T) {
+	int8Validator := NewIntegerValidator(8, false)
+	assert.NoError(t, int8Validator.Validate("-128"))
+	assert.NoError(t, int8Validator.Validate(" 127 "))
+	assert.Error(t, int8Validator.Validate("128"), "Значение не помещается в int8")
+	assert.Error(t, int8Validator.Validate("1.5"), "Дробное число не является целым")
+	assert.Contains(t, int8Validator.Description(), "-128")
+
+	uint64Validator := NewIntegerValidator(64, true)
+	assert.NoError(t, uint64Validator.Validate("18446744073709551615"))
+	assert.Error(t, uint64Validator.Validate("-1"), "Беззнаковый тип не принимает отрицательные числа")
+	assert.Contains(t, uint64Validator.Description(), "18446744073709551615")
+
+	int64Validator := NewIntegerValidator(0, false)
+	assert.Equal(t, 64, int64Validator.Bits, "Неверная разрядность заменяется на 64")
+	assert.Contains(t, int64Validator.Description(), "-9223372036854775808")
+}
+
+func TestCompositeValidatorFirstError(t *testing.T) {
+	composite := NewCompositeValidator(FirstError, NewIntegerValidator(16, true), NewNumberValidator(1024, 65535))
+
+	assert.NoError(t, composite.Validate("8080"))
+	rangeErr := NewNumberValidator(1024, 65535).Validate("80")
+	assert.Equal(t, rangeErr, composite.Validate("80"), "Возвращается ошибка второго валидатора")
+	assert.Equal(t, NewIntegerValidator(16, true).Validate("abc"), composite.Validate("abc"),
+		"Ошибка разбора возвращается до проверки диапазона")
+}
+
 func TestEdgeCases(t *testing.T) {
 	// Тестируем крайние случаи
 
@@ -320,3 +348,28 @@ func TestIPValidatorDefaultBehavior(t *testing.T) {
 	validator := NewIPValidator(false, false) // Ни IPv4, ни IPv6 не разрешены
 	assert.True(t, validator.allowIPv4, "IPv4 должен быть разрешен по умолчанию")
 }
+
+func TestValidatorFactoryByName(t *testing.T) {
+	// Имя не зависит от регистра и разделителей
+	validator, err := DefaultFactory.ByName("Port")
+	assert.NoError(t, err)
+	assert.NoError(t, validator.Validate("8080"))
+	assert.Error(t, validator.Validate("70000"))
+
+	validator, err = DefaultFactory.ByName("min_length", 3)
+	assert.NoError(t, err)
+	assert.Error(t, validator.Validate("ab"))
+	assert.NoError(t, validator.Validate("abc"))
+
+	validator, err = DefaultFactory.ByName("range", 10, 20)
+	assert.NoError(t, err)
+	assert.Error(t, validator.Validate("5"))
+
+	// Неизвестное имя и неверное число аргументов
+	_, err = DefaultFactory.ByName("zip")
+	assert.Error(t, err)
+	_, err = DefaultFactory.ByName("Range", 1)
+	assert.Error(t, err)
+	_, err = DefaultFactory.ByName("email", 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package ziva
+
+import (
+	"io"
+)
+
+// ----------------------------------------------------------------------------
+// Построчный режим
+// ----------------------------------------------------------------------------
+
+// WithLineMode включает построчный режим для последовательных консолей
+// и простых терминалов (TERM=vt100, TERM=dumb), где полноэкранная перерисовка
+// искажает вывод. Каждая задача задаёт вопрос обычными строками, например
+// пронумерованные варианты и приглашение "Среда [1-3]: ", а ответ читается
+// строкой из in. Курсор терминала не перемещается.
+//
+// Поддерживаются все задачи очереди: в задачах выбора ответом служит номер,
+// ключ или название варианта (в MultiSelectTask — через запятую), пустая строка
+// выбирает значение по умолчанию; ввод проверяется теми же валидаторами.
+// Некорректный ответ сопровождается сообщением, и вопрос задаётся повторно.
+// FuncTask выполняются как обычно. Тайм-ауты в этом режиме не отсчитываются.
+//
+// @param in Источник ответов (nil — os.Stdin)
+// @return Указатель на очередь задач
+func (q *Queue) WithLineMode(in io.Reader) *Queue {
+	q.model.WithLineMode(in)
+	return q
+}
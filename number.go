@@ -0,0 +1,130 @@
+package ziva
+
+import (
+	"flag"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/task"
+)
+
+// ----------------------------------------------------------------------------
+// Выбор числа
+// ----------------------------------------------------------------------------
+
+// NumberTask представляет задачу выбора числа из диапазона
+type NumberTask struct {
+	*task.NumberTask
+}
+
+// NewNumberTask создает задачу выбора числа из диапазона [low, high]: ←/→ изменяют
+// значение на шаг, PgUp/PgDn - на десять шагов, Home/End выбирают границы,
+// цифры вводят значение напрямую. Шкала под значением показывает его положение
+// в диапазоне. Выбранное число возвращается GetInt() и GetFloat(), в результатах
+// очереди - значением int (при точности 0) или float64 (вид KindNumber).
+//
+//	ziva.NewNumberTask("Размер кэша", 64, 1024, 64).
+//		WithValue(256).
+//		WithUnit("MB")
+//
+// @param title Заголовок задачи
+// @param low Нижняя граница диапазона
+// @param high Верхняя граница диапазона
+// @param step Шаг изменения значения (количество знаков после запятой определяется по шагу и границам)
+// @return Указатель на новую задачу выбора числа
+func NewNumberTask(title string, low, high, step float64) *NumberTask {
+	return &NumberTask{task.NewNumberTask(title, low, high, step)}
+}
+
+// WithValue задаёт начальное значение (по умолчанию - нижняя граница диапазона)
+//
+// @param value Начальное значение
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithValue(value float64) *NumberTask {
+	t.NumberTask.WithValue(value)
+	return t
+}
+
+// WithPrecision задаёт количество знаков после запятой
+//
+// @param precision Количество знаков после запятой (от 0 до 6)
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithPrecision(precision int) *NumberTask {
+	t.NumberTask.WithPrecision(precision)
+	return t
+}
+
+// WithUnit задаёт единицу измерения, выводимую после числа ("MB", "%", "ms")
+//
+// @param unit Единица измерения
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithUnit(unit string) *NumberTask {
+	t.NumberTask.WithUnit(unit)
+	return t
+}
+
+// WithGaugeWidth задаёт ширину шкалы в символах (по умолчанию 30, 0 - без шкалы)
+//
+// @param width Ширина шкалы
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithGaugeWidth(width int) *NumberTask {
+	t.NumberTask.WithGaugeWidth(width)
+	return t
+}
+
+// WithTimeout устанавливает тайм-аут для задачи с числом по умолчанию
+//
+// @param duration Тайм-аут
+// @param defaultValue Число по умолчанию
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithTimeout(duration time.Duration, defaultValue float64) *NumberTask {
+	t.NumberTask.WithTimeout(duration, defaultValue)
+	return t
+}
+
+// FromEnv берёт начальное значение из переменной окружения
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) FromEnv(name string, mode ...PrefillMode) *NumberTask {
+	t.NumberTask.FromEnv(name, mode...)
+	return t
+}
+
+// FromFlag берёт начальное значение из флага командной строки, явно указанного при разборе fs
+//
+// @param fs Набор флагов
+// @param name Имя флага
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *NumberTask {
+	t.NumberTask.FromFlag(fs, name, mode...)
+	return t
+}
+
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) WithID(id string) *NumberTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) When(condition func(Results) bool) *NumberTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *NumberTask) Then(followUp func(Results) []Task) *NumberTask {
+	t.SetFollowUp(followUp)
+	return t
+}
@@ -0,0 +1,87 @@
+package ziva
+
+import (
+	"github.com/qzeleza/ziva/internal/task"
+)
+
+// ----------------------------------------------------------------------------
+// ParallelGroup
+// ----------------------------------------------------------------------------
+
+// ParallelGroup представляет группу задач выполнения функций, выполняемых одновременно
+type ParallelGroup struct {
+	*task.ParallelGroup
+}
+
+// NewParallelGroup создает группу задач, функции которых выполняются параллельно.
+// Группа занимает в очереди одно место, выводит состояние каждой задачи вложенными
+// строками и завершается, когда завершены все задачи.
+//
+// @param title Заголовок группы
+// @param tasks Задачи выполнения функций
+// @return Указатель на новую группу
+func NewParallelGroup(title string, tasks ...*FuncTask) *ParallelGroup {
+	children := make([]*task.FuncTask, 0, len(tasks))
+	for _, t := range tasks {
+		if t != nil {
+			children = append(children, t.FuncTask)
+		}
+	}
+	return &ParallelGroup{task.NewParallelGroup(title, children...)}
+}
+
+// WithConcurrency ограничивает число одновременно выполняемых задач группы
+//
+// @param limit Максимальное число задач (0 — без ограничения)
+// @return Указатель на группу для цепочки вызовов
+func (g *ParallelGroup) WithConcurrency(limit int) *ParallelGroup {
+	g.ParallelGroup.WithConcurrency(limit)
+	return g
+}
+
+// WithFailFast задаёт поведение группы при ошибке.
+// При failFast=true первая ошибка отменяет оставшиеся задачи, иначе группа
+// дожидается всех задач и собирает все ошибки.
+//
+// @param failFast Флаг отмены оставшихся задач после первой ошибки
+// @return Указатель на группу для цепочки вызовов
+func (g *ParallelGroup) WithFailFast(failFast bool) *ParallelGroup {
+	g.ParallelGroup.WithFailFast(failFast)
+	return g
+}
+
+// WithStopOnError устанавливает флаг остановки очереди при ошибке группы
+//
+// @param stop Флаг остановки очереди при ошибке
+// @return Указатель на группу для цепочки вызовов
+func (g *ParallelGroup) WithStopOnError(stop bool) *ParallelGroup {
+	g.SetStopOnError(stop)
+	return g
+}
+
+// WithID задаёт стабильный идентификатор группы для сопоставления результатов
+//
+// @param id Идентификатор группы
+// @return Указатель на группу для цепочки вызовов
+func (g *ParallelGroup) WithID(id string) *ParallelGroup {
+	g.SetID(id)
+	return g
+}
+
+// When задаёт условие выполнения группы по результатам предыдущих задач.
+//
+// @param condition Функция-условие
+// @return Указатель на группу для цепочки вызовов
+func (g *ParallelGroup) When(condition func(Results) bool) *ParallelGroup {
+	g.SetCondition(condition)
+	return g
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения группы.
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на группу для цепочки вызовов
+func (g *ParallelGroup) Then(followUp func(Results) []Task) *ParallelGroup {
+	g.SetFollowUp(followUp)
+	return g
+}
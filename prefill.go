@@ -0,0 +1,118 @@
+package ziva
+
+import (
+	"flag"
+
+	"github.com/qzeleza/ziva/internal/task"
+)
+
+// ----------------------------------------------------------------------------
+// Начальные значения из переменных окружения и флагов
+// ----------------------------------------------------------------------------
+
+// PrefillMode определяет, как задача использует значение из переменной
+// окружения или флага командной строки (FromEnv, FromFlag).
+type PrefillMode = task.PrefillMode
+
+const (
+	// PrefillDefault - значение выбирается по умолчанию, пользователь подтверждает или меняет его
+	PrefillDefault = task.PrefillDefault
+	// PrefillAccept - корректное значение принимается без вопроса
+	PrefillAccept = task.PrefillAccept
+)
+
+// FromEnv берёт начальный ответ из переменной окружения (да/yes/true/1 или нет/no/false/0).
+// Значение читается, когда очередь доходит до задачи; пустая переменная не учитывается.
+// Если ответ совпадает со значением переменной, в итоговом виде указывается источник: "(из $NAME)".
+//
+//	ziva.NewYesNoTask("Отладка", "Включить отладку?").FromEnv("APP_DEBUG", ziva.PrefillAccept)
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *YesNoTask) FromEnv(name string, mode ...PrefillMode) *YesNoTask {
+	t.YesNoTask.FromEnv(name, mode...)
+	return t
+}
+
+// FromFlag берёт начальный ответ из флага командной строки, явно указанного при разборе fs.
+//
+// @param fs Набор флагов
+// @param name Имя флага
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *YesNoTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *YesNoTask {
+	t.YesNoTask.FromFlag(fs, name, mode...)
+	return t
+}
+
+// FromEnv берёт начальный вариант (ключ или название) из переменной окружения.
+// Несуществующий или недоступный вариант не используется.
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *SingleSelectTask) FromEnv(name string, mode ...PrefillMode) *SingleSelectTask {
+	t.SingleSelectTask.FromEnv(name, mode...)
+	return t
+}
+
+// FromFlag берёт начальный вариант из флага командной строки, явно указанного при разборе fs.
+//
+//	env := fs.String("env", "dev", "среда развертывания")
+//	fs.Parse(os.Args[1:])
+//	ziva.NewSingleSelectTask("Среда", items).FromFlag(fs, "env")
+//
+// @param fs Набор флагов
+// @param name Имя флага
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *SingleSelectTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *SingleSelectTask {
+	t.SingleSelectTask.FromFlag(fs, name, mode...)
+	return t
+}
+
+// FromEnv берёт начальный набор вариантов (через запятую) из переменной окружения.
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *MultiSelectTask) FromEnv(name string, mode ...PrefillMode) *MultiSelectTask {
+	t.MultiSelectTask.FromEnv(name, mode...)
+	return t
+}
+
+// FromFlag берёт начальный набор вариантов из флага командной строки, явно указанного при разборе fs.
+//
+// @param fs Набор флагов
+// @param name Имя флага
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *MultiSelectTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *MultiSelectTask {
+	t.MultiSelectTask.FromFlag(fs, name, mode...)
+	return t
+}
+
+// FromEnv берёт начальное значение поля из переменной окружения.
+// Значение, не прошедшее валидатор задачи, не используется.
+//
+//	ziva.NewInputTask("Порт", "Введите порт").WithValidator(ziva.DefaultValidators.Port()).FromEnv("APP_PORT")
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *InputTask) FromEnv(name string, mode ...PrefillMode) *InputTask {
+	t.InputTaskNew.FromEnv(name, mode...)
+	return t
+}
+
+// FromFlag берёт начальное значение поля из флага командной строки, явно указанного при разборе fs.
+//
+// @param fs Набор флагов
+// @param name Имя флага
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *InputTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *InputTask {
+	t.InputTaskNew.FromFlag(fs, name, mode...)
+	return t
+}
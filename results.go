@@ -0,0 +1,110 @@
+package ziva
+
+import (
+	"io"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/query"
+)
+
+// ----------------------------------------------------------------------------
+// Результаты очереди
+// ----------------------------------------------------------------------------
+
+// TaskResult содержит итог выполнения одной задачи очереди:
+// заголовок, вид задачи, выбранные значения, признак выбора по тайм-ауту,
+// ошибку и её тип, а также длительность выполнения.
+type TaskResult = common.TaskResult
+
+// Results — упорядоченный список результатов задач очереди.
+type Results = common.Results
+
+// ResultStatus описывает итоговое состояние задачи.
+type ResultStatus = common.ResultStatus
+
+const (
+	// ResultSuccess - задача завершена успешно
+	ResultSuccess = common.ResultSuccess
+	// ResultError - задача завершена с ошибкой
+	ResultError = common.ResultError
+	// ResultCancelled - задача отменена пользователем
+	ResultCancelled = common.ResultCancelled
+	// ResultPending - очередь не дошла до задачи
+	ResultPending = common.ResultPending
+	// ResultSkipped - задача пропущена, так как её условие (When) не выполнено
+	ResultSkipped = common.ResultSkipped
+)
+
+const (
+	// KindYesNo - задача Да/Нет (значение bool)
+	KindYesNo = common.KindYesNo
+	// KindSingleSelect - задача выбора одного варианта (значение string)
+	KindSingleSelect = common.KindSingleSelect
+	// KindMultiSelect - задача множественного выбора (значения []string)
+	KindMultiSelect = common.KindMultiSelect
+	// KindInput - задача ввода (значение string)
+	KindInput = common.KindInput
+	// KindPath - задача выбора файла или каталога (значение string, при выборе нескольких - значения []string)
+	KindPath = common.KindPath
+	// KindDate - задача выбора даты (значение string в формате "2006-01-02")
+	KindDate = common.KindDate
+	// KindTime - задача выбора времени суток (значение string в формате "15:04" или "15:04:05")
+	KindTime = common.KindTime
+	// KindDuration - задача выбора длительности (значение string в формате time.Duration, например "1h30m0s")
+	KindDuration = common.KindDuration
+	// KindNumber - задача выбора числа (значение int при точности 0, иначе float64)
+	KindNumber = common.KindNumber
+	// KindFunc - задача выполнения функции
+	KindFunc = common.KindFunc
+	// KindParallel - параллельная группа задач выполнения функций
+	KindParallel = common.KindParallel
+)
+
+// Results возвращает упорядоченный список результатов всех задач очереди.
+// Вызывается после Run; задачи, до которых очередь не дошла, имеют состояние ResultPending.
+//
+// @return Список результатов задач
+func (q *Queue) Results() Results {
+	return q.model.Results()
+}
+
+// WriteJSON записывает результаты очереди в формате JSON для обработки скриптами.
+// Значения задач ввода пароля заменяются маской.
+//
+// @param w Поток вывода
+// @return Ошибка записи
+func (q *Queue) WriteJSON(w io.Writer) error {
+	return q.model.WriteJSON(w)
+}
+
+// ----------------------------------------------------------------------------
+// Журнал сеанса
+// ----------------------------------------------------------------------------
+
+// TranscriptFormat определяет формат записей журнала сеанса.
+type TranscriptFormat = query.TranscriptFormat
+
+const (
+	// TranscriptText - строка "ключ=значение" на задачу:
+	// 2024-05-01T10:00:00Z task="Среда" kind=single_select status=success value="Боевая" key="prod" timed_out=false
+	TranscriptText = query.TranscriptText
+	// TranscriptJSONLines - JSON-объект на строку с полями time, index, id, title, kind,
+	// status, value, key, timed_out, error и error_type
+	TranscriptJSONLines = query.TranscriptJSONLines
+)
+
+// WithTranscript включает журнал сеанса для аудита действий оператора:
+// после завершения каждой задачи в w дописывается запись с моментом завершения,
+// заголовком, отображаемым значением (value) и исходным ключом (key),
+// признаком автоматического выбора по тайм-ауту и типом ошибки.
+// Значения задач ввода пароля заменяются маской.
+// Повторное прохождение задачи (возврат к предыдущей задаче) добавляет новую запись.
+// Ошибка записи журнала возвращается из Run.
+//
+// @param w Поток журнала, например файл, открытый с os.O_APPEND (nil — журнал отключён)
+// @param format Формат записей
+// @return Указатель на очередь задач
+func (q *Queue) WithTranscript(w io.Writer, format TranscriptFormat) *Queue {
+	q.model.WithTranscript(w, format)
+	return q
+}
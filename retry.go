@@ -0,0 +1,40 @@
+package ziva
+
+import (
+	terrors "github.com/qzeleza/ziva/internal/errors"
+	"github.com/qzeleza/ziva/internal/task"
+)
+
+// ----------------------------------------------------------------------------
+// Повтор выполнения функций
+// ----------------------------------------------------------------------------
+
+// RetryPolicy задаёт число попыток, экспоненциальную задержку со случайным отклонением
+// и типы ошибок, при которых функция FuncTask выполняется повторно.
+type RetryPolicy = task.RetryPolicy
+
+// DefaultRetryPolicy возвращает политику повтора по умолчанию:
+// три попытки с задержкой от 1 до 30 секунд и выбором действия после исчерпания попыток.
+var DefaultRetryPolicy = task.DefaultRetryPolicy
+
+// ErrorType описывает тип ошибки задачи (используется в RetryPolicy.RetryOn и TaskResult).
+type ErrorType = terrors.ErrorType
+
+const (
+	// ErrorTypeUnknown - неизвестная ошибка
+	ErrorTypeUnknown = terrors.ErrorTypeUnknown
+	// ErrorTypeValidation - ошибка валидации
+	ErrorTypeValidation = terrors.ErrorTypeValidation
+	// ErrorTypeUserCancel - операция отменена пользователем
+	ErrorTypeUserCancel = terrors.ErrorTypeUserCancel
+	// ErrorTypeTimeout - истёк тайм-аут операции
+	ErrorTypeTimeout = terrors.ErrorTypeTimeout
+	// ErrorTypeNetwork - сетевая ошибка
+	ErrorTypeNetwork = terrors.ErrorTypeNetwork
+	// ErrorTypeFileSystem - ошибка файловой системы
+	ErrorTypeFileSystem = terrors.ErrorTypeFileSystem
+	// ErrorTypePermission - ошибка прав доступа
+	ErrorTypePermission = terrors.ErrorTypePermission
+	// ErrorTypeConfiguration - ошибка конфигурации
+	ErrorTypeConfiguration = terrors.ErrorTypeConfiguration
+)
@@ -0,0 +1,92 @@
+package ziva
+
+import (
+	"io"
+
+	"github.com/qzeleza/ziva/internal/sink"
+)
+
+// ----------------------------------------------------------------------------
+// Запись ответов в файлы конфигурации
+// ----------------------------------------------------------------------------
+
+// Sink — файл конфигурации, в который записываются ответы очереди (см. NewSink).
+type Sink = sink.Sink
+
+// SinkFormat преобразует ответы в содержимое файла конфигурации.
+// Собственный формат реализует метод Encode([]SinkValue) ([]byte, error).
+type SinkFormat = sink.Format
+
+// SinkValue — ответ задачи под ключом файла конфигурации
+// (значение string, bool или []string).
+type SinkValue = sink.Value
+
+// NewSink создаёт файл конфигурации для ответов очереди.
+// Задачи сопоставляются с ключами методом Map(идентификатор, ключ); без Map
+// записываются ответы всех задач с идентификатором (WithID).
+// Файл записывается атомарно (временный файл и переименование) и не
+// перезаписывается, если содержимое не изменилось. WithConfirm задаёт
+// подтверждение перезаписи с предпросмотром изменений, Preview возвращает
+// изменения без записи.
+//
+//	queue.WithSink(ziva.NewSink("/etc/config/app", ziva.UCIFormat("app", "main")).
+//		Map("env", "environment").
+//		Map("port", "port").
+//		WithConfirm(ziva.ConfirmOverwrite(os.Stdin, os.Stdout)))
+//
+// @param path Путь к файлу
+// @param format Формат файла
+// @return Указатель на файл конфигурации
+func NewSink(path string, format SinkFormat) *Sink {
+	return sink.New(path, format)
+}
+
+// EnvFormat — файл переменных окружения (.env): KEY="value".
+func EnvFormat() SinkFormat {
+	return sink.Env{}
+}
+
+// ShellExportFormat — сценарий оболочки: export KEY='value'.
+func ShellExportFormat() SinkFormat {
+	return sink.Env{Export: true}
+}
+
+// JSONFormat — JSON-объект с ключами в порядке Map.
+func JSONFormat() SinkFormat {
+	return sink.JSON{}
+}
+
+// YAMLFormat — отображение YAML с ключами в порядке Map.
+func YAMLFormat() SinkFormat {
+	return sink.YAML{}
+}
+
+// UCIFormat — файл конфигурации OpenWrt (/etc/config/<имя>) с одной секцией
+// "config <sectionType> '<name>'"; логические значения записываются как '1'/'0',
+// ответы мультивыбора — строками list. Файл перезаписывается целиком.
+//
+// @param sectionType Тип секции
+// @param name Имя секции (пустое — анонимная секция)
+func UCIFormat(sectionType, name string) SinkFormat {
+	return sink.UCI{Type: sectionType, Name: name}
+}
+
+// ConfirmOverwrite возвращает подтверждение перезаписи для Sink.WithConfirm:
+// изменения файла выводятся в out, ответ читается из in.
+//
+// @param in Источник ответа
+// @param out Поток вывода
+// @return Функция подтверждения
+func ConfirmOverwrite(in io.Reader, out io.Writer) func(path, diff string) bool {
+	return sink.ConfirmPrompt(in, out)
+}
+
+// WithSink добавляет файлы конфигурации, в которые записываются ответы после
+// выполнения очереди до конца без ошибок. Ошибка записи возвращается из Run.
+//
+// @param sinks Файлы конфигурации
+// @return Указатель на очередь задач
+func (q *Queue) WithSink(sinks ...*Sink) *Queue {
+	q.model.WithSink(sinks...)
+	return q
+}
@@ -0,0 +1,241 @@
+package ziva
+
+import (
+	"io"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/spec"
+)
+
+// ----------------------------------------------------------------------------
+// Декларативное описание очереди
+// ----------------------------------------------------------------------------
+
+// QueueSpec — декларативное описание очереди: заголовок, название и версия приложения,
+// нумерация задач и список задач. Загружается из YAML или JSON функцией LoadQueue.
+//
+// Пример документа:
+//
+//	title: Установка
+//	app_name: Жива
+//	version: "1.0"
+//	numbering: {format: "[%d]"}
+//	tasks:
+//	  - type: single_select
+//	    id: env
+//	    title: Среда развертывания
+//	    items:
+//	      - {key: dev, name: Разработка}
+//	      - {key: prod, name: Боевая}
+//	    default: dev
+//	    timeout: 10s
+//	  - type: input
+//	    id: port
+//	    title: Порт
+//	    input_type: number
+//	    validator: port
+type QueueSpec = spec.Queue
+
+// TaskSpec описывает задачу очереди. Поле Type принимает значения
+// KindYesNo, KindSingleSelect, KindMultiSelect и KindInput.
+type TaskSpec = spec.Task
+
+// ItemSpec описывает элемент списка задачи выбора.
+type ItemSpec = spec.Item
+
+// DependencySpec описывает зависимость элемента мультивыбора (см. MultiSelectDependencyRule).
+type DependencySpec = spec.Dependency
+
+// DependencyActionsSpec перечисляет ключи элементов, затрагиваемых зависимостью.
+type DependencyActionsSpec = spec.DependencyActions
+
+// NumberingSpec описывает нумерацию задач очереди.
+type NumberingSpec = spec.Numbering
+
+// SpecError — ошибка схемы с номером строки, столбца и путём к полю (например, "tasks[1].validator").
+type SpecError = spec.Error
+
+// SpecErrors — список ошибок схемы; возвращается LoadQueue и QueueFromSpec.
+type SpecErrors = spec.Errors
+
+// LoadQueue читает описание очереди в формате YAML или JSON и создаёт очередь задач.
+// Ошибки схемы возвращаются как SpecErrors с позициями в документе.
+//
+// @param r Источник данных
+// @return Очередь задач или ошибка разбора/проверки
+func LoadQueue(r io.Reader) (*Queue, error) {
+	queueSpec, err := spec.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return QueueFromSpec(queueSpec)
+}
+
+// LoadQueueFile читает описание очереди из файла в формате YAML или JSON и создаёт очередь задач.
+//
+// @param path Путь к файлу
+// @return Очередь задач или ошибка чтения/разбора/проверки
+func LoadQueueFile(path string) (*Queue, error) {
+	queueSpec, err := spec.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return QueueFromSpec(queueSpec)
+}
+
+// QueueFromSpec создаёт очередь задач по описанию.
+// Описание предварительно проверяется; задачи создаются конструкторами
+// NewYesNoTask, NewSingleSelectTask, NewMultiSelectTask и NewInputTask.
+//
+// @param s Описание очереди
+// @return Очередь задач или ошибка проверки описания
+func QueueFromSpec(s *QueueSpec) (*Queue, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	queue := NewQueue(s.Title)
+	if s.AppName != "" {
+		queue.WithAppName(s.AppName, s.Version)
+	}
+	if s.Numbering != nil {
+		queue.WithTasksNumbered(s.Numbering.KeepFirstSymbol, s.Numbering.Format)
+	}
+	if s.Summary != nil && !*s.Summary {
+		queue.WithOutSummary()
+	}
+	if s.ResultLine != nil && !*s.ResultLine {
+		queue.WithOutResultLine()
+	}
+	if s.ClearScreen {
+		queue.WithClearScreen(true)
+	}
+	if s.BackNavigation {
+		queue.WithBackNavigation()
+	}
+
+	for _, taskSpec := range s.Tasks {
+		queue.AddTasks(taskFromSpec(taskSpec))
+	}
+	return queue, nil
+}
+
+// taskFromSpec создаёт задачу по проверенному описанию.
+//
+// @param s Описание задачи
+// @return Задача очереди
+func taskFromSpec(s TaskSpec) Task {
+	switch s.Kind() {
+	case common.KindYesNo:
+		return yesNoFromSpec(s)
+	case common.KindSingleSelect:
+		return singleSelectFromSpec(s)
+	case common.KindMultiSelect:
+		return multiSelectFromSpec(s)
+	default:
+		return inputFromSpec(s)
+	}
+}
+
+// yesNoFromSpec создаёт задачу Да/Нет по описанию
+func yesNoFromSpec(s TaskSpec) *YesNoTask {
+	question := s.Question
+	if question == "" {
+		question = s.Title
+	}
+	t := NewYesNoTask(s.Title, question).WithID(s.ID)
+	if s.YesLabel != "" || s.NoLabel != "" {
+		t.WithCustomLabels(s.YesLabel, s.NoLabel)
+	}
+
+	yes, hasDefault := s.DefaultYes()
+	switch {
+	case s.Timeout > 0 && hasDefault && !yes:
+		t.WithDefaultNoAndTimeout(s.Timeout)
+	case s.Timeout > 0:
+		t.WithDefaultYesAndTimeout(s.Timeout)
+	case hasDefault && !yes:
+		t.WithDefaultNo()
+	case hasDefault:
+		t.WithDefaultYes()
+	}
+	return t
+}
+
+// singleSelectFromSpec создаёт задачу одиночного выбора по описанию
+func singleSelectFromSpec(s TaskSpec) *SingleSelectTask {
+	items := s.TaskItems()
+	t := NewSingleSelectTask(s.Title, items).WithID(s.ID)
+	if s.Viewport > 0 {
+		t.WithViewport(s.Viewport)
+	}
+	if len(s.Disabled) > 0 {
+		t.WithItemsDisabled(s.Disabled)
+	}
+
+	key, hasDefault := s.DefaultText()
+	if hasDefault {
+		t.WithDefaultItem(key)
+	}
+	if s.Timeout > 0 {
+		if !hasDefault {
+			key = items[0].Key
+		}
+		t.WithTimeout(s.Timeout, key)
+	}
+	return t
+}
+
+// multiSelectFromSpec создаёт задачу множественного выбора по описанию
+func multiSelectFromSpec(s TaskSpec) *MultiSelectTask {
+	t := NewMultiSelectTask(s.Title, s.TaskItems()).WithID(s.ID)
+	if s.Viewport > 0 {
+		t.WithViewport(s.Viewport)
+	}
+	if len(s.Disabled) > 0 {
+		t.WithItemsDisabled(s.Disabled)
+	}
+	if enabled, label := s.SelectAllLabel(); enabled && label != "" {
+		t.WithSelectAll(label)
+	} else if enabled {
+		t.WithSelectAll()
+	}
+	if s.RequireSelection {
+		t.WithRequireSelection(true)
+	}
+	if rules := s.DependencyRules(); rules != nil {
+		t.WithDependencies(rules)
+	}
+
+	keys := s.DefaultKeys()
+	if len(keys) > 0 {
+		t.WithDefaultItems(keys)
+	}
+	if s.Timeout > 0 {
+		t.WithTimeout(s.Timeout, keys)
+	}
+	return t
+}
+
+// inputFromSpec создаёт задачу ввода по описанию
+func inputFromSpec(s TaskSpec) *InputTask {
+	t := NewInputTask(s.Title, s.Prompt).WithID(s.ID)
+	// Валидатор задаётся до типа ввода, чтобы тип не подставил свой валидатор по умолчанию
+	if validator, _ := s.TaskValidator(); validator != nil {
+		t.WithValidator(validator)
+	}
+	t.WithInputType(s.InputKind())
+	if s.Placeholder != "" {
+		t.WithPlaceholder(s.Placeholder)
+	}
+	if s.AllowEmpty {
+		t.WithAllowEmpty(true)
+	}
+	if value, ok := s.DefaultText(); ok && s.Timeout > 0 {
+		t.WithTimeout(s.Timeout, value)
+	} else if ok {
+		// Без тайм-аута значение по умолчанию подставляется в поле как начальное
+		t.WithValue(value)
+	}
+	return t
+}
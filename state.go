@@ -0,0 +1,79 @@
+package ziva
+
+import (
+	"fmt"
+
+	"github.com/qzeleza/ziva/internal/common"
+	"github.com/qzeleza/ziva/internal/defaults"
+	"github.com/qzeleza/ziva/internal/task"
+)
+
+// ----------------------------------------------------------------------------
+// Возобновление прерванной очереди
+// ----------------------------------------------------------------------------
+
+// ResumeTaskID — идентификатор вопроса о возобновлении очереди.
+// В неинтерактивном режиме ответ на вопрос можно передать под этим ключом
+// (без ответа очередь возобновляется).
+const ResumeTaskID = "resume"
+
+// ResumeMode определяет, как FuncTask, успешно выполненная в прерванном запуске,
+// обрабатывается при возобновлении очереди.
+type ResumeMode = task.ResumeMode
+
+const (
+	// ResumeIdempotent - задача отмечается выполненной без повторного вызова функции (по умолчанию)
+	ResumeIdempotent = task.ResumeIdempotent
+	// ResumeRerun - функция выполняется заново
+	ResumeRerun = task.ResumeRerun
+)
+
+// WithStateFile включает сохранение состояния очереди в файл path: после каждого
+// шага туда записываются ответы завершённых задач (файл доступен только владельцу).
+// Если очередь была прервана, при следующем Run она спрашивает
+// "Запуск был прерван. Продолжить с шага 7?" и при согласии отмечает выполненные задачи завершёнными
+// с сохранёнными ответами, а затем продолжает с первой невыполненной.
+//
+// Пароли сохраняются только при заданном WithStateKey, иначе запрашиваются заново.
+// FuncTask по умолчанию повторно не выполняются (см. FuncTask.WithResumeMode).
+// Восстановление прекращается на задаче, заголовок которой не совпадает с сохранённым.
+// После успешного завершения очереди файл удаляется.
+//
+// @param path Путь к файлу состояния
+// @return Указатель на очередь задач
+func (q *Queue) WithStateFile(path string) *Queue {
+	q.model.WithStateFile(path, resumePrompt)
+	return q
+}
+
+// WithStateKey задаёт ключ, которым значения паролей шифруются в файле состояния (AES-GCM).
+// При возобновлении нужен тот же ключ; пароль, который не удалось расшифровать,
+// запрашивается заново.
+//
+// @param key Ключ произвольной длины
+// @return Указатель на очередь задач
+func (q *Queue) WithStateKey(key []byte) *Queue {
+	q.model.WithStateKey(key)
+	return q
+}
+
+// WithResumeMode задаёт поведение задачи при возобновлении очереди из файла состояния:
+// ResumeIdempotent - выполненная функция повторно не вызывается,
+// ResumeRerun - функция выполняется заново (подключения, запуск служб).
+//
+// @param mode Режим возобновления
+// @return Указатель на задачу для цепочки вызовов
+func (t *FuncTask) WithResumeMode(mode ResumeMode) *FuncTask {
+	t.FuncTask.WithResumeMode(mode)
+	return t
+}
+
+// resumePrompt создаёт вопрос о возобновлении очереди с ответом "Да" по умолчанию
+func resumePrompt(step int) common.Task {
+	question := fmt.Sprintf(defaults.ResumeQuestionFormat, step)
+	prompt := task.NewYesNoTask(question, question).
+		WithDefaultYes().
+		WithNoAsError()
+	prompt.SetID(ResumeTaskID)
+	return prompt
+}
@@ -0,0 +1,164 @@
+package ziva
+
+import (
+	"flag"
+	"time"
+
+	"github.com/qzeleza/ziva/internal/task"
+	"github.com/qzeleza/ziva/internal/validation"
+)
+
+// ----------------------------------------------------------------------------
+// Многострочный ввод
+// ----------------------------------------------------------------------------
+
+// TextAreaTask представляет задачу многострочного ввода текста
+type TextAreaTask struct {
+	*task.TextAreaTask
+}
+
+// NewTextAreaTask создает задачу многострочного ввода: публичные ключи SSH, сертификаты,
+// тексты баннеров, фрагменты JSON. Enter переносит строку, Ctrl+D или Alt+Enter
+// подтверждают ввод. В итоговом виде выводятся первые строки текста.
+//
+//	ziva.NewTextAreaTask("Ключ SSH", "Вставьте публичный ключ").
+//		WithHeight(4).
+//		WithMaxLength(1024)
+//
+// @param title Заголовок задачи
+// @param prompt Подсказка для ввода
+// @return Указатель на новую задачу многострочного ввода
+func NewTextAreaTask(title, prompt string) *TextAreaTask {
+	return &TextAreaTask{task.NewTextAreaTask(title, prompt)}
+}
+
+// WithHeight задаёт высоту поля в строках (по умолчанию 6); более длинный текст прокручивается
+//
+// @param height Высота поля
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithHeight(height int) *TextAreaTask {
+	t.TextAreaTask.WithHeight(height)
+	return t
+}
+
+// WithLineNumbers включает или отключает нумерацию строк
+//
+// @param show Показывать номера строк
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithLineNumbers(show bool) *TextAreaTask {
+	t.TextAreaTask.WithLineNumbers(show)
+	return t
+}
+
+// WithMaxLength задаёт максимальную длину текста в символах
+//
+// @param length Максимальная длина
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithMaxLength(length int) *TextAreaTask {
+	t.TextAreaTask.WithMaxLength(length)
+	return t
+}
+
+// WithPreviewLines задаёт количество строк текста в итоговом виде задачи (по умолчанию 3)
+//
+// @param lines Количество строк
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithPreviewLines(lines int) *TextAreaTask {
+	t.TextAreaTask.WithPreviewLines(lines)
+	return t
+}
+
+// WithValidator устанавливает валидатор для текста
+//
+// @param validator Валидатор
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithValidator(validator validation.Validator) *TextAreaTask {
+	t.TextAreaTask.WithValidator(validator)
+	return t
+}
+
+// WithPlaceholder устанавливает текст-заполнитель пустого поля
+//
+// @param placeholder Текст-заполнитель
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithPlaceholder(placeholder string) *TextAreaTask {
+	t.TextAreaTask.WithPlaceholder(placeholder)
+	return t
+}
+
+// WithValue заполняет поле начальным текстом, который пользователь может изменить.
+// В неинтерактивном и построчном режимах он используется как значение по умолчанию.
+//
+// @param value Начальный текст
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithValue(value string) *TextAreaTask {
+	t.TextAreaTask.WithValue(value)
+	return t
+}
+
+// WithAllowEmpty разрешает пустой текст
+//
+// @param allow Разрешить пустой текст
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithAllowEmpty(allow bool) *TextAreaTask {
+	t.TextAreaTask.WithAllowEmpty(allow)
+	return t
+}
+
+// WithTimeout устанавливает тайм-аут для задачи с текстом по умолчанию
+//
+// @param duration Тайм-аут
+// @param defaultValue Текст по умолчанию
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithTimeout(duration time.Duration, defaultValue string) *TextAreaTask {
+	t.TextAreaTask.WithTimeout(duration, defaultValue)
+	return t
+}
+
+// FromEnv берёт начальный текст поля из переменной окружения
+//
+// @param name Имя переменной окружения
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) FromEnv(name string, mode ...PrefillMode) *TextAreaTask {
+	t.TextAreaTask.FromEnv(name, mode...)
+	return t
+}
+
+// FromFlag берёт начальный текст поля из флага командной строки, явно указанного при разборе fs
+//
+// @param fs Набор флагов
+// @param name Имя флага
+// @param mode Необязательный режим (по умолчанию PrefillDefault)
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) FromFlag(fs *flag.FlagSet, name string, mode ...PrefillMode) *TextAreaTask {
+	t.TextAreaTask.FromFlag(fs, name, mode...)
+	return t
+}
+
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) WithID(id string) *TextAreaTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) When(condition func(Results) bool) *TextAreaTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *TextAreaTask) Then(followUp func(Results) []Task) *TextAreaTask {
+	t.SetFollowUp(followUp)
+	return t
+}
@@ -0,0 +1,168 @@
+package ziva
+
+import (
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// ----------------------------------------------------------------------------
+// Темы оформления
+// ----------------------------------------------------------------------------
+
+// Theme — тема оформления очереди: палитра, иконки, символы дерева задач,
+// тексты статусов и стили элементов. Применяется методом Queue.WithTheme
+// и не изменяет глобальные стили, поэтому очереди одной программы
+// могут выглядеть по-разному.
+//
+// Пример:
+//
+//	theme := ziva.LightTheme()
+//	theme.Icons.Done = "+"
+//	theme.Labels.Success = "ГОТОВО"
+//	queue := ziva.NewQueue("Установка").WithTheme(theme)
+type Theme = ui.Theme
+
+// Palette — цвета темы по назначению элементов; из неё строится тема функцией NewTheme.
+type Palette = ui.Palette
+
+// ThemeIcons — иконки темы.
+type ThemeIcons = ui.Icons
+
+// ThemeGlyphs — символы дерева задач, префиксов и полосы прогресса.
+type ThemeGlyphs = ui.Glyphs
+
+// ThemeLabels — тексты статусов темы; пустое поле означает текст текущего языка.
+type ThemeLabels = ui.Labels
+
+// ThemeStyles — стили элементов темы.
+type ThemeStyles = ui.Styles
+
+// Названия встроенных тем (см. ThemeByName)
+const (
+	ThemeNameDefault      = ui.ThemeNameDefault
+	ThemeNameMonochrome   = ui.ThemeNameMonochrome
+	ThemeNameHighContrast = ui.ThemeNameHighContrast
+	ThemeNameLight        = ui.ThemeNameLight
+	ThemeNameEmbedded     = ui.ThemeNameEmbedded
+)
+
+// NewTheme создаёт тему из палитры.
+//
+// @param name Название темы
+// @param palette Палитра
+// @return Новая тема
+func NewTheme(name string, palette Palette) *Theme {
+	return ui.NewTheme(name, palette)
+}
+
+// DefaultPalette возвращает палитру темы по умолчанию (основа для собственных палитр).
+func DefaultPalette() Palette {
+	return ui.DefaultPalette()
+}
+
+// DefaultTheme возвращает тему по умолчанию: яркая палитра для тёмного фона.
+func DefaultTheme() *Theme {
+	return ui.DefaultTheme()
+}
+
+// MonochromeTheme возвращает тему без цвета: элементы различаются начертанием.
+func MonochromeTheme() *Theme {
+	return ui.MonochromeTheme()
+}
+
+// HighContrastTheme возвращает контрастную тему на базовых ANSI-цветах.
+func HighContrastTheme() *Theme {
+	return ui.HighContrastTheme()
+}
+
+// LightTheme возвращает тему для светлого фона терминала.
+func LightTheme() *Theme {
+	return ui.LightTheme()
+}
+
+// EmbeddedTheme возвращает тему для встроенных систем: 16 ANSI-цветов и ASCII-символы.
+func EmbeddedTheme() *Theme {
+	return ui.EmbeddedTheme()
+}
+
+// ThemeByName возвращает встроенную тему по названию: "default", "monochrome",
+// "high-contrast", "light" или "embedded" (синоним — "ascii").
+//
+// @param name Название темы
+// @return Тема и признак того, что тема найдена
+func ThemeByName(name string) (*Theme, bool) {
+	return ui.ThemeByName(name)
+}
+
+// ThemeNames возвращает названия встроенных тем.
+func ThemeNames() []string {
+	return append([]string(nil), ui.ThemeNames...)
+}
+
+// WithTheme задаёт тему оформления очереди и всех её задач.
+// Очередь сохраняет копию темы: последующие изменения переданного значения
+// на неё не влияют. Без темы используются глобальные стили
+// (SetExitMenuItemStyle, SetErrorColor и т.д.).
+// Поверх темы применяются файл темы пользователя (см. ThemeConfigPath)
+// и переменные NO_COLOR/CLICOLOR, отключающие цвет.
+//
+// @param theme Тема оформления (nil — глобальные стили)
+// @return Указатель на очередь задач
+func (q *Queue) WithTheme(theme *Theme) *Queue {
+	q.model.WithTheme(theme)
+	return q
+}
+
+// ----------------------------------------------------------------------------
+// Файл темы пользователя
+// ----------------------------------------------------------------------------
+
+// ThemeConfig — переопределение темы из файла YAML или JSON: базовая тема (base),
+// цвета палитры (palette), иконки (icons), символы дерева задач (glyphs)
+// и тексты статусов (labels).
+//
+// Пример файла ~/.config/ziva/theme.yaml:
+//
+//	base: high-contrast
+//	palette:
+//	  accent: "#5DA9E9"
+//	  muted: 8
+//	icons:
+//	  done: "+"
+//	labels:
+//	  success: "ГОТОВО"
+type ThemeConfig = ui.ThemeConfig
+
+// ThemeEnvVar — переменная окружения с путём к файлу темы пользователя
+const ThemeEnvVar = ui.ThemeEnvVar
+
+// ThemeConfigPath возвращает путь к файлу темы пользователя: значение ZIVA_THEME
+// или первый найденный файл theme.yaml, theme.yml, theme.json в каталоге
+// $XDG_CONFIG_HOME/ziva (по умолчанию ~/.config/ziva).
+// Тема из этого файла применяется ко всем очередям программы.
+//
+// @return Путь к файлу или пустая строка, если файл не найден
+func ThemeConfigPath() string {
+	return ui.ThemeConfigPath()
+}
+
+// UserThemeError возвращает ошибку чтения или проверки файла темы пользователя.
+// Некорректный файл не применяется; программа может сообщить об этом оператору.
+//
+// @return Ошибка с путём к файлу и полями или nil
+func UserThemeError() error {
+	_, err := ui.UserThemeConfig()
+	return err
+}
+
+// LoadThemeFile читает переопределение темы из файла и строит тему
+// на основе темы по умолчанию (или темы из поля base).
+//
+// @param path Путь к файлу YAML или JSON
+// @return Тема или ошибка чтения/проверки файла
+func LoadThemeFile(path string) (*Theme, error) {
+	config, err := ui.LoadThemeConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return config.Apply(ui.DefaultTheme()), nil
+}
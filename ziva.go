@@ -29,8 +29,11 @@
 package ziva
 
 import (
+	"context"
+	"io"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/qzeleza/ziva/internal/autoconfig"
 	"github.com/qzeleza/ziva/internal/common"
@@ -68,6 +71,7 @@ const (
 // Queue представляет очередь задач для выполнения
 type Queue struct {
 	model *query.Model
+	bind  func(Results) error // Запись ответов в структуру после выполнения (см. NewForm)
 }
 
 // DisableCompletionDelay полностью отключает задержку завершения задач (по умолчанию включена).
@@ -216,7 +220,42 @@ func (q *Queue) SetErrorColor(color query.ErrorColor) *Queue {
 //
 // @return Ошибка, если она возникла
 func (q *Queue) Run() error {
-	return q.model.Run()
+	if err := q.model.Run(); err != nil {
+		return err
+	}
+	if q.bind != nil {
+		return q.bind(q.model.Results())
+	}
+	return nil
+}
+
+// Model возвращает модель bubbletea очереди. Модель позволяет встроить очередь
+// в собственную программу bubbletea или управлять ею пошагово в тестах (см. пакет zivatest).
+//
+// @return Модель bubbletea
+func (q *Queue) Model() tea.Model {
+	return q.model
+}
+
+// WithOutput задаёт поток вывода очереди (по умолчанию os.Stdout).
+//
+// @param w Поток вывода
+// @return Указатель на очередь задач
+func (q *Queue) WithOutput(w io.Writer) *Queue {
+	q.model.WithOutput(w)
+	return q
+}
+
+// WithBackNavigation включает возврат к предыдущей задаче (режим мастера).
+// Стрелка влево (в задаче ввода — при пустом поле) или выбор пункта "Назад"
+// повторно открывает предыдущую задачу с её прежним ответом, чтобы пользователь
+// мог исправить ошибку без перезапуска всей очереди.
+// Возврат к задачам-функциям не выполняется, так как их действия уже произведены.
+//
+// @return Указатель на очередь задач
+func (q *Queue) WithBackNavigation() *Queue {
+	q.model.WithBackNavigation(true)
+	return q
 }
 
 // ----------------------------------------------------------------------------
@@ -343,6 +382,34 @@ func (t *YesNoTask) WithNoAsError() *YesNoTask {
 	return t
 }
 
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *YesNoTask) WithID(id string) *YesNoTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач.
+// Если условие возвращает false, задача пропускается и отображается как пропущенная.
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *YesNoTask) When(condition func(Results) bool) *YesNoTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи.
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *YesNoTask) Then(followUp func(Results) []Task) *YesNoTask {
+	t.SetFollowUp(followUp)
+	return t
+}
+
 // ----------------------------------------------------------------------------
 // SingleSelectTask
 // ----------------------------------------------------------------------------
@@ -415,6 +482,34 @@ func (t *SingleSelectTask) GetSelectedIndex() int {
 	return t.SingleSelectTask.GetSelectedIndex()
 }
 
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *SingleSelectTask) WithID(id string) *SingleSelectTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач.
+// Если условие возвращает false, задача пропускается и отображается как пропущенная.
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *SingleSelectTask) When(condition func(Results) bool) *SingleSelectTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи.
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *SingleSelectTask) Then(followUp func(Results) []Task) *SingleSelectTask {
+	t.SetFollowUp(followUp)
+	return t
+}
+
 // ----------------------------------------------------------------------------
 // MultiSelectTask
 // ----------------------------------------------------------------------------
@@ -502,6 +597,34 @@ func (t *MultiSelectTask) GetSelected() []string {
 	return t.MultiSelectTask.GetSelected()
 }
 
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *MultiSelectTask) WithID(id string) *MultiSelectTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач.
+// Если условие возвращает false, задача пропускается и отображается как пропущенная.
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *MultiSelectTask) When(condition func(Results) bool) *MultiSelectTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи.
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *MultiSelectTask) Then(followUp func(Results) []Task) *MultiSelectTask {
+	t.SetFollowUp(followUp)
+	return t
+}
+
 // ----------------------------------------------------------------------------
 // InputTask
 // ----------------------------------------------------------------------------
@@ -530,6 +653,16 @@ func (t *InputTask) WithTimeout(duration time.Duration, defaultValue string) *In
 	return t
 }
 
+// WithValue заполняет поле начальным значением, которое пользователь может изменить.
+// В неинтерактивном и построчном режимах оно используется как значение по умолчанию.
+//
+// @param value Начальное значение
+// @return Указатель на задачу для цепочки вызовов
+func (t *InputTask) WithValue(value string) *InputTask {
+	t.InputTaskNew.WithValue(value)
+	return t
+}
+
 // WithValidator устанавливает валидатор для ввода
 //
 // @param validator Валидатор для ввода
@@ -565,6 +698,34 @@ func (t *InputTask) GetValue() string {
 	return t.InputTaskNew.GetValue()
 }
 
+// WithID задаёт стабильный идентификатор задачи для сопоставления ответов и результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *InputTask) WithID(id string) *InputTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач.
+// Если условие возвращает false, задача пропускается и отображается как пропущенная.
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *InputTask) When(condition func(Results) bool) *InputTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи.
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *InputTask) Then(followUp func(Results) []Task) *InputTask {
+	t.SetFollowUp(followUp)
+	return t
+}
+
 // InputType представляет тип поля ввода
 type InputType = task.InputType
 
@@ -591,6 +752,30 @@ func NewFuncTask(title string, fn func() error, opts ...task.FuncTaskOption) *Fu
 	return &FuncTask{task.NewFuncTask(title, fn, opts...)}
 }
 
+// NewFuncTaskCtx создает задачу выполнения функции с поддержкой отмены через контекст.
+// Контекст отменяется, когда пользователь прерывает задачу или очередь,
+// а также по истечении срока, заданного WithDeadline (задача завершается ошибкой тайм-аута).
+//
+// @param title Заголовок задачи
+// @param fn Функция, которая будет выполнена
+// @param opts Опции для конфигурации задачи
+// @return Указатель на новую задачу выполнения функции
+func NewFuncTaskCtx(title string, fn func(ctx context.Context) error, opts ...task.FuncTaskOption) *FuncTask {
+	return &FuncTask{task.NewFuncTaskCtx(title, fn, opts...)}
+}
+
+// NewFuncTaskWithProgress создает задачу выполнения функции, сообщающей о ходе работы.
+// Через p функция передаёт процент, количество байт и строку состояния — они выводятся
+// полосой прогресса с оценкой оставшегося времени, а после завершения выводится общее время.
+//
+// @param title Заголовок задачи
+// @param fn Функция, которая будет выполнена
+// @param opts Опции для конфигурации задачи
+// @return Указатель на новую задачу выполнения функции
+func NewFuncTaskWithProgress(title string, fn func(p Progress) error, opts ...task.FuncTaskOption) *FuncTask {
+	return &FuncTask{task.NewFuncTaskWithProgress(title, fn, opts...)}
+}
+
 // FuncTask представляет задачу выполнения функции
 type FuncTask struct {
 	*task.FuncTask
@@ -605,16 +790,71 @@ func (t *FuncTask) WithStopOnError(stop bool) *FuncTask {
 	return t
 }
 
+// WithDeadline ограничивает время выполнения функции.
+// По истечении срока контекст функции отменяется, а задача завершается ошибкой тайм-аута.
+//
+// @param deadline Максимальная длительность выполнения
+// @return Указатель на задачу для цепочки вызовов
+func (t *FuncTask) WithDeadline(deadline time.Duration) *FuncTask {
+	t.FuncTask.WithDeadline(deadline)
+	return t
+}
+
+// WithRetry включает повтор функции при повторяемых ошибках (по умолчанию — сетевых и тайм-аутах)
+// с экспоненциальной задержкой. Если policy.Prompt установлен, после исчерпания попыток
+// пользователю предлагается повторить, пропустить задачу или прервать её.
+//
+// @param policy Политика повтора
+// @return Указатель на задачу для цепочки вызовов
+func (t *FuncTask) WithRetry(policy RetryPolicy) *FuncTask {
+	t.FuncTask.WithRetry(policy)
+	return t
+}
+
+// WithID задаёт стабильный идентификатор задачи для сопоставления результатов
+//
+// @param id Идентификатор задачи
+// @return Указатель на задачу для цепочки вызовов
+func (t *FuncTask) WithID(id string) *FuncTask {
+	t.SetID(id)
+	return t
+}
+
+// When задаёт условие выполнения задачи по результатам предыдущих задач.
+// Если условие возвращает false, задача пропускается и отображается как пропущенная.
+//
+// @param condition Функция-условие
+// @return Указатель на задачу для цепочки вызовов
+func (t *FuncTask) When(condition func(Results) bool) *FuncTask {
+	t.SetCondition(condition)
+	return t
+}
+
+// Then задаёт обработчик, добавляющий задачи в очередь сразу после завершения этой задачи.
+//
+// @param followUp Функция, возвращающая новые задачи по результатам очереди
+// @return Указатель на задачу для цепочки вызовов
+func (t *FuncTask) Then(followUp func(Results) []Task) *FuncTask {
+	t.SetFollowUp(followUp)
+	return t
+}
+
 // Валидаторы - экспорт фабрики валидаторов
 var DefaultValidators = validation.DefaultFactory
 
 // FuncTaskOption представляет опцию для конфигурации FuncTask
 type FuncTaskOption = task.FuncTaskOption
 
+// Progress передаёт прогресс из функции задачи NewFuncTaskWithProgress
+type Progress = task.Progress
+
 // Опции для FuncTask
 var (
 	WithSummaryFunction = task.WithSummaryFunction
 	WithStopOnError     = task.WithStopOnError
+	WithDeadline        = task.WithDeadlineOption
+	WithRetry           = task.WithRetryOption
+	WithResumeMode      = task.WithResumeModeOption
 )
 
 // Стили для текста
@@ -0,0 +1,59 @@
+package zivatest
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Epoch — начальное время поддельных часов. Одинаковое для всех тестов,
+// чтобы снимки представления не зависели от момента запуска.
+var Epoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// timerMsg — запрос таймера от поддельных часов. Сообщение не доходит до очереди:
+// Harness откладывает его и выполняет fn, когда время часов достигнет срабатывания.
+type timerMsg struct {
+	delay time.Duration
+	fn    func(time.Time) tea.Msg
+}
+
+// fakeClock — поддельные часы: время идёт только по командам Harness
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// newFakeClock создаёт поддельные часы, показывающие Epoch
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: Epoch}
+}
+
+// Now возвращает текущее время поддельных часов.
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Tick возвращает команду, которая передаёт Harness запрос таймера вместо ожидания.
+func (c *fakeClock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return timerMsg{delay: d, fn: fn}
+	}
+}
+
+// After возвращает уже сработавший канал: ожидания внутри функций задач
+// (задержка завершения, паузы между повторами) не замедляют тесты.
+func (c *fakeClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+// set переводит часы на указанное время
+func (c *fakeClock) set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
@@ -0,0 +1,69 @@
+package zivatest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/qzeleza/ziva/internal/ui"
+)
+
+// UpdateGoldenEnv — переменная окружения, при непустом значении которой
+// AssertGolden перезаписывает эталонные снимки вместо сравнения:
+//
+//	ZIVA_UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "ZIVA_UPDATE_GOLDEN"
+
+// GoldenDir — каталог эталонных снимков относительно пакета теста
+const GoldenDir = "testdata"
+
+// Plain удаляет из представления ANSI-оформление и пробелы в конце строк,
+// чтобы снимок не зависел от цветового профиля терминала.
+//
+// @param view Представление очереди или задачи
+// @return Текст без оформления
+func Plain(view string) string {
+	lines := strings.Split(ui.StripANSI(view), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AssertGolden сравнивает текст с эталонным снимком testdata/<name>.golden.
+// ANSI-оформление удаляется перед сравнением (см. Plain).
+// Если задана переменная ZIVA_UPDATE_GOLDEN, снимок записывается заново.
+//
+// @param tb Тест
+// @param name Имя снимка (без расширения)
+// @param got Полученное представление
+func AssertGolden(tb testing.TB, name, got string) {
+	tb.Helper()
+
+	got = Plain(got)
+	path := filepath.Join(GoldenDir, name+".golden")
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			tb.Fatalf("zivatest: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			tb.Fatalf("zivatest: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		tb.Fatalf("zivatest: снимок %s не найден; создайте его, запустив тест с %s=1", path, UpdateGoldenEnv)
+	}
+	if err != nil {
+		tb.Fatalf("zivatest: %v", err)
+	}
+
+	if string(want) != got {
+		tb.Errorf("zivatest: представление не совпадает со снимком %s\n--- ожидалось ---\n%s\n--- получено ---\n%s", path, want, got)
+	}
+}
@@ -0,0 +1,56 @@
+package zivatest
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyTypes — названия клавиш, которые принимает Harness.Press
+var keyTypes = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"space":     tea.KeySpace,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"tab":       tea.KeyTab,
+	"shift+tab": tea.KeyShiftTab,
+	"backspace": tea.KeyBackspace,
+	"delete":    tea.KeyDelete,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+	"esc":       tea.KeyEsc,
+	"ctrl+c":    tea.KeyCtrlC,
+}
+
+// parseKey преобразует название клавиши в сообщение bubbletea.
+// Одиночный символ ("y", "/", "q") передаётся как набранный текст.
+//
+// @param name Название клавиши
+// @return Сообщение клавиатуры и признак того, что название распознано
+func parseKey(name string) (tea.KeyMsg, bool) {
+	if keyType, ok := keyTypes[strings.ToLower(name)]; ok {
+		if keyType == tea.KeySpace {
+			return runeKey(' '), true
+		}
+		return tea.KeyMsg{Type: keyType}, true
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		r, _ := utf8.DecodeRuneInString(name)
+		return runeKey(r), true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// runeKey возвращает сообщение нажатия клавиши символа так же, как его формирует bubbletea:
+// пробел приходит как KeySpace, остальные символы — как KeyRunes
+func runeKey(r rune) tea.KeyMsg {
+	if r == ' ' {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
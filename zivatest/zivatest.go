@@ -0,0 +1,258 @@
+// Package zivatest помогает тестировать сценарии, построенные на очередях ziva,
+// без терминала: очередь управляется пошаговым сценарием клавиш, тайм-ауты
+// срабатывают мгновенно по поддельным часам, а представление сравнивается
+// с эталонными снимками без ANSI-оформления.
+//
+// Пример:
+//
+//	func TestInstall(t *testing.T) {
+//		confirm := ziva.NewYesNoTask("Подтверждение", "Продолжить?")
+//		env := ziva.NewSingleSelectTask("Среда", items).WithTimeout(10*time.Second, "dev")
+//		name := ziva.NewInputTask("Имя", "Введите имя:")
+//
+//		h := zivatest.New(t, ziva.NewQueue("Установка").AddTasks(confirm, env, name))
+//		h.Press("enter")  // подтверждаем
+//		h.Timeout()       // истекает тайм-аут выбора среды
+//		h.Type("router").Press("enter")
+//
+//		assert.True(t, confirm.IsYes())
+//		assert.Equal(t, "dev", env.GetSelected())
+//		assert.Equal(t, "router", name.GetValue())
+//		zivatest.AssertGolden(t, "install", h.FinalView())
+//	}
+//
+// Команды задач выполняются синхронно в горутине теста, поэтому результат
+// не зависит от планировщика. Функции FuncTask выполняются по-настоящему.
+package zivatest
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/qzeleza/ziva"
+)
+
+// Размер окна терминала, с которым Harness запускает очередь
+const (
+	DefaultWidth  = 80
+	DefaultHeight = 24
+)
+
+// maxSteps ограничивает число сообщений, обрабатываемых за одно действие,
+// чтобы зациклившийся сценарий завершал тест ошибкой, а не зависал
+const maxSteps = 100000
+
+// timer — отложенное сообщение поддельных часов
+type timer struct {
+	at time.Time               // Момент срабатывания
+	fn func(time.Time) tea.Msg // Функция, формирующая сообщение
+}
+
+// Harness управляет очередью в тесте: передаёт ей клавиши, переводит
+// поддельные часы и возвращает представление без ANSI-оформления.
+type Harness struct {
+	tb     testing.TB
+	model  tea.Model
+	clock  *fakeClock
+	timers []timer // Ожидающие таймеры в порядке постановки
+	done   bool
+}
+
+// New подключает к очереди поддельные часы и запускает её так же,
+// как это делает программа bubbletea: Init и размер окна DefaultWidth×DefaultHeight.
+//
+// @param tb Тест
+// @param queue Очередь задач
+// @return Harness для управления очередью
+func New(tb testing.TB, queue *ziva.Queue) *Harness {
+	tb.Helper()
+
+	h := &Harness{tb: tb, clock: newFakeClock()}
+	queue.WithClock(h.clock)
+	h.model = queue.Model()
+
+	h.exec(h.model.Init())
+	if !h.done {
+		h.Resize(DefaultWidth, DefaultHeight)
+	}
+	return h
+}
+
+// Press передаёт очереди нажатия клавиш по названиям: "enter", "space", "up", "down",
+// "left", "right", "tab", "shift+tab", "backspace", "delete", "home", "end",
+// "pgup", "pgdown", "esc", "ctrl+c" или одиночный символ ("y", "/", "q").
+//
+// @param keys Названия клавиш
+// @return Harness для цепочки вызовов
+func (h *Harness) Press(keys ...string) *Harness {
+	h.tb.Helper()
+	for _, name := range keys {
+		msg, ok := parseKey(name)
+		if !ok {
+			h.tb.Fatalf("zivatest: неизвестная клавиша %q", name)
+		}
+		h.Send(msg)
+	}
+	return h
+}
+
+// Type набирает текст: каждый символ передаётся отдельным нажатием.
+//
+// @param text Набираемый текст
+// @return Harness для цепочки вызовов
+func (h *Harness) Type(text string) *Harness {
+	h.tb.Helper()
+	for _, r := range text {
+		h.Send(runeKey(r))
+	}
+	return h
+}
+
+// Resize передаёт очереди новый размер окна терминала.
+//
+// @param width Ширина окна
+// @param height Высота окна
+// @return Harness для цепочки вызовов
+func (h *Harness) Resize(width, height int) *Harness {
+	h.tb.Helper()
+	return h.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Send передаёт очереди произвольное сообщение bubbletea и выполняет
+// все возникшие команды.
+//
+// @param msg Сообщение
+// @return Harness для цепочки вызовов
+func (h *Harness) Send(msg tea.Msg) *Harness {
+	h.tb.Helper()
+	if h.done {
+		h.tb.Fatalf("zivatest: очередь уже завершена, сообщение %T не доставлено", msg)
+	}
+	h.exec(func() tea.Msg { return msg })
+	return h
+}
+
+// Advance переводит поддельные часы вперёд на d, по порядку доставляя
+// сообщения таймеров, срок которых наступил (тайм-ауты, обратный отсчёт,
+// повторы FuncTask, кадры спиннеров).
+//
+// @param d Длительность
+// @return Harness для цепочки вызовов
+func (h *Harness) Advance(d time.Duration) *Harness {
+	h.tb.Helper()
+	h.advanceTo(h.clock.Now().Add(d))
+	return h
+}
+
+// Timeout мгновенно истекает все ожидающие таймеры: часы переводятся
+// до самого позднего из них. Таймеры, запущенные задачами после этого
+// (например, тайм-аут следующей задачи), не срабатывают.
+//
+// @return Harness для цепочки вызовов
+func (h *Harness) Timeout() *Harness {
+	h.tb.Helper()
+	if len(h.timers) == 0 {
+		return h
+	}
+	latest := h.timers[0].at
+	for _, t := range h.timers[1:] {
+		if t.at.After(latest) {
+			latest = t.at
+		}
+	}
+	h.advanceTo(latest)
+	return h
+}
+
+// Now возвращает время поддельных часов.
+func (h *Harness) Now() time.Time {
+	return h.clock.Now()
+}
+
+// Done сообщает, завершилась ли очередь (все задачи выполнены или очередь остановлена).
+func (h *Harness) Done() bool {
+	return h.done
+}
+
+// View возвращает текущее представление очереди без ANSI-оформления.
+func (h *Harness) View() string {
+	return Plain(h.model.View())
+}
+
+// FinalView возвращает итоговое представление завершённой очереди без ANSI-оформления.
+// Если очередь ещё не завершена, тест прерывается.
+//
+// @return Итоговое представление
+func (h *Harness) FinalView() string {
+	h.tb.Helper()
+	if !h.done {
+		h.tb.Fatalf("zivatest: очередь ещё не завершена")
+	}
+	return h.View()
+}
+
+// advanceTo доставляет сообщения таймеров со сроком не позже target и переводит часы на target
+func (h *Harness) advanceTo(target time.Time) {
+	for !h.done {
+		index := -1
+		for i, t := range h.timers {
+			if !t.at.After(target) && (index < 0 || t.at.Before(h.timers[index].at)) {
+				index = i
+			}
+		}
+		if index < 0 {
+			break
+		}
+
+		t := h.timers[index]
+		h.timers = append(h.timers[:index], h.timers[index+1:]...)
+		h.clock.set(t.at)
+		h.exec(func() tea.Msg { return t.fn(t.at) })
+	}
+	if target.After(h.clock.Now()) {
+		h.clock.set(target)
+	}
+}
+
+// exec выполняет команду и все порождённые ею команды по порядку в горутине теста.
+// Сообщения пакетов команд разворачиваются, запросы таймеров откладываются до перевода часов.
+func (h *Harness) exec(cmd tea.Cmd) {
+	h.tb.Helper()
+
+	pending := []tea.Cmd{cmd}
+	for steps := 0; len(pending) > 0; steps++ {
+		if steps > maxSteps {
+			h.tb.Fatalf("zivatest: очередь не успокоилась за %d сообщений", maxSteps)
+		}
+
+		next := pending[0]
+		pending = pending[1:]
+		if next == nil {
+			continue
+		}
+
+		switch msg := next().(type) {
+		case nil:
+		case tea.BatchMsg:
+			pending = append(pending, msg...)
+		case tea.QuitMsg:
+			h.done = true
+		case timerMsg:
+			h.schedule(msg)
+		default:
+			if h.done {
+				continue
+			}
+			var cmd tea.Cmd
+			h.model, cmd = h.model.Update(msg)
+			pending = append(pending, cmd)
+		}
+	}
+}
+
+// schedule откладывает сообщение таймера до момента срабатывания.
+// Таймеры с одинаковым моментом срабатывают в порядке постановки.
+func (h *Harness) schedule(msg timerMsg) {
+	h.timers = append(h.timers, timer{at: h.clock.Now().Add(msg.delay), fn: msg.fn})
+}
@@ -0,0 +1,88 @@
+package zivatest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qzeleza/ziva"
+	"github.com/qzeleza/ziva/zivatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// environments — варианты выбора среды для тестов
+var environments = []ziva.Item{{Key: "dev", Name: "Разработка"}, {Key: "test", Name: "Тестирование"}, {Key: "prod", Name: "Боевая"}}
+
+// TestHarnessDrivesQueueWithKeys проверяет прохождение очереди сценарием клавиш
+func TestHarnessDrivesQueueWithKeys(t *testing.T) {
+	confirm := ziva.NewYesNoTask("Подтверждение", "Продолжить установку?")
+	env := ziva.NewSingleSelectTask("Среда", environments)
+	parts := ziva.NewMultiSelectTask("Компоненты", []ziva.Item{{Key: "api"}, {Key: "web"}, {Key: "db"}})
+	name := ziva.NewInputTask("Имя узла", "Введите имя узла:")
+
+	h := zivatest.New(t, ziva.NewQueue("Установка").AddTasks(confirm, env, parts, name))
+	h.Press("enter")
+	h.Press("down", "down", "enter")
+	h.Press("down", "space", "down", "space")
+	zivatest.AssertGolden(t, "multiselect_active", h.View())
+
+	h.Press("enter")
+	h.Type("router-1").Press("enter")
+
+	require.True(t, h.Done(), "очередь должна завершиться")
+	assert.True(t, confirm.IsYes())
+	assert.Equal(t, "prod", env.GetSelected())
+	assert.Equal(t, []string{"web", "db"}, parts.GetSelected())
+	assert.Equal(t, "router-1", name.GetValue())
+	zivatest.AssertGolden(t, "queue_final", h.FinalView())
+}
+
+// TestHarnessFiresTimeoutsInstantly проверяет обратный отсчёт и тайм-аут по поддельным часам
+func TestHarnessFiresTimeoutsInstantly(t *testing.T) {
+	env := ziva.NewSingleSelectTask("Среда", environments).WithTimeout(10*time.Second, "test")
+	confirm := ziva.NewYesNoTask("Подтверждение", "Продолжить?").WithTimeoutNo(30 * time.Second)
+
+	h := zivatest.New(t, ziva.NewQueue("Установка").AddTasks(env, confirm))
+	assert.Contains(t, h.View(), "[00:10]")
+
+	h.Advance(3 * time.Second)
+	assert.Contains(t, h.View(), "[00:07]", "обратный отсчёт должен идти по поддельным часам")
+	assert.False(t, env.IsDone())
+
+	h.Timeout()
+	assert.Equal(t, "test", env.GetSelected(), "по тайм-ауту выбирается значение по умолчанию")
+	assert.Equal(t, zivatest.Epoch.Add(10*time.Second), h.Now())
+	assert.False(t, confirm.IsDone(), "тайм-аут следующей задачи не должен срабатывать")
+
+	h.Timeout()
+	require.True(t, h.Done())
+	assert.True(t, confirm.IsNo())
+}
+
+// TestHarnessRetriesWithoutWaiting проверяет повтор FuncTask без реального ожидания
+func TestHarnessRetriesWithoutWaiting(t *testing.T) {
+	calls := 0
+	download := ziva.NewFuncTask("Загрузка", func() error {
+		calls++
+		if calls == 1 {
+			return errors.New("временный сбой")
+		}
+		return nil
+	}).WithRetry(ziva.RetryPolicy{MaxAttempts: 2, InitialDelay: time.Minute, RetryOn: []ziva.ErrorType{ziva.ErrorTypeUnknown}})
+
+	started := time.Now()
+	h := zivatest.New(t, ziva.NewQueue("Обновление").AddTasks(download))
+	assert.False(t, h.Done(), "повтор ожидает перевода часов")
+
+	h.Timeout()
+	require.True(t, h.Done())
+	assert.Equal(t, 2, calls)
+	assert.False(t, download.HasError())
+	assert.Less(t, time.Since(started), time.Minute)
+}
+
+// TestPlainStripsStyling проверяет удаление ANSI-оформления и хвостовых пробелов
+func TestPlainStripsStyling(t *testing.T) {
+	assert.Equal(t, "ГОТОВО\nстрока", zivatest.Plain("\x1b[1;32mГОТОВО\x1b[0m   \nстрока  "))
+}